@@ -28,6 +28,7 @@ type App struct {
 	dbPool      db.Database
 	authService *service.AuthService
 	urlUsecase  *usecase.URLUsecase
+	urlHashList *service.URLHashList
 	audit       *audit.Subject
 	healthSrv   *health.Server
 	servers     []Server
@@ -45,13 +46,13 @@ func New() (*App, error) {
 		return nil, err
 	}
 
-	h, dbPool, authService, auditSubject, urlUsecase, grpcSrv, healthSrv, err := initDependencies(cfg, logger)
+	h, dbPool, authService, auditSubject, urlUsecase, urlHashList, grpcSrv, healthSrv, err := initDependencies(cfg, logger)
 	if err != nil {
 		logger.Sync()
 		return nil, err
 	}
 
-	router := newRouter(h, logger, authService, cfg.TrustedSubnet)
+	router := newRouter(h, logger, authService, cfg.TrustedSubnet, cfg.Limits.MaxBodyBytes)
 
 	return &App{
 		config:      cfg,
@@ -60,6 +61,7 @@ func New() (*App, error) {
 		dbPool:      dbPool,
 		authService: authService,
 		urlUsecase:  urlUsecase,
+		urlHashList: urlHashList,
 		audit:       auditSubject,
 		healthSrv:   healthSrv,
 		servers: []Server{
@@ -85,11 +87,15 @@ func Run() error {
 		return err
 	}
 
-	// Health checker живёт пока серверы работают.
-	// Отменяется первым — до shutdown, чтобы не обновлять статус в процессе остановки.
+	// Health checker и фоновая очистка ссылок живут пока серверы работают.
+	// Отменяются первыми — до shutdown, чтобы не обновлять статус в процессе остановки.
 	healthCtx, cancelHealth := context.WithCancel(context.Background())
 	defer cancelHealth()
 	app.startHealthChecker(healthCtx)
+	app.startPurger(healthCtx)
+	app.startExpirySweeper(healthCtx)
+	app.startHistoryPruner(healthCtx)
+	app.startURLHashListReloader(healthCtx)
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
@@ -129,9 +135,12 @@ func Run() error {
 }
 
 // Close освобождает ресурсы приложения в безопасном порядке:
-// 1. Ждёт завершения горутин удаления URL (работают с БД).
-// 2. Ждёт завершения горутин аудита (работают с файлом/сетью).
-// 3. Закрывает пул соединений с БД.
+//  1. Ждёт завершения фоновых операций usecase: удаления, очистки и истечения ссылок,
+//     очистки истории адресов (работают с хранилищем).
+//  2. Останавливает горутины записи переходов и сброса счётчиков, запущенные в NewURLUsecase,
+//     дописав в хранилище накопленные переходы и счётчики.
+//  3. Ждёт завершения горутин аудита (работают с файлом/сетью).
+//  4. Закрывает пул соединений с БД.
 func (a *App) Close() {
 	if a.urlUsecase != nil {
 		a.urlUsecase.Close()
@@ -25,14 +25,38 @@ import (
 // initDependencies инициализирует все зависимости приложения.
 func initDependencies(cfg *config.Config, logger *zap.Logger) (
 	*handler.Handler, db.Database, *service.AuthService, *audit.Subject, *usecase.URLUsecase,
-	*grpc.Server, *health.Server, error,
+	*service.URLHashList, *grpc.Server, *health.Server, error,
 ) {
+	codeFilter, err := service.LoadCodeFilter(cfg.CodeDenyListFile)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to load code filter: %w", err)
+	}
+
+	words, err := service.LoadWordList(cfg.WordCode.ListFile)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to load word list: %w", err)
+	}
+
+	geo, err := service.LoadGeoIP(cfg.GeoIPFile)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to load GeoIP database: %w", err)
+	}
+
+	blocklist, err := service.LoadDomainBlocklist(cfg.DomainBlocklistFile)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to load domain blocklist: %w", err)
+	}
+
+	hashList, err := service.LoadURLHashList(cfg.URLCheck.HashListFile)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to load URL hash list: %w", err)
+	}
+
 	var dbPool db.Database
 	if cfg.DatabaseDSN != "" {
-		var err error
 		dbPool, err = initDatabase(cfg, logger)
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to initialize database: %w", err)
+			return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to initialize database: %w", err)
 		}
 	}
 
@@ -41,13 +65,30 @@ func initDependencies(cfg *config.Config, logger *zap.Logger) (
 		if dbPool != nil {
 			dbPool.Close()
 		}
-		return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to initialize storage: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to initialize storage: %w", err)
 	}
 
 	repo := repository.New(storage)
-	urlService := service.NewURLService(repo, cfg)
+	serviceOpts := []service.URLServiceOption{
+		service.WithCodeFilter(codeFilter),
+		service.WithWordGenerator(service.NewWordCodeGenerator(words, cfg.WordCode.Count)),
+	}
+	if cfg.CodeRecycling.Enabled {
+		serviceOpts = append(serviceOpts, service.WithCodeRecycling())
+		logger.Info("Short code recycling enabled", zap.Stringer("quarantine", cfg.CodeRecycling.Quarantine))
+	}
+	urlService := service.NewURLService(repo, cfg, serviceOpts...)
 	authService := service.NewAuthService(cfg.JWTSecret)
-	urlUsecase := usecase.NewURLUsecase(repo, urlService, cfg, logger)
+	usecaseOpts := []usecase.Option{usecase.WithGeoIP(geo), usecase.WithDomainBlocklist(blocklist)}
+	if hashList != nil {
+		usecaseOpts = append(usecaseOpts, usecase.WithURLCheckers(hashList))
+		logger.Info("Malicious URL hash list loaded",
+			zap.String("path", cfg.URLCheck.HashListFile),
+			zap.Int("hashes", hashList.Len()),
+			zap.Bool("on_redirect", cfg.URLCheck.OnRedirect),
+		)
+	}
+	urlUsecase := usecase.NewURLUsecase(repo, urlService, cfg, logger, usecaseOpts...)
 
 	auditSubject := initAudit(cfg, logger)
 
@@ -57,22 +98,25 @@ func initDependencies(cfg *config.Config, logger *zap.Logger) (
 	}
 	h := handler.New(urlUsecase, logger, dbPool, handlerOpts...)
 
-	grpcSrv, healthSrv := initGRPCServer(urlUsecase, authService, auditSubject, logger)
+	grpcSrv, healthSrv := initGRPCServer(cfg, urlUsecase, authService, auditSubject, logger)
 
-	return h, dbPool, authService, auditSubject, urlUsecase, grpcSrv, healthSrv, nil
+	return h, dbPool, authService, auditSubject, urlUsecase, hashList, grpcSrv, healthSrv, nil
 }
 
-// initGRPCServer создаёт gRPC-сервер с chain-интерцепторами и регистрирует:
+// initGRPCServer создаёт gRPC-сервер с chain-интерцепторами и ограничением размера
+// входящего сообщения (после распаковки) и регистрирует:
 //   - ShortenerService — основной бизнес-хендлер
 //   - Health — стандартный health check (grpc_health_v1)
 //   - Reflection — для grpcurl и Postman без .proto файла
 func initGRPCServer(
+	cfg *config.Config,
 	urlUsecase *usecase.URLUsecase,
 	authService *service.AuthService,
 	auditSubject *audit.Subject,
 	logger *zap.Logger,
 ) (*grpc.Server, *health.Server) {
 	srv := grpc.NewServer(
+		grpc.MaxRecvMsgSize(int(cfg.Limits.MaxBodyBytes)),
 		grpc.ChainUnaryInterceptor(
 			grpchandler.LoggingInterceptor(logger),
 			grpchandler.AuthInterceptor(authService),
@@ -142,13 +186,20 @@ func initDatabase(cfg *config.Config, logger *zap.Logger) (db.Database, error) {
 // 2. File storage (если указан путь к файлу)
 // 3. In-memory storage
 func initStorage(cfg *config.Config, dbPool db.Database, logger *zap.Logger) (repository.Store, error) {
+	// Статистика отдаётся за StatsWindow, более старые переходы не хранятся в памяти и в файле
+	opts := []store.Option{store.WithClickRetention(usecase.StatsWindow)}
+	if cfg.CaseInsensitiveCodes {
+		opts = append(opts, store.WithCaseInsensitiveCodes())
+		logger.Info("Case-insensitive short codes enabled")
+	}
+
 	if dbPool != nil {
 		logger.Info("Using PostgreSQL storage")
-		return store.NewDatabaseStore(dbPool), nil
+		return store.NewDatabaseStore(dbPool, opts...), nil
 	}
 
 	if cfg.FileStoragePath != "" {
-		fileStore, err := store.NewFileStore(cfg.FileStoragePath)
+		fileStore, err := store.NewFileStore(cfg.FileStoragePath, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create file store: %w", err)
 		}
@@ -157,5 +208,5 @@ func initStorage(cfg *config.Config, dbPool db.Database, logger *zap.Logger) (re
 	}
 
 	logger.Info("Using in-memory storage")
-	return store.NewStore(), nil
+	return store.NewStore(opts...), nil
 }
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// startExpirySweeper запускает фоновую горутину, которая периодически помечает удалёнными
+// ссылки с истёкшим сроком жизни. Горутина завершается при отмене ctx.
+//
+// Переход по истёкшей ссылке запрещается сразу при чтении, независимо от sweeper:
+// он нужен, чтобы такие ссылки пропадали из списка пользователя и попадали под очистку.
+func (a *App) startExpirySweeper(ctx context.Context) {
+	if a.urlUsecase == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(a.config.ExpirySweepInterval.Duration())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.sweepExpiredURLs()
+			}
+		}
+	}()
+}
+
+// sweepExpiredURLs выполняет один проход sweeper и логирует результат.
+func (a *App) sweepExpiredURLs() {
+	expired, err := a.urlUsecase.SweepExpiredURLs()
+	if err != nil {
+		a.logger.Error("expiry: failed to mark expired URLs", zap.Error(err))
+		return
+	}
+	if expired > 0 {
+		a.logger.Info("expiry: expired URLs marked as deleted", zap.Int("expired", expired))
+	}
+}
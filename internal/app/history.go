@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// startHistoryPruner запускает фоновую горутину, которая периодически удаляет
+// версии адресов ссылок старше URLHistory.Retention. Горутина завершается при отмене ctx.
+//
+// Если срок хранения не задан, история хранится бессрочно и очистка не нужна.
+func (a *App) startHistoryPruner(ctx context.Context) {
+	if a.urlUsecase == nil || a.config.URLHistory.Retention <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(a.config.URLHistory.Interval.Duration())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.pruneURLHistory()
+			}
+		}
+	}()
+}
+
+// pruneURLHistory выполняет один проход очистки истории и логирует результат.
+func (a *App) pruneURLHistory() {
+	pruned, err := a.urlUsecase.PruneURLHistory()
+	if err != nil {
+		a.logger.Error("history: failed to prune URL versions", zap.Int("pruned", pruned), zap.Error(err))
+		return
+	}
+	if pruned > 0 {
+		a.logger.Info("history: URL versions pruned", zap.Int("pruned", pruned))
+	}
+}
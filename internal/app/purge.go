@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// startPurger запускает фоновую горутину, которая периодически окончательно удаляет
+// мягко удалённые ссылки старше Purge.Retention. Горутина завершается при отмене ctx.
+//
+// Если срок хранения не задан, удалённые ссылки хранятся бессрочно и очистка не нужна.
+func (a *App) startPurger(ctx context.Context) {
+	if a.urlUsecase == nil || a.config.Purge.Retention <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(a.config.Purge.Interval.Duration())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.purgeDeletedURLs()
+			}
+		}
+	}()
+}
+
+// purgeDeletedURLs выполняет один проход очистки и логирует результат.
+func (a *App) purgeDeletedURLs() {
+	purged, err := a.urlUsecase.PurgeDeletedURLs()
+	if err != nil {
+		a.logger.Error("purge: failed to purge deleted URLs", zap.Int("purged", purged), zap.Error(err))
+		return
+	}
+	if purged > 0 {
+		a.logger.Info("purge: deleted URLs purged", zap.Int("purged", purged))
+	}
+}
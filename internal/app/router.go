@@ -8,13 +8,17 @@ import (
 	"go.uber.org/zap"
 )
 
-// newRouter создает и настраивает роутер приложения
-func newRouter(h *handler.Handler, logger *zap.Logger, authService *service.AuthService, trustedSubnet string) *chi.Mux {
+// newRouter создает и настраивает роутер приложения. Тело запроса ограничено
+// maxBodyBytes байтами и до распаковки gzip, и после неё.
+func newRouter(
+	h *handler.Handler, logger *zap.Logger, authService *service.AuthService, trustedSubnet string, maxBodyBytes int64,
+) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middleware
 	r.Use(middleware.Logger(logger))
-	r.Use(middleware.GzipMiddleware(logger))
+	r.Use(middleware.BodyLimit(maxBodyBytes))
+	r.Use(middleware.GzipMiddleware(logger, maxBodyBytes))
 
 	// Auth
 	authMiddleware := middleware.NewAuthMiddleware(authService, logger)
@@ -22,6 +26,8 @@ func newRouter(h *handler.Handler, logger *zap.Logger, authService *service.Auth
 	// Routes
 	r.Get("/ping", h.Ping)
 	r.Get("/{id}", h.GetURL)
+	r.Post("/{id}", h.PostURL)
+	r.Get("/api/qr/{code}", h.GetQRCode)
 
 	// Authenticated routes - маршруты создания URL с опциональной аутентификацией
 	r.With(authMiddleware.OptionalAuth).Post("/", h.CreateURL)
@@ -31,14 +37,34 @@ func newRouter(h *handler.Handler, logger *zap.Logger, authService *service.Auth
 	// User URLs routes - требуют аутентификации
 	r.With(authMiddleware.RequireAuth).Get("/api/user/urls", h.GetUserURLs)
 	r.With(authMiddleware.RequireAuth).Delete("/api/user/urls", h.DeleteURLs)
+	r.With(authMiddleware.RequireAuth).Post("/api/user/urls/restore", h.RestoreURLs)
+	r.With(authMiddleware.RequireAuth).Patch("/api/user/urls/{code}", h.UpdateURL)
+	r.With(authMiddleware.RequireAuth).Put("/api/user/urls/{code}/labels", h.SetURLLabels)
+	r.With(authMiddleware.RequireAuth).Put("/api/user/urls/{code}/rules", h.SetURLRules)
+	r.With(authMiddleware.RequireAuth).Put("/api/user/urls/{code}/variants", h.SetURLVariants)
+	r.With(authMiddleware.RequireAuth).Get("/api/user/urls/{code}/stats", h.GetURLStats)
+	r.With(authMiddleware.RequireAuth).Get("/api/user/urls/{code}/history", h.GetURLHistory)
+	r.With(authMiddleware.RequireAuth).Post("/api/user/urls/{code}/rollback", h.RollbackURL)
+	r.With(authMiddleware.RequireAuth).Put("/api/user/settings", h.UpdateUserSettings)
 
 	// Internal routes - если TrustedSubnet задан, доступны только из доверенной подсети;
 	// если не задан — механизм ограничения отключён и маршрут открыт для всех.
-	if trustedSubnet != "" {
-		r.With(middleware.TrustedSubnet(trustedSubnet, logger)).Get("/api/internal/stats", h.GetStats)
-	} else {
+	r.Group(func(r chi.Router) {
+		if trustedSubnet != "" {
+			r.Use(middleware.TrustedSubnet(trustedSubnet, logger))
+		}
 		r.Get("/api/internal/stats", h.GetStats)
-	}
+	})
+
+	// Admin routes - меняют состояние сервиса, поэтому закрыты по умолчанию:
+	// без TrustedSubnet middleware.TrustedSubnet отвечает 403 на любой запрос.
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.TrustedSubnet(trustedSubnet, logger))
+		r.Put("/api/internal/urls/{code}/unsafe", h.SetURLUnsafe)
+		r.Get("/api/internal/blocklist", h.GetBlockRules)
+		r.Post("/api/internal/blocklist", h.BlockDomain)
+		r.Delete("/api/internal/blocklist", h.UnblockDomain)
+	})
 
 	return r
 }
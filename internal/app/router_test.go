@@ -0,0 +1,75 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/handler"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// adminRequest описывает запрос к маршруту, меняющему состояние сервиса
+type adminRequest struct {
+	method string
+	path   string
+	body   string
+}
+
+var adminRequests = []adminRequest{
+	{method: http.MethodPut, path: "/api/internal/urls/abc/unsafe", body: `{"unsafe": true}`},
+	{method: http.MethodGet, path: "/api/internal/blocklist"},
+	{method: http.MethodPost, path: "/api/internal/blocklist", body: `{"pattern": "evil.test"}`},
+	{method: http.MethodDelete, path: "/api/internal/blocklist", body: `{"pattern": "evil.test"}`},
+}
+
+func TestRouter_AdminRoutesClosedWithoutTrustedSubnet(t *testing.T) {
+	// Мок без ожиданий: обращение к usecase провалит тест
+	h := handler.New(mocks.NewMockURLUsecase(t), zap.NewNop(), nil)
+	router := newRouter(h, zap.NewNop(), service.NewAuthService("secret"), "", 1<<20)
+
+	for _, ar := range adminRequests {
+		t.Run(ar.method+" "+ar.path, func(t *testing.T) {
+			req := httptest.NewRequest(ar.method, ar.path, strings.NewReader(ar.body))
+			req.Header.Set("X-Real-IP", "203.0.113.7")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+}
+
+func TestRouter_AdminRoutesTrustedSubnet(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().GetBlockRules().Return([]model.BlockRule{}).Once()
+	h := handler.New(mockUsecase, zap.NewNop(), nil)
+	router := newRouter(h, zap.NewNop(), service.NewAuthService("secret"), "10.0.0.0/8", 1<<20)
+
+	tests := []struct {
+		name     string
+		realIP   string
+		wantCode int
+	}{
+		{name: "Trusted client", realIP: "10.1.2.3", wantCode: http.StatusOK},
+		{name: "Untrusted client", realIP: "203.0.113.7", wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/internal/blocklist", nil)
+			req.Header.Set("X-Real-IP", tt.realIP)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
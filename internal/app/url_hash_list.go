@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// startURLHashListReloader запускает фоновую горутину, которая периодически проверяет,
+// не заменён ли файл списка хешей вредоносных URL, и подхватывает новую версию.
+// Горутина завершается при отмене ctx.
+//
+// Если список не задан, перечитывать нечего.
+func (a *App) startURLHashListReloader(ctx context.Context) {
+	if a.urlHashList == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(a.config.URLCheck.ReloadInterval.Duration())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.reloadURLHashList()
+			}
+		}
+	}()
+}
+
+// reloadURLHashList перечитывает изменившийся список и логирует результат.
+// При ошибке продолжает действовать прежняя версия списка.
+func (a *App) reloadURLHashList() {
+	reloaded, err := a.urlHashList.Reload()
+	if err != nil {
+		a.logger.Error("url check: failed to reload URL hash list, keeping previous version", zap.Error(err))
+		return
+	}
+	if reloaded {
+		a.logger.Info("url check: URL hash list reloaded", zap.Int("hashes", a.urlHashList.Len()))
+	}
+}
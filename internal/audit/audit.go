@@ -14,6 +14,13 @@ import (
 const (
 	ActionShorten = "shorten"
 	ActionFollow  = "follow"
+	ActionUpdate  = "update"
+	// ActionBlock — добавление правила блокировки доменов.
+	ActionBlock = "block"
+	// ActionUnblock — снятие правила блокировки доменов.
+	ActionUnblock = "unblock"
+	// ActionBlocked — переход, отклонённый блокировкой домена назначения.
+	ActionBlocked = "blocked"
 )
 
 // Event представляет событие аудита.
@@ -21,8 +28,15 @@ type Event struct {
 	Action    string `json:"action"`
 	UserID    string `json:"user_id,omitempty"`
 	URL       string `json:"url"`
+	OldURL    string `json:"old_url,omitempty"`
 	ShortCode string `json:"short_code,omitempty"`
-	TS        int64  `json:"ts"`
+	// Variant — номер варианта сплит-ссылки, на который ведёт переход; 0 — без вариантов.
+	Variant int `json:"variant,omitempty"`
+	// Rule — правило блокировки доменов, которое добавлено, снято или сработало.
+	Rule string `json:"rule,omitempty"`
+	// Reason — причина блокировки, указанная модератором (например, номер жалобы).
+	Reason string `json:"reason,omitempty"`
+	TS     int64  `json:"ts"`
 }
 
 // NewEvent создаёт событие аудита с текущим unix-временем.
@@ -47,6 +61,43 @@ func NewFollowEvent(userID, shortCode, originalURL string) Event {
 	}
 }
 
+// NewUpdateEvent создаёт событие аудита для изменения адреса короткой ссылки.
+// URL содержит новый адрес, OldURL — прежний.
+func NewUpdateEvent(userID, shortCode, oldURL, newURL string) Event {
+	return Event{
+		TS:        time.Now().Unix(),
+		Action:    ActionUpdate,
+		UserID:    userID,
+		ShortCode: shortCode,
+		URL:       newURL,
+		OldURL:    oldURL,
+	}
+}
+
+// NewBlockEvent создаёт событие аудита для добавления (ActionBlock) или снятия (ActionUnblock)
+// правила блокировки доменов.
+func NewBlockEvent(action, rule, reason string) Event {
+	return Event{
+		TS:     time.Now().Unix(),
+		Action: action,
+		Rule:   rule,
+		Reason: reason,
+	}
+}
+
+// NewBlockedEvent создаёт событие аудита для перехода по короткому коду,
+// отклонённого правилом блокировки домена назначения.
+func NewBlockedEvent(userID, shortCode, url, rule string) Event {
+	return Event{
+		TS:        time.Now().Unix(),
+		Action:    ActionBlocked,
+		UserID:    userID,
+		ShortCode: shortCode,
+		URL:       url,
+		Rule:      rule,
+	}
+}
+
 // Observer — интерфейс приёмника событий аудита (низкоуровневый, возвращает error).
 type Observer interface {
 	Notify(ctx context.Context, event Event) error
@@ -7,8 +7,12 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/caarlos0/env/v11"
 )
 
@@ -18,31 +22,157 @@ type RetryConfig struct {
 	MaxAttempts int `env:"MAX_ATTEMPTS" envDefault:"100" json:"retry_max_attempts"`
 }
 
+// WordCodeConfig хранит параметры генератора читаемых кодов из слов.
+type WordCodeConfig struct {
+	// ListFile — путь к файлу со списком слов (одно слово на строку); пустой — встроенный список.
+	ListFile string `env:"LIST_FILE" json:"list_file"`
+	// Count — количество слов в коде (от 1 до 4).
+	Count int `env:"COUNT" envDefault:"2" json:"count"`
+}
+
+// MinCodeQuarantine — минимальный срок карантина переиспользуемого кода.
+// Браузеры и прокси могут эвристически кэшировать редирект без явного Cache-Control,
+// поэтому код не выдаётся повторно раньше, чем истечёт любой разумный срок кэширования
+// старого маппинга.
+const MinCodeQuarantine = 24 * time.Hour
+
+// MaxIndexedURLLength — наибольшая длина адреса назначения при хранении в PostgreSQL:
+// строка индекса (original_url, user_id) не может превышать примерно 2700 байт.
+const MaxIndexedURLLength = 2600
+
+// PurgeConfig хранит параметры окончательного удаления мягко удалённых ссылок.
+type PurgeConfig struct {
+	// Retention — сколько хранить мягко удалённую ссылку до окончательного удаления; 0 — не удалять.
+	Retention Duration `env:"RETENTION" json:"retention"`
+	// Interval — период запуска фоновой очистки.
+	Interval Duration `env:"INTERVAL" envDefault:"1h" json:"interval"`
+}
+
+// URLHistoryConfig хранит параметры хранения истории изменений адресов ссылок.
+type URLHistoryConfig struct {
+	// Retention — сколько хранить прежние версии адреса; 0 — хранить бессрочно.
+	// Последняя версия каждой ссылки сохраняется независимо от срока.
+	Retention Duration `env:"RETENTION" json:"retention"`
+	// Interval — период запуска фоновой очистки истории.
+	Interval Duration `env:"INTERVAL" envDefault:"1h" json:"interval"`
+}
+
+// CodeRecyclingConfig хранит параметры повторного использования освободившихся кодов.
+type CodeRecyclingConfig struct {
+	// Enabled включает пул освободившихся кодов.
+	Enabled bool `env:"ENABLED" json:"enabled"`
+	// Quarantine — срок, в течение которого освободившийся код не выдаётся повторно;
+	// не меньше MinCodeQuarantine.
+	Quarantine Duration `env:"QUARANTINE" envDefault:"720h" json:"quarantine"`
+}
+
+// LinkPasswordConfig хранит параметры защиты ссылок паролем.
+type LinkPasswordConfig struct {
+	// MaxAttempts — число неверных паролей для одного кода, после которого попытки блокируются до конца окна.
+	MaxAttempts int `env:"MAX_ATTEMPTS" envDefault:"5" json:"max_attempts"`
+	// Window — окно, в котором считаются неверные попытки.
+	Window Duration `env:"WINDOW" envDefault:"15m" json:"window"`
+	// AccessTTL — срок действия куки, подтверждающей ввод пароля.
+	AccessTTL Duration `env:"ACCESS_TTL" envDefault:"10m" json:"access_ttl"`
+}
+
+// URLNormalizationConfig хранит параметры приведения адресов к каноническому виду перед дедупликацией.
+type URLNormalizationConfig struct {
+	// Enabled включает нормализацию: схема и хост в нижнем регистре, хост в punycode,
+	// без порта по умолчанию, с очищенным путём.
+	Enabled bool `env:"ENABLED" json:"enabled"`
+	// SortQuery сортирует query-параметры по ключу.
+	SortQuery bool `env:"SORT_QUERY" json:"sort_query"`
+	// StripTracking удаляет известные параметры отслеживания (utm_*, fbclid, gclid и др.).
+	StripTracking bool `env:"STRIP_TRACKING" json:"strip_tracking"`
+}
+
+// URLValidationConfig хранит параметры проверки адресов назначения.
+type URLValidationConfig struct {
+	// AllowedSchemes — схемы, разрешённые в адресах назначения.
+	AllowedSchemes []string `env:"ALLOWED_SCHEMES" envSeparator:"," json:"allowed_schemes"`
+	// AllowPrivateHosts разрешает адреса localhost и частных сетей (например, для локальной разработки).
+	AllowPrivateHosts bool `env:"ALLOW_PRIVATE_HOSTS" json:"allow_private_hosts"`
+}
+
+// URLCheckConfig хранит параметры проверки адресов назначения по локальному списку хешей вредоносных URL.
+type URLCheckConfig struct {
+	// HashListFile — путь к файлу хешей префиксов URL; пустой — проверка выключена.
+	HashListFile string `env:"HASH_LIST_FILE" json:"hash_list_file"`
+	// ReloadInterval — как часто проверять, не заменён ли файл списка.
+	ReloadInterval Duration `env:"RELOAD_INTERVAL" envDefault:"1m" json:"reload_interval"`
+	// OnRedirect включает проверку при переходе: ссылка на вредоносный адрес
+	// открывается только через страницу предпросмотра.
+	OnRedirect bool `env:"ON_REDIRECT" json:"on_redirect"`
+}
+
+// LimitsConfig хранит ограничения размера запросов.
+type LimitsConfig struct {
+	// MaxBodyBytes — наибольший размер тела HTTP-запроса, в том числе после распаковки gzip,
+	// и входящего сообщения gRPC в байтах.
+	MaxBodyBytes int64 `env:"MAX_BODY_BYTES" envDefault:"1048576" json:"max_body_bytes"`
+	// MaxURLLength — наибольшая длина адреса назначения в байтах.
+	MaxURLLength int `env:"MAX_URL_LENGTH" envDefault:"2048" json:"max_url_length"`
+	// MaxBatchSize — наибольшее число адресов в пакетном запросе.
+	MaxBatchSize int `env:"MAX_BATCH_SIZE" envDefault:"1000" json:"max_batch_size"`
+}
+
 // Config содержит всю конфигурацию приложения.
 // Поля помечены тегами env для автоматической загрузки из переменных окружения
 // и тегами json для загрузки из файла конфигурации.
 type Config struct {
-	BaseURL         URLPrefix      `env:"BASE_URL"           json:"base_url"`
-	FileStoragePath string         `env:"FILE_STORAGE_PATH"  json:"file_storage_path"`
-	DatabaseDSN     string         `env:"DATABASE_DSN"       json:"database_dsn"`
-	JWTSecret       string         `env:"JWT_SECRET" envDefault:"your-secret-key" json:"jwt_secret"`
-	AuditFile       string         `env:"AUDIT_FILE"         json:"audit_file"`
-	AuditURL        string         `env:"AUDIT_URL"          json:"audit_url"`
-	TrustedSubnet   string         `env:"TRUSTED_SUBNET"     json:"trusted_subnet"`
-	ServerAddress   NetworkAddress `env:"SERVER_ADDRESS"     json:"server_address"`
-	GRPCAddress     NetworkAddress `env:"GRPC_ADDRESS"       json:"grpc_address"`
-	Retry           RetryConfig    `envPrefix:"RETRY_"       json:"retry"`
-	EnableHTTPS     bool           `env:"ENABLE_HTTPS"       json:"enable_https"`
+	BaseURL              URLPrefix              `env:"BASE_URL"                 json:"base_url"`
+	FileStoragePath      string                 `env:"FILE_STORAGE_PATH"        json:"file_storage_path"`
+	DatabaseDSN          string                 `env:"DATABASE_DSN"             json:"database_dsn"`
+	JWTSecret            string                 `env:"JWT_SECRET" envDefault:"your-secret-key" json:"jwt_secret"`
+	AuditFile            string                 `env:"AUDIT_FILE"               json:"audit_file"`
+	AuditURL             string                 `env:"AUDIT_URL"                json:"audit_url"`
+	TrustedSubnet        string                 `env:"TRUSTED_SUBNET"           json:"trusted_subnet"`
+	CodeDenyListFile     string                 `env:"CODE_DENY_LIST_FILE"      json:"code_deny_list_file"`
+	ServerAddress        NetworkAddress         `env:"SERVER_ADDRESS"           json:"server_address"`
+	GRPCAddress          NetworkAddress         `env:"GRPC_ADDRESS"             json:"grpc_address"`
+	Retry                RetryConfig            `envPrefix:"RETRY_"             json:"retry"`
+	WordCode             WordCodeConfig         `envPrefix:"WORD_CODE_"         json:"word_code"`
+	EnableHTTPS          bool                   `env:"ENABLE_HTTPS"             json:"enable_https"`
+	ExpirySweepInterval  Duration               `env:"EXPIRY_SWEEP_INTERVAL" envDefault:"1m" json:"expiry_sweep_interval"`
+	Purge                PurgeConfig            `envPrefix:"PURGE_"             json:"purge"`
+	CodeRecycling        CodeRecyclingConfig    `envPrefix:"CODE_RECYCLING_"    json:"code_recycling"`
+	CaseInsensitiveCodes bool                   `env:"CASE_INSENSITIVE_CODES"   json:"case_insensitive_codes"`
+	LinkPassword         LinkPasswordConfig     `envPrefix:"LINK_PASSWORD_"     json:"link_password"`
+	GeoIPFile            string                 `env:"GEOIP_FILE"               json:"geoip_file"`
+	DomainBlocklistFile  string                 `env:"DOMAIN_BLOCKLIST_FILE"    json:"domain_blocklist_file"`
+	URLHistory           URLHistoryConfig       `envPrefix:"URL_HISTORY_"       json:"url_history"`
+	PreviewUnsafeLinks   bool                   `env:"PREVIEW_UNSAFE_LINKS"     json:"preview_unsafe_links"`
+	RedirectStatus       int                    `env:"REDIRECT_STATUS" envDefault:"307" json:"redirect_status"`
+	RedirectCacheMaxAge  Duration               `env:"REDIRECT_CACHE_MAX_AGE"   json:"redirect_cache_max_age"`
+	URLNormalization     URLNormalizationConfig `envPrefix:"URL_NORMALIZATION_" json:"url_normalization"`
+	URLValidation        URLValidationConfig    `envPrefix:"URL_VALIDATION_"    json:"url_validation"`
+	URLCheck             URLCheckConfig         `envPrefix:"URL_CHECK_"         json:"url_check"`
+	Limits               LimitsConfig           `envPrefix:"LIMITS_"            json:"limits"`
 }
 
 // NewDefaultConfig возвращает конфигурацию со значениями по умолчанию
 func NewDefaultConfig() *Config {
 	return &Config{
-		ServerAddress: NetworkAddress{Host: "localhost", Port: 8080},
-		GRPCAddress:   NetworkAddress{Host: "localhost", Port: 3200},
-		BaseURL:       URLPrefix("http://localhost:8080/"),
-		JWTSecret:     "your-secret-key",
-		Retry:         RetryConfig{MaxAttempts: 100},
+		ServerAddress:       NetworkAddress{Host: "localhost", Port: 8080},
+		GRPCAddress:         NetworkAddress{Host: "localhost", Port: 3200},
+		BaseURL:             URLPrefix("http://localhost:8080/"),
+		JWTSecret:           "your-secret-key",
+		Retry:               RetryConfig{MaxAttempts: 100},
+		WordCode:            WordCodeConfig{Count: 2},
+		ExpirySweepInterval: Duration(time.Minute),
+		Purge:               PurgeConfig{Interval: Duration(time.Hour)},
+		URLHistory:          URLHistoryConfig{Interval: Duration(time.Hour)},
+		CodeRecycling:       CodeRecyclingConfig{Quarantine: Duration(30 * 24 * time.Hour)},
+		RedirectStatus:      http.StatusTemporaryRedirect,
+		URLValidation:       URLValidationConfig{AllowedSchemes: []string{"http", "https"}},
+		URLCheck:            URLCheckConfig{ReloadInterval: Duration(time.Minute)},
+		Limits:              LimitsConfig{MaxBodyBytes: 1 << 20, MaxURLLength: 2048, MaxBatchSize: 1000},
+		LinkPassword: LinkPasswordConfig{
+			MaxAttempts: 5,
+			Window:      Duration(15 * time.Minute),
+			AccessTTL:   Duration(10 * time.Minute),
+		},
 	}
 }
 
@@ -64,7 +194,28 @@ func Load() (*Config, error) {
 	auditFileFlag := flag.String("audit-file", "", "path to audit log file")
 	auditURLFlag := flag.String("audit-url", "", "URL of remote audit server")
 	trustedSubnetFlag := flag.String("t", "", "trusted subnet in CIDR notation (e.g. 192.168.1.0/24)")
+	wordListFlag := flag.String("word-list", "", "path to word list for human-readable codes")
+	wordCountFlag := flag.Int("word-count", 0, "number of words in human-readable codes")
+	codeDenyListFlag := flag.String("code-deny-list", "", "path to file with words forbidden in short codes")
+	geoIPFileFlag := flag.String("geoip-file", "", "path to CSV GeoIP database (start_ip,end_ip,country)")
+	domainBlocklistFlag := flag.String("domain-blocklist", "", "path to file with blocked destination domains (host, *.domain or /regex/ per line)")
+	urlHashListFlag := flag.String("url-hash-list", "", "path to file with SHA-256 hashes of malicious URL prefixes (hex, one per line)")
+	checkOnRedirectFlag := flag.Bool("check-urls-on-redirect", false, "hold redirects to URLs from the malicious URL list behind a preview page")
 	enableHTTPSFlag := flag.Bool("s", false, "enable HTTPS")
+	caseInsensitiveFlag := flag.Bool("case-insensitive-codes", false, "generate single-case codes and look them up case-insensitively")
+	purgeRetentionFlag := flag.String("purge-retention", "", "how long soft-deleted links are kept before purge (e.g. 720h)")
+	historyRetentionFlag := flag.String("url-history-retention", "", "how long previous link destinations are kept (e.g. 2160h)")
+	previewUnsafeFlag := flag.Bool("preview-unsafe-links", false, "show a preview page instead of redirecting for links flagged unsafe")
+	redirectStatusFlag := flag.Int("redirect-status", 0, "default redirect status code: 301, 302, 307 or 308")
+	redirectMaxAgeFlag := flag.String("redirect-cache-max-age", "", "default Cache-Control max-age of redirects (e.g. 1h); 0 disables caching")
+	normalizeURLsFlag := flag.Bool("normalize-urls", false, "normalize URLs to a canonical form before deduplication")
+	allowedSchemesFlag := flag.String("allowed-schemes", "", "comma-separated URL schemes allowed in destinations (default http,https)")
+	allowPrivateHostsFlag := flag.Bool("allow-private-hosts", false, "allow destinations on localhost and private networks")
+	maxBodyBytesFlag := flag.Int64("max-body-bytes", 0, "maximum request body size in bytes, after gzip decompression")
+	maxURLLengthFlag := flag.Int("max-url-length", 0, "maximum destination URL length in bytes")
+	maxBatchSizeFlag := flag.Int("max-batch-size", 0, "maximum number of URLs in a batch request")
+	codeRecyclingFlag := flag.Bool("code-recycling", false, "reuse codes freed by purged links after quarantine")
+	codeQuarantineFlag := flag.String("code-quarantine", "", "quarantine period before a freed code is reused (e.g. 720h)")
 	configFileFlag := flag.String("c", "", "path to JSON config file")
 	flag.StringVar(configFileFlag, "config", "", "path to JSON config file")
 	flag.Parse()
@@ -92,6 +243,27 @@ func Load() (*Config, error) {
 	if *enableHTTPSFlag {
 		cfg.EnableHTTPS = true
 	}
+	if *caseInsensitiveFlag {
+		cfg.CaseInsensitiveCodes = true
+	}
+	if *codeRecyclingFlag {
+		cfg.CodeRecycling.Enabled = true
+	}
+	if *previewUnsafeFlag {
+		cfg.PreviewUnsafeLinks = true
+	}
+	if *normalizeURLsFlag {
+		cfg.URLNormalization.Enabled = true
+	}
+	if *checkOnRedirectFlag {
+		cfg.URLCheck.OnRedirect = true
+	}
+	if *allowPrivateHostsFlag {
+		cfg.URLValidation.AllowPrivateHosts = true
+	}
+	if *allowedSchemesFlag != "" {
+		cfg.URLValidation.AllowedSchemes = strings.Split(*allowedSchemesFlag, ",")
+	}
 	if *addrFlag != "" {
 		if err := cfg.ServerAddress.Set(*addrFlag); err != nil {
 			return nil, fmt.Errorf("invalid server address flag: %w", err)
@@ -128,11 +300,127 @@ func Load() (*Config, error) {
 	if *trustedSubnetFlag != "" {
 		cfg.TrustedSubnet = *trustedSubnetFlag
 	}
+	if *wordListFlag != "" {
+		cfg.WordCode.ListFile = *wordListFlag
+	}
+	if *wordCountFlag > 0 {
+		cfg.WordCode.Count = *wordCountFlag
+	}
+	if *codeDenyListFlag != "" {
+		cfg.CodeDenyListFile = *codeDenyListFlag
+	}
+	if *geoIPFileFlag != "" {
+		cfg.GeoIPFile = *geoIPFileFlag
+	}
+	if *domainBlocklistFlag != "" {
+		cfg.DomainBlocklistFile = *domainBlocklistFlag
+	}
+	if *urlHashListFlag != "" {
+		cfg.URLCheck.HashListFile = *urlHashListFlag
+	}
+	if *purgeRetentionFlag != "" {
+		if err := cfg.Purge.Retention.Set(*purgeRetentionFlag); err != nil {
+			return nil, fmt.Errorf("invalid purge retention flag: %w", err)
+		}
+	}
+	if *historyRetentionFlag != "" {
+		if err := cfg.URLHistory.Retention.Set(*historyRetentionFlag); err != nil {
+			return nil, fmt.Errorf("invalid URL history retention flag: %w", err)
+		}
+	}
+	if *redirectStatusFlag != 0 {
+		cfg.RedirectStatus = *redirectStatusFlag
+	}
+	if *redirectMaxAgeFlag != "" {
+		if err := cfg.RedirectCacheMaxAge.Set(*redirectMaxAgeFlag); err != nil {
+			return nil, fmt.Errorf("invalid redirect cache max-age flag: %w", err)
+		}
+	}
+	if *maxBodyBytesFlag > 0 {
+		cfg.Limits.MaxBodyBytes = *maxBodyBytesFlag
+	}
+	if *maxURLLengthFlag > 0 {
+		cfg.Limits.MaxURLLength = *maxURLLengthFlag
+	}
+	if *maxBatchSizeFlag > 0 {
+		cfg.Limits.MaxBatchSize = *maxBatchSizeFlag
+	}
+	if *codeQuarantineFlag != "" {
+		if err := cfg.CodeRecycling.Quarantine.Set(*codeQuarantineFlag); err != nil {
+			return nil, fmt.Errorf("invalid code quarantine flag: %w", err)
+		}
+	}
 
 	// ENV переменные имеют высший приоритет.
 	if err := env.Parse(cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse environment variables: %w", err)
 	}
 
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
+
+// validate проверяет согласованность параметров, которые нельзя проверить по отдельности.
+func (c *Config) validate() error {
+	if c.CodeRecycling.Enabled && c.CodeRecycling.Quarantine.Duration() < MinCodeQuarantine {
+		return fmt.Errorf("code recycling quarantine %s is shorter than minimum %s",
+			c.CodeRecycling.Quarantine, Duration(MinCodeQuarantine))
+	}
+	if c.ExpirySweepInterval <= 0 {
+		return fmt.Errorf("expiry sweep interval must be positive")
+	}
+	if c.Purge.Retention > 0 && c.Purge.Interval <= 0 {
+		return fmt.Errorf("purge interval must be positive when purge retention is set")
+	}
+	if c.URLHistory.Retention > 0 && c.URLHistory.Interval <= 0 {
+		return fmt.Errorf("URL history interval must be positive when URL history retention is set")
+	}
+	if c.URLCheck.HashListFile != "" && c.URLCheck.ReloadInterval <= 0 {
+		return fmt.Errorf("URL hash list reload interval must be positive when URL hash list is set")
+	}
+	if !model.IsRedirectStatus(c.RedirectStatus) {
+		return fmt.Errorf("redirect status %d is not one of 301, 302, 307, 308", c.RedirectStatus)
+	}
+	// Закэшированный редирект не должен пережить карантин кода, иначе после повторной выдачи
+	// кода клиенты уйдут по старому адресу
+	if c.RedirectCacheMaxAge.Duration() > MinCodeQuarantine {
+		return fmt.Errorf("redirect cache max-age %s is longer than maximum %s",
+			c.RedirectCacheMaxAge, Duration(MinCodeQuarantine))
+	}
+	if len(c.URLValidation.AllowedSchemes) == 0 {
+		return fmt.Errorf("at least one allowed URL scheme is required")
+	}
+	for _, scheme := range c.URLValidation.AllowedSchemes {
+		if !isValidScheme(scheme) {
+			return fmt.Errorf("invalid allowed URL scheme %q", scheme)
+		}
+	}
+	if c.Limits.MaxBodyBytes <= 0 || c.Limits.MaxURLLength <= 0 || c.Limits.MaxBatchSize <= 0 {
+		return fmt.Errorf("request body, URL length and batch size limits must be positive")
+	}
+	if c.DatabaseDSN != "" && c.Limits.MaxURLLength > MaxIndexedURLLength {
+		return fmt.Errorf("max URL length %d is longer than %d supported by database storage",
+			c.Limits.MaxURLLength, MaxIndexedURLLength)
+	}
+	if c.LinkPassword.MaxAttempts <= 0 || c.LinkPassword.Window <= 0 || c.LinkPassword.AccessTTL <= 0 {
+		return fmt.Errorf("link password attempts, window and access TTL must be positive")
+	}
+	return nil
+}
+
+// isValidScheme сообщает, является ли строка допустимой схемой URL (RFC 3986):
+// буква, за которой следуют буквы, цифры, «+», «-» или «.»
+func isValidScheme(scheme string) bool {
+	for i, r := range scheme {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && ('0' <= r && r <= '9' || r == '+' || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return scheme != ""
+}
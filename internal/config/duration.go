@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Duration — длительность, задаваемая строкой в формате time.ParseDuration ("90m", "720h").
+// Реализует интерфейсы flag.Value и encoding.TextUnmarshaler, поэтому одинаково
+// читается из флагов, переменных окружения и JSON-файла конфигурации.
+type Duration time.Duration
+
+// Duration возвращает значение как time.Duration.
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// String возвращает строковое представление длительности.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Set разбирает строку длительности. Отрицательные значения не допускаются.
+func (d *Duration) Set(value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration format: %s", value)
+	}
+	if parsed < 0 {
+		return fmt.Errorf("duration must not be negative: %s", value)
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// UnmarshalText реализует encoding.TextUnmarshaler, делегируя парсинг методу Set.
+func (d *Duration) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
@@ -3,23 +3,58 @@ package grpchandler
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/avc-dev/url-shortener/internal/audit"
 	"github.com/avc-dev/url-shortener/internal/middleware"
 	"github.com/avc-dev/url-shortener/internal/model"
 	pb "github.com/avc-dev/url-shortener/internal/proto"
 	"github.com/avc-dev/url-shortener/internal/usecase"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// ErrorDomain — домен в деталях ErrorInfo ошибок сервиса.
+	ErrorDomain = "shortener.v1"
+	// ReasonURLExpired — причина NotFound для ссылки с истёкшим сроком жизни.
+	ReasonURLExpired = "URL_EXPIRED"
+	// ReasonClickLimitReached — причина NotFound для ссылки, у которой закончились переходы.
+	ReasonClickLimitReached = "CLICK_LIMIT_REACHED"
+	// ReasonPasswordRequired — причина PermissionDenied для защищённой ссылки без пароля.
+	ReasonPasswordRequired = "PASSWORD_REQUIRED"
+	// ReasonInvalidPassword — причина PermissionDenied для неверного пароля ссылки.
+	ReasonInvalidPassword = "INVALID_PASSWORD"
+	// ReasonPreviewRequired — причина FailedPrecondition для небезопасной ссылки без подтверждения.
+	ReasonPreviewRequired = "PREVIEW_REQUIRED"
+	// ReasonURLBlocked — причина PermissionDenied для ссылки на заблокированный домен.
+	ReasonURLBlocked = "URL_BLOCKED"
+	// ReasonLimitExceeded — причина InvalidArgument для запроса, превысившего ограничение размера;
+	// название ограничения и допустимое значение передаются в метаданных ErrorInfo.
+	ReasonLimitExceeded = "LIMIT_EXCEEDED"
 )
 
 // URLUsecase определяет интерфейс бизнес-логики, используемой gRPC-хендлером.
 // Совпадает с подмножеством handler.URLUsecase, чтобы оба хендлера были
 // фасадами над одним usecase без дублирования логики.
 type URLUsecase interface {
-	CreateShortURLFromString(urlString string, userID string) (string, error)
-	GetOriginalURL(code string) (string, error)
-	GetURLsByUserID(userID string) ([]model.UserURLResponse, error)
+	CreateShortURLFromString(urlString string, userID string, opts model.LinkOptions) (string, error)
+	GetOriginalURL(code string, access model.LinkAccess, visit model.Visit) (model.Redirect, error)
+	RecordClick(code string, visit model.Visit)
+	GetURLsByUserID(userID string, request model.URLListRequest) (model.URLList, error)
+	SetURLLabels(code string, labels model.LinkLabels, userID string) (model.LinkLabels, error)
+	SetURLRules(code string, rules []model.RedirectRule, userID string) ([]model.RedirectRule, error)
+	SetURLVariants(code string, variants []model.SplitVariant, userID string) ([]model.SplitVariant, error)
+	GetDeletedURLsByUserID(userID string) ([]model.UserURLResponse, error)
+	RestoreURLs(codes []string, userID string) ([]string, error)
+	UpdateURL(code, urlString, userID string) (model.URLUpdate, error)
+	GetURLStats(code string, userID string) (model.URLStats, error)
+	GetQRCode(code string, opts model.QROptions) (model.QRImage, error)
 }
 
 // Handler реализует ShortenerServiceServer и делегирует вызовы в URLUsecase.
@@ -40,7 +75,12 @@ func New(uc URLUsecase, auditors ...audit.Notifier) *Handler {
 func (h *Handler) ShortenURL(ctx context.Context, req *pb.URLShortenRequest) (*pb.URLShortenResponse, error) {
 	userID, _ := middleware.GetUserIDFromContext(ctx)
 
-	shortURL, err := h.usecase.CreateShortURLFromString(req.GetUrl(), userID)
+	opts, err := linkOptionsFromRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	shortURL, err := h.usecase.CreateShortURLFromString(req.GetUrl(), userID, opts)
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -50,40 +90,332 @@ func (h *Handler) ShortenURL(ctx context.Context, req *pb.URLShortenRequest) (*p
 }
 
 // ExpandURL реализует rpc ExpandURL — возвращает оригинальный URL по короткому коду.
+// Query-строка перехода из запроса передаётся в адрес назначения по правилам ссылки,
+// а сведения о посетителе выбирают адрес по правилам условного редиректа.
+// Вариант сплит-ссылки из ответа клиент передаёт в следующих запросах, чтобы посетитель
+// оставался на нём. Переход учитывается в статистике ссылки, как и редирект по HTTP.
 func (h *Handler) ExpandURL(ctx context.Context, req *pb.URLExpandRequest) (*pb.URLExpandResponse, error) {
-	originalURL, err := h.usecase.GetOriginalURL(req.GetId())
+	query, err := url.ParseQuery(req.GetQuery())
 	if err != nil {
-		return nil, mapError(err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
 	}
 
+	visit := model.Visit{
+		UserAgent:      req.GetUserAgent(),
+		IP:             req.GetClientIp(),
+		AcceptLanguage: req.GetAcceptLanguage(),
+		Query:          query,
+		Variant:        int(req.GetVariant()),
+	}
+	redirect, err := h.usecase.GetOriginalURL(req.GetId(),
+		model.LinkAccess{Password: req.GetPassword(), Confirmed: req.GetConfirmed()}, visit)
 	userID, _ := middleware.GetUserIDFromContext(ctx)
-	h.emitAudit(ctx, audit.NewFollowEvent(userID, req.GetId(), originalURL))
-	return pb.URLExpandResponse_builder{Result: originalURL}.Build(), nil
+	var blockedErr usecase.URLBlockedError
+	if errors.As(err, &blockedErr) {
+		h.emitAudit(ctx, audit.NewBlockedEvent(userID, req.GetId(), blockedErr.URL, blockedErr.Rule))
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	visit.Variant = redirect.Variant
+	h.usecase.RecordClick(req.GetId(), visit)
+
+	event := audit.NewFollowEvent(userID, req.GetId(), redirect.URL)
+	event.Variant = redirect.Variant
+	h.emitAudit(ctx, event)
+	return pb.URLExpandResponse_builder{
+		Result:         redirect.URL,
+		RedirectStatus: int32(redirect.Status),
+		CacheMaxAge:    durationpb.New(redirect.MaxAge),
+		Variant:        int32(redirect.Variant),
+	}.Build(), nil
 }
 
-// ListUserURLs реализует rpc ListUserURLs — возвращает все URL текущего пользователя.
+// ListUserURLs реализует rpc ListUserURLs — возвращает страницу URL текущего пользователя
+// с заданными тегом, папкой и подстрокой адреса, а с deleted=true — корзину удалённых ссылок, которые ещё можно восстановить.
 // Требует валидного JWT-токена в metadata: анонимные запросы возвращают Unauthenticated.
-func (h *Handler) ListUserURLs(ctx context.Context, _ *pb.ListUserURLsRequest) (*pb.UserURLsResponse, error) {
+func (h *Handler) ListUserURLs(ctx context.Context, req *pb.ListUserURLsRequest) (*pb.UserURLsResponse, error) {
 	if !IsAuthenticated(ctx) {
 		return nil, status.Error(codes.Unauthenticated, "valid authorization token required")
 	}
 
 	userID, _ := middleware.GetUserIDFromContext(ctx)
 
-	urls, err := h.usecase.GetURLsByUserID(userID)
+	var list model.URLList
+	var err error
+	if req.GetDeleted() {
+		list.URLs, err = h.usecase.GetDeletedURLsByUserID(userID)
+	} else {
+		list, err = h.usecase.GetURLsByUserID(userID, model.URLListRequest{
+			Filter:    model.URLFilter{Tag: req.GetTag(), Folder: req.GetFolder()},
+			Search:    req.GetSearch(),
+			Sort:      req.GetSort(),
+			Order:     req.GetOrder(),
+			PageSize:  int(req.GetPageSize()),
+			PageToken: req.GetPageToken(),
+		})
+	}
 	if err != nil {
 		return nil, mapError(err)
 	}
 
-	data := make([]*pb.URLData, 0, len(urls))
-	for _, u := range urls {
-		data = append(data, pb.URLData_builder{
+	data := make([]*pb.URLData, 0, len(list.URLs))
+	for _, u := range list.URLs {
+		item := pb.URLData_builder{
 			ShortUrl:    u.ShortURL,
 			OriginalUrl: u.OriginalURL,
+			Tags:        u.Tags,
+			Folder:      u.Folder,
+			Clicks:      u.Clicks,
+		}
+		if u.DeletedAt != nil {
+			item.DeletedAt = timestamppb.New(*u.DeletedAt)
+		}
+		if u.CreatedAt != nil {
+			item.CreatedAt = timestamppb.New(*u.CreatedAt)
+		}
+		if u.LastAccessedAt != nil {
+			item.LastAccessedAt = timestamppb.New(*u.LastAccessedAt)
+		}
+		data = append(data, item.Build())
+	}
+
+	return pb.UserURLsResponse_builder{Url: data, NextPageToken: list.NextPageToken}.Build(), nil
+}
+
+// RestoreURLs реализует rpc RestoreURLs — восстанавливает удалённые ссылки текущего пользователя
+// и возвращает восстановленные коды. Требует валидного JWT-токена.
+func (h *Handler) RestoreURLs(ctx context.Context, req *pb.RestoreURLsRequest) (*pb.RestoreURLsResponse, error) {
+	if !IsAuthenticated(ctx) {
+		return nil, status.Error(codes.Unauthenticated, "valid authorization token required")
+	}
+	if len(req.GetCodes()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "codes must not be empty")
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(ctx)
+
+	restored, err := h.usecase.RestoreURLs(req.GetCodes(), userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return pb.RestoreURLsResponse_builder{Codes: restored}.Build(), nil
+}
+
+// UpdateURL реализует rpc UpdateURL — меняет адрес ссылки.
+// Требует валидного JWT-токена: изменить можно только свою ссылку.
+func (h *Handler) UpdateURL(ctx context.Context, req *pb.UpdateURLRequest) (*pb.UpdateURLResponse, error) {
+	if !IsAuthenticated(ctx) {
+		return nil, status.Error(codes.Unauthenticated, "valid authorization token required")
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(ctx)
+
+	update, err := h.usecase.UpdateURL(req.GetCode(), req.GetUrl(), userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	h.emitAudit(ctx, audit.NewUpdateEvent(userID, req.GetCode(), update.PreviousURL, update.OriginalURL))
+	return pb.UpdateURLResponse_builder{
+		ShortUrl:    update.ShortURL,
+		OriginalUrl: update.OriginalURL,
+		PreviousUrl: update.PreviousURL,
+	}.Build(), nil
+}
+
+// SetURLLabels реализует rpc SetURLLabels — заменяет теги и папку ссылки.
+// Требует валидного JWT-токена: изменить метки можно только у своей ссылки.
+func (h *Handler) SetURLLabels(ctx context.Context, req *pb.SetURLLabelsRequest) (*pb.SetURLLabelsResponse, error) {
+	if !IsAuthenticated(ctx) {
+		return nil, status.Error(codes.Unauthenticated, "valid authorization token required")
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(ctx)
+
+	labels, err := h.usecase.SetURLLabels(req.GetCode(),
+		model.LinkLabels{Tags: req.GetTags(), Folder: req.GetFolder()}, userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return pb.SetURLLabelsResponse_builder{Tags: labels.Tags, Folder: labels.Folder}.Build(), nil
+}
+
+// SetURLRules реализует rpc SetURLRules — заменяет правила условного редиректа ссылки.
+// Требует валидного JWT-токена: изменить правила можно только у своей ссылки.
+func (h *Handler) SetURLRules(ctx context.Context, req *pb.SetURLRulesRequest) (*pb.SetURLRulesResponse, error) {
+	if !IsAuthenticated(ctx) {
+		return nil, status.Error(codes.Unauthenticated, "valid authorization token required")
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(ctx)
+
+	rules, err := h.usecase.SetURLRules(req.GetCode(), redirectRulesFromProto(req.GetRules()), userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return pb.SetURLRulesResponse_builder{Rules: redirectRulesToProto(rules)}.Build(), nil
+}
+
+// redirectRulesFromProto переводит правила редиректа из сообщений запроса в модель
+func redirectRulesFromProto(rules []*pb.RedirectRule) []model.RedirectRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]model.RedirectRule, len(rules))
+	for i, rule := range rules {
+		result[i] = model.RedirectRule{
+			Platforms: rule.GetPlatforms(),
+			Languages: rule.GetLanguages(),
+			Countries: rule.GetCountries(),
+			URL:       rule.GetUrl(),
+		}
+	}
+	return result
+}
+
+// redirectRulesToProto переводит правила редиректа в сообщения ответа
+func redirectRulesToProto(rules []model.RedirectRule) []*pb.RedirectRule {
+	result := make([]*pb.RedirectRule, len(rules))
+	for i, rule := range rules {
+		result[i] = pb.RedirectRule_builder{
+			Platforms: rule.Platforms,
+			Languages: rule.Languages,
+			Countries: rule.Countries,
+			Url:       rule.URL,
+		}.Build()
+	}
+	return result
+}
+
+// SetURLVariants реализует rpc SetURLVariants — заменяет варианты сплит-ссылки.
+// Требует валидного JWT-токена: изменить варианты можно только у своей ссылки.
+func (h *Handler) SetURLVariants(ctx context.Context, req *pb.SetURLVariantsRequest) (*pb.SetURLVariantsResponse, error) {
+	if !IsAuthenticated(ctx) {
+		return nil, status.Error(codes.Unauthenticated, "valid authorization token required")
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(ctx)
+
+	variants, err := h.usecase.SetURLVariants(req.GetCode(), splitVariantsFromProto(req.GetVariants()), userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return pb.SetURLVariantsResponse_builder{Variants: splitVariantsToProto(variants)}.Build(), nil
+}
+
+// splitVariantsFromProto переводит варианты сплит-ссылки из сообщений запроса в модель
+func splitVariantsFromProto(variants []*pb.SplitVariant) []model.SplitVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	result := make([]model.SplitVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = model.SplitVariant{URL: variant.GetUrl(), Weight: int(variant.GetWeight())}
+	}
+	return result
+}
+
+// splitVariantsToProto переводит варианты сплит-ссылки в сообщения ответа
+func splitVariantsToProto(variants []model.SplitVariant) []*pb.SplitVariant {
+	result := make([]*pb.SplitVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = pb.SplitVariant_builder{Url: variant.URL, Weight: int32(variant.Weight)}.Build()
+	}
+	return result
+}
+
+// GetURLStats реализует rpc GetURLStats — возвращает статистику переходов по ссылке.
+// Требует валидного JWT-токена: статистика доступна только владельцу ссылки.
+func (h *Handler) GetURLStats(ctx context.Context, req *pb.URLStatsRequest) (*pb.URLStatsResponse, error) {
+	if !IsAuthenticated(ctx) {
+		return nil, status.Error(codes.Unauthenticated, "valid authorization token required")
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(ctx)
+
+	stats, err := h.usecase.GetURLStats(req.GetCode(), userID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return pb.URLStatsResponse_builder{
+		From:           timestamppb.New(stats.From),
+		To:             timestamppb.New(stats.To),
+		TotalClicks:    int64(stats.TotalClicks),
+		UniqueVisitors: int64(stats.UniqueVisitors),
+		Hourly:         statsBuckets(stats.Hourly),
+		Daily:          statsBuckets(stats.Daily),
+		Referrers:      statsCounts(stats.Referrers),
+		Browsers:       statsCounts(stats.Browsers),
+		Countries:      statsCounts(stats.Countries),
+		Variants:       variantCounts(stats.Variants),
+	}.Build(), nil
+}
+
+// GetQRCode реализует rpc GetQRCode — возвращает QR-код полного короткого URL ссылки.
+func (h *Handler) GetQRCode(_ context.Context, req *pb.QRCodeRequest) (*pb.QRCodeResponse, error) {
+	opts := model.QROptions{
+		Format: req.GetFormat(),
+		Size:   int(req.GetSize()),
+		Level:  req.GetErrorCorrection(),
+	}
+	if req.HasMargin() {
+		margin := int(req.GetMargin())
+		opts.Margin = &margin
+	}
+
+	image, err := h.usecase.GetQRCode(req.GetCode(), opts)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return pb.QRCodeResponse_builder{
+		Image:       image.Data,
+		ContentType: image.ContentType,
+		Etag:        image.ETag,
+	}.Build(), nil
+}
+
+// statsBuckets преобразует бакеты статистики в сообщения protobuf
+func statsBuckets(buckets []model.StatsBucket) []*pb.StatsBucket {
+	result := make([]*pb.StatsBucket, 0, len(buckets))
+	for _, b := range buckets {
+		result = append(result, pb.StatsBucket_builder{
+			Start:  timestamppb.New(b.Start),
+			Clicks: int64(b.Clicks),
 		}.Build())
 	}
+	return result
+}
 
-	return pb.UserURLsResponse_builder{Url: data}.Build(), nil
+// variantCounts преобразует переходы по вариантам сплит-ссылки в сообщения protobuf
+func variantCounts(counts []model.VariantCount) []*pb.VariantCount {
+	result := make([]*pb.VariantCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, pb.VariantCount_builder{
+			Variant: int32(c.Variant),
+			Clicks:  int64(c.Clicks),
+		}.Build())
+	}
+	return result
+}
+
+// statsCounts преобразует распределение переходов в сообщения protobuf
+func statsCounts(counts []model.StatsCount) []*pb.StatsCount {
+	result := make([]*pb.StatsCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, pb.StatsCount_builder{
+			Value:  c.Value,
+			Clicks: int64(c.Clicks),
+		}.Build())
+	}
+	return result
 }
 
 // emitAudit уведомляет всех аудиторов о событии.
@@ -93,13 +425,103 @@ func (h *Handler) emitAudit(ctx context.Context, event audit.Event) {
 	}
 }
 
+// linkOptionsFromRequest собирает параметры создания ссылки из полей запроса.
+func linkOptionsFromRequest(req *pb.URLShortenRequest) (model.LinkOptions, error) {
+	opts := model.LinkOptions{
+		CodeStyle: model.CodeStyle(req.GetCodeStyle()),
+		MaxClicks: int(req.GetMaxClicks()),
+		Password:  req.GetPassword(),
+		Labels:    model.LinkLabels{Tags: req.GetTags(), Folder: req.GetFolder()},
+		Redirect:  model.RedirectPolicy{Status: int(req.GetRedirectStatus())},
+		Query: model.QueryPolicy{
+			Passthrough: model.QueryPassthrough(req.GetQueryPassthrough()),
+			UTM: model.UTMParams{
+				Source:   req.GetUtm().GetSource(),
+				Medium:   req.GetUtm().GetMedium(),
+				Campaign: req.GetUtm().GetCampaign(),
+				Term:     req.GetUtm().GetTerm(),
+				Content:  req.GetUtm().GetContent(),
+			},
+		},
+		Rules:    redirectRulesFromProto(req.GetRules()),
+		Variants: splitVariantsFromProto(req.GetVariants()),
+	}
+	if req.HasTtl() {
+		if err := req.GetTtl().CheckValid(); err != nil {
+			return model.LinkOptions{}, fmt.Errorf("invalid ttl: %w", err)
+		}
+		opts.TTL = req.GetTtl().AsDuration()
+	}
+	if req.HasExpiresAt() {
+		if err := req.GetExpiresAt().CheckValid(); err != nil {
+			return model.LinkOptions{}, fmt.Errorf("invalid expires_at: %w", err)
+		}
+		opts.ExpiresAt = req.GetExpiresAt().AsTime()
+	}
+	if req.HasCacheMaxAge() {
+		if err := req.GetCacheMaxAge().CheckValid(); err != nil {
+			return model.LinkOptions{}, fmt.Errorf("invalid cache_max_age: %w", err)
+		}
+		maxAge := req.GetCacheMaxAge().AsDuration()
+		opts.Redirect.MaxAge = &maxAge
+	}
+	return opts, nil
+}
+
+// statusWithReason возвращает status-ошибку с деталями ErrorInfo,
+// по которым клиент может различать причины при одинаковом коде.
+func statusWithReason(code codes.Code, msg, reason string) error {
+	return statusWithMetadata(code, msg, reason, nil)
+}
+
+// statusWithMetadata возвращает status-ошибку с деталями ErrorInfo и метаданными причины
+func statusWithMetadata(code codes.Code, msg, reason string, metadata map[string]string) error {
+	st, err := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
 // mapError преобразует ошибки usecase в gRPC status-коды.
 func mapError(err error) error {
+	// Для адреса, отклонённого политикой сервиса, причина usecase.URLRejectReason передаётся в ErrorInfo
+	var rejectedErr usecase.URLRejectedError
+	if errors.As(err, &rejectedErr) {
+		return statusWithReason(codes.InvalidArgument, err.Error(), string(rejectedErr.Reason))
+	}
+	var limitErr usecase.LimitExceededError
+	if errors.As(err, &limitErr) {
+		return statusWithMetadata(codes.InvalidArgument, err.Error(), ReasonLimitExceeded, map[string]string{
+			"limit": string(limitErr.Limit),
+			"max":   strconv.FormatInt(limitErr.Max, 10),
+		})
+	}
+
 	switch {
-	case errors.Is(err, usecase.ErrInvalidURL), errors.Is(err, usecase.ErrEmptyURL):
+	case errors.Is(err, usecase.ErrInvalidURL), errors.Is(err, usecase.ErrEmptyURL),
+		errors.Is(err, usecase.ErrInvalidOptions):
 		return status.Error(codes.InvalidArgument, err.Error())
 	case errors.Is(err, usecase.ErrURLNotFound):
 		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrURLExpired):
+		return statusWithReason(codes.NotFound, "URL expired", ReasonURLExpired)
+	case errors.Is(err, usecase.ErrClickLimitReached):
+		return statusWithReason(codes.NotFound, "click limit reached", ReasonClickLimitReached)
+	case errors.Is(err, usecase.ErrPasswordRequired):
+		return statusWithReason(codes.PermissionDenied, "password required", ReasonPasswordRequired)
+	case errors.Is(err, usecase.ErrInvalidPassword):
+		return statusWithReason(codes.PermissionDenied, "invalid password", ReasonInvalidPassword)
+	case errors.Is(err, usecase.ErrTooManyAttempts):
+		return status.Error(codes.ResourceExhausted, "too many password attempts")
+	case errors.Is(err, usecase.ErrURLBlocked):
+		return statusWithReason(codes.PermissionDenied, "link destination is blocked", ReasonURLBlocked)
+	case errors.Is(err, usecase.ErrPreviewRequired):
+		return statusWithReason(codes.FailedPrecondition, "link is flagged as unsafe", ReasonPreviewRequired)
 	case errors.Is(err, usecase.ErrURLDeleted):
 		return status.Error(codes.NotFound, "URL deleted")
 	default:
@@ -3,7 +3,9 @@ package grpchandler_test
 import (
 	"context"
 	"net"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/grpchandler"
 	"github.com/avc-dev/url-shortener/internal/mocks"
@@ -15,12 +17,16 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const bufSize = 1024 * 1024
@@ -83,7 +89,7 @@ func TestShortenURL_Success(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "user-123").
+		CreateShortURLFromString("https://example.com", "user-123", model.LinkOptions{}).
 		Return("http://localhost:8080/abc12345", nil).Once()
 
 	resp, err := ts.client.ShortenURL(ts.authCtx(t, "user-123"), pb.URLShortenRequest_builder{Url: "https://example.com"}.Build())
@@ -91,12 +97,120 @@ func TestShortenURL_Success(t *testing.T) {
 	assert.Equal(t, "http://localhost:8080/abc12345", resp.GetResult())
 }
 
+func TestShortenURL_CodeStyle(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com", "user-123", model.LinkOptions{CodeStyle: model.CodeStyleWords}).
+		Return("http://localhost:8080/brave-otter-42", nil).Once()
+
+	resp, err := ts.client.ShortenURL(ts.authCtx(t, "user-123"), pb.URLShortenRequest_builder{
+		Url:       "https://example.com",
+		CodeStyle: "words",
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/brave-otter-42", resp.GetResult())
+}
+
+func TestShortenURL_InvalidCodeStyle(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com", mock.AnythingOfType("string"), model.LinkOptions{CodeStyle: "emoji"}).
+		Return("", usecase.ErrInvalidOptions).Once()
+
+	_, err := ts.client.ShortenURL(context.Background(), pb.URLShortenRequest_builder{
+		Url:       "https://example.com",
+		CodeStyle: "emoji",
+	}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestShortenURL_Expiry(t *testing.T) {
+	ts := newTestServer(t)
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ts.mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com", "user-123", model.LinkOptions{TTL: time.Hour, ExpiresAt: expiresAt}).
+		Return("", usecase.ErrInvalidOptions).Once()
+
+	_, err := ts.client.ShortenURL(ts.authCtx(t, "user-123"), pb.URLShortenRequest_builder{
+		Url:       "https://example.com",
+		Ttl:       durationpb.New(time.Hour),
+		ExpiresAt: timestamppb.New(expiresAt),
+	}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestShortenURL_MaxClicks(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com", "user-123", model.LinkOptions{MaxClicks: 5}).
+		Return("http://localhost:8080/abc", nil).Once()
+
+	resp, err := ts.client.ShortenURL(ts.authCtx(t, "user-123"), pb.URLShortenRequest_builder{
+		Url:       "https://example.com",
+		MaxClicks: 5,
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/abc", resp.GetResult())
+}
+
+func TestShortenURL_RedirectPolicy(t *testing.T) {
+	ts := newTestServer(t)
+	maxAge := time.Duration(0)
+
+	ts.mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com", "user-123",
+			model.LinkOptions{Redirect: model.RedirectPolicy{Status: 301, MaxAge: &maxAge}}).
+		Return("http://localhost:8080/abc", nil).Once()
+
+	_, err := ts.client.ShortenURL(ts.authCtx(t, "user-123"), pb.URLShortenRequest_builder{
+		Url:            "https://example.com",
+		RedirectStatus: 301,
+		CacheMaxAge:    durationpb.New(0),
+	}.Build())
+	require.NoError(t, err)
+}
+
+func TestShortenURL_QueryPolicy(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com", "user-123", model.LinkOptions{Query: model.QueryPolicy{
+			Passthrough: model.QueryPassthroughMerge,
+			UTM:         model.UTMParams{Source: "app", Campaign: "launch"},
+		}}).
+		Return("http://localhost:8080/abc", nil).Once()
+
+	_, err := ts.client.ShortenURL(ts.authCtx(t, "user-123"), pb.URLShortenRequest_builder{
+		Url:              "https://example.com",
+		QueryPassthrough: "merge",
+		Utm:              pb.UTMParams_builder{Source: "app", Campaign: "launch"}.Build(),
+	}.Build())
+	require.NoError(t, err)
+}
+
+func TestShortenURL_InvalidTimestamp(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := ts.client.ShortenURL(context.Background(), pb.URLShortenRequest_builder{
+		Url:       "https://example.com",
+		ExpiresAt: &timestamppb.Timestamp{Nanos: -1},
+	}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestShortenURL_AnonymousUser(t *testing.T) {
 	// ShortenURL работает без токена: URL создаётся под сгенерированным анонимным user_id.
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", mock.AnythingOfType("string")).
+		CreateShortURLFromString("https://example.com", mock.AnythingOfType("string"), model.LinkOptions{}).
 		Return("http://localhost:8080/abc12345", nil).Once()
 
 	resp, err := ts.client.ShortenURL(context.Background(), pb.URLShortenRequest_builder{Url: "https://example.com"}.Build())
@@ -113,7 +227,7 @@ func TestShortenURL_PlainToken(t *testing.T) {
 	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", token))
 
 	ts.mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "user-plain").
+		CreateShortURLFromString("https://example.com", "user-plain", model.LinkOptions{}).
 		Return("http://localhost:8080/abc12345", nil).Once()
 
 	resp, err := ts.client.ShortenURL(ctx, pb.URLShortenRequest_builder{Url: "https://example.com"}.Build())
@@ -125,7 +239,7 @@ func TestShortenURL_InvalidURL(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		CreateShortURLFromString("not-a-url", mock.AnythingOfType("string")).
+		CreateShortURLFromString("not-a-url", mock.AnythingOfType("string"), model.LinkOptions{}).
 		Return("", usecase.ErrInvalidURL).Once()
 
 	_, err := ts.client.ShortenURL(context.Background(), pb.URLShortenRequest_builder{Url: "not-a-url"}.Build())
@@ -133,11 +247,29 @@ func TestShortenURL_InvalidURL(t *testing.T) {
 	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
+func TestShortenURL_RejectedURL(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		CreateShortURLFromString("http://127.0.0.1/", mock.AnythingOfType("string"), model.LinkOptions{}).
+		Return("", usecase.URLRejectedError{Reason: usecase.RejectPrivateHost, Detail: "private host"}).Once()
+
+	_, err := ts.client.ShortenURL(context.Background(), pb.URLShortenRequest_builder{Url: "http://127.0.0.1/"}.Build())
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, string(usecase.RejectPrivateHost), info.GetReason())
+}
+
 func TestShortenURL_EmptyURL(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		CreateShortURLFromString("", mock.AnythingOfType("string")).
+		CreateShortURLFromString("", mock.AnythingOfType("string"), model.LinkOptions{}).
 		Return("", usecase.ErrEmptyURL).Once()
 
 	_, err := ts.client.ShortenURL(context.Background(), pb.URLShortenRequest_builder{Url: ""}.Build())
@@ -149,7 +281,7 @@ func TestShortenURL_URLAlreadyExists(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", mock.AnythingOfType("string")).
+		CreateShortURLFromString("https://example.com", mock.AnythingOfType("string"), model.LinkOptions{}).
 		Return("", usecase.URLAlreadyExistsError{Code: "http://localhost:8080/existing"}).Once()
 
 	_, err := ts.client.ShortenURL(context.Background(), pb.URLShortenRequest_builder{Url: "https://example.com"}.Build())
@@ -164,20 +296,93 @@ func TestExpandURL_Success(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		GetOriginalURL("abc12345").
-		Return("https://example.com", nil).Once()
+		GetOriginalURL("abc12345", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com"}, nil).Once()
+	ts.mockUsecase.EXPECT().RecordClick("abc12345", mock.Anything).Once()
 
 	resp, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "abc12345"}.Build())
 	require.NoError(t, err)
 	assert.Equal(t, "https://example.com", resp.GetResult())
 }
 
+func TestExpandURL_RedirectPolicy(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("abc12345", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com", Status: 308, MaxAge: time.Hour}, nil).Once()
+	ts.mockUsecase.EXPECT().RecordClick("abc12345", mock.Anything).Once()
+
+	resp, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "abc12345"}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, int32(308), resp.GetRedirectStatus())
+	assert.Equal(t, time.Hour, resp.GetCacheMaxAge().AsDuration())
+}
+
+func TestExpandURL_Query(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("abc12345", model.LinkAccess{}, model.Visit{Query: url.Values{"ref": {"tw"}}}).
+		Return(model.Redirect{URL: "https://example.com/?ref=tw"}, nil).Once()
+	ts.mockUsecase.EXPECT().RecordClick("abc12345", mock.Anything).Once()
+
+	resp, err := ts.client.ExpandURL(context.Background(),
+		pb.URLExpandRequest_builder{Id: "abc12345", Query: "ref=tw"}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/?ref=tw", resp.GetResult())
+
+	_, err = ts.client.ExpandURL(context.Background(),
+		pb.URLExpandRequest_builder{Id: "abc12345", Query: "ref=%zz"}.Build())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestExpandURL_Visitor(t *testing.T) {
+	ts := newTestServer(t)
+
+	visit := model.Visit{
+		UserAgent:      "Mozilla/5.0 (iPhone)",
+		IP:             "203.0.113.7",
+		AcceptLanguage: "de",
+		Query:          url.Values{},
+	}
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("abc12345", model.LinkAccess{}, visit).
+		Return(model.Redirect{URL: "https://apps.apple.com/app"}, nil).Once()
+	// Переход учитывается в статистике со сведениями о посетителе
+	ts.mockUsecase.EXPECT().RecordClick("abc12345", visit).Once()
+
+	resp, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{
+		Id:             "abc12345",
+		UserAgent:      "Mozilla/5.0 (iPhone)",
+		AcceptLanguage: "de",
+		ClientIp:       "203.0.113.7",
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "https://apps.apple.com/app", resp.GetResult())
+}
+
+func TestExpandURL_SplitVariant(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("abc12345", model.LinkAccess{}, model.Visit{Query: url.Values{}, Variant: 2}).
+		Return(model.Redirect{URL: "https://b.example.com", Variant: 2}, nil).Once()
+	ts.mockUsecase.EXPECT().RecordClick("abc12345", model.Visit{Query: url.Values{}, Variant: 2}).Once()
+
+	resp, err := ts.client.ExpandURL(context.Background(),
+		pb.URLExpandRequest_builder{Id: "abc12345", Variant: 2}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "https://b.example.com", resp.GetResult())
+	assert.Equal(t, int32(2), resp.GetVariant())
+}
+
 func TestExpandURL_NotFound(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		GetOriginalURL("unknown").
-		Return("", usecase.ErrURLNotFound).Once()
+		GetOriginalURL("unknown", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrURLNotFound).Once()
 
 	_, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "unknown"}.Build())
 	require.Error(t, err)
@@ -188,25 +393,187 @@ func TestExpandURL_Deleted(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		GetOriginalURL("deleted").
-		Return("", usecase.ErrURLDeleted).Once()
+		GetOriginalURL("deleted", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrURLDeleted).Once()
 
 	_, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "deleted"}.Build())
 	require.Error(t, err)
 	assert.Equal(t, codes.NotFound, status.Code(err))
 }
 
+func TestExpandURL_Expired(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("expired", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrURLExpired).Once()
+
+	_, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "expired"}.Build())
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, grpchandler.ReasonURLExpired, info.GetReason())
+}
+
+func TestExpandURL_ClickLimitReached(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("burned", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrClickLimitReached).Once()
+
+	_, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "burned"}.Build())
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, grpchandler.ReasonClickLimitReached, info.GetReason())
+}
+
+func TestExpandURL_Password(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("locked", model.LinkAccess{Password: "secret"}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com"}, nil).Once()
+	ts.mockUsecase.EXPECT().RecordClick("locked", mock.Anything).Once()
+
+	resp, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{
+		Id:       "locked",
+		Password: "secret",
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", resp.GetResult())
+}
+
+func TestExpandURL_PasswordErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{name: "Password required", err: usecase.ErrPasswordRequired, wantCode: codes.PermissionDenied, wantReason: grpchandler.ReasonPasswordRequired},
+		{name: "Invalid password", err: usecase.ErrInvalidPassword, wantCode: codes.PermissionDenied, wantReason: grpchandler.ReasonInvalidPassword},
+		{name: "Too many attempts", err: usecase.ErrTooManyAttempts, wantCode: codes.ResourceExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+
+			ts.mockUsecase.EXPECT().
+				GetOriginalURL("locked", model.LinkAccess{}, mock.Anything).
+				Return(model.Redirect{}, tt.err).Once()
+
+			_, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "locked"}.Build())
+			require.Error(t, err)
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code())
+			if tt.wantReason == "" {
+				return
+			}
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantReason, info.GetReason())
+		})
+	}
+}
+
+func TestExpandURL_Blocked(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("abc", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.URLBlockedError{URL: "https://evil.test/", Rule: "evil.test"}).Once()
+
+	_, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "abc"}.Build())
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, grpchandler.ReasonURLBlocked, info.GetReason())
+}
+
+func TestExpandURL_PreviewRequired(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("flagged", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrPreviewRequired).Once()
+	ts.mockUsecase.EXPECT().
+		GetOriginalURL("flagged", model.LinkAccess{Confirmed: true}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com"}, nil).Once()
+	ts.mockUsecase.EXPECT().RecordClick("flagged", mock.Anything).Once()
+
+	_, err := ts.client.ExpandURL(context.Background(), pb.URLExpandRequest_builder{Id: "flagged"}.Build())
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, grpchandler.ReasonPreviewRequired, info.GetReason())
+
+	resp, err := ts.client.ExpandURL(context.Background(),
+		pb.URLExpandRequest_builder{Id: "flagged", Confirmed: true}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", resp.GetResult())
+}
+
+// ─── GetQRCode ───────────────────────────────────────────────────────────────
+
+func TestGetQRCode(t *testing.T) {
+	ts := newTestServer(t)
+
+	margin := 0
+	ts.mockUsecase.EXPECT().
+		GetQRCode("abc", model.QROptions{Format: "svg", Size: 300, Level: "Q", Margin: &margin}).
+		Return(model.QRImage{Data: []byte("<svg/>"), ContentType: "image/svg+xml", ETag: `"e"`}, nil).Once()
+	ts.mockUsecase.EXPECT().
+		GetQRCode("missing", model.QROptions{}).
+		Return(model.QRImage{}, usecase.ErrURLNotFound).Once()
+
+	resp, err := ts.client.GetQRCode(context.Background(), pb.QRCodeRequest_builder{
+		Code:            "abc",
+		Format:          "svg",
+		Size:            300,
+		ErrorCorrection: "Q",
+		Margin:          proto.Int32(0),
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("<svg/>"), resp.GetImage())
+	assert.Equal(t, "image/svg+xml", resp.GetContentType())
+	assert.Equal(t, `"e"`, resp.GetEtag())
+
+	_, err = ts.client.GetQRCode(context.Background(), pb.QRCodeRequest_builder{Code: "missing"}.Build())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 // ─── ListUserURLs ─────────────────────────────────────────────────────────────
 
 func TestListUserURLs_Success(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		GetURLsByUserID("user-123").
-		Return([]model.UserURLResponse{
+		GetURLsByUserID("user-123", model.URLListRequest{}).
+		Return(model.URLList{URLs: []model.UserURLResponse{
 			{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://example.com"},
 			{ShortURL: "http://localhost:8080/def", OriginalURL: "https://google.com"},
-		}, nil).Once()
+		}}, nil).Once()
 
 	resp, err := ts.client.ListUserURLs(ts.authCtx(t, "user-123"), pb.ListUserURLsRequest_builder{}.Build())
 	require.NoError(t, err)
@@ -219,8 +586,8 @@ func TestListUserURLs_Empty(t *testing.T) {
 	ts := newTestServer(t)
 
 	ts.mockUsecase.EXPECT().
-		GetURLsByUserID("user-123").
-		Return([]model.UserURLResponse{}, nil).Once()
+		GetURLsByUserID("user-123", model.URLListRequest{}).
+		Return(model.URLList{}, nil).Once()
 
 	resp, err := ts.client.ListUserURLs(ts.authCtx(t, "user-123"), pb.ListUserURLsRequest_builder{}.Build())
 	require.NoError(t, err)
@@ -247,3 +614,362 @@ func TestListUserURLs_InvalidToken_Unauthenticated(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, codes.Unauthenticated, status.Code(err))
 }
+
+func TestListUserURLs_Deleted(t *testing.T) {
+	ts := newTestServer(t)
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	ts.mockUsecase.EXPECT().
+		GetDeletedURLsByUserID("user-123").
+		Return([]model.UserURLResponse{
+			{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://example.com", DeletedAt: &deletedAt},
+		}, nil).Once()
+
+	resp, err := ts.client.ListUserURLs(ts.authCtx(t, "user-123"), pb.ListUserURLsRequest_builder{Deleted: true}.Build())
+	require.NoError(t, err)
+	require.Len(t, resp.GetUrl(), 1)
+	assert.True(t, deletedAt.Equal(resp.GetUrl()[0].GetDeletedAt().AsTime()))
+}
+
+func TestListUserURLs_Filter(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetURLsByUserID("user-123", model.URLListRequest{
+			Filter: model.URLFilter{Tag: "work", Folder: "Projects"},
+		}).
+		Return(model.URLList{URLs: []model.UserURLResponse{
+			{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://example.com",
+				Tags: []string{"work"}, Folder: "Projects"},
+		}}, nil).Once()
+
+	resp, err := ts.client.ListUserURLs(ts.authCtx(t, "user-123"),
+		pb.ListUserURLsRequest_builder{Tag: "work", Folder: "Projects"}.Build())
+	require.NoError(t, err)
+	require.Len(t, resp.GetUrl(), 1)
+	assert.Equal(t, []string{"work"}, resp.GetUrl()[0].GetTags())
+	assert.Equal(t, "Projects", resp.GetUrl()[0].GetFolder())
+	assert.False(t, resp.GetUrl()[0].HasLastAccessedAt(), "never followed link has no last access time")
+}
+
+func TestListUserURLs_Pagination(t *testing.T) {
+	ts := newTestServer(t)
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	accessedAt := createdAt.Add(time.Hour)
+
+	ts.mockUsecase.EXPECT().
+		GetURLsByUserID("user-123", model.URLListRequest{
+			Search:    "docs",
+			Sort:      "clicks",
+			Order:     "desc",
+			PageSize:  1,
+			PageToken: "tok-1",
+		}).
+		Return(model.URLList{
+			URLs: []model.UserURLResponse{
+				{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://example.com/docs",
+					Clicks: 3, CreatedAt: &createdAt, LastAccessedAt: &accessedAt},
+			},
+			NextPageToken: "tok-2",
+		}, nil).Once()
+
+	resp, err := ts.client.ListUserURLs(ts.authCtx(t, "user-123"), pb.ListUserURLsRequest_builder{
+		Search:    "docs",
+		Sort:      "clicks",
+		Order:     "desc",
+		PageSize:  1,
+		PageToken: "tok-1",
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "tok-2", resp.GetNextPageToken())
+	require.Len(t, resp.GetUrl(), 1)
+	assert.Equal(t, int64(3), resp.GetUrl()[0].GetClicks())
+	assert.True(t, createdAt.Equal(resp.GetUrl()[0].GetCreatedAt().AsTime()))
+	assert.True(t, accessedAt.Equal(resp.GetUrl()[0].GetLastAccessedAt().AsTime()))
+}
+
+func TestListUserURLs_InvalidSort(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetURLsByUserID("user-123", model.URLListRequest{Sort: "title"}).
+		Return(model.URLList{}, usecase.ErrInvalidOptions).Once()
+
+	_, err := ts.client.ListUserURLs(ts.authCtx(t, "user-123"), pb.ListUserURLsRequest_builder{Sort: "title"}.Build())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// ─── SetURLLabels ─────────────────────────────────────────────────────────────
+
+func TestSetURLLabels_Success(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		SetURLLabels("abc", model.LinkLabels{Tags: []string{"Work"}, Folder: "Projects"}, "user-123").
+		Return(model.LinkLabels{Tags: []string{"work"}, Folder: "Projects"}, nil).Once()
+
+	resp, err := ts.client.SetURLLabels(ts.authCtx(t, "user-123"),
+		pb.SetURLLabelsRequest_builder{Code: "abc", Tags: []string{"Work"}, Folder: "Projects"}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"work"}, resp.GetTags())
+	assert.Equal(t, "Projects", resp.GetFolder())
+}
+
+func TestSetURLLabels_NotFound(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		SetURLLabels("abc", model.LinkLabels{}, "user-123").
+		Return(model.LinkLabels{}, usecase.ErrURLNotFound).Once()
+
+	_, err := ts.client.SetURLLabels(ts.authCtx(t, "user-123"), pb.SetURLLabelsRequest_builder{Code: "abc"}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSetURLLabels_NoToken_Unauthenticated(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := ts.client.SetURLLabels(context.Background(), pb.SetURLLabelsRequest_builder{Code: "abc"}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// ─── SetURLRules ──────────────────────────────────────────────────────────────
+
+func TestSetURLRules_Success(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		SetURLRules("abc", []model.RedirectRule{{Countries: []string{"de"}, URL: "https://example.de"}}, "user-123").
+		Return([]model.RedirectRule{{Countries: []string{"DE"}, URL: "https://example.de"}}, nil).Once()
+
+	resp, err := ts.client.SetURLRules(ts.authCtx(t, "user-123"), pb.SetURLRulesRequest_builder{
+		Code:  "abc",
+		Rules: []*pb.RedirectRule{pb.RedirectRule_builder{Countries: []string{"de"}, Url: "https://example.de"}.Build()},
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, resp.GetRules(), 1)
+	assert.Equal(t, []string{"DE"}, resp.GetRules()[0].GetCountries())
+	assert.Equal(t, "https://example.de", resp.GetRules()[0].GetUrl())
+}
+
+func TestSetURLRules_InvalidArgument(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		SetURLRules("abc", []model.RedirectRule{{URL: "https://example.de"}}, "user-123").
+		Return(nil, usecase.ErrInvalidOptions).Once()
+
+	_, err := ts.client.SetURLRules(ts.authCtx(t, "user-123"), pb.SetURLRulesRequest_builder{
+		Code:  "abc",
+		Rules: []*pb.RedirectRule{pb.RedirectRule_builder{Url: "https://example.de"}.Build()},
+	}.Build())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSetURLRules_NoToken_Unauthenticated(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := ts.client.SetURLRules(context.Background(), pb.SetURLRulesRequest_builder{Code: "abc"}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestSetURLVariants_Success(t *testing.T) {
+	ts := newTestServer(t)
+	variants := []model.SplitVariant{{URL: "https://a.example.com", Weight: 3}, {URL: "https://b.example.com", Weight: 1}}
+
+	ts.mockUsecase.EXPECT().SetURLVariants("abc", variants, "user-123").Return(variants, nil).Once()
+
+	resp, err := ts.client.SetURLVariants(ts.authCtx(t, "user-123"), pb.SetURLVariantsRequest_builder{
+		Code: "abc",
+		Variants: []*pb.SplitVariant{
+			pb.SplitVariant_builder{Url: "https://a.example.com", Weight: 3}.Build(),
+			pb.SplitVariant_builder{Url: "https://b.example.com", Weight: 1}.Build(),
+		},
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, resp.GetVariants(), 2)
+	assert.Equal(t, "https://b.example.com", resp.GetVariants()[1].GetUrl())
+	assert.Equal(t, int32(1), resp.GetVariants()[1].GetWeight())
+}
+
+func TestSetURLVariants_InvalidArgument(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		SetURLVariants("abc", []model.SplitVariant{{URL: "https://a.example.com", Weight: 1}}, "user-123").
+		Return(nil, usecase.ErrInvalidOptions).Once()
+
+	_, err := ts.client.SetURLVariants(ts.authCtx(t, "user-123"), pb.SetURLVariantsRequest_builder{
+		Code:     "abc",
+		Variants: []*pb.SplitVariant{pb.SplitVariant_builder{Url: "https://a.example.com", Weight: 1}.Build()},
+	}.Build())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSetURLVariants_NoToken_Unauthenticated(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := ts.client.SetURLVariants(context.Background(), pb.SetURLVariantsRequest_builder{Code: "abc"}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// ─── RestoreURLs ──────────────────────────────────────────────────────────────
+
+func TestRestoreURLs_Success(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		RestoreURLs([]string{"abc", "def"}, "user-123").
+		Return([]string{"abc"}, nil).Once()
+
+	resp, err := ts.client.RestoreURLs(ts.authCtx(t, "user-123"),
+		pb.RestoreURLsRequest_builder{Codes: []string{"abc", "def"}}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc"}, resp.GetCodes())
+}
+
+func TestRestoreURLs_EmptyCodes_InvalidArgument(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := ts.client.RestoreURLs(ts.authCtx(t, "user-123"), pb.RestoreURLsRequest_builder{}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRestoreURLs_NoToken_Unauthenticated(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := ts.client.RestoreURLs(context.Background(),
+		pb.RestoreURLsRequest_builder{Codes: []string{"abc"}}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// ─── GetURLStats ──────────────────────────────────────────────────────────────
+
+func TestGetURLStats_Success(t *testing.T) {
+	ts := newTestServer(t)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	ts.mockUsecase.EXPECT().
+		GetURLStats("abc", "user-123").
+		Return(model.URLStats{
+			Code:           "abc",
+			TotalClicks:    3,
+			UniqueVisitors: 2,
+			Daily:          []model.StatsBucket{{Start: day, Clicks: 3}},
+			Countries:      []model.StatsCount{{Value: "US", Clicks: 3}},
+			Variants:       []model.VariantCount{{Variant: 1, Clicks: 2}, {Variant: 2, Clicks: 1}},
+		}, nil).Once()
+
+	resp, err := ts.client.GetURLStats(ts.authCtx(t, "user-123"), pb.URLStatsRequest_builder{Code: "abc"}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.GetTotalClicks())
+	assert.Equal(t, int64(2), resp.GetUniqueVisitors())
+	require.Len(t, resp.GetDaily(), 1)
+	assert.True(t, day.Equal(resp.GetDaily()[0].GetStart().AsTime()))
+	require.Len(t, resp.GetCountries(), 1)
+	assert.Equal(t, "US", resp.GetCountries()[0].GetValue())
+	require.Len(t, resp.GetVariants(), 2)
+	assert.Equal(t, int32(2), resp.GetVariants()[1].GetVariant())
+	assert.Equal(t, int64(1), resp.GetVariants()[1].GetClicks())
+}
+
+func TestGetURLStats_NotFound(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		GetURLStats("abc", "user-123").
+		Return(model.URLStats{}, usecase.ErrURLNotFound).Once()
+
+	_, err := ts.client.GetURLStats(ts.authCtx(t, "user-123"), pb.URLStatsRequest_builder{Code: "abc"}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetURLStats_NoToken_Unauthenticated(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := ts.client.GetURLStats(context.Background(), pb.URLStatsRequest_builder{Code: "abc"}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// ─── UpdateURL ────────────────────────────────────────────────────────────────
+
+func TestUpdateURL_Success(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		UpdateURL("abc", "https://new.com", "user-123").
+		Return(model.URLUpdate{
+			ShortURL:    "http://localhost:8080/abc",
+			OriginalURL: "https://new.com",
+			PreviousURL: "https://old.com",
+		}, nil).Once()
+
+	resp, err := ts.client.UpdateURL(ts.authCtx(t, "user-123"),
+		pb.UpdateURLRequest_builder{Code: "abc", Url: "https://new.com"}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/abc", resp.GetShortUrl())
+	assert.Equal(t, "https://new.com", resp.GetOriginalUrl())
+	assert.Equal(t, "https://old.com", resp.GetPreviousUrl())
+}
+
+func TestUpdateURL_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "Foreign link", err: usecase.ErrURLNotFound, code: codes.NotFound},
+		{name: "Invalid URL", err: usecase.ErrInvalidURL, code: codes.InvalidArgument},
+		{name: "Conflict", err: usecase.URLAlreadyExistsError{Code: "http://localhost:8080/other"}, code: codes.AlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			ts.mockUsecase.EXPECT().
+				UpdateURL("abc", "https://new.com", "user-123").
+				Return(model.URLUpdate{}, tt.err).Once()
+
+			_, err := ts.client.UpdateURL(ts.authCtx(t, "user-123"),
+				pb.UpdateURLRequest_builder{Code: "abc", Url: "https://new.com"}.Build())
+			require.Error(t, err)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestUpdateURL_NoToken_Unauthenticated(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := ts.client.UpdateURL(context.Background(),
+		pb.UpdateURLRequest_builder{Code: "abc", Url: "https://new.com"}.Build())
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestShortenURL_URLTooLong(t *testing.T) {
+	ts := newTestServer(t)
+
+	ts.mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com/long", mock.AnythingOfType("string"), model.LinkOptions{}).
+		Return("", usecase.LimitExceededError{Limit: usecase.LimitURLLength, Max: 16, Actual: 24}).Once()
+
+	_, err := ts.client.ShortenURL(context.Background(), pb.URLShortenRequest_builder{Url: "https://example.com/long"}.Build())
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, grpchandler.ReasonLimitExceeded, info.GetReason())
+	assert.Equal(t, map[string]string{"limit": "url_length", "max": "16"}, info.GetMetadata())
+}
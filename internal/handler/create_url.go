@@ -14,6 +14,10 @@ func (h *Handler) CreateURL(w http.ResponseWriter, req *http.Request) {
 	// userID может быть пустым для анонимных пользователей
 
 	body, err := io.ReadAll(req.Body)
+	if limitErr, ok := bodyLimitError(err); ok {
+		h.handleError(w, limitErr)
+		return
+	}
 	if err != nil {
 		h.logger.Warn("failed to read request body",
 			zap.Error(err),
@@ -23,8 +27,14 @@ func (h *Handler) CreateURL(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	opts, err := linkOptionsFromQuery(req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
 	originalURL := string(body)
-	shortURL, err := h.usecase.CreateShortURLFromString(originalURL, userID)
+	shortURL, err := h.usecase.CreateShortURLFromString(originalURL, userID, opts)
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -15,6 +15,10 @@ func (h *Handler) CreateURLBatch(w http.ResponseWriter, req *http.Request) {
 
 	var requests []model.BatchShortenRequest
 	if err := json.NewDecoder(req.Body).Decode(&requests); err != nil {
+		if limitErr, ok := bodyLimitError(err); ok {
+			h.handleErrorJSON(w, limitErr)
+			return
+		}
 		h.logger.Warn("failed to decode JSON request",
 			zap.Error(err),
 			zap.String("remote_addr", req.RemoteAddr),
@@ -38,13 +42,19 @@ func (h *Handler) CreateURLBatch(w http.ResponseWriter, req *http.Request) {
 		urlStrings[i] = request.OriginalURL
 	}
 
-	// Создаем короткие URL
-	shortURLs, err := h.usecase.CreateShortURLsBatch(urlStrings, userID)
+	opts, err := linkOptionsFromQuery(req)
 	if err != nil {
 		h.handleError(w, err)
 		return
 	}
 
+	// Создаем короткие URL
+	shortURLs, err := h.usecase.CreateShortURLsBatch(urlStrings, userID, opts)
+	if err != nil {
+		h.handleErrorJSON(w, err)
+		return
+	}
+
 	// Формируем ответ
 	responses := make([]model.BatchShortenResponse, len(requests))
 	for i, request := range requests {
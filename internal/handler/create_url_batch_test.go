@@ -9,6 +9,7 @@ import (
 
 	"github.com/avc-dev/url-shortener/internal/mocks"
 	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -35,7 +36,7 @@ func TestCreateURLBatch(t *testing.T) {
 				{CorrelationID: "2", OriginalURL: "https://google.com"},
 			},
 			mockSetup: func() {
-				mockUsecase.EXPECT().CreateShortURLsBatch([]string{"https://example.com", "https://google.com"}, "").
+				mockUsecase.EXPECT().CreateShortURLsBatch([]string{"https://example.com", "https://google.com"}, "", model.LinkOptions{}).
 					Return([]string{"http://localhost:8080/abc123", "http://localhost:8080/def456"}, nil)
 			},
 			expectedStatus: http.StatusCreated,
@@ -48,6 +49,19 @@ func TestCreateURLBatch(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   false,
 		},
+		{
+			name: "batch too large",
+			requestBody: []model.BatchShortenRequest{
+				{CorrelationID: "1", OriginalURL: "https://example.com"},
+				{CorrelationID: "2", OriginalURL: "https://example.org"},
+			},
+			mockSetup: func() {
+				mockUsecase.EXPECT().CreateShortURLsBatch([]string{"https://example.com", "https://example.org"}, "", model.LinkOptions{}).
+					Return(nil, usecase.LimitExceededError{Limit: usecase.LimitBatchSize, Max: 1, Actual: 2})
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   false,
+		},
 		{
 			name:           "invalid json",
 			requestBody:    "invalid json",
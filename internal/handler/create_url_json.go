@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/avc-dev/url-shortener/internal/audit"
+	"github.com/avc-dev/url-shortener/internal/model"
 	"go.uber.org/zap"
 )
 
@@ -12,6 +13,38 @@ import (
 type ShortenRequest struct {
 	// URL — оригинальный URL, который нужно сократить.
 	URL string `json:"url"`
+	// CodeStyle — стиль генерируемого кода ("random" или "words"); необязательное поле.
+	CodeStyle string `json:"code_style,omitempty"`
+	// TTL — срок жизни ссылки в формате Go ("24h"); необязательное поле.
+	TTL string `json:"ttl,omitempty"`
+	// ExpiresAt — момент истечения ссылки в формате RFC 3339; необязательное поле,
+	// взаимоисключающее с TTL.
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// MaxClicks — число переходов, после которого ссылка перестаёт работать;
+	// необязательное поле, 0 — без ограничения.
+	MaxClicks int `json:"max_clicks,omitempty"`
+	// Password — пароль, без которого по ссылке нельзя перейти; необязательное поле.
+	// Передаётся только в теле запроса, чтобы не попадать в журналы вместе с URL.
+	Password string `json:"password,omitempty"`
+	// Tags — теги ссылки; необязательное поле.
+	Tags []string `json:"tags,omitempty"`
+	// Folder — папка ссылки; необязательное поле.
+	Folder string `json:"folder,omitempty"`
+	// RedirectStatus — код ответа на переход (301, 302, 307 или 308); необязательное поле.
+	RedirectStatus int `json:"redirect_status,omitempty"`
+	// CacheMaxAge — срок кэширования редиректа в формате Go ("1h", "0s" — не кэшировать);
+	// необязательное поле.
+	CacheMaxAge string `json:"cache_max_age,omitempty"`
+	// QueryPassthrough — передача query-параметров перехода в адрес назначения
+	// ("merge" или "override"); необязательное поле, по умолчанию параметры не передаются.
+	QueryPassthrough string `json:"query_passthrough,omitempty"`
+	// UTM — метки UTM, добавляемые к адресу назначения; необязательное поле.
+	UTM model.UTMParams `json:"utm"`
+	// Rules — правила условного редиректа по устройству, языку и стране в порядке проверки;
+	// необязательное поле.
+	Rules []model.RedirectRule `json:"rules,omitempty"`
+	// Variants — варианты сплит-ссылки с весами; необязательное поле.
+	Variants []model.SplitVariant `json:"variants,omitempty"`
 }
 
 // ShortenResponse — тело ответа на успешный POST /api/shorten.
@@ -27,6 +60,10 @@ func (h *Handler) CreateURLJSON(w http.ResponseWriter, req *http.Request) {
 
 	var request ShortenRequest
 	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		if limitErr, ok := bodyLimitError(err); ok {
+			h.handleErrorJSON(w, limitErr)
+			return
+		}
 		h.logger.Warn("failed to decode JSON request",
 			zap.Error(err),
 			zap.String("remote_addr", req.RemoteAddr),
@@ -35,7 +72,29 @@ func (h *Handler) CreateURLJSON(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	shortURL, err := h.usecase.CreateShortURLFromString(request.URL, userID)
+	opts := model.LinkOptions{
+		CodeStyle: codeStyleFromRequest(req, request.CodeStyle),
+		MaxClicks: request.MaxClicks,
+		Password:  request.Password,
+		Labels:    model.LinkLabels{Tags: request.Tags, Folder: request.Folder},
+		Redirect:  model.RedirectPolicy{Status: request.RedirectStatus},
+		Query: model.QueryPolicy{
+			Passthrough: model.QueryPassthrough(request.QueryPassthrough),
+			UTM:         request.UTM,
+		},
+		Rules:    request.Rules,
+		Variants: request.Variants,
+	}
+	if err := parseExpiry(&opts, request.TTL, request.ExpiresAt); err != nil {
+		h.handleErrorJSON(w, err)
+		return
+	}
+	if err := parseCacheMaxAge(&opts, request.CacheMaxAge); err != nil {
+		h.handleErrorJSON(w, err)
+		return
+	}
+
+	shortURL, err := h.usecase.CreateShortURLFromString(request.URL, userID, opts)
 	if err != nil {
 		h.handleErrorJSON(w, err)
 		return
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/avc-dev/url-shortener/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,7 +25,7 @@ func TestCreateURLJSON_Success(t *testing.T) {
 	expectedShortURL := "http://localhost:8080/abc12345"
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return(expectedShortURL, nil).
 		Once()
 
@@ -147,7 +148,7 @@ func TestCreateURLJSON_ErrorMapping(t *testing.T) {
 			// Arrange
 			mockUsecase := mocks.NewMockURLUsecase(t)
 			mockUsecase.EXPECT().
-				CreateShortURLFromString("https://example.com", "").
+				CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 				Return("", tt.usecaseError).
 				Once()
 
@@ -179,7 +180,7 @@ func TestCreateURLJSON_ResponseFormat(t *testing.T) {
 	mockUsecase := mocks.NewMockURLUsecase(t)
 	expectedShortURL := "http://localhost:8080/abc12345"
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://practicum.yandex.ru", "").
+		CreateShortURLFromString("https://practicum.yandex.ru", "", model.LinkOptions{}).
 		Return(expectedShortURL, nil).
 		Once()
 
@@ -225,7 +226,7 @@ func TestCreateURLJSON_ContentType(t *testing.T) {
 	// Arrange
 	mockUsecase := mocks.NewMockURLUsecase(t)
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return("http://localhost:8080/abc12345", nil).
 		Once()
 
@@ -257,7 +258,7 @@ func TestCreateURLJSON_PassesURLAsIs(t *testing.T) {
 	// Проверяем что usecase получает URL как есть из JSON
 	inputURL := "https://example.com"
 	mockUsecase.EXPECT().
-		CreateShortURLFromString(inputURL, "").
+		CreateShortURLFromString(inputURL, "", model.LinkOptions{}).
 		Return("http://localhost:8080/test1234", nil).
 		Once()
 
@@ -284,7 +285,7 @@ func TestCreateURLJSON_URLAlreadyExists(t *testing.T) {
 	mockUsecase := mocks.NewMockURLUsecase(t)
 	existingShortURL := "http://localhost:8080/existing"
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return("", usecase.URLAlreadyExistsError{Code: existingShortURL}).
 		Once()
 
@@ -318,3 +319,52 @@ func TestCreateURLJSON_URLAlreadyExists(t *testing.T) {
 
 	assert.Equal(t, existingShortURL, response.Result)
 }
+
+// TestCreateURLJSON_RejectedURL проверяет, что отказ политики адресов возвращается с причиной
+func TestCreateURLJSON_RejectedURL(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		CreateShortURLFromString("ftp://example.com", "", model.LinkOptions{}).
+		Return("", usecase.URLRejectedError{Reason: usecase.RejectSchemeNotAllowed, Detail: `scheme is not allowed: "ftp"`}).
+		Once()
+
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	bodyBytes, err := json.Marshal(ShortenRequest{URL: "ftp://example.com"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateURLJSON(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var response URLRejectedResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, "SCHEME_NOT_ALLOWED", response.Reason)
+	assert.Equal(t, `invalid URL: scheme is not allowed: "ftp"`, response.Error)
+}
+
+func TestCreateURLJSON_BodyTooLarge(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	bodyBytes, err := json.Marshal(ShortenRequest{URL: "https://example.com/" + strings.Repeat("a", 64)})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten", bytes.NewBuffer(bodyBytes))
+	req.Body = http.MaxBytesReader(w, req.Body, 32)
+
+	handler.CreateURLJSON(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var response LimitExceededResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, LimitExceededResponse{Error: "body_size exceeds limit 32", Limit: "body_size", Max: 32}, response)
+}
@@ -6,9 +6,11 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/avc-dev/url-shortener/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,7 +24,7 @@ func TestCreateURL_Success(t *testing.T) {
 	expectedShortURL := "http://localhost:8080/testcode"
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return(expectedShortURL, nil).
 		Once()
 
@@ -54,7 +56,7 @@ func TestCreateURL_EmptyBody(t *testing.T) {
 
 	// Usecase получит пустую строку и вернет ошибку валидации
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("", "").
+		CreateShortURLFromString("", "", model.LinkOptions{}).
 		Return("", usecase.ErrEmptyURL).
 		Once()
 
@@ -120,6 +122,11 @@ func TestCreateURL_ErrorMapping(t *testing.T) {
 			usecaseError:       usecase.ErrEmptyURL,
 			expectedHTTPStatus: http.StatusBadRequest,
 		},
+		{
+			name:               "ErrInvalidOptions maps to 400",
+			usecaseError:       usecase.ErrInvalidOptions,
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
 		{
 			name:               "Unknown error maps to 500",
 			usecaseError:       errors.New("unknown error"),
@@ -133,7 +140,7 @@ func TestCreateURL_ErrorMapping(t *testing.T) {
 			mockUsecase := mocks.NewMockURLUsecase(t)
 
 			mockUsecase.EXPECT().
-				CreateShortURLFromString("https://example.com", "").
+				CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 				Return("", tt.usecaseError).
 				Once()
 
@@ -161,7 +168,7 @@ func TestCreateURL_ContentType(t *testing.T) {
 	mockUsecase := mocks.NewMockURLUsecase(t)
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return("http://localhost:8080/testcode", nil).
 		Once()
 
@@ -189,7 +196,7 @@ func TestCreateURL_ResponseBody(t *testing.T) {
 	expectedShortURL := "http://localhost:8080/abc12345"
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://practicum.yandex.ru", "").
+		CreateShortURLFromString("https://practicum.yandex.ru", "", model.LinkOptions{}).
 		Return(expectedShortURL, nil).
 		Once()
 
@@ -240,7 +247,7 @@ func TestCreateURL_PassesBodyAsIs(t *testing.T) {
 
 			// Проверяем что usecase получает URL как есть, без обработки
 			mockUsecase.EXPECT().
-				CreateShortURLFromString(tt.inputURL, "").
+				CreateShortURLFromString(tt.inputURL, "", model.LinkOptions{}).
 				Return("http://localhost:8080/testcode", nil).
 				Once()
 
@@ -267,3 +274,56 @@ type errorReader struct {
 func (e *errorReader) Read(p []byte) (n int, err error) {
 	return 0, e.err
 }
+
+// TestCreateURL_RejectedURL проверяет, что текстовый ответ на отказ политики адресов начинается с причины
+func TestCreateURL_RejectedURL(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		CreateShortURLFromString("http://localhost/", "", model.LinkOptions{}).
+		Return("", usecase.URLRejectedError{Reason: usecase.RejectPrivateHost, Detail: "private host"}).
+		Once()
+
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("http://localhost/"))
+	w := httptest.NewRecorder()
+
+	handler.CreateURL(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "PRIVATE_HOST\ninvalid URL: private host", string(body))
+}
+
+func TestCreateURL_BodyTooLarge(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("https://example.com/"+strings.Repeat("a", 64)))
+	req.Body = http.MaxBytesReader(w, req.Body, 32)
+
+	handler.CreateURL(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, "body_size\nbody_size exceeds limit 32", w.Body.String())
+}
+
+func TestCreateURL_URLTooLong(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com/long", "", model.LinkOptions{}).
+		Return("", usecase.LimitExceededError{Limit: usecase.LimitURLLength, Max: 16, Actual: 24}).
+		Once()
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	w := httptest.NewRecorder()
+	handler.CreateURL(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("https://example.com/long")))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "url_length\nurl_length 24 exceeds limit 16", w.Body.String())
+}
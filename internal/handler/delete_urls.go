@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/avc-dev/url-shortener/internal/model"
 	"go.uber.org/zap"
 )
 
-// DeleteURLs обрабатывает DELETE запрос для удаления нескольких URL пользователя
+// DeleteURLs обрабатывает DELETE запрос для удаления нескольких URL пользователя.
+// С параметрами tag или folder удаляет все ссылки с тегом или в папке и возвращает их коды;
+// тело запроса при этом не читается.
 func (h *Handler) DeleteURLs(w http.ResponseWriter, req *http.Request) {
 	// Получаем userID из контекста
 	userID, ok := h.getUserIDFromRequest(req)
@@ -17,6 +20,11 @@ func (h *Handler) DeleteURLs(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if filter := urlFilterFromQuery(req); !filter.IsZero() {
+		h.deleteURLsByFilter(w, filter, userID)
+		return
+	}
+
 	// Декодируем тело запроса
 	var codes []string
 	if err := json.NewDecoder(req.Body).Decode(&codes); err != nil {
@@ -43,3 +51,19 @@ func (h *Handler) DeleteURLs(w http.ResponseWriter, req *http.Request) {
 	// Возвращаем 202 Accepted - запрос принят для обработки
 	w.WriteHeader(http.StatusAccepted)
 }
+
+// deleteURLsByFilter синхронно удаляет ссылки пользователя, подходящие под фильтр
+func (h *Handler) deleteURLsByFilter(w http.ResponseWriter, filter model.URLFilter, userID string) {
+	deleted, err := h.usecase.DeleteURLsByFilter(filter, userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(deleted); err != nil {
+		h.logger.Error("failed to encode deleted codes", zap.Error(err))
+	}
+}
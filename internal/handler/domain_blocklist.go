@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/avc-dev/url-shortener/internal/audit"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// blockedTemplate — HTML-страница вместо перехода на заблокированный домен.
+// Адрес назначения не выводится и продолжить переход со страницы нельзя.
+var blockedTemplate = template.Must(template.New("blocked").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>Link blocked</title>
+</head>
+<body>
+<h1>This link has been blocked</h1>
+<p role="alert">The destination of this link has been blocked following an abuse report.</p>
+</body>
+</html>
+`))
+
+// BlockRuleRequest — тело запроса добавления или снятия правила блокировки доменов.
+// Reason попадает только в аудит (например, номер жалобы).
+type BlockRuleRequest struct {
+	Pattern string `json:"pattern"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// blockedResponse — тело JSON-ответа на переход по заблокированной ссылке
+type blockedResponse struct {
+	Error string `json:"error"`
+}
+
+// renderBlocked отдаёт ответ со статусом 451 вместо перехода на заблокированный домен:
+// JSON, если клиент принимает application/json, иначе HTML-страницу предупреждения
+func (h *Handler) renderBlocked(w http.ResponseWriter, req *http.Request) {
+	// Блокировку можно снять — не кэшируем
+	w.Header().Set("Cache-Control", "no-store")
+
+	if strings.Contains(req.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnavailableForLegalReasons)
+		if err := json.NewEncoder(w).Encode(blockedResponse{Error: "link destination is blocked"}); err != nil {
+			h.logger.Error("failed to encode blocked response", zap.Error(err))
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusUnavailableForLegalReasons)
+	if err := blockedTemplate.Execute(w, nil); err != nil {
+		h.logger.Error("failed to render blocked page", zap.Error(err))
+	}
+}
+
+// GetBlockRules возвращает правила блокировки доменов: GET /api/internal/blocklist.
+// Проверка доступа по IP выполняется middleware.TrustedSubnet на уровне роутера.
+func (h *Handler) GetBlockRules(w http.ResponseWriter, req *http.Request) {
+	rules := h.usecase.GetBlockRules()
+	if rules == nil {
+		rules = []model.BlockRule{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(rules); err != nil {
+		h.logger.Error("failed to encode block rules", zap.Error(err))
+	}
+}
+
+// BlockDomain добавляет правило блокировки доменов: POST /api/internal/blocklist
+// с телом {"pattern": "*.example.com", "reason": "..."}. Отвечает 201 с правилом
+// в каноническом виде или 200, если такое правило уже было. Добавление записывается в аудит.
+func (h *Handler) BlockDomain(w http.ResponseWriter, req *http.Request) {
+	request, ok := h.decodeBlockRuleRequest(w, req)
+	if !ok {
+		return
+	}
+
+	rule, created, err := h.usecase.BlockDomain(request.Pattern)
+	if err != nil {
+		h.handleBlocklistError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		h.emitAuditEvent(req, audit.NewBlockEvent(audit.ActionBlock, rule.Pattern, request.Reason))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		h.logger.Error("failed to encode block rule", zap.Error(err))
+	}
+}
+
+// UnblockDomain снимает правило блокировки доменов: DELETE /api/internal/blocklist
+// с телом {"pattern": "*.example.com", "reason": "..."}. Отвечает 204 или 404,
+// если правила нет. Снятие записывается в аудит.
+func (h *Handler) UnblockDomain(w http.ResponseWriter, req *http.Request) {
+	request, ok := h.decodeBlockRuleRequest(w, req)
+	if !ok {
+		return
+	}
+
+	rule, err := h.usecase.UnblockDomain(request.Pattern)
+	if err != nil {
+		h.handleBlocklistError(w, err)
+		return
+	}
+
+	h.emitAuditEvent(req, audit.NewBlockEvent(audit.ActionUnblock, rule.Pattern, request.Reason))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleBlocklistError отвечает на ошибку изменения списка блокировки. Если список
+// не удалось сохранить, изменение не применено и его можно повторить — отвечает 503.
+func (h *Handler) handleBlocklistError(w http.ResponseWriter, err error) {
+	if errors.Is(err, usecase.ErrServiceUnavailable) {
+		h.logger.Warn("failed to save domain blocklist", zap.Error(err))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	h.handleError(w, err)
+}
+
+// decodeBlockRuleRequest разбирает тело запроса правила блокировки; при ошибке отвечает 400
+func (h *Handler) decodeBlockRuleRequest(w http.ResponseWriter, req *http.Request) (BlockRuleRequest, bool) {
+	var request BlockRuleRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		h.logger.Warn("failed to decode JSON request",
+			zap.Error(err),
+			zap.String("remote_addr", req.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return BlockRuleRequest{}, false
+	}
+	return request, true
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/audit"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetBlockRules(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().GetBlockRules().Return(nil).Once()
+	h := New(mockUsecase, zap.NewNop(), nil)
+
+	w := httptest.NewRecorder()
+	h.GetBlockRules(w, httptest.NewRequest(http.MethodGet, "/api/internal/blocklist", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestBlockDomain(t *testing.T) {
+	rule := model.BlockRule{Pattern: "*.evil.test", Kind: model.BlockRuleSuffix}
+
+	tests := []struct {
+		name         string
+		body         string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		expectAudit  bool
+	}{
+		{
+			name: "New rule",
+			body: `{"pattern": "*.Evil.test", "reason": "report 42"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().BlockDomain("*.Evil.test").Return(rule, true, nil).Once()
+			},
+			expectedCode: http.StatusCreated,
+			expectAudit:  true,
+		},
+		{
+			name: "Existing rule",
+			body: `{"pattern": "*.evil.test"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().BlockDomain("*.evil.test").Return(rule, false, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Invalid pattern",
+			body: `{"pattern": "evil.test/path"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().BlockDomain("evil.test/path").Return(model.BlockRule{}, false, usecase.ErrInvalidOptions).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Blocklist not saved",
+			body: `{"pattern": "*.evil.test"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().BlockDomain("*.evil.test").Return(model.BlockRule{}, false, usecase.ErrServiceUnavailable).Once()
+			},
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			aud := &testAuditor{}
+			h := New(mockUsecase, zap.NewNop(), nil, aud)
+
+			w := httptest.NewRecorder()
+			h.BlockDomain(w, httptest.NewRequest(http.MethodPost, "/api/internal/blocklist", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusCreated || tt.expectedCode == http.StatusOK {
+				var got model.BlockRule
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+				assert.Equal(t, rule, got)
+			}
+
+			events := aud.snapshot()
+			if !tt.expectAudit {
+				assert.Empty(t, events)
+				return
+			}
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.ActionBlock, events[0].Action)
+			assert.Equal(t, "*.evil.test", events[0].Rule)
+			assert.Equal(t, "report 42", events[0].Reason)
+		})
+	}
+}
+
+func TestUnblockDomain(t *testing.T) {
+	t.Run("Rule removed", func(t *testing.T) {
+		mockUsecase := mocks.NewMockURLUsecase(t)
+		mockUsecase.EXPECT().UnblockDomain("evil.test").
+			Return(model.BlockRule{Pattern: "evil.test", Kind: model.BlockRuleExact}, nil).Once()
+		aud := &testAuditor{}
+		h := New(mockUsecase, zap.NewNop(), nil, aud)
+
+		w := httptest.NewRecorder()
+		h.UnblockDomain(w, httptest.NewRequest(http.MethodDelete, "/api/internal/blocklist",
+			strings.NewReader(`{"pattern": "evil.test", "reason": "appeal accepted"}`)))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		events := aud.snapshot()
+		require.Len(t, events, 1)
+		assert.Equal(t, audit.ActionUnblock, events[0].Action)
+		assert.Equal(t, "evil.test", events[0].Rule)
+		assert.Equal(t, "appeal accepted", events[0].Reason)
+	})
+
+	t.Run("Unknown rule", func(t *testing.T) {
+		mockUsecase := mocks.NewMockURLUsecase(t)
+		mockUsecase.EXPECT().UnblockDomain("other.test").
+			Return(model.BlockRule{}, usecase.ErrBlockRuleNotFound).Once()
+		aud := &testAuditor{}
+		h := New(mockUsecase, zap.NewNop(), nil, aud)
+
+		w := httptest.NewRecorder()
+		h.UnblockDomain(w, httptest.NewRequest(http.MethodDelete, "/api/internal/blocklist",
+			strings.NewReader(`{"pattern": "other.test"}`)))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, aud.snapshot())
+	})
+}
+
+func TestGetURL_BlockedDomain(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		bodyPart    string
+	}{
+		{name: "Warning page", contentType: "text/html; charset=utf-8", bodyPart: "This link has been blocked"},
+		{name: "JSON", accept: "application/json", contentType: "application/json", bodyPart: `"error"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().GetOriginalURL("abc", model.LinkAccess{}, mock.Anything).
+				Return(model.Redirect{}, usecase.URLBlockedError{URL: "https://evil.test/", Rule: "evil.test"}).Once()
+			aud := &testAuditor{}
+			h := New(mockUsecase, zap.NewNop(), nil, aud)
+
+			req := httptest.NewRequest(http.MethodGet, "/abc", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "abc")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			h.GetURL(w, req)
+
+			assert.Equal(t, http.StatusUnavailableForLegalReasons, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.Contains(t, w.Body.String(), tt.bodyPart)
+			assert.NotContains(t, w.Body.String(), "evil.test", "destination is not disclosed")
+
+			events := aud.snapshot()
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.ActionBlocked, events[0].Action)
+			assert.Equal(t, "abc", events[0].ShortCode)
+			assert.Equal(t, "https://evil.test/", events[0].URL)
+			assert.Equal(t, "evil.test", events[0].Rule)
+		})
+	}
+}
+
+func TestGetURL_PreviewBlockedDomain(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().PreviewURL("abc", model.LinkAccess{}).
+		Return(model.URLPreview{}, usecase.URLBlockedError{URL: "https://evil.test/", Rule: "evil.test"}).Once()
+	h := New(mockUsecase, zap.NewNop(), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/abc+", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "abc+")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	h.GetURL(w, req)
+
+	assert.Equal(t, http.StatusUnavailableForLegalReasons, w.Code)
+}
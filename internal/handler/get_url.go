@@ -1,23 +1,115 @@
 package handler
 
 import (
+	"errors"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
-	"github.com/go-chi/chi/v5"
+	"github.com/avc-dev/url-shortener/internal/audit"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
 )
 
-// GetURL обрабатывает GET запрос для редиректа на оригинальный URL по короткому коду
+// GetURL обрабатывает GET запрос для редиректа на оригинальный URL по короткому коду.
+// Код ответа и Cache-Control задаются параметрами редиректа ссылки.
+// Для защищённой паролем ссылки без действительной куки доступа отдаёт форму ввода пароля.
+// По /{id}+ или ?preview=1, а также для небезопасной ссылки, если для таких ссылок
+// включён предпросмотр, вместо редиректа отдаёт страницу предпросмотра; подтвердить переход
+// можно только формой этой страницы (см. ConfirmURL).
+// Вариант сплит-ссылки закрепляется за посетителем кукой. Переход на заблокированный
+// домен не выполняется: отдаётся страница предупреждения со статусом 451.
 func (h *Handler) GetURL(w http.ResponseWriter, req *http.Request) {
-	code := chi.URLParam(req, "id")
+	code, preview := previewMode(req)
+	access := linkAccessFromRequest(req)
 
-	originalURL, err := h.usecase.GetOriginalURL(code)
+	if preview {
+		h.renderPreview(w, req, code, access)
+		return
+	}
+
+	h.followURL(w, req, code, access, 0)
+}
+
+// followURL выполняет переход по ссылке: отвечает редиректом с кодом status,
+// а при нулевом status — с кодом ответа из параметров редиректа ссылки
+func (h *Handler) followURL(w http.ResponseWriter, req *http.Request, code string, access model.LinkAccess, status int) {
+	visit := visitFromRequest(req)
+	visit.Variant = pinnedSplitVariant(req)
+	redirect, err := h.usecase.GetOriginalURL(code, access, visit)
+	if errors.Is(err, usecase.ErrPreviewRequired) {
+		h.renderPreview(w, req, code, access)
+		return
+	}
+	var blockedErr usecase.URLBlockedError
+	if errors.As(err, &blockedErr) {
+		userID, _ := h.getUserIDFromRequest(req)
+		h.emitAuditEvent(req, audit.NewBlockedEvent(userID, code, blockedErr.URL, blockedErr.Rule))
+		h.renderBlocked(w, req)
+		return
+	}
 	if err != nil {
-		h.handleError(w, err)
+		h.handlePasswordError(w, req, code, err)
 		return
 	}
 
 	userID, _ := h.getUserIDFromRequest(req)
-	h.emitAuditFollow(req, userID, code, originalURL)
+	h.emitAuditFollow(req, userID, code, redirect)
+	visit.Variant = redirect.Variant
+	h.usecase.RecordClick(code, visit)
+
+	if redirect.Variant > 0 {
+		pinSplitVariant(w, req, code, redirect.Variant)
+	}
+
+	if status == 0 {
+		status = redirect.Status
+	}
+	w.Header().Set("Cache-Control", redirectCacheControl(redirect.MaxAge))
+	http.Redirect(w, req, redirect.URL, status)
+}
+
+// linkAccessFromRequest собирает подтверждения доступа к ссылке из куки запроса
+func linkAccessFromRequest(req *http.Request) model.LinkAccess {
+	var access model.LinkAccess
+	if cookie, err := req.Cookie(linkAccessCookieName); err == nil {
+		access.Token = cookie.Value
+	}
+	return access
+}
+
+// redirectCacheControl возвращает Cache-Control редиректа. Без явного запрета
+// браузеры кэшируют 301 и 308 бессрочно, поэтому некэшируемый редирект получает no-store.
+func redirectCacheControl(maxAge time.Duration) string {
+	seconds := int64(maxAge / time.Second)
+	if seconds <= 0 {
+		return "no-store"
+	}
+	return "public, max-age=" + strconv.FormatInt(seconds, 10)
+}
+
+// visitFromRequest собирает сведения о переходе для статистики.
+// Адрес клиента берётся из X-Real-IP, как и в middleware.TrustedSubnet,
+// а при его отсутствии — из адреса соединения. Служебный параметр preview
+// в query-параметры перехода не попадает.
+func visitFromRequest(req *http.Request) model.Visit {
+	ip := req.Header.Get("X-Real-IP")
+	if ip == "" {
+		ip = req.RemoteAddr
+		if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			ip = host
+		}
+	}
+
+	query := req.URL.Query()
+	query.Del("preview")
 
-	http.Redirect(w, req, originalURL, http.StatusTemporaryRedirect)
+	return model.Visit{
+		Referrer:       req.Referer(),
+		UserAgent:      req.UserAgent(),
+		IP:             ip,
+		AcceptLanguage: req.Header.Get("Accept-Language"),
+		Query:          query,
+	}
 }
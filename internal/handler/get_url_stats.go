@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// GetURLStats возвращает статистику переходов по ссылке аутентифицированного пользователя.
+// Для чужой или несуществующей ссылки отвечает 404.
+func (h *Handler) GetURLStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserIDFromRequest(r)
+	if !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	stats, err := h.usecase.GetURLStats(chi.URLParam(r, "code"), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		h.logger.Error("failed to encode URL stats", zap.Error(err))
+	}
+}
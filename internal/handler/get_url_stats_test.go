@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestGetURLStats проверяет выдачу статистики переходов владельцу ссылки
+func TestGetURLStats(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+	}{
+		{
+			name:   "Success",
+			userID: "user-1",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetURLStats("abc", "user-1").
+					Return(model.URLStats{Code: "abc", TotalClicks: 3, UniqueVisitors: 2}, nil).
+					Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Unauthorized",
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "Foreign link",
+			userID: "user-2",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetURLStats("abc", "user-2").
+					Return(model.URLStats{}, fmt.Errorf("%w: code abc", usecase.ErrURLNotFound)).
+					Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			handler := New(mockUsecase, zap.NewNop(), nil)
+
+			w := httptest.NewRecorder()
+			handler.GetURLStats(w, newCodeRequest(http.MethodGet, "abc", "/stats", "", tt.userID))
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+				var stats model.URLStats
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+				assert.Equal(t, 3, stats.TotalClicks)
+				assert.Equal(t, 2, stats.UniqueVisitors)
+			}
+		})
+	}
+}
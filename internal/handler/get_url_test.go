@@ -4,12 +4,16 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/avc-dev/url-shortener/internal/usecase"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 )
 
@@ -63,9 +67,10 @@ func TestGetURL_Success(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 			mockUsecase.EXPECT().
-				GetOriginalURL(tt.code).
-				Return(tt.expectedURL, nil).
+				GetOriginalURL(tt.code, model.LinkAccess{}, mock.Anything).
+				Return(model.Redirect{URL: tt.expectedURL, Status: http.StatusTemporaryRedirect}, nil).
 				Once()
 
 			handler := New(mockUsecase, zap.NewNop(), nil)
@@ -118,9 +123,10 @@ func TestGetURL_NotFound(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 			mockUsecase.EXPECT().
-				GetOriginalURL(tt.code).
-				Return("", usecase.ErrURLNotFound).
+				GetOriginalURL(tt.code, model.LinkAccess{}, mock.Anything).
+				Return(model.Redirect{}, usecase.ErrURLNotFound).
 				Once()
 
 			handler := New(mockUsecase, zap.NewNop(), nil)
@@ -149,9 +155,10 @@ func TestGetURL_NotFound(t *testing.T) {
 func TestGetURL_EmptyCode(t *testing.T) {
 	// Arrange
 	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 	mockUsecase.EXPECT().
-		GetOriginalURL("").
-		Return("", usecase.ErrURLNotFound).
+		GetOriginalURL("", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrURLNotFound).
 		Once()
 
 	handler := New(mockUsecase, zap.NewNop(), nil)
@@ -211,9 +218,10 @@ func TestGetURL_CodeExtraction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 			mockUsecase.EXPECT().
-				GetOriginalURL(tt.expectedCode).
-				Return("https://example.com", nil).
+				GetOriginalURL(tt.expectedCode, model.LinkAccess{}, mock.Anything).
+				Return(model.Redirect{URL: "https://example.com", Status: http.StatusTemporaryRedirect}, nil).
 				Once()
 
 			handler := New(mockUsecase, zap.NewNop(), nil)
@@ -295,15 +303,16 @@ func TestGetURL_BoundaryConditions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 			if tt.returnError != nil {
 				mockUsecase.EXPECT().
-					GetOriginalURL(tt.code).
-					Return("", usecase.ErrURLNotFound).
+					GetOriginalURL(tt.code, model.LinkAccess{}, mock.Anything).
+					Return(model.Redirect{}, usecase.ErrURLNotFound).
 					Once()
 			} else {
 				mockUsecase.EXPECT().
-					GetOriginalURL(tt.code).
-					Return(tt.returnURL, nil).
+					GetOriginalURL(tt.code, model.LinkAccess{}, mock.Anything).
+					Return(model.Redirect{URL: tt.returnURL, Status: http.StatusTemporaryRedirect}, nil).
 					Once()
 			}
 
@@ -336,9 +345,10 @@ func TestGetURL_BoundaryConditions(t *testing.T) {
 func TestGetURL_UnicodeURL(t *testing.T) {
 	// Arrange
 	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 	mockUsecase.EXPECT().
-		GetOriginalURL("abc12345").
-		Return("https://example.com/путь", nil).
+		GetOriginalURL("abc12345", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com/путь", Status: http.StatusTemporaryRedirect}, nil).
 		Once()
 
 	handler := New(mockUsecase, zap.NewNop(), nil)
@@ -378,9 +388,10 @@ func TestGetURL_UnicodeURL(t *testing.T) {
 func TestGetURL_RedirectStatusCode(t *testing.T) {
 	// Arrange
 	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 	mockUsecase.EXPECT().
-		GetOriginalURL("abc12345").
-		Return("https://example.com", nil).
+		GetOriginalURL("abc12345", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com", Status: http.StatusTemporaryRedirect}, nil).
 		Once()
 
 	handler := New(mockUsecase, zap.NewNop(), nil)
@@ -410,9 +421,11 @@ func TestGetURL_UsecaseInteraction(t *testing.T) {
 	expectedCode := "testcode"
 
 	mockUsecase := mocks.NewMockURLUsecase(t)
+
+	mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 	mockUsecase.EXPECT().
-		GetOriginalURL(expectedCode).
-		Return("https://example.com", nil).
+		GetOriginalURL(expectedCode, model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com", Status: http.StatusTemporaryRedirect}, nil).
 		Once()
 
 	handler := New(mockUsecase, zap.NewNop(), nil)
@@ -432,12 +445,13 @@ func TestGetURL_UsecaseInteraction(t *testing.T) {
 func TestGetURL_ConcurrentRequests(t *testing.T) {
 	// Arrange
 	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
 	// Ожидаем 10 различных вызовов
 	for i := 0; i < 10; i++ {
 		code := string(rune('a' + i))
 		mockUsecase.EXPECT().
-			GetOriginalURL(code).
-			Return("https://example.com/"+code, nil).
+			GetOriginalURL(code, model.LinkAccess{}, mock.Anything).
+			Return(model.Redirect{URL: "https://example.com/" + code, Status: http.StatusTemporaryRedirect}, nil).
 			Once()
 	}
 
@@ -474,3 +488,136 @@ func TestGetURL_ConcurrentRequests(t *testing.T) {
 		<-done
 	}
 }
+
+// TestGetURL_Gone проверяет ответ 410 для удалённой и истёкшей ссылки
+func TestGetURL_Gone(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "Deleted link", err: usecase.ErrURLDeleted},
+		{name: "Expired link", err: usecase.ErrURLExpired},
+		{name: "Click limit reached", err: usecase.ErrClickLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Maybe()
+			mockUsecase.EXPECT().
+				GetOriginalURL("abc12345", model.LinkAccess{}, mock.Anything).
+				Return(model.Redirect{}, tt.err).
+				Once()
+
+			handler := New(mockUsecase, zap.NewNop(), nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/abc12345", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "abc12345")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.GetURL(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusGone, resp.StatusCode)
+		})
+	}
+}
+
+// TestGetURL_RecordsClick проверяет, что успешный переход передаётся в статистику со сведениями о клиенте
+func TestGetURL_RecordsClick(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		GetOriginalURL("abc12345", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com", Status: http.StatusTemporaryRedirect}, nil).
+		Once()
+	mockUsecase.EXPECT().
+		RecordClick("abc12345", model.Visit{
+			Referrer:  "https://news.example.org/post",
+			UserAgent: "Mozilla/5.0 Firefox/128.0",
+			IP:        "203.0.113.7",
+			Query:     url.Values{},
+		}).
+		Once()
+
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/abc12345", nil)
+	req.Header.Set("Referer", "https://news.example.org/post")
+	req.Header.Set("User-Agent", "Mozilla/5.0 Firefox/128.0")
+	req.Header.Set("X-Real-IP", "203.0.113.7")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "abc12345")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.GetURL(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+}
+
+// TestGetURL_RedirectPolicy проверяет, что код ответа и Cache-Control берутся из параметров редиректа
+func TestGetURL_RedirectPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		redirect      model.Redirect
+		expectedCache string
+	}{
+		{
+			name:          "Cacheable permanent redirect",
+			redirect:      model.Redirect{URL: "https://example.com", Status: http.StatusMovedPermanently, MaxAge: time.Hour},
+			expectedCache: "public, max-age=3600",
+		},
+		{
+			name:          "Not cacheable",
+			redirect:      model.Redirect{URL: "https://example.com", Status: http.StatusPermanentRedirect},
+			expectedCache: "no-store",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().GetOriginalURL("abc", model.LinkAccess{}, mock.Anything).Return(tt.redirect, nil).Once()
+			mockUsecase.EXPECT().RecordClick("abc", mock.Anything).Once()
+			handler := New(mockUsecase, zap.NewNop(), nil)
+
+			w := httptest.NewRecorder()
+			handler.GetURL(w, newRedirectRequest("/abc", "abc"))
+
+			assert.Equal(t, tt.redirect.Status, w.Code)
+			assert.Equal(t, tt.redirect.URL, w.Header().Get("Location"))
+			assert.Equal(t, tt.expectedCache, w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+// TestGetURL_ForwardsVisit проверяет, что Accept-Language и query-параметры перехода
+// без служебного preview передаются и в usecase для редиректа, и в статистику
+func TestGetURL_ForwardsVisit(t *testing.T) {
+	visit := model.Visit{
+		IP:             "192.0.2.1",
+		AcceptLanguage: "de-AT,de;q=0.9",
+		Query:          url.Values{"ref": {"tw"}, "lang": {"de"}},
+	}
+
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		GetOriginalURL("abc", model.LinkAccess{}, visit).
+		Return(model.Redirect{URL: "https://example.com/?lang=de&ref=tw", Status: http.StatusFound}, nil).
+		Once()
+	mockUsecase.EXPECT().RecordClick("abc", visit).Once()
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	req := newRedirectRequest("/abc?ref=tw&preview=0&lang=de", "abc")
+	req.Header.Set("Accept-Language", "de-AT,de;q=0.9")
+	w := httptest.NewRecorder()
+	handler.GetURL(w, req)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/?lang=de&ref=tw", w.Header().Get("Location"))
+}
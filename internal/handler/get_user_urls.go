@@ -2,12 +2,20 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
 	"go.uber.org/zap"
 )
 
-// GetUserURLs возвращает все URL для аутентифицированного пользователя
+// GetUserURLs возвращает страницу URL аутентифицированного пользователя.
+// Параметры tag и folder отбирают ссылки с тегом и в папке, search — по подстроке адреса;
+// sort (created_at, clicks, url) и order (asc, desc) задают порядок, page_size — размер страницы.
+// Ссылка на следующую страницу передаётся в заголовке Link с rel="next".
+// С параметром deleted=true возвращает корзину — удалённые ссылки, которые ещё можно восстановить.
 func (h *Handler) GetUserURLs(w http.ResponseWriter, r *http.Request) {
 	userID, ok := h.getUserIDFromRequest(r)
 	if !ok {
@@ -16,25 +24,66 @@ func (h *Handler) GetUserURLs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	urls, err := h.usecase.GetURLsByUserID(userID)
+	var list model.URLList
+	var err error
+	if r.URL.Query().Get("deleted") == "true" {
+		list.URLs, err = h.usecase.GetDeletedURLsByUserID(userID)
+	} else {
+		var request model.URLListRequest
+		request, err = urlListRequestFromQuery(r)
+		if err == nil {
+			list, err = h.usecase.GetURLsByUserID(userID, request)
+		}
+	}
 	if err != nil {
 		h.handleError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if list.NextPageToken != "" {
+		w.Header().Set("Link", nextPageLink(r, list.NextPageToken))
+	}
 
 	// Если нет URL, возвращаем 204 No Content
-	if len(urls) == 0 {
+	if len(list.URLs) == 0 {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 
-	if err := json.NewEncoder(w).Encode(urls); err != nil {
+	if err := json.NewEncoder(w).Encode(list.URLs); err != nil {
 		h.logger.Error("failed to encode user URLs", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
+
+// urlListRequestFromQuery собирает параметры списка ссылок из query-параметров
+func urlListRequestFromQuery(r *http.Request) (model.URLListRequest, error) {
+	query := r.URL.Query()
+	request := model.URLListRequest{
+		Filter:    urlFilterFromQuery(r),
+		Search:    query.Get("search"),
+		Sort:      query.Get("sort"),
+		Order:     query.Get("order"),
+		PageToken: query.Get("page_token"),
+	}
+	if pageSize := query.Get("page_size"); pageSize != "" {
+		n, err := strconv.Atoi(pageSize)
+		if err != nil {
+			return model.URLListRequest{}, fmt.Errorf("%w: invalid page_size %q", usecase.ErrInvalidOptions, pageSize)
+		}
+		request.PageSize = n
+	}
+	return request, nil
+}
+
+// nextPageLink формирует значение заголовка Link для следующей страницы:
+// тот же запрос с новым page_token. Ссылка относительная и разрешается от URL запроса.
+func nextPageLink(r *http.Request, pageToken string) string {
+	query := r.URL.Query()
+	query.Set("page_token", pageToken)
+	return fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, query.Encode())
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetUserURLs_Pagination(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		expectedLink string
+	}{
+		{
+			name:   "Next page link keeps the query",
+			target: "/api/user/urls?sort=clicks&order=desc&page_size=2&search=docs",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetURLsByUserID("user-1", model.URLListRequest{
+					Search:   "docs",
+					Sort:     "clicks",
+					Order:    "desc",
+					PageSize: 2,
+				}).Return(model.URLList{
+					URLs:          []model.UserURLResponse{{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://example.com/docs"}},
+					NextPageToken: "tok-2",
+				}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedLink: "/api/user/urls?order=desc&page_size=2&page_token=tok-2&search=docs&sort=clicks",
+		},
+		{
+			name:   "Last page has no link",
+			target: "/api/user/urls?page_token=tok-2",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetURLsByUserID("user-1", model.URLListRequest{PageToken: "tok-2"}).
+					Return(model.URLList{URLs: []model.UserURLResponse{{ShortURL: "http://localhost:8080/def"}}}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Non-numeric page size",
+			target:       "/api/user/urls?page_size=ten",
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Invalid sort rejected by usecase",
+			target: "/api/user/urls?sort=title",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetURLsByUserID("user-1", mock.Anything).
+					Return(model.URLList{}, usecase.ErrInvalidOptions).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			w := httptest.NewRecorder()
+			h.GetUserURLs(w, newUserRequest(http.MethodGet, tt.target, "", "user-1"))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			link := w.Header().Get("Link")
+			if tt.expectedLink == "" {
+				assert.Empty(t, link)
+				return
+			}
+			require.True(t, strings.HasPrefix(link, "<") && strings.HasSuffix(link, `>; rel="next"`), link)
+			got, err := url.Parse(strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`))
+			require.NoError(t, err)
+			want, err := url.Parse(tt.expectedLink)
+			require.NoError(t, err)
+			assert.Equal(t, want.Path, got.Path)
+			assert.Equal(t, want.Query(), got.Query())
+		})
+	}
+}
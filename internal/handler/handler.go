@@ -6,6 +6,7 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/avc-dev/url-shortener/internal/audit"
@@ -23,12 +24,30 @@ type Auditor = audit.Notifier
 
 // URLUsecase определяет интерфейс для бизнес-логики работы с URL
 type URLUsecase interface {
-	CreateShortURLFromString(urlString string, userID string) (string, error)
-	CreateShortURLsBatch(urlStrings []string, userID string) ([]string, error)
-	GetOriginalURL(code string) (string, error)
-	GetURLsByUserID(userID string) ([]model.UserURLResponse, error)
+	CreateShortURLFromString(urlString string, userID string, opts model.LinkOptions) (string, error)
+	CreateShortURLsBatch(urlStrings []string, userID string, opts model.LinkOptions) ([]string, error)
+	GetOriginalURL(code string, access model.LinkAccess, visit model.Visit) (model.Redirect, error)
+	PreviewURL(code string, access model.LinkAccess) (model.URLPreview, error)
+	SetURLUnsafe(code string, unsafe bool) error
+	GetQRCode(code string, opts model.QROptions) (model.QRImage, error)
+	UnlockURL(code, password string) (string, error)
+	RecordClick(code string, visit model.Visit)
+	GetURLStats(code string, userID string) (model.URLStats, error)
+	GetURLsByUserID(userID string, request model.URLListRequest) (model.URLList, error)
+	SetURLLabels(code string, labels model.LinkLabels, userID string) (model.LinkLabels, error)
+	SetURLRules(code string, rules []model.RedirectRule, userID string) ([]model.RedirectRule, error)
+	SetURLVariants(code string, variants []model.SplitVariant, userID string) ([]model.SplitVariant, error)
+	UpdateURL(code, urlString, userID string) (model.URLUpdate, error)
+	GetURLHistory(code, userID string) (model.URLHistory, error)
+	RollbackURL(code string, version int, userID string) (model.URLUpdate, error)
 	DeleteURLs(codes []string, userID string) error
+	DeleteURLsByFilter(filter model.URLFilter, userID string) ([]string, error)
+	GetDeletedURLsByUserID(userID string) ([]model.UserURLResponse, error)
+	RestoreURLs(codes []string, userID string) ([]string, error)
 	GetStats() (model.Stats, error)
+	GetBlockRules() []model.BlockRule
+	BlockDomain(pattern string) (model.BlockRule, bool, error)
+	UnblockDomain(pattern string) (model.BlockRule, error)
 }
 
 // Handler обрабатывает HTTP запросы
@@ -62,12 +81,20 @@ func (h *Handler) emitAudit(r *http.Request, action, userID, url string) {
 }
 
 // emitAuditFollow уведомляет аудиторов о переходе по короткому коду,
-// сохраняя как короткий код (для трассировки), так и оригинальный URL.
-func (h *Handler) emitAuditFollow(r *http.Request, userID, shortCode, originalURL string) {
+// сохраняя короткий код (для трассировки), адрес назначения и вариант сплит-ссылки.
+func (h *Handler) emitAuditFollow(r *http.Request, userID, shortCode string, redirect model.Redirect) {
 	if len(h.auditors) == 0 {
 		return
 	}
-	event := audit.NewFollowEvent(userID, shortCode, originalURL)
+	event := audit.NewFollowEvent(userID, shortCode, redirect.URL)
+	event.Variant = redirect.Variant
+	for _, a := range h.auditors {
+		a.Notify(r.Context(), event)
+	}
+}
+
+// emitAuditEvent уведомляет всех зарегистрированных аудиторов о готовом событии
+func (h *Handler) emitAuditEvent(r *http.Request, event audit.Event) {
 	for _, a := range h.auditors {
 		a.Notify(r.Context(), event)
 	}
@@ -93,16 +120,50 @@ func (h *Handler) Ping(w http.ResponseWriter, r *http.Request) {
 
 // handleError маппит ошибки usecase на HTTP статусы
 func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	var limitErr usecase.LimitExceededError
+	if errors.As(err, &limitErr) {
+		// Превышенное ограничение передаётся первой строкой тела, как и причина отказа в адресе
+		h.logger.Debug("limit exceeded", zap.String("limit", string(limitErr.Limit)), zap.Error(err))
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(limitStatus(limitErr))
+		fmt.Fprintf(w, "%s\n%s", limitErr.Limit, err)
+		return
+	}
+
+	var rejectedErr usecase.URLRejectedError
+	if errors.As(err, &rejectedErr) {
+		// Причина отказа передаётся первой строкой тела, чтобы клиент мог её различать
+		h.logger.Debug("URL rejected", zap.String("reason", string(rejectedErr.Reason)), zap.Error(err))
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%s\n%s", rejectedErr.Reason, err)
+		return
+	}
+
 	switch {
-	case errors.Is(err, usecase.ErrInvalidURL), errors.Is(err, usecase.ErrEmptyURL):
+	case errors.Is(err, usecase.ErrInvalidURL), errors.Is(err, usecase.ErrEmptyURL),
+		errors.Is(err, usecase.ErrInvalidOptions):
 		h.logger.Debug("bad request", zap.Error(err))
 		w.WriteHeader(http.StatusBadRequest)
-	case errors.Is(err, usecase.ErrURLNotFound):
+	case errors.Is(err, usecase.ErrURLNotFound), errors.Is(err, usecase.ErrVersionNotFound):
 		h.logger.Debug("URL not found", zap.Error(err))
 		w.WriteHeader(http.StatusNotFound)
-	case errors.Is(err, usecase.ErrURLDeleted):
-		h.logger.Debug("URL deleted", zap.Error(err))
+	case errors.Is(err, usecase.ErrURLDeleted), errors.Is(err, usecase.ErrURLExpired),
+		errors.Is(err, usecase.ErrClickLimitReached):
+		h.logger.Debug("URL gone", zap.Error(err))
 		w.WriteHeader(http.StatusGone)
+	case errors.Is(err, usecase.ErrPasswordRequired), errors.Is(err, usecase.ErrInvalidPassword):
+		h.logger.Debug("link password required", zap.Error(err))
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Is(err, usecase.ErrTooManyAttempts):
+		h.logger.Debug("too many link password attempts", zap.Error(err))
+		w.WriteHeader(http.StatusTooManyRequests)
+	case errors.Is(err, usecase.ErrBlockRuleNotFound):
+		h.logger.Debug("block rule not found", zap.Error(err))
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, usecase.ErrURLBlocked):
+		h.logger.Debug("URL blocked", zap.Error(err))
+		w.WriteHeader(http.StatusUnavailableForLegalReasons)
 	default:
 		var urlExistsErr usecase.URLAlreadyExistsError
 		if errors.As(err, &urlExistsErr) {
@@ -116,6 +177,40 @@ func (h *Handler) handleError(w http.ResponseWriter, err error) {
 	}
 }
 
+// URLRejectedResponse — тело ответа JSON API на адрес, отклонённый политикой сервиса
+type URLRejectedResponse struct {
+	Error  string `json:"error"`
+	Reason string `json:"reason"`
+}
+
+// LimitExceededResponse — тело ответа JSON API на запрос, превысивший ограничение размера
+type LimitExceededResponse struct {
+	Error string `json:"error"`
+	Limit string `json:"limit"`
+	Max   int64  `json:"max"`
+	// Actual — фактическое значение; не передаётся, если оно неизвестно.
+	Actual int64 `json:"actual,omitempty"`
+}
+
+// limitStatus возвращает код ответа на превышение ограничения: 413 для слишком большого тела,
+// 400 для остальных ограничений
+func limitStatus(err usecase.LimitExceededError) int {
+	if err.Limit == usecase.LimitBodySize {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// bodyLimitError возвращает LimitExceededError, если чтение тела запроса
+// прервано ограничением его размера
+func bodyLimitError(err error) (usecase.LimitExceededError, bool) {
+	var maxErr *http.MaxBytesError
+	if !errors.As(err, &maxErr) {
+		return usecase.LimitExceededError{}, false
+	}
+	return usecase.LimitExceededError{Limit: usecase.LimitBodySize, Max: maxErr.Limit}, true
+}
+
 // handleErrorJSON обрабатывает ошибки для JSON API endpoints
 func (h *Handler) handleErrorJSON(w http.ResponseWriter, err error) {
 	var urlExistsErr usecase.URLAlreadyExistsError
@@ -130,6 +225,35 @@ func (h *Handler) handleErrorJSON(w http.ResponseWriter, err error) {
 		return
 	}
 
+	var limitErr usecase.LimitExceededError
+	if errors.As(err, &limitErr) {
+		h.logger.Debug("limit exceeded", zap.String("limit", string(limitErr.Limit)), zap.Error(err))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(limitStatus(limitErr))
+		response := LimitExceededResponse{
+			Error:  err.Error(),
+			Limit:  string(limitErr.Limit),
+			Max:    limitErr.Max,
+			Actual: limitErr.Actual,
+		}
+		if jsonErr := json.NewEncoder(w).Encode(response); jsonErr != nil {
+			h.logger.Error("failed to encode JSON response", zap.Error(jsonErr))
+		}
+		return
+	}
+
+	var rejectedErr usecase.URLRejectedError
+	if errors.As(err, &rejectedErr) {
+		h.logger.Debug("URL rejected", zap.String("reason", string(rejectedErr.Reason)), zap.Error(err))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		response := URLRejectedResponse{Error: err.Error(), Reason: string(rejectedErr.Reason)}
+		if jsonErr := json.NewEncoder(w).Encode(response); jsonErr != nil {
+			h.logger.Error("failed to encode JSON response", zap.Error(jsonErr))
+		}
+		return
+	}
+
 	// Для остальных ошибок используем обычную обработку
 	h.handleError(w, err)
 }
@@ -11,9 +11,11 @@ import (
 
 	"github.com/avc-dev/url-shortener/internal/audit"
 	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/avc-dev/url-shortener/internal/usecase"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -45,7 +47,7 @@ func TestNew_WithAuditor_StoresAuditor(t *testing.T) {
 	h := New(mockUsecase, zap.NewNop(), nil, aud)
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return("http://localhost/abc", nil).
 		Once()
 
@@ -64,7 +66,7 @@ func TestNew_WithMultipleAuditors_AllNotified(t *testing.T) {
 	h := New(mockUsecase, zap.NewNop(), nil, aud1, aud2, aud3)
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return("http://localhost/abc", nil).
 		Once()
 
@@ -87,7 +89,7 @@ func TestEmitAudit_NilAuditor_NoPanic(t *testing.T) {
 	h := New(mockUsecase, zap.NewNop(), nil) // без аудитора
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return("http://localhost/abc", nil).
 		Once()
 
@@ -105,7 +107,7 @@ func TestCreateURL_EmitsAuditShortenEvent(t *testing.T) {
 	h := New(mockUsecase, zap.NewNop(), nil, aud)
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com/original", "").
+		CreateShortURLFromString("https://example.com/original", "", model.LinkOptions{}).
 		Return("http://localhost/abc", nil).
 		Once()
 
@@ -126,7 +128,7 @@ func TestCreateURL_Error_DoesNotEmitAuditEvent(t *testing.T) {
 	h := New(mockUsecase, zap.NewNop(), nil, aud)
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("bad-url", "").
+		CreateShortURLFromString("bad-url", "", model.LinkOptions{}).
 		Return("", usecase.ErrURLNotFound).
 		Once()
 
@@ -145,7 +147,7 @@ func TestCreateURLJSON_EmitsAuditShortenEvent(t *testing.T) {
 
 	originalURL := "https://example.com/json-original"
 	mockUsecase.EXPECT().
-		CreateShortURLFromString(originalURL, "").
+		CreateShortURLFromString(originalURL, "", model.LinkOptions{}).
 		Return("http://localhost/xyz", nil).
 		Once()
 
@@ -168,7 +170,7 @@ func TestCreateURLJSON_Error_DoesNotEmitAuditEvent(t *testing.T) {
 	h := New(mockUsecase, zap.NewNop(), nil, aud)
 
 	mockUsecase.EXPECT().
-		CreateShortURLFromString("https://example.com", "").
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{}).
 		Return("", usecase.ErrURLNotFound).
 		Once()
 
@@ -183,13 +185,14 @@ func TestCreateURLJSON_Error_DoesNotEmitAuditEvent(t *testing.T) {
 
 func TestGetURL_EmitsAuditFollowEvent(t *testing.T) {
 	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().RecordClick(mock.Anything, mock.Anything).Once()
 	aud := &testAuditor{}
 	h := New(mockUsecase, zap.NewNop(), nil, aud)
 
 	originalURL := "https://example.com/original-page"
 	mockUsecase.EXPECT().
-		GetOriginalURL("abc123").
-		Return(originalURL, nil).
+		GetOriginalURL("abc123", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{URL: originalURL, Status: http.StatusTemporaryRedirect}, nil).
 		Once()
 
 	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
@@ -213,8 +216,8 @@ func TestGetURL_Error_DoesNotEmitAuditEvent(t *testing.T) {
 	h := New(mockUsecase, zap.NewNop(), nil, aud)
 
 	mockUsecase.EXPECT().
-		GetOriginalURL("notfound").
-		Return("", usecase.ErrURLNotFound).
+		GetOriginalURL("notfound", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrURLNotFound).
 		Once()
 
 	req := httptest.NewRequest(http.MethodGet, "/notfound", nil)
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// SetURLLabels заменяет теги и папку ссылки аутентифицированного пользователя.
+// Тело — {"tags": [...], "folder": "..."}; отсутствующие поля снимают метки.
+// Отвечает сохранёнными метками в каноническом виде.
+func (h *Handler) SetURLLabels(w http.ResponseWriter, req *http.Request) {
+	userID, ok := h.getUserIDFromRequest(req)
+	if !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var request model.LinkLabels
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		h.logger.Warn("failed to decode JSON request",
+			zap.Error(err),
+			zap.String("remote_addr", req.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	labels, err := h.usecase.SetURLLabels(chi.URLParam(req, "code"), request, userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(labels); err != nil {
+		h.logger.Error("failed to encode URL labels", zap.Error(err))
+	}
+}
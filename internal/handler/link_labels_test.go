@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestSetURLLabels проверяет замену меток ссылки и маппинг ошибок на HTTP-статусы
+func TestSetURLLabels(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "Success",
+			body: `{"tags":["Work","docs"],"folder":"Projects"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLLabels("abc",
+					model.LinkLabels{Tags: []string{"Work", "docs"}, Folder: "Projects"}, "user-1").
+					Return(model.LinkLabels{Tags: []string{"docs", "work"}, Folder: "Projects"}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"tags":["docs","work"],"folder":"Projects"}`,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid labels",
+			body: `{"tags":[""]}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLLabels("abc", model.LinkLabels{Tags: []string{""}}, "user-1").
+					Return(model.LinkLabels{}, usecase.ErrInvalidOptions).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Foreign link",
+			body: `{"tags":["work"]}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLLabels("abc", model.LinkLabels{Tags: []string{"work"}}, "user-1").
+					Return(model.LinkLabels{}, usecase.ErrURLNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			req := newCodeRequest(http.MethodPut, "abc", "/labels", tt.body, "user-1")
+			w := httptest.NewRecorder()
+			h.SetURLLabels(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetUserURLs_Filter(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		GetURLsByUserID("user-1", model.URLListRequest{Filter: model.URLFilter{Tag: "work", Folder: "Projects"}}).
+		Return(model.URLList{URLs: []model.UserURLResponse{{
+			ShortURL:    "http://localhost:8080/abc",
+			OriginalURL: "https://example.com",
+			Tags:        []string{"work"},
+			Folder:      "Projects",
+		}}}, nil).Once()
+	h := New(mockUsecase, zap.NewNop(), nil)
+
+	w := httptest.NewRecorder()
+	h.GetUserURLs(w, newUserRequest(http.MethodGet, "/api/user/urls?tag=work&folder=Projects", "", "user-1"))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var urls []model.UserURLResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&urls))
+	require.Len(t, urls, 1)
+	assert.Equal(t, []string{"work"}, urls[0].Tags)
+	assert.Equal(t, "Projects", urls[0].Folder)
+}
+
+func TestDeleteURLs_ByFilter(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().DeleteURLsByFilter(model.URLFilter{Folder: "Old"}, "user-1").
+		Return([]string{"abc"}, nil).Once()
+	h := New(mockUsecase, zap.NewNop(), nil)
+
+	w := httptest.NewRecorder()
+	h.DeleteURLs(w, newUserRequest(http.MethodDelete, "/api/user/urls?folder=Old", "", "user-1"))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `["abc"]`, w.Body.String())
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+)
+
+// linkOptionsFromQuery собирает параметры создания ссылки из query-параметров.
+// Используется эндпоинтами, тело которых не может содержать параметры: POST / и батч.
+func linkOptionsFromQuery(req *http.Request) (model.LinkOptions, error) {
+	query := req.URL.Query()
+	opts := model.LinkOptions{
+		CodeStyle: codeStyleFromRequest(req, query.Get("code_style")),
+		Labels:    model.LinkLabels{Tags: query["tag"], Folder: query.Get("folder")},
+		Query: model.QueryPolicy{
+			Passthrough: model.QueryPassthrough(query.Get("query_passthrough")),
+			UTM: model.UTMParams{
+				Source:   query.Get("utm_source"),
+				Medium:   query.Get("utm_medium"),
+				Campaign: query.Get("utm_campaign"),
+				Term:     query.Get("utm_term"),
+				Content:  query.Get("utm_content"),
+			},
+		},
+	}
+	if err := parseExpiry(&opts, query.Get("ttl"), query.Get("expires_at")); err != nil {
+		return model.LinkOptions{}, err
+	}
+	if maxClicks := query.Get("max_clicks"); maxClicks != "" {
+		n, err := strconv.Atoi(maxClicks)
+		if err != nil {
+			return model.LinkOptions{}, fmt.Errorf("%w: invalid max_clicks %q", usecase.ErrInvalidOptions, maxClicks)
+		}
+		opts.MaxClicks = n
+	}
+	if status := query.Get("redirect_status"); status != "" {
+		n, err := strconv.Atoi(status)
+		if err != nil {
+			return model.LinkOptions{}, fmt.Errorf("%w: invalid redirect_status %q", usecase.ErrInvalidOptions, status)
+		}
+		opts.Redirect.Status = n
+	}
+	if err := parseCacheMaxAge(&opts, query.Get("cache_max_age")); err != nil {
+		return model.LinkOptions{}, err
+	}
+	return opts, nil
+}
+
+// parseCacheMaxAge разбирает срок кэширования редиректа в формате Go ("1h", "0s").
+// Пустое значение означает срок по умолчанию, "0s" запрещает кэширование.
+func parseCacheMaxAge(opts *model.LinkOptions, maxAge string) error {
+	if maxAge == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(maxAge)
+	if err != nil {
+		return fmt.Errorf("%w: invalid cache_max_age %q", usecase.ErrInvalidOptions, maxAge)
+	}
+	opts.Redirect.MaxAge = &d
+	return nil
+}
+
+// parseExpiry разбирает срок жизни ссылки: ttl — длительность в формате Go ("24h", "90m"),
+// expires_at — момент в формате RFC 3339. Пустые значения означают, что параметр не задан.
+// Ошибки оборачивают usecase.ErrInvalidOptions, поэтому отображаются в 400.
+func parseExpiry(opts *model.LinkOptions, ttl, expiresAt string) error {
+	if ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return fmt.Errorf("%w: invalid ttl %q", usecase.ErrInvalidOptions, ttl)
+		}
+		opts.TTL = d
+	}
+	if expiresAt != "" {
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return fmt.Errorf("%w: invalid expires_at %q", usecase.ErrInvalidOptions, expiresAt)
+		}
+		opts.ExpiresAt = t
+	}
+	return nil
+}
+
+// urlFilterFromQuery собирает фильтр списка ссылок из query-параметров tag и folder
+func urlFilterFromQuery(req *http.Request) model.URLFilter {
+	query := req.URL.Query()
+	return model.URLFilter{Tag: query.Get("tag"), Folder: query.Get("folder")}
+}
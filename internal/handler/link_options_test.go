@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// TestCreateURL_ExpiryQuery проверяет разбор ttl и expires_at из query-параметров
+func TestCreateURL_ExpiryQuery(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	hour := time.Hour
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedOpts   *model.LinkOptions
+		expectedStatus int
+	}{
+		{
+			name:           "TTL",
+			query:          "?ttl=24h",
+			expectedOpts:   &model.LinkOptions{TTL: 24 * time.Hour},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Absolute expiry",
+			query:          "?expires_at=2030-01-02T03:04:05Z",
+			expectedOpts:   &model.LinkOptions{ExpiresAt: expiresAt},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Max clicks",
+			query:          "?max_clicks=3",
+			expectedOpts:   &model.LinkOptions{MaxClicks: 3},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid max clicks",
+			query:          "?max_clicks=many",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Redirect policy",
+			query:          "?redirect_status=301&cache_max_age=1h",
+			expectedOpts:   &model.LinkOptions{Redirect: model.RedirectPolicy{Status: 301, MaxAge: &hour}},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid redirect status",
+			query:          "?redirect_status=moved",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Query policy",
+			query: "?query_passthrough=merge&utm_source=qr&utm_campaign=spring",
+			expectedOpts: &model.LinkOptions{Query: model.QueryPolicy{
+				Passthrough: model.QueryPassthroughMerge,
+				UTM:         model.UTMParams{Source: "qr", Campaign: "spring"},
+			}},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid cache max-age",
+			query:          "?cache_max_age=forever",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid TTL",
+			query:          "?ttl=tomorrow",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid expires_at",
+			query:          "?expires_at=2030-01-02",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			if tt.expectedOpts != nil {
+				mockUsecase.EXPECT().
+					CreateShortURLFromString("https://example.com", "", *tt.expectedOpts).
+					Return("http://localhost:8080/testcode", nil).
+					Once()
+			}
+
+			handler := New(mockUsecase, zap.NewNop(), nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/"+tt.query, bytes.NewBufferString("https://example.com"))
+			w := httptest.NewRecorder()
+
+			handler.CreateURL(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+// TestCreateURLJSON_Expiry проверяет разбор ttl и expires_at из тела JSON-запроса
+func TestCreateURLJSON_Expiry(t *testing.T) {
+	t.Run("TTL passed to usecase", func(t *testing.T) {
+		mockUsecase := mocks.NewMockURLUsecase(t)
+		mockUsecase.EXPECT().
+			CreateShortURLFromString("https://example.com", "", model.LinkOptions{TTL: 90 * time.Minute}).
+			Return("http://localhost:8080/testcode", nil).
+			Once()
+
+		handler := New(mockUsecase, zap.NewNop(), nil)
+
+		body := bytes.NewBufferString(`{"url":"https://example.com","ttl":"90m"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten", body)
+		w := httptest.NewRecorder()
+
+		handler.CreateURLJSON(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("Max clicks passed to usecase", func(t *testing.T) {
+		mockUsecase := mocks.NewMockURLUsecase(t)
+		mockUsecase.EXPECT().
+			CreateShortURLFromString("https://example.com", "", model.LinkOptions{MaxClicks: 1}).
+			Return("http://localhost:8080/testcode", nil).
+			Once()
+
+		handler := New(mockUsecase, zap.NewNop(), nil)
+
+		body := bytes.NewBufferString(`{"url":"https://example.com","max_clicks":1}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten", body)
+		w := httptest.NewRecorder()
+
+		handler.CreateURLJSON(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("Redirect policy passed to usecase", func(t *testing.T) {
+		noCache := time.Duration(0)
+		mockUsecase := mocks.NewMockURLUsecase(t)
+		mockUsecase.EXPECT().
+			CreateShortURLFromString("https://example.com", "",
+				model.LinkOptions{Redirect: model.RedirectPolicy{Status: 302, MaxAge: &noCache}}).
+			Return("http://localhost:8080/testcode", nil).
+			Once()
+
+		handler := New(mockUsecase, zap.NewNop(), nil)
+
+		body := bytes.NewBufferString(`{"url":"https://example.com","redirect_status":302,"cache_max_age":"0s"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten", body)
+		w := httptest.NewRecorder()
+
+		handler.CreateURLJSON(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("Query policy passed to usecase", func(t *testing.T) {
+		mockUsecase := mocks.NewMockURLUsecase(t)
+		mockUsecase.EXPECT().
+			CreateShortURLFromString("https://example.com", "", model.LinkOptions{Query: model.QueryPolicy{
+				Passthrough: model.QueryPassthroughOverride,
+				UTM:         model.UTMParams{Source: "newsletter", Medium: "email"},
+			}}).
+			Return("http://localhost:8080/testcode", nil).
+			Once()
+
+		handler := New(mockUsecase, zap.NewNop(), nil)
+
+		body := bytes.NewBufferString(`{"url":"https://example.com","query_passthrough":"override",` +
+			`"utm":{"source":"newsletter","medium":"email"}}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten", body)
+		w := httptest.NewRecorder()
+
+		handler.CreateURLJSON(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("Split variants passed to usecase", func(t *testing.T) {
+		mockUsecase := mocks.NewMockURLUsecase(t)
+		mockUsecase.EXPECT().
+			CreateShortURLFromString("https://example.com", "", model.LinkOptions{Variants: []model.SplitVariant{
+				{URL: "https://a.example.com", Weight: 70},
+				{URL: "https://b.example.com", Weight: 30},
+			}}).
+			Return("http://localhost:8080/testcode", nil).
+			Once()
+
+		handler := New(mockUsecase, zap.NewNop(), nil)
+
+		body := bytes.NewBufferString(`{"url":"https://example.com","variants":[` +
+			`{"url":"https://a.example.com","weight":70},{"url":"https://b.example.com","weight":30}]}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten", body)
+		w := httptest.NewRecorder()
+
+		handler.CreateURLJSON(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("Invalid expires_at rejected", func(t *testing.T) {
+		handler := New(mocks.NewMockURLUsecase(t), zap.NewNop(), nil)
+
+		body := bytes.NewBufferString(`{"url":"https://example.com","expires_at":"soon"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten", body)
+		w := httptest.NewRecorder()
+
+		handler.CreateURLJSON(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// linkAccessCookieName — имя куки с токеном доступа к защищённой ссылке.
+// Кука ограничена путём /{id}, поэтому браузер отправляет её только для своей ссылки;
+// срок действия задаётся внутри подписанного токена.
+const linkAccessCookieName = "link_access"
+
+// passwordPromptTemplate — HTML-форма ввода пароля защищённой ссылки.
+// Форма отправляется POST-запросом на тот же путь /{id} вместе с query-строкой перехода.
+var passwordPromptTemplate = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+</head>
+<body>
+<h1>This link is password protected</h1>
+{{if .Message}}<p role="alert">{{.Message}}</p>{{end}}
+<form method="post" action="{{.Action}}">
+<label>Password <input type="password" name="password" autocomplete="current-password" autofocus required></label>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// passwordPrompt — данные шаблона формы ввода пароля
+type passwordPrompt struct {
+	Action  string
+	Message string
+}
+
+// PostURL обрабатывает POST /{id}: подтверждение перехода с формы предпросмотра
+// небезопасной ссылки (поле confirm) или пароль защищённой ссылки
+func (h *Handler) PostURL(w http.ResponseWriter, req *http.Request) {
+	if req.PostFormValue("confirm") != "" {
+		h.ConfirmURL(w, req)
+		return
+	}
+	h.UnlockURL(w, req)
+}
+
+// UnlockURL обрабатывает POST /{id} с паролем защищённой ссылки.
+// При верном пароле устанавливает куку с подписанным токеном доступа и перенаправляет
+// на GET /{id} с той же query-строкой, где переход выполняется как обычно.
+func (h *Handler) UnlockURL(w http.ResponseWriter, req *http.Request) {
+	code := chi.URLParam(req, "id")
+
+	token, err := h.usecase.UnlockURL(code, req.PostFormValue("password"))
+	if err != nil {
+		h.handlePasswordError(w, req, code, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     linkAccessCookieName,
+		Value:    token,
+		Path:     "/" + code,
+		HttpOnly: true,
+		Secure:   req.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, req, linkPath(req, code), http.StatusSeeOther)
+}
+
+// handlePasswordError отображает форму ввода пароля для ошибок доступа к защищённой ссылке,
+// остальные ошибки обрабатываются как обычно
+func (h *Handler) handlePasswordError(w http.ResponseWriter, req *http.Request, code string, err error) {
+	action := linkPath(req, code)
+	switch {
+	case errors.Is(err, usecase.ErrPasswordRequired):
+		h.renderPasswordPrompt(w, http.StatusUnauthorized, action, "")
+	case errors.Is(err, usecase.ErrInvalidPassword):
+		h.renderPasswordPrompt(w, http.StatusUnauthorized, action, "Incorrect password.")
+	case errors.Is(err, usecase.ErrTooManyAttempts):
+		h.renderPasswordPrompt(w, http.StatusTooManyRequests, action, "Too many attempts. Try again later.")
+	default:
+		h.handleError(w, err)
+	}
+}
+
+// renderPasswordPrompt отдаёт HTML-форму ввода пароля с указанным статусом;
+// action — адрес, на который отправляется форма
+func (h *Handler) renderPasswordPrompt(w http.ResponseWriter, status int, action, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := passwordPromptTemplate.Execute(w, passwordPrompt{Action: action, Message: message}); err != nil {
+		h.logger.Error("failed to render password prompt", zap.Error(err))
+	}
+}
+
+// linkPath возвращает путь ссылки /{id} с query-строкой запроса: параметры перехода
+// сохраняются, пока пользователь вводит пароль
+func linkPath(req *http.Request, code string) string {
+	if req.URL.RawQuery == "" {
+		return "/" + code
+	}
+	return "/" + code + "?" + req.URL.RawQuery
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/repository"
+	"github.com/avc-dev/url-shortener/internal/service"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// withCode добавляет параметр маршрута id в контекст запроса
+func withCode(req *http.Request, code string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", code)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestGetURL_PasswordPrompt проверяет, что защищённая ссылка без доступа отдаёт форму пароля
+func TestGetURL_PasswordPrompt(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		GetOriginalURL("locked", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrPasswordRequired).
+		Once()
+
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	req := withCode(httptest.NewRequest(http.MethodGet, "/locked", nil), "locked")
+	w := httptest.NewRecorder()
+
+	handler.GetURL(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), `action="/locked"`)
+	assert.Contains(t, w.Body.String(), `name="password"`)
+}
+
+// TestGetURL_AccessCookie проверяет, что токен из куки передаётся в usecase
+func TestGetURL_AccessCookie(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		GetOriginalURL("locked", model.LinkAccess{Token: "signed-token"}, mock.Anything).
+		Return(model.Redirect{URL: "https://example.com", Status: http.StatusTemporaryRedirect}, nil).
+		Once()
+	mockUsecase.EXPECT().RecordClick("locked", mock.Anything).Once()
+
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	req := withCode(httptest.NewRequest(http.MethodGet, "/locked", nil), "locked")
+	req.AddCookie(&http.Cookie{Name: linkAccessCookieName, Value: "signed-token"})
+	w := httptest.NewRecorder()
+
+	handler.GetURL(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+	assert.Equal(t, "https://example.com", resp.Header.Get("Location"))
+}
+
+// TestUnlockURL проверяет обработку отправленной формы пароля
+func TestUnlockURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		err            error
+		expectedStatus int
+	}{
+		{name: "Correct password", token: "signed-token", expectedStatus: http.StatusSeeOther},
+		{name: "Wrong password", err: usecase.ErrInvalidPassword, expectedStatus: http.StatusUnauthorized},
+		{name: "Too many attempts", err: usecase.ErrTooManyAttempts, expectedStatus: http.StatusTooManyRequests},
+		{name: "Unknown code", err: usecase.ErrURLNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().
+				UnlockURL("locked", "secret").
+				Return(tt.token, tt.err).
+				Once()
+
+			handler := New(mockUsecase, zap.NewNop(), nil)
+
+			form := url.Values{"password": {"secret"}}
+			req := httptest.NewRequest(http.MethodPost, "/locked", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req = withCode(req, "locked")
+			w := httptest.NewRecorder()
+
+			handler.UnlockURL(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.err != nil {
+				assert.Empty(t, resp.Cookies())
+				return
+			}
+			assert.Equal(t, "/locked", resp.Header.Get("Location"))
+			require.Len(t, resp.Cookies(), 1)
+			cookie := resp.Cookies()[0]
+			assert.Equal(t, linkAccessCookieName, cookie.Name)
+			assert.Equal(t, "signed-token", cookie.Value)
+			assert.Equal(t, "/locked", cookie.Path)
+			assert.True(t, cookie.HttpOnly)
+		})
+	}
+}
+
+// TestUnlockURL_KeepsQuery проверяет, что форма пароля и редирект после неё
+// сохраняют query-строку перехода
+func TestUnlockURL_KeepsQuery(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		GetOriginalURL("locked", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrPasswordRequired).
+		Once()
+	mockUsecase.EXPECT().UnlockURL("locked", "secret").Return("signed-token", nil).Once()
+
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	req := withCode(httptest.NewRequest(http.MethodGet, "/locked?utm_source=mail", nil), "locked")
+	w := httptest.NewRecorder()
+	handler.GetURL(w, req)
+	assert.Contains(t, w.Body.String(), `action="/locked?utm_source=mail"`)
+
+	form := url.Values{"password": {"secret"}}
+	req = httptest.NewRequest(http.MethodPost, "/locked?utm_source=mail&lang=en", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	handler.UnlockURL(w, withCode(req, "locked"))
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+	assert.Equal(t, "/locked?utm_source=mail&lang=en", resp.Header.Get("Location"))
+}
+
+// TestUnlockURL_ConcurrentGuesses проверяет, что одновременные попытки подбора пароля
+// не превышают лимит: лишние получают 429, даже пока идёт проверка первых
+func TestUnlockURL_ConcurrentGuesses(t *testing.T) {
+	const guesses = 20
+
+	cfg := config.NewDefaultConfig()
+	repo := repository.New(store.NewStore())
+	uc := usecase.NewURLUsecase(repo, service.NewURLService(repo, cfg), cfg, zap.NewNop())
+	defer uc.Close()
+	handler := New(uc, zap.NewNop(), nil)
+
+	shortURL, err := uc.CreateShortURLFromString("https://example.com", "user-1", model.LinkOptions{Password: "secret"})
+	require.NoError(t, err)
+	code := path.Base(shortURL)
+
+	var wg sync.WaitGroup
+	var unauthorized, throttled atomic.Int32
+	for range guesses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			form := url.Values{"password": {"guess"}}
+			req := httptest.NewRequest(http.MethodPost, "/"+code, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			handler.UnlockURL(w, withCode(req, code))
+
+			switch w.Code {
+			case http.StatusUnauthorized:
+				unauthorized.Add(1)
+			case http.StatusTooManyRequests:
+				throttled.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(cfg.LinkPassword.MaxAttempts), unauthorized.Load())
+	assert.Equal(t, int32(guesses-cfg.LinkPassword.MaxAttempts), throttled.Load())
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// previewSuffix — суффикс кода, запрашивающий предпросмотр вместо перехода: GET /{id}+
+const previewSuffix = "+"
+
+// linkConfirmCookieName — имя куки с токеном подтверждения перехода по небезопасной ссылке.
+// Кука выдаётся вместе со страницей предпросмотра, ограничена путём /{id} и не отправляется
+// с запросами с других сайтов, поэтому подтвердить переход может только форма этой страницы.
+const linkConfirmCookieName = "link_confirm"
+
+// previewTemplate — HTML-страница предпросмотра ссылки.
+// Адрес назначения выводится текстом, а продолжение ведёт на /{id}, чтобы переход прошёл
+// через сервис и был учтён как обычно. Для небезопасной ссылки продолжение — форма,
+// отправляемая POST-запросом с подтверждением на тот же путь.
+var previewTemplate = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>{{if .Unsafe}}Warning: unsafe link{{else}}Link preview{{end}}</title>
+</head>
+<body>
+{{if .Unsafe}}<h1>This link may be unsafe</h1>
+<p role="alert">This link has been flagged as unsafe. Continue only if you trust the destination.</p>
+{{else}}<h1>Link preview</h1>
+{{end}}<dl>
+<dt>Short link</dt><dd>{{.ShortURL}}</dd>
+<dt>Destination</dt><dd>{{.OriginalURL}}</dd>
+{{with .CreatedAt}}<dt>Created</dt><dd><time datetime="{{.UTC.Format "2006-01-02T15:04:05Z07:00"}}">{{.UTC.Format "2 Jan 2006"}}</time></dd>
+{{end}}<dt>Clicks</dt><dd>{{.Clicks}}</dd>
+</dl>
+{{if .Unsafe}}<form method="post" action="{{.Action}}">
+<input type="hidden" name="confirm" value="1">
+<button type="submit">Continue to destination</button>
+</form>
+{{else}}<p><a href="{{.Action}}" rel="nofollow noreferrer">Continue to destination</a></p>
+{{end}}
+</body>
+</html>
+`))
+
+// previewPage — данные шаблона страницы предпросмотра
+type previewPage struct {
+	model.URLPreview
+	// Action — адрес продолжения перехода: /{id} с query-параметрами перехода
+	Action string
+}
+
+// previewMode разбирает запрос перехода: код ссылки и, нужен ли предпросмотр.
+// Предпросмотр запрашивается суффиксом кода /{id}+ или параметром preview=1.
+func previewMode(req *http.Request) (code string, preview bool) {
+	code = chi.URLParam(req, "id")
+	if trimmed, ok := strings.CutSuffix(code, previewSuffix); ok {
+		return trimmed, true
+	}
+	return code, req.URL.Query().Get("preview") == "1"
+}
+
+// ConfirmURL обрабатывает POST /{id} с формы предпросмотра небезопасной ссылки:
+// переход подтверждается токеном из куки, выданной вместе со страницей предпросмотра.
+// Отвечает 303, чтобы браузер перешёл на адрес назначения GET-запросом.
+func (h *Handler) ConfirmURL(w http.ResponseWriter, req *http.Request) {
+	code := chi.URLParam(req, "id")
+	access := linkAccessFromRequest(req)
+	if cookie, err := req.Cookie(linkConfirmCookieName); err == nil {
+		access.ConfirmToken = cookie.Value
+	}
+
+	h.followURL(w, req, code, access, http.StatusSeeOther)
+}
+
+// continueAction возвращает адрес продолжения перехода со страницы предпросмотра:
+// /{id} с query-параметрами перехода, кроме служебного preview
+func continueAction(req *http.Request, code string) string {
+	query := req.URL.Query()
+	query.Del("preview")
+	if len(query) == 0 {
+		return "/" + code
+	}
+	return "/" + code + "?" + query.Encode()
+}
+
+// renderPreview отдаёт предпросмотр ссылки: JSON, если клиент принимает application/json,
+// иначе HTML-страницу. Переход при этом не выполняется и не учитывается.
+func (h *Handler) renderPreview(w http.ResponseWriter, req *http.Request, code string, access model.LinkAccess) {
+	preview, err := h.usecase.PreviewURL(code, access)
+	if errors.Is(err, usecase.ErrURLBlocked) {
+		h.renderBlocked(w, req)
+		return
+	}
+	if err != nil {
+		h.handlePasswordError(w, req, code, err)
+		return
+	}
+
+	// Число переходов меняется, а пометка небезопасности может быть снята — не кэшируем
+	w.Header().Set("Cache-Control", "no-store")
+	if preview.ConfirmToken != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     linkConfirmCookieName,
+			Value:    preview.ConfirmToken,
+			Path:     "/" + code,
+			HttpOnly: true,
+			Secure:   req.TLS != nil,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+
+	if strings.Contains(req.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(preview); err != nil {
+			h.logger.Error("failed to encode URL preview", zap.Error(err))
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := previewTemplate.Execute(w, previewPage{URLPreview: preview, Action: continueAction(req, code)}); err != nil {
+		h.logger.Error("failed to render URL preview", zap.Error(err))
+	}
+}
+
+// unsafeRequest — тело запроса пометки ссылки небезопасной
+type unsafeRequest struct {
+	Unsafe bool `json:"unsafe"`
+}
+
+// SetURLUnsafe помечает ссылку небезопасной или снимает пометку: PUT /api/internal/urls/{code}/unsafe
+// с телом {"unsafe": true}. Проверка доступа по IP выполняется middleware.TrustedSubnet на уровне роутера.
+func (h *Handler) SetURLUnsafe(w http.ResponseWriter, req *http.Request) {
+	var request unsafeRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		h.logger.Warn("failed to decode JSON request",
+			zap.Error(err),
+			zap.String("remote_addr", req.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.usecase.SetURLUnsafe(chi.URLParam(req, "code"), request.Unsafe); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
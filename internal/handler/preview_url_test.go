@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newRedirectRequest создаёт запрос перехода по /{id} с параметром маршрута id
+func newRedirectRequest(target, id string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestGetURL_Preview(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	preview := model.URLPreview{
+		ShortURL:    "http://localhost:8080/abc",
+		OriginalURL: "https://example.com/<script>",
+		CreatedAt:   &createdAt,
+		Clicks:      42,
+	}
+
+	tests := []struct {
+		name         string
+		target       string
+		id           string
+		accept       string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		contains     []string
+	}{
+		{
+			name:   "Plus suffix renders HTML preview",
+			target: "/abc+",
+			id:     "abc+",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().PreviewURL("abc", model.LinkAccess{}).Return(preview, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			contains: []string{
+				"Link preview",
+				"https://example.com/&lt;script&gt;",
+				`datetime="2024-05-01T12:00:00Z"`,
+				"<dd>42</dd>",
+				`href="/abc"`,
+			},
+		},
+		{
+			name:   "Query parameter requests preview",
+			target: "/abc?preview=1&ref=tw",
+			id:     "abc",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().PreviewURL("abc", model.LinkAccess{}).Return(preview, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			contains:     []string{"Link preview", `href="/abc?ref=tw"`},
+		},
+		{
+			name:   "Unsafe link is previewed by default",
+			target: "/abc",
+			id:     "abc",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetOriginalURL("abc", model.LinkAccess{}, mock.Anything).Return(model.Redirect{}, usecase.ErrPreviewRequired).Once()
+				unsafe := preview
+				unsafe.Unsafe = true
+				unsafe.ConfirmToken = "confirm-token"
+				m.EXPECT().PreviewURL("abc", model.LinkAccess{}).Return(unsafe, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			contains: []string{
+				"This link may be unsafe",
+				`role="alert"`,
+				`<form method="post" action="/abc">`,
+				`name="confirm"`,
+			},
+		},
+		{
+			name:   "Password-protected link shows password form",
+			target: "/abc+",
+			id:     "abc+",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().PreviewURL("abc", model.LinkAccess{}).Return(model.URLPreview{}, usecase.ErrPasswordRequired).Once()
+			},
+			expectedCode: http.StatusUnauthorized,
+			contains:     []string{"password protected"},
+		},
+		{
+			name:   "Unknown code",
+			target: "/abc+",
+			id:     "abc+",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().PreviewURL("abc", model.LinkAccess{}).Return(model.URLPreview{}, usecase.ErrURLNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			w := httptest.NewRecorder()
+			h.GetURL(w, newRedirectRequest(tt.target, tt.id))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Empty(t, w.Header().Get("Location"))
+			for _, s := range tt.contains {
+				assert.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestGetURL_PreviewJSON(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().PreviewURL("abc", model.LinkAccess{}).
+		Return(model.URLPreview{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://example.com", Clicks: 7}, nil).Once()
+	h := New(mockUsecase, zap.NewNop(), nil)
+
+	req := newRedirectRequest("/abc+", "abc+")
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	h.GetURL(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	var got model.URLPreview
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, "https://example.com", got.OriginalURL)
+	assert.Equal(t, int64(7), got.Clicks)
+}
+
+// TestGetURL_UnsafePreviewCookie проверяет, что страница предпросмотра небезопасной ссылки
+// выдаёт куку с токеном подтверждения, ограниченную путём ссылки и своим сайтом
+func TestGetURL_UnsafePreviewCookie(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().PreviewURL("abc", model.LinkAccess{}).
+		Return(model.URLPreview{OriginalURL: "https://example.com", Unsafe: true, ConfirmToken: "confirm-token"}, nil).Once()
+	h := New(mockUsecase, zap.NewNop(), nil)
+
+	w := httptest.NewRecorder()
+	h.GetURL(w, newRedirectRequest("/abc+", "abc+"))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, linkConfirmCookieName, cookies[0].Name)
+	assert.Equal(t, "confirm-token", cookies[0].Value)
+	assert.Equal(t, "/abc", cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+}
+
+// TestGetURL_QueryDoesNotConfirm проверяет, что параметр запроса не подтверждает переход:
+// ссылка вида /{id}?preview=0 по-прежнему ведёт на страницу предпросмотра
+func TestGetURL_QueryDoesNotConfirm(t *testing.T) {
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().GetOriginalURL("abc", model.LinkAccess{}, mock.Anything).
+		Return(model.Redirect{}, usecase.ErrPreviewRequired).Once()
+	mockUsecase.EXPECT().PreviewURL("abc", model.LinkAccess{}).
+		Return(model.URLPreview{OriginalURL: "https://example.com", Unsafe: true, ConfirmToken: "confirm-token"}, nil).Once()
+	h := New(mockUsecase, zap.NewNop(), nil)
+
+	w := httptest.NewRecorder()
+	h.GetURL(w, newRedirectRequest("/abc?preview=0", "abc"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+	assert.Contains(t, w.Body.String(), "This link may be unsafe")
+}
+
+func TestPostURL_Confirm(t *testing.T) {
+	tests := []struct {
+		name         string
+		cookie       string
+		redirectErr  error
+		expectedCode int
+	}{
+		{name: "Confirmed by preview cookie", cookie: "confirm-token", expectedCode: http.StatusSeeOther},
+		{name: "Without preview cookie", redirectErr: usecase.ErrPreviewRequired, expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().GetOriginalURL("abc", model.LinkAccess{ConfirmToken: tt.cookie}, mock.Anything).
+				Return(model.Redirect{URL: "https://example.com", Status: http.StatusTemporaryRedirect}, tt.redirectErr).Once()
+			if tt.redirectErr == nil {
+				mockUsecase.EXPECT().RecordClick("abc", mock.Anything).Once()
+			} else {
+				mockUsecase.EXPECT().PreviewURL("abc", model.LinkAccess{}).
+					Return(model.URLPreview{OriginalURL: "https://example.com", Unsafe: true, ConfirmToken: "confirm-token"}, nil).Once()
+			}
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/abc", strings.NewReader("confirm=1"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: linkConfirmCookieName, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+			h.PostURL(w, withCode(req, "abc"))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.redirectErr == nil {
+				// После POST браузер переходит на адрес назначения GET-запросом
+				assert.Equal(t, "https://example.com", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestSetURLUnsafe(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+	}{
+		{
+			name: "Flag link",
+			body: `{"unsafe": true}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLUnsafe("abc", true).Return(nil).Once()
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "Unknown code",
+			body: `{"unsafe": false}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLUnsafe("abc", false).Return(usecase.ErrURLNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/internal/urls/abc/unsafe", strings.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("code", "abc")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+			h.SetURLUnsafe(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
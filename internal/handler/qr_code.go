@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// qrCacheControl — QR-код зависит только от короткого URL и параметров, поэтому кэшируется надолго;
+// после истечения кэша клиент перепроверяет его по ETag
+const qrCacheControl = "public, max-age=86400"
+
+// GetQRCode отдаёт QR-код полного короткого URL: GET /api/qr/{code}.
+// Параметры: format (png, svg), size — ширина в пикселях, ec — уровень коррекции (L, M, Q, H),
+// margin — поле в модулях. Запрос с совпадающим If-None-Match получает 304 Not Modified.
+func (h *Handler) GetQRCode(w http.ResponseWriter, req *http.Request) {
+	opts, ok := qrOptionsFromQuery(req)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	image, err := h.usecase.GetQRCode(chi.URLParam(req, "code"), opts)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", image.ETag)
+	w.Header().Set("Cache-Control", qrCacheControl)
+	if etagMatches(req.Header.Get("If-None-Match"), image.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", image.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(image.Data)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(image.Data); err != nil {
+		h.logger.Error("failed to write QR code", zap.Error(err))
+	}
+}
+
+// qrOptionsFromQuery собирает параметры QR-кода из query-параметров;
+// false означает нечисловые size или margin
+func qrOptionsFromQuery(req *http.Request) (model.QROptions, bool) {
+	query := req.URL.Query()
+	opts := model.QROptions{
+		Format: strings.ToLower(query.Get("format")),
+		Level:  query.Get("ec"),
+	}
+
+	if size := query.Get("size"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil {
+			return model.QROptions{}, false
+		}
+		opts.Size = n
+	}
+	if margin := query.Get("margin"); margin != "" {
+		n, err := strconv.Atoi(margin)
+		if err != nil {
+			return model.QROptions{}, false
+		}
+		opts.Margin = &n
+	}
+
+	return opts, true
+}
+
+// etagMatches проверяет заголовок If-None-Match: список ETag через запятую или «*».
+// Слабые валидаторы W/ сравниваются по значению, как требует RFC 9110 для If-None-Match.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestGetQRCode(t *testing.T) {
+	margin := 2
+	image := model.QRImage{Data: []byte("<svg/>"), ContentType: "image/svg+xml", ETag: `"abc123"`}
+
+	tests := []struct {
+		name         string
+		query        string
+		ifNoneMatch  string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:  "Returns image",
+			query: "?format=SVG&size=300&ec=h&margin=2",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetQRCode("abc", model.QROptions{Format: "svg", Size: 300, Level: "h", Margin: &margin}).
+					Return(image, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "<svg/>",
+		},
+		{
+			name:        "Matching ETag",
+			ifNoneMatch: `"other", W/"abc123"`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetQRCode("abc", model.QROptions{}).Return(image, nil).Once()
+			},
+			expectedCode: http.StatusNotModified,
+		},
+		{
+			name:        "Stale ETag",
+			ifNoneMatch: `"other"`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetQRCode("abc", model.QROptions{}).Return(image, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "<svg/>",
+		},
+		{
+			name:         "Non-numeric size",
+			query:        "?size=big",
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Invalid options",
+			query: "?format=gif",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetQRCode("abc", model.QROptions{Format: "gif"}).
+					Return(model.QRImage{}, usecase.ErrInvalidOptions).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown code",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetQRCode("abc", model.QROptions{}).Return(model.QRImage{}, usecase.ErrURLNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/qr/abc"+tt.query, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("code", "abc")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+			h.GetQRCode(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			if tt.expectedCode == http.StatusOK || tt.expectedCode == http.StatusNotModified {
+				assert.Equal(t, image.ETag, w.Header().Get("ETag"))
+				assert.Equal(t, qrCacheControl, w.Header().Get("Cache-Control"))
+			}
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, image.ContentType, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
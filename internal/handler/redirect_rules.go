@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// URLRules — тело запроса и ответа PUT /api/user/urls/{code}/rules.
+type URLRules struct {
+	// Rules — правила условного редиректа в порядке проверки; пустой список снимает правила.
+	Rules []model.RedirectRule `json:"rules"`
+}
+
+// SetURLRules заменяет правила условного редиректа ссылки аутентифицированного пользователя.
+// Отвечает сохранёнными правилами в каноническом виде.
+func (h *Handler) SetURLRules(w http.ResponseWriter, req *http.Request) {
+	userID, ok := h.getUserIDFromRequest(req)
+	if !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var request URLRules
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		h.logger.Warn("failed to decode JSON request",
+			zap.Error(err),
+			zap.String("remote_addr", req.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rules, err := h.usecase.SetURLRules(chi.URLParam(req, "code"), request.Rules, userID)
+	if err != nil {
+		h.handleErrorJSON(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if rules == nil {
+		rules = []model.RedirectRule{}
+	}
+	if err := json.NewEncoder(w).Encode(URLRules{Rules: rules}); err != nil {
+		h.logger.Error("failed to encode URL rules", zap.Error(err))
+	}
+}
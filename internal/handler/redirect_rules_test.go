@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// TestSetURLRules проверяет замену правил редиректа ссылки и маппинг ошибок на HTTP-статусы
+func TestSetURLRules(t *testing.T) {
+	rules := []model.RedirectRule{{Platforms: []string{"iOS"}, URL: "https://apps.apple.com/app"}}
+
+	tests := []struct {
+		name         string
+		body         string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "Success",
+			body: `{"rules":[{"platforms":["iOS"],"url":"https://apps.apple.com/app"}]}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLRules("abc", rules, "user-1").
+					Return([]model.RedirectRule{{Platforms: []string{"ios"}, URL: "https://apps.apple.com/app"}}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"rules":[{"platforms":["ios"],"url":"https://apps.apple.com/app"}]}`,
+		},
+		{
+			name: "Rules removed",
+			body: `{"rules":[]}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLRules("abc", []model.RedirectRule{}, "user-1").Return(nil, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"rules":[]}`,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{"rules":{}}`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid rules",
+			body: `{"rules":[{"platforms":["iOS"],"url":"https://apps.apple.com/app"}]}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLRules("abc", rules, "user-1").Return(nil, usecase.ErrInvalidOptions).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Foreign link",
+			body: `{"rules":[{"platforms":["iOS"],"url":"https://apps.apple.com/app"}]}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLRules("abc", rules, "user-1").Return(nil, usecase.ErrURLNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			req := newCodeRequest(http.MethodPut, "abc", "/rules", tt.body, "user-1")
+			w := httptest.NewRecorder()
+			h.SetURLRules(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
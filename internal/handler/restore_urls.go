@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// RestoreURLs обрабатывает POST запрос на восстановление удалённых URL пользователя.
+// Возвращает список восстановленных кодов; коды, которые нельзя восстановить, пропускаются.
+func (h *Handler) RestoreURLs(w http.ResponseWriter, req *http.Request) {
+	userID, ok := h.getUserIDFromRequest(req)
+	if !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var codes []string
+	if err := json.NewDecoder(req.Body).Decode(&codes); err != nil {
+		h.logger.Debug("failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if len(codes) == 0 {
+		h.logger.Debug("empty codes list")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	restored, err := h.usecase.RestoreURLs(codes, userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(restored); err != nil {
+		h.logger.Error("failed to encode restored codes", zap.Error(err))
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/middleware"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newUserRequest(method, target, body, userID string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, userID))
+}
+
+// newCodeRequest создаёт запрос к /api/user/urls/{code}{suffix} с параметром маршрута code
+// и userID в контексте; с пустым userID запрос считается неаутентифицированным
+func newCodeRequest(method, code, suffix, body, userID string) *http.Request {
+	req := httptest.NewRequest(method, "/api/user/urls/"+code+suffix, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	if userID != "" {
+		ctx = context.WithValue(ctx, middleware.UserIDContextKey, userID)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestRestoreURLs(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		expectedBody []string
+	}{
+		{
+			name: "Success",
+			body: `["abc","def"]`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().RestoreURLs([]string{"abc", "def"}, "user-1").Return([]string{"abc"}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: []string{"abc"},
+		},
+		{
+			name:         "Empty codes",
+			body:         `[]`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Service unavailable",
+			body: `["abc"]`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().RestoreURLs([]string{"abc"}, "user-1").
+					Return(nil, errors.Join(usecase.ErrServiceUnavailable, errors.New("db down"))).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			w := httptest.NewRecorder()
+			h.RestoreURLs(w, newUserRequest(http.MethodPost, "/api/user/urls/restore", tt.body, "user-1"))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != nil {
+				var restored []string
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&restored))
+				assert.Equal(t, tt.expectedBody, restored)
+			}
+		})
+	}
+}
+
+func TestGetUserURLs_Deleted(t *testing.T) {
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().GetDeletedURLsByUserID("user-1").Return([]model.UserURLResponse{
+		{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://example.com", DeletedAt: &deletedAt},
+	}, nil).Once()
+	h := New(mockUsecase, zap.NewNop(), nil)
+
+	w := httptest.NewRecorder()
+	h.GetUserURLs(w, newUserRequest(http.MethodGet, "/api/user/urls?deleted=true", "", "user-1"))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var urls []model.UserURLResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&urls))
+	require.Len(t, urls, 1)
+	require.NotNil(t, urls[0].DeletedAt)
+	assert.True(t, deletedAt.Equal(*urls[0].DeletedAt))
+}
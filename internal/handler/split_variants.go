@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// splitVariantCookieName — имя куки с номером варианта сплит-ссылки, закреплённого за посетителем.
+// Кука ограничена путём /{id}, поэтому у каждой ссылки свой закреплённый вариант.
+const splitVariantCookieName = "split_variant"
+
+// splitVariantCookieMaxAge — срок, на который вариант сплит-ссылки закрепляется за посетителем
+const splitVariantCookieMaxAge = 30 * 24 * time.Hour
+
+// URLVariants — тело запроса и ответа PUT /api/user/urls/{code}/variants.
+type URLVariants struct {
+	// Variants — варианты сплит-ссылки с весами; пустой список снимает сплит-тест.
+	Variants []model.SplitVariant `json:"variants"`
+}
+
+// SetURLVariants заменяет варианты сплит-ссылки аутентифицированного пользователя.
+// Отвечает сохранёнными вариантами в каноническом виде.
+func (h *Handler) SetURLVariants(w http.ResponseWriter, req *http.Request) {
+	userID, ok := h.getUserIDFromRequest(req)
+	if !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var request URLVariants
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		h.logger.Warn("failed to decode JSON request",
+			zap.Error(err),
+			zap.String("remote_addr", req.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	variants, err := h.usecase.SetURLVariants(chi.URLParam(req, "code"), request.Variants, userID)
+	if err != nil {
+		h.handleErrorJSON(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if variants == nil {
+		variants = []model.SplitVariant{}
+	}
+	if err := json.NewEncoder(w).Encode(URLVariants{Variants: variants}); err != nil {
+		h.logger.Error("failed to encode URL variants", zap.Error(err))
+	}
+}
+
+// pinnedSplitVariant возвращает номер варианта сплит-ссылки из куки посетителя; 0 — куки нет
+// или значение некорректно
+func pinnedSplitVariant(req *http.Request) int {
+	cookie, err := req.Cookie(splitVariantCookieName)
+	if err != nil {
+		return 0
+	}
+	variant, err := strconv.Atoi(cookie.Value)
+	if err != nil || variant < 0 {
+		return 0
+	}
+	return variant
+}
+
+// pinSplitVariant закрепляет за посетителем вариант сплит-ссылки code, на который ведёт переход
+func pinSplitVariant(w http.ResponseWriter, req *http.Request, code string, variant int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     splitVariantCookieName,
+		Value:    strconv.Itoa(variant),
+		Path:     "/" + code,
+		MaxAge:   int(splitVariantCookieMaxAge / time.Second),
+		HttpOnly: true,
+		Secure:   req.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestSetURLVariants проверяет замену вариантов сплит-ссылки и маппинг ошибок на HTTP-статусы
+func TestSetURLVariants(t *testing.T) {
+	variants := []model.SplitVariant{{URL: "https://a.example.com", Weight: 3}, {URL: "https://b.example.com", Weight: 1}}
+	body := `{"variants":[{"url":"https://a.example.com","weight":3},{"url":"https://b.example.com","weight":1}]}`
+
+	tests := []struct {
+		name         string
+		body         string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "Success",
+			body: body,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLVariants("abc", variants, "user-1").Return(variants, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: body,
+		},
+		{
+			name: "Variants removed",
+			body: `{"variants":[]}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLVariants("abc", []model.SplitVariant{}, "user-1").Return(nil, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"variants":[]}`,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{"variants":{}}`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid variants",
+			body: body,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLVariants("abc", variants, "user-1").Return(nil, usecase.ErrInvalidOptions).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Foreign link",
+			body: body,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().SetURLVariants("abc", variants, "user-1").Return(nil, usecase.ErrURLNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			h := New(mockUsecase, zap.NewNop(), nil)
+
+			req := newCodeRequest(http.MethodPut, "abc", "/variants", tt.body, "user-1")
+			w := httptest.NewRecorder()
+			h.SetURLVariants(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestGetURL_SplitVariant проверяет передачу закреплённого варианта в usecase,
+// учёт выбранного варианта в статистике и закрепление его кукой
+func TestGetURL_SplitVariant(t *testing.T) {
+	pinned := model.Visit{IP: "192.0.2.1", Query: url.Values{}, Variant: 1}
+	chosen := pinned
+	chosen.Variant = 2
+
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		GetOriginalURL("abc", model.LinkAccess{}, pinned).
+		Return(model.Redirect{URL: "https://b.example.com", Status: http.StatusFound, Variant: 2}, nil).
+		Once()
+	mockUsecase.EXPECT().RecordClick("abc", chosen).Once()
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	req := newRedirectRequest("/abc", "abc")
+	req.AddCookie(&http.Cookie{Name: splitVariantCookieName, Value: "1"})
+	w := httptest.NewRecorder()
+	handler.GetURL(w, req)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://b.example.com", w.Header().Get("Location"))
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, splitVariantCookieName, cookies[0].Name)
+	assert.Equal(t, "2", cookies[0].Value)
+	assert.Equal(t, "/abc", cookies[0].Path)
+	assert.Equal(t, int(splitVariantCookieMaxAge.Seconds()), cookies[0].MaxAge)
+}
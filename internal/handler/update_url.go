@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/avc-dev/url-shortener/internal/audit"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// UpdateURLRequest — тело PATCH-запроса к /api/user/urls/{code}.
+type UpdateURLRequest struct {
+	// URL — новый оригинальный URL ссылки.
+	URL string `json:"url"`
+}
+
+// UpdateURL изменяет адрес короткой ссылки аутентифицированного пользователя.
+// Для чужой или несуществующей ссылки отвечает 404, при совпадении нового адреса
+// с другой ссылкой пользователя — 409 с её коротким URL.
+func (h *Handler) UpdateURL(w http.ResponseWriter, req *http.Request) {
+	userID, ok := h.getUserIDFromRequest(req)
+	if !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var request UpdateURLRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		h.logger.Warn("failed to decode JSON request",
+			zap.Error(err),
+			zap.String("remote_addr", req.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	code := chi.URLParam(req, "code")
+	update, err := h.usecase.UpdateURL(code, request.URL, userID)
+	if err != nil {
+		h.handleErrorJSON(w, err)
+		return
+	}
+
+	h.emitAuditEvent(req, audit.NewUpdateEvent(userID, code, update.PreviousURL, update.OriginalURL))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(update); err != nil {
+		h.logger.Error("failed to encode URL update", zap.Error(err))
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/audit"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestUpdateURL проверяет изменение адреса ссылки и маппинг ошибок на HTTP-статусы
+func TestUpdateURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		body         string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "Success",
+			userID: "user-1",
+			body:   `{"url":"https://new.com"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().UpdateURL("abc", "https://new.com", "user-1").
+					Return(model.URLUpdate{
+						ShortURL:    "http://localhost:8080/abc",
+						OriginalURL: "https://new.com",
+						PreviousURL: "https://old.com",
+					}, nil).
+					Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Unauthorized",
+			body:         `{"url":"https://new.com"}`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "Invalid JSON",
+			userID:       "user-1",
+			body:         `{"url":`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Invalid URL",
+			userID: "user-1",
+			body:   `{"url":"not-a-url"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().UpdateURL("abc", "not-a-url", "user-1").
+					Return(model.URLUpdate{}, usecase.ErrInvalidURL).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Foreign link",
+			userID: "user-2",
+			body:   `{"url":"https://new.com"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().UpdateURL("abc", "https://new.com", "user-2").
+					Return(model.URLUpdate{}, usecase.ErrURLNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "Conflict with another own link",
+			userID: "user-1",
+			body:   `{"url":"https://taken.com"}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().UpdateURL("abc", "https://taken.com", "user-1").
+					Return(model.URLUpdate{}, usecase.URLAlreadyExistsError{Code: "http://localhost:8080/other"}).Once()
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `{"result":"http://localhost:8080/other"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			aud := &testAuditor{}
+			handler := New(mockUsecase, zap.NewNop(), nil, aud)
+
+			w := httptest.NewRecorder()
+			handler.UpdateURL(w, newCodeRequest(http.MethodPatch, "abc", "", tt.body, tt.userID))
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+
+			if tt.expectedCode != http.StatusOK {
+				assert.Empty(t, aud.snapshot(), "failed update is not audited")
+				return
+			}
+
+			var update model.URLUpdate
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&update))
+			assert.Equal(t, "https://old.com", update.PreviousURL)
+
+			events := aud.snapshot()
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.ActionUpdate, events[0].Action)
+			assert.Equal(t, "user-1", events[0].UserID)
+			assert.Equal(t, "abc", events[0].ShortCode)
+			assert.Equal(t, "https://old.com", events[0].OldURL)
+			assert.Equal(t, "https://new.com", events[0].URL)
+		})
+	}
+}
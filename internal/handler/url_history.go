@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/avc-dev/url-shortener/internal/audit"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// RollbackURLRequest — тело POST-запроса к /api/user/urls/{code}/rollback.
+type RollbackURLRequest struct {
+	// Version — номер версии из истории ссылки, адрес которой нужно вернуть.
+	Version int `json:"version"`
+}
+
+// GetURLHistory возвращает текущий адрес ссылки аутентифицированного пользователя
+// и её прежние версии. Для чужой или несуществующей ссылки отвечает 404.
+func (h *Handler) GetURLHistory(w http.ResponseWriter, req *http.Request) {
+	userID, ok := h.getUserIDFromRequest(req)
+	if !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	history, err := h.usecase.GetURLHistory(chi.URLParam(req, "code"), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		h.logger.Error("failed to encode URL history", zap.Error(err))
+	}
+}
+
+// RollbackURL возвращает ссылке адрес из указанной версии истории.
+// Для неизвестной версии отвечает 404, при совпадении адреса с другой ссылкой
+// пользователя — 409 с её коротким URL.
+func (h *Handler) RollbackURL(w http.ResponseWriter, req *http.Request) {
+	userID, ok := h.getUserIDFromRequest(req)
+	if !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var request RollbackURLRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil || request.Version <= 0 {
+		h.logger.Warn("invalid rollback request",
+			zap.Error(err),
+			zap.String("remote_addr", req.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	code := chi.URLParam(req, "code")
+	update, err := h.usecase.RollbackURL(code, request.Version, userID)
+	if err != nil {
+		h.handleErrorJSON(w, err)
+		return
+	}
+
+	if update.PreviousURL != update.OriginalURL {
+		h.emitAuditEvent(req, audit.NewUpdateEvent(userID, code, update.PreviousURL, update.OriginalURL))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(update); err != nil {
+		h.logger.Error("failed to encode URL update", zap.Error(err))
+	}
+}
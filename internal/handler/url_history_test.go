@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/audit"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestGetURLHistory проверяет выдачу истории адресов владельцу ссылки
+func TestGetURLHistory(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+	}{
+		{
+			name:   "Success",
+			userID: "user-1",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetURLHistory("abc", "user-1").
+					Return(model.URLHistory{
+						Code:        "abc",
+						OriginalURL: "https://v2.com",
+						Versions:    []model.URLVersion{{Version: 1, OriginalURL: "https://v1.com", ChangedBy: "user-1"}},
+					}, nil).
+					Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Unauthorized",
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "Foreign link",
+			userID: "user-2",
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().GetURLHistory("abc", "user-2").
+					Return(model.URLHistory{}, fmt.Errorf("%w: code abc", usecase.ErrURLNotFound)).
+					Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			handler := New(mockUsecase, zap.NewNop(), nil)
+
+			req := newCodeRequest(http.MethodGet, "abc", "/history", "", tt.userID)
+			w := httptest.NewRecorder()
+			handler.GetURLHistory(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			if tt.expectedCode == http.StatusOK {
+				var history model.URLHistory
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+				assert.Equal(t, "https://v2.com", history.OriginalURL)
+				require.Len(t, history.Versions, 1)
+				assert.Equal(t, "https://v1.com", history.Versions[0].OriginalURL)
+			}
+		})
+	}
+}
+
+// TestRollbackURL проверяет откат ссылки к версии из истории
+func TestRollbackURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		setupMock    func(m *mocks.MockURLUsecase)
+		expectedCode int
+	}{
+		{
+			name: "Success",
+			body: `{"version":1}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().RollbackURL("abc", 1, "user-1").
+					Return(model.URLUpdate{
+						ShortURL:    "http://localhost:8080/abc",
+						OriginalURL: "https://v1.com",
+						PreviousURL: "https://v2.com",
+					}, nil).
+					Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Missing version",
+			body:         `{}`,
+			setupMock:    func(m *mocks.MockURLUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown version",
+			body: `{"version":7}`,
+			setupMock: func(m *mocks.MockURLUsecase) {
+				m.EXPECT().RollbackURL("abc", 7, "user-1").
+					Return(model.URLUpdate{}, usecase.ErrVersionNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			tt.setupMock(mockUsecase)
+			aud := &testAuditor{}
+			handler := New(mockUsecase, zap.NewNop(), nil, aud)
+
+			w := httptest.NewRecorder()
+			handler.RollbackURL(w, newCodeRequest(http.MethodPost, "abc", "/rollback", tt.body, "user-1"))
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+
+			events := aud.snapshot()
+			if tt.expectedCode != http.StatusOK {
+				assert.Empty(t, events)
+				return
+			}
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.ActionUpdate, events[0].Action)
+			assert.Equal(t, "https://v2.com", events[0].OldURL)
+			assert.Equal(t, "https://v1.com", events[0].URL)
+		})
+	}
+}
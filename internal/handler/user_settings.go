@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"go.uber.org/zap"
+)
+
+// codeStyleCookieName — имя куки с предпочитаемым пользователем стилем кодов.
+const codeStyleCookieName = "code_style"
+
+// codeStyleCookieMaxAge совпадает со сроком жизни куки user_token:
+// настройка живёт столько же, сколько идентичность пользователя.
+const codeStyleCookieMaxAge = 86400
+
+// UserSettingsRequest — тело PUT-запроса к /api/user/settings.
+type UserSettingsRequest struct {
+	// CodeStyle — стиль кодов по умолчанию для новых ссылок пользователя
+	// ("random" или "words"); пустое значение сбрасывает настройку.
+	CodeStyle string `json:"code_style"`
+}
+
+// UpdateUserSettings сохраняет настройки пользователя.
+// Стиль кодов хранится в куке, поэтому применяется ко всем последующим
+// запросам на создание ссылок без явного code_style.
+func (h *Handler) UpdateUserSettings(w http.ResponseWriter, req *http.Request) {
+	if _, ok := h.getUserIDFromRequest(req); !ok {
+		h.logger.Debug("user ID not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var request UserSettingsRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		h.logger.Warn("failed to decode JSON request",
+			zap.Error(err),
+			zap.String("remote_addr", req.RemoteAddr),
+		)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	style := model.CodeStyle(request.CodeStyle)
+	if !style.IsValid() {
+		h.logger.Debug("unknown code style", zap.String("code_style", request.CodeStyle))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	cookie := &http.Cookie{
+		Name:     codeStyleCookieName,
+		Value:    string(style),
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   codeStyleCookieMaxAge,
+	}
+	if style == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// codeStyleFromRequest выбирает стиль кода: явно заданный в запросе имеет приоритет
+// над настройкой пользователя из куки. Кука не подписана и может быть изменена клиентом,
+// поэтому неизвестный стиль в ней не отклоняет запрос, а заменяется стилем по умолчанию.
+func codeStyleFromRequest(req *http.Request, explicit string) model.CodeStyle {
+	if explicit != "" {
+		return model.CodeStyle(explicit)
+	}
+	if cookie, err := req.Cookie(codeStyleCookieName); err == nil {
+		if style := model.CodeStyle(cookie.Value); style.IsValid() {
+			return style
+		}
+	}
+	return ""
+}
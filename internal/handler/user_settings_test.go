@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/middleware"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestUpdateUserSettings проверяет сохранение стиля кодов в куке
+func TestUpdateUserSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCookie string
+		expectedMaxAge int
+	}{
+		{
+			name:           "Set words style",
+			body:           `{"code_style":"words"}`,
+			expectedStatus: http.StatusNoContent,
+			expectedCookie: "words",
+			expectedMaxAge: codeStyleCookieMaxAge,
+		},
+		{
+			name:           "Reset style",
+			body:           `{"code_style":""}`,
+			expectedStatus: http.StatusNoContent,
+			expectedCookie: "",
+			expectedMaxAge: -1,
+		},
+		{
+			name:           "Unknown style",
+			body:           `{"code_style":"emoji"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid JSON",
+			body:           `{invalid`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handler := New(mocks.NewMockURLUsecase(t), zap.NewNop(), nil)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/user/settings", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, "test-user"))
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.UpdateUserSettings(w, req)
+
+			// Assert
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusNoContent {
+				assert.Empty(t, resp.Cookies())
+				return
+			}
+			require.Len(t, resp.Cookies(), 1)
+			cookie := resp.Cookies()[0]
+			assert.Equal(t, codeStyleCookieName, cookie.Name)
+			assert.Equal(t, tt.expectedCookie, cookie.Value)
+			assert.Equal(t, tt.expectedMaxAge, cookie.MaxAge)
+		})
+	}
+}
+
+// TestUpdateUserSettings_NoUserID проверяет обработку запроса без userID
+func TestUpdateUserSettings_NoUserID(t *testing.T) {
+	handler := New(mocks.NewMockURLUsecase(t), zap.NewNop(), nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/user/settings", strings.NewReader(`{"code_style":"words"}`))
+	w := httptest.NewRecorder()
+
+	handler.UpdateUserSettings(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestCreateURL_CodeStyleSelection проверяет приоритет явного стиля над настройкой пользователя
+func TestCreateURL_CodeStyleSelection(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		cookie        string
+		expectedStyle model.CodeStyle
+	}{
+		{name: "No preference", target: "/", expectedStyle: ""},
+		{name: "Query parameter", target: "/?code_style=words", expectedStyle: model.CodeStyleWords},
+		{name: "User preference from cookie", target: "/", cookie: "words", expectedStyle: model.CodeStyleWords},
+		{name: "Query parameter overrides cookie", target: "/?code_style=random", cookie: "words", expectedStyle: model.CodeStyleRandom},
+		{name: "Unknown style in cookie falls back to default", target: "/", cookie: "emoji", expectedStyle: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockUsecase := mocks.NewMockURLUsecase(t)
+			mockUsecase.EXPECT().
+				CreateShortURLFromString("https://example.com", "", model.LinkOptions{CodeStyle: tt.expectedStyle}).
+				Return("http://localhost:8080/brave-otter-42", nil).
+				Once()
+
+			handler := New(mockUsecase, zap.NewNop(), nil)
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewBufferString("https://example.com"))
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: codeStyleCookieName, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.CreateURL(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusCreated, w.Code)
+		})
+	}
+}
+
+// TestCreateURLJSON_CodeStyleField проверяет передачу стиля из тела JSON-запроса
+func TestCreateURLJSON_CodeStyleField(t *testing.T) {
+	// Arrange
+	mockUsecase := mocks.NewMockURLUsecase(t)
+	mockUsecase.EXPECT().
+		CreateShortURLFromString("https://example.com", "", model.LinkOptions{CodeStyle: model.CodeStyleWords}).
+		Return("http://localhost:8080/brave-otter-42", nil).
+		Once()
+
+	handler := New(mockUsecase, zap.NewNop(), nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten",
+		strings.NewReader(`{"url":"https://example.com","code_style":"words"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.CreateURLJSON(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import "net/http"
+
+// BodyLimit возвращает middleware, ограничивающий размер тела запроса maxBytes байтами.
+// Чтение сверх ограничения возвращает *http.MaxBytesError, по которому обработчик
+// отвечает 413. Распакованное сжатое тело отдельно ограничивает GzipMiddleware.
+func BodyLimit(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantLimit bool
+	}{
+		{name: "Within limit", body: strings.Repeat("a", 16)},
+		{name: "Over limit", body: strings.Repeat("a", 17), wantLimit: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readErr error
+			var body []byte
+			mw := BodyLimit(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, readErr = io.ReadAll(r.Body)
+			}))
+
+			mw.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body)))
+
+			if !tt.wantLimit {
+				require.NoError(t, readErr)
+				assert.Equal(t, tt.body, string(body))
+				return
+			}
+			var maxErr *http.MaxBytesError
+			require.True(t, errors.As(readErr, &maxErr))
+			assert.Equal(t, int64(16), maxErr.Limit)
+		})
+	}
+}
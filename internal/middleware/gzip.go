@@ -98,8 +98,11 @@ func (w *gzipResponseWriter) Close() error {
 	return nil
 }
 
-// GzipMiddleware добавляет поддержку сжатия gzip для запросов и ответов
-func GzipMiddleware(logger *zap.Logger) func(next http.Handler) http.Handler {
+// GzipMiddleware добавляет поддержку сжатия gzip для запросов и ответов.
+// Распакованное тело запроса ограничено maxBodyBytes байтами, чтобы небольшое
+// сжатое тело не разворачивалось в неограниченный поток (gzip-бомба): чтение
+// сверх ограничения возвращает *http.MaxBytesError.
+func GzipMiddleware(logger *zap.Logger, maxBodyBytes int64) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Обработка входящих сжатых запросов
@@ -123,7 +126,7 @@ func GzipMiddleware(logger *zap.Logger) func(next http.Handler) http.Handler {
 						)
 					}
 				}()
-				r.Body = cr
+				r.Body = http.MaxBytesReader(w, cr, maxBodyBytes)
 			}
 
 			// Проверяем, поддерживает ли клиент сжатие
@@ -3,6 +3,7 @@ package middleware
 import (
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -115,7 +116,7 @@ func TestGzipMiddleware_CompressResponse(t *testing.T) {
 			})
 
 			// Оборачиваем в gzip middleware
-			wrappedHandler := GzipMiddleware(logger)(handler)
+			wrappedHandler := GzipMiddleware(logger, 1<<20)(handler)
 
 			// Создаем тестовый запрос
 			req := httptest.NewRequest(http.MethodPost, "/", nil)
@@ -209,7 +210,7 @@ func TestGzipMiddleware_DecompressRequest(t *testing.T) {
 			})
 
 			// Оборачиваем в gzip middleware
-			wrappedHandler := GzipMiddleware(logger)(handler)
+			wrappedHandler := GzipMiddleware(logger, 1<<20)(handler)
 
 			// Подготавливаем тело запроса
 			var requestBody io.Reader
@@ -277,7 +278,7 @@ func TestGzipMiddleware_BothDirections(t *testing.T) {
 	})
 
 	// Оборачиваем в gzip middleware
-	wrappedHandler := GzipMiddleware(logger)(handler)
+	wrappedHandler := GzipMiddleware(logger, 1<<20)(handler)
 
 	// Сжимаем тело запроса
 	compressedRequest, err := compressString(expectedRequest)
@@ -363,7 +364,7 @@ func TestGzipMiddleware_LogsErrors(t *testing.T) {
 		})
 
 		// Оборачиваем в gzip middleware
-		wrappedHandler := GzipMiddleware(logger)(handler)
+		wrappedHandler := GzipMiddleware(logger, 1<<20)(handler)
 
 		// Отправляем невалидные gzip данные
 		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("invalid gzip data"))
@@ -407,3 +408,35 @@ func TestGzipMiddleware_LogsErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestGzipMiddleware_DecompressedBodyLimit(t *testing.T) {
+	// Небольшое сжатое тело, которое разворачивается далеко за ограничение
+	compressed, err := compressString(strings.Repeat("0", 1<<20))
+	if err != nil {
+		t.Fatalf("Failed to compress test data: %v", err)
+	}
+	if len(compressed) >= 4096 {
+		t.Fatalf("Compressed body is unexpectedly large: %d bytes", len(compressed))
+	}
+
+	var readErr error
+	var read int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		read, readErr = len(body), err
+		w.WriteHeader(http.StatusOK)
+	})
+	wrappedHandler := GzipMiddleware(zaptest.NewLogger(t), 4096)(handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+	wrappedHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var maxErr *http.MaxBytesError
+	if !errors.As(readErr, &maxErr) {
+		t.Fatalf("Expected *http.MaxBytesError, got %v", readErr)
+	}
+	if maxErr.Limit != 4096 || read > 4096 {
+		t.Errorf("Expected reading to stop at 4096 bytes, read %d with limit %d", read, maxErr.Limit)
+	}
+}
@@ -0,0 +1,2 @@
+-- Revert code column width (fails if word codes longer than 10 characters exist)
+ALTER TABLE urls ALTER COLUMN code TYPE VARCHAR(10);
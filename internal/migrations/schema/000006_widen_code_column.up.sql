@@ -0,0 +1,2 @@
+-- Widen code column to fit human-readable word codes (e.g. "brave-otter-42")
+ALTER TABLE urls ALTER COLUMN code TYPE VARCHAR(64);
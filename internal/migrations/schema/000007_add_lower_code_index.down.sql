@@ -0,0 +1,2 @@
+-- Remove functional index for case-insensitive code lookups
+DROP INDEX IF EXISTS idx_urls_code_lower;
@@ -0,0 +1,5 @@
+-- Functional index for case-insensitive code lookups (CASE_INSENSITIVE_CODES mode).
+-- The index is intentionally non-unique: codes created before the mode was enabled
+-- may differ only in case. They stay reachable by their exact spelling, while a
+-- case-insensitive lookup resolves to the exact match first and then to the oldest row.
+CREATE INDEX IF NOT EXISTS idx_urls_code_lower ON urls (lower(code));
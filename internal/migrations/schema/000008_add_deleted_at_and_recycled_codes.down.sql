@@ -0,0 +1,5 @@
+-- Remove recycled codes pool and soft-delete timestamps
+DROP TABLE IF EXISTS recycled_codes;
+
+DROP INDEX IF EXISTS idx_urls_deleted_at;
+ALTER TABLE urls DROP COLUMN IF EXISTS deleted_at;
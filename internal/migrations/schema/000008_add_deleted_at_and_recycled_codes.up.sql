@@ -0,0 +1,15 @@
+-- Track when a link was soft-deleted so it can be purged after a retention period.
+-- Links deleted before this migration get the migration time as their deletion time.
+ALTER TABLE urls ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+UPDATE urls SET deleted_at = CURRENT_TIMESTAMP WHERE is_deleted = true;
+
+CREATE INDEX IF NOT EXISTS idx_urls_deleted_at ON urls(deleted_at) WHERE is_deleted = true;
+
+-- Pool of codes freed by purged links. A code becomes eligible for reuse
+-- only after available_at, when no cache can still hold the old mapping.
+CREATE TABLE IF NOT EXISTS recycled_codes (
+    code VARCHAR(64) PRIMARY KEY,
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_recycled_codes_available_at ON recycled_codes(available_at);
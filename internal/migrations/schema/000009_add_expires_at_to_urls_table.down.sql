@@ -0,0 +1,17 @@
+-- Remove link expiry. Links keep their rows and become permanent. The restored full
+-- unique index cannot hold an expiring link that duplicates another link of the same
+-- user, so such duplicates stop the rollback instead of being deleted.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM urls GROUP BY original_url, user_id HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'cannot remove link expiry: some users have several links to the same URL; resolve them before rolling back';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id);
+
+DROP INDEX IF EXISTS idx_urls_expires_at;
+ALTER TABLE urls DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,10 @@
+-- Optional absolute expiry for links; NULL means the link never expires.
+ALTER TABLE urls ADD COLUMN expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- Partial index for the background sweeper that marks expired links as deleted
+CREATE INDEX IF NOT EXISTS idx_urls_expires_at ON urls(expires_at) WHERE expires_at IS NOT NULL AND is_deleted = false;
+
+-- Deduplication applies only to permanent links: shortening the same URL with a TTL
+-- always creates a new link, so the (original_url, user_id) uniqueness becomes partial.
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id) WHERE expires_at IS NULL;
@@ -0,0 +1,17 @@
+-- Remove click limits. Links keep their rows and become unlimited. The restored unique
+-- index cannot hold a click-limited link that duplicates another link of the same user,
+-- so such duplicates stop the rollback instead of being deleted.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM urls WHERE expires_at IS NULL
+        GROUP BY original_url, user_id HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'cannot remove click limits: some users have several links to the same URL; resolve them before rolling back';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id) WHERE expires_at IS NULL;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS remaining_clicks;
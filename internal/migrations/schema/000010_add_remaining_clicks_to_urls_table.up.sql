@@ -0,0 +1,6 @@
+-- Optional click limit for burn-after-N links; NULL means unlimited clicks.
+ALTER TABLE urls ADD COLUMN remaining_clicks INTEGER DEFAULT NULL;
+
+-- Click-limited links are not deduplicated either, so they are excluded from uniqueness.
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id) WHERE expires_at IS NULL AND remaining_clicks IS NULL;
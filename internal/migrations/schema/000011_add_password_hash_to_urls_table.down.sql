@@ -0,0 +1,13 @@
+-- Remove link passwords. Dropping the password would make protected links public,
+-- so the rollback stops while any exist instead of deleting or exposing them.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM urls WHERE password_hash IS NOT NULL) THEN
+        RAISE EXCEPTION 'cannot remove link passwords: password-protected links exist; remove or unprotect them before rolling back';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id) WHERE expires_at IS NULL AND remaining_clicks IS NULL;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS password_hash;
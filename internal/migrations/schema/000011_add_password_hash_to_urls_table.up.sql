@@ -0,0 +1,6 @@
+-- Optional bcrypt hash of the link password; NULL means the link is not protected.
+ALTER TABLE urls ADD COLUMN password_hash TEXT DEFAULT NULL;
+
+-- Password-protected links are not deduplicated, so they are excluded from uniqueness.
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id) WHERE expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL;
@@ -0,0 +1,3 @@
+-- Remove aggregated click counters.
+ALTER TABLE urls DROP COLUMN IF EXISTS last_accessed_at;
+ALTER TABLE urls DROP COLUMN IF EXISTS click_count;
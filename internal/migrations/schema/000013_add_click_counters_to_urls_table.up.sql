@@ -0,0 +1,3 @@
+-- Aggregated click counters, updated in batches instead of on every redirect.
+ALTER TABLE urls ADD COLUMN click_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE urls ADD COLUMN last_accessed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
@@ -0,0 +1,2 @@
+-- Remove link version history.
+DROP TABLE IF EXISTS url_versions;
@@ -0,0 +1,12 @@
+-- Previous destinations of links; a row is written each time a link target changes.
+CREATE TABLE IF NOT EXISTS url_versions (
+    id BIGSERIAL PRIMARY KEY,
+    url_id INTEGER NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    original_url TEXT NOT NULL,
+    changed_by VARCHAR(36) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (url_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_url_versions_changed_at ON url_versions(changed_at);
@@ -0,0 +1,4 @@
+-- Remove link folders and tags.
+DROP TABLE IF EXISTS url_tags;
+DROP INDEX IF EXISTS idx_urls_user_id_folder;
+ALTER TABLE urls DROP COLUMN IF EXISTS folder;
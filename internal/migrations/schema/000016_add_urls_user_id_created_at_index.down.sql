@@ -0,0 +1,2 @@
+-- Remove the pagination index.
+DROP INDEX IF EXISTS idx_urls_user_id_created_at;
@@ -0,0 +1,2 @@
+-- Keyset pagination of a user's links ordered by creation time.
+CREATE INDEX IF NOT EXISTS idx_urls_user_id_created_at ON urls(user_id, created_at, code);
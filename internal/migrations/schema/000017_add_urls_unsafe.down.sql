@@ -0,0 +1,2 @@
+-- Remove the moderation flag.
+ALTER TABLE urls DROP COLUMN IF EXISTS unsafe;
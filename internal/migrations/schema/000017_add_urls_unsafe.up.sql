@@ -0,0 +1,2 @@
+-- Moderation flag: links marked unsafe can be shown as a preview page instead of redirecting.
+ALTER TABLE urls ADD COLUMN unsafe BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+-- Remove per-link redirect settings; links fall back to the configured defaults.
+ALTER TABLE urls DROP COLUMN IF EXISTS cache_max_age;
+ALTER TABLE urls DROP COLUMN IF EXISTS redirect_status;
@@ -0,0 +1,4 @@
+-- Per-link redirect response: status code and Cache-Control max-age in seconds.
+-- NULL means the deployment-wide default from the configuration.
+ALTER TABLE urls ADD COLUMN redirect_status SMALLINT DEFAULT NULL;
+ALTER TABLE urls ADD COLUMN cache_max_age INTEGER DEFAULT NULL;
@@ -0,0 +1,19 @@
+-- Remove query rewriting. Links keep their rows and redirect to the destination as is.
+-- The restored unique index cannot hold such a link that duplicates another link of
+-- the same user, so such duplicates stop the rollback instead of being deleted.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM urls
+        WHERE expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL
+        GROUP BY original_url, user_id HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'cannot remove query rewriting: some users have several links to the same URL; resolve them before rolling back';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id) WHERE expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS utm_params;
+ALTER TABLE urls DROP COLUMN IF EXISTS query_passthrough;
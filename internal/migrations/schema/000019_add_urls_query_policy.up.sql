@@ -0,0 +1,11 @@
+-- Per-link query rewriting on redirect: forwarding of the visit query string
+-- ("merge" or "override") and UTM parameters stored as an encoded query string.
+ALTER TABLE urls ADD COLUMN query_passthrough TEXT DEFAULT NULL;
+ALTER TABLE urls ADD COLUMN utm_params TEXT DEFAULT NULL;
+
+-- Links that rewrite the destination query are not deduplicated: one URL usually
+-- has a link per campaign. They are excluded from uniqueness like restricted links.
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id)
+    WHERE expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL
+        AND query_passthrough IS NULL AND utm_params IS NULL;
@@ -0,0 +1,2 @@
+-- Remove conditional redirect rules; every visit goes to original_url.
+ALTER TABLE urls DROP COLUMN IF EXISTS redirect_rules;
@@ -0,0 +1,4 @@
+-- Ordered conditional redirect rules of a link as a JSON array of
+-- {"platforms": [...], "languages": [...], "countries": [...], "url": "..."}.
+-- NULL means every visit goes to original_url.
+ALTER TABLE urls ADD COLUMN redirect_rules JSONB DEFAULT NULL;
@@ -0,0 +1,3 @@
+-- Remove split variants; every visit goes to original_url.
+ALTER TABLE url_clicks DROP COLUMN IF EXISTS variant;
+ALTER TABLE urls DROP COLUMN IF EXISTS split_variants;
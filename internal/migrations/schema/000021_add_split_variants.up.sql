@@ -0,0 +1,6 @@
+-- Weighted A/B variants of a link as a JSON array of {"url": "...", "weight": N}.
+-- NULL means every visit goes to original_url.
+ALTER TABLE urls ADD COLUMN split_variants JSONB DEFAULT NULL;
+
+-- 1-based variant a click was sent to; 0 for links without variants.
+ALTER TABLE url_clicks ADD COLUMN variant SMALLINT NOT NULL DEFAULT 0;
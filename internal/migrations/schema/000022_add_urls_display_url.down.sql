@@ -0,0 +1,2 @@
+-- Remove submitted destinations; lists show the canonical original_url.
+ALTER TABLE urls DROP COLUMN IF EXISTS display_url;
@@ -0,0 +1,4 @@
+-- The destination as the user submitted it, when URL normalization changed it.
+-- original_url holds the canonical form used for redirects and deduplication;
+-- NULL means the submitted string equals original_url.
+ALTER TABLE urls ADD COLUMN display_url TEXT DEFAULT NULL;
@@ -0,0 +1,19 @@
+-- Restore the narrower unique index. A link with a redirect policy, rules or variants
+-- that duplicates another link of the same user cannot be indexed, so such duplicates
+-- stop the rollback instead of being deleted.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM urls
+        WHERE expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL
+            AND query_passthrough IS NULL AND utm_params IS NULL
+        GROUP BY original_url, user_id HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'cannot restore link uniqueness: some users have several links to the same URL; resolve them before rolling back';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id)
+    WHERE expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL
+        AND query_passthrough IS NULL AND utm_params IS NULL;
@@ -0,0 +1,9 @@
+-- Links with a custom redirect policy, redirect rules or A/B variants are not
+-- deduplicated either, so the same user may keep a plain link and such a link to
+-- one URL. Exclude them from uniqueness too.
+DROP INDEX IF EXISTS idx_urls_original_url_user_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url_user_id ON urls(original_url, user_id)
+    WHERE expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL
+        AND query_passthrough IS NULL AND utm_params IS NULL
+        AND redirect_status IS NULL AND cache_max_age IS NULL
+        AND redirect_rules IS NULL AND split_variants IS NULL;
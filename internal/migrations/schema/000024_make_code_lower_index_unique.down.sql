@@ -0,0 +1,3 @@
+-- Allow codes that differ only in case again; keep the lookup index non-unique
+DROP INDEX IF EXISTS idx_urls_code_lower;
+CREATE INDEX IF NOT EXISTS idx_urls_code_lower ON urls (lower(code));
@@ -0,0 +1,24 @@
+-- Short codes become unique regardless of case, so a case-insensitive lookup
+-- (CASE_INSENSITIVE_CODES mode) always resolves to a single link. Codes that differ
+-- only in case are reported rather than renamed: renaming would break links that
+-- were already shared. Resolve every reported group before running the migration.
+DO $$
+DECLARE
+    collisions TEXT;
+BEGIN
+    SELECT string_agg(codes, '; ') INTO collisions
+    FROM (
+        SELECT string_agg(code, ', ' ORDER BY id) AS codes
+        FROM urls
+        GROUP BY lower(code)
+        HAVING COUNT(*) > 1
+    ) AS groups;
+
+    IF collisions IS NOT NULL THEN
+        RAISE EXCEPTION 'short codes differ only in case: %', collisions
+            USING HINT = 'purge or re-create all but one link in each group, then run the migration again';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_urls_code_lower;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_code_lower ON urls (lower(code));
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	model "github.com/avc-dev/url-shortener/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,9 +22,118 @@ func (_m *MockURLRepository) EXPECT() *MockURLRepository_Expecter {
 	return &MockURLRepository_Expecter{mock: &_m.Mock}
 }
 
-// CreateOrGetURL provides a mock function with given fields: code, url, userID
-func (_m *MockURLRepository) CreateOrGetURL(code model.Code, url model.URL, userID string) (model.Code, bool, error) {
-	ret := _m.Called(code, url, userID)
+// AcquireRecycledCode provides a mock function with given fields: now
+func (_m *MockURLRepository) AcquireRecycledCode(now time.Time) (model.Code, bool, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireRecycledCode")
+	}
+
+	var r0 model.Code
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(time.Time) (model.Code, bool, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) model.Code); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(model.Code)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) bool); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(time.Time) error); ok {
+		r2 = rf(now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockURLRepository_AcquireRecycledCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireRecycledCode'
+type MockURLRepository_AcquireRecycledCode_Call struct {
+	*mock.Call
+}
+
+// AcquireRecycledCode is a helper method to define mock.On call
+//   - now time.Time
+func (_e *MockURLRepository_Expecter) AcquireRecycledCode(now interface{}) *MockURLRepository_AcquireRecycledCode_Call {
+	return &MockURLRepository_AcquireRecycledCode_Call{Call: _e.mock.On("AcquireRecycledCode", now)}
+}
+
+func (_c *MockURLRepository_AcquireRecycledCode_Call) Run(run func(now time.Time)) *MockURLRepository_AcquireRecycledCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_AcquireRecycledCode_Call) Return(_a0 model.Code, _a1 bool, _a2 error) *MockURLRepository_AcquireRecycledCode_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockURLRepository_AcquireRecycledCode_Call) RunAndReturn(run func(time.Time) (model.Code, bool, error)) *MockURLRepository_AcquireRecycledCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddClickCounts provides a mock function with given fields: counts
+func (_m *MockURLRepository) AddClickCounts(counts []model.ClickCount) error {
+	ret := _m.Called(counts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddClickCounts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]model.ClickCount) error); ok {
+		r0 = rf(counts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLRepository_AddClickCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddClickCounts'
+type MockURLRepository_AddClickCounts_Call struct {
+	*mock.Call
+}
+
+// AddClickCounts is a helper method to define mock.On call
+//   - counts []model.ClickCount
+func (_e *MockURLRepository_Expecter) AddClickCounts(counts interface{}) *MockURLRepository_AddClickCounts_Call {
+	return &MockURLRepository_AddClickCounts_Call{Call: _e.mock.On("AddClickCounts", counts)}
+}
+
+func (_c *MockURLRepository_AddClickCounts_Call) Run(run func(counts []model.ClickCount)) *MockURLRepository_AddClickCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]model.ClickCount))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_AddClickCounts_Call) Return(_a0 error) *MockURLRepository_AddClickCounts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLRepository_AddClickCounts_Call) RunAndReturn(run func([]model.ClickCount) error) *MockURLRepository_AddClickCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrGetURL provides a mock function with given fields: code, url, userID, opts
+func (_m *MockURLRepository) CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error) {
+	ret := _m.Called(code, url, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateOrGetURL")
@@ -31,23 +142,23 @@ func (_m *MockURLRepository) CreateOrGetURL(code model.Code, url model.URL, user
 	var r0 model.Code
 	var r1 bool
 	var r2 error
-	if rf, ok := ret.Get(0).(func(model.Code, model.URL, string) (model.Code, bool, error)); ok {
-		return rf(code, url, userID)
+	if rf, ok := ret.Get(0).(func(model.Code, model.URL, string, model.LinkOptions) (model.Code, bool, error)); ok {
+		return rf(code, url, userID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(model.Code, model.URL, string) model.Code); ok {
-		r0 = rf(code, url, userID)
+	if rf, ok := ret.Get(0).(func(model.Code, model.URL, string, model.LinkOptions) model.Code); ok {
+		r0 = rf(code, url, userID, opts)
 	} else {
 		r0 = ret.Get(0).(model.Code)
 	}
 
-	if rf, ok := ret.Get(1).(func(model.Code, model.URL, string) bool); ok {
-		r1 = rf(code, url, userID)
+	if rf, ok := ret.Get(1).(func(model.Code, model.URL, string, model.LinkOptions) bool); ok {
+		r1 = rf(code, url, userID, opts)
 	} else {
 		r1 = ret.Get(1).(bool)
 	}
 
-	if rf, ok := ret.Get(2).(func(model.Code, model.URL, string) error); ok {
-		r2 = rf(code, url, userID)
+	if rf, ok := ret.Get(2).(func(model.Code, model.URL, string, model.LinkOptions) error); ok {
+		r2 = rf(code, url, userID, opts)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -64,13 +175,14 @@ type MockURLRepository_CreateOrGetURL_Call struct {
 //   - code model.Code
 //   - url model.URL
 //   - userID string
-func (_e *MockURLRepository_Expecter) CreateOrGetURL(code interface{}, url interface{}, userID interface{}) *MockURLRepository_CreateOrGetURL_Call {
-	return &MockURLRepository_CreateOrGetURL_Call{Call: _e.mock.On("CreateOrGetURL", code, url, userID)}
+//   - opts model.LinkOptions
+func (_e *MockURLRepository_Expecter) CreateOrGetURL(code interface{}, url interface{}, userID interface{}, opts interface{}) *MockURLRepository_CreateOrGetURL_Call {
+	return &MockURLRepository_CreateOrGetURL_Call{Call: _e.mock.On("CreateOrGetURL", code, url, userID, opts)}
 }
 
-func (_c *MockURLRepository_CreateOrGetURL_Call) Run(run func(code model.Code, url model.URL, userID string)) *MockURLRepository_CreateOrGetURL_Call {
+func (_c *MockURLRepository_CreateOrGetURL_Call) Run(run func(code model.Code, url model.URL, userID string, opts model.LinkOptions)) *MockURLRepository_CreateOrGetURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(model.Code), args[1].(model.URL), args[2].(string))
+		run(args[0].(model.Code), args[1].(model.URL), args[2].(string), args[3].(model.LinkOptions))
 	})
 	return _c
 }
@@ -80,22 +192,22 @@ func (_c *MockURLRepository_CreateOrGetURL_Call) Return(_a0 model.Code, _a1 bool
 	return _c
 }
 
-func (_c *MockURLRepository_CreateOrGetURL_Call) RunAndReturn(run func(model.Code, model.URL, string) (model.Code, bool, error)) *MockURLRepository_CreateOrGetURL_Call {
+func (_c *MockURLRepository_CreateOrGetURL_Call) RunAndReturn(run func(model.Code, model.URL, string, model.LinkOptions) (model.Code, bool, error)) *MockURLRepository_CreateOrGetURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateURLsBatch provides a mock function with given fields: urls, userID
-func (_m *MockURLRepository) CreateURLsBatch(urls map[model.Code]model.URL, userID string) error {
-	ret := _m.Called(urls, userID)
+// CreateURLsBatch provides a mock function with given fields: urls, userID, opts
+func (_m *MockURLRepository) CreateURLsBatch(urls map[model.Code]model.URL, userID string, opts model.LinkOptions) error {
+	ret := _m.Called(urls, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateURLsBatch")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(map[model.Code]model.URL, string) error); ok {
-		r0 = rf(urls, userID)
+	if rf, ok := ret.Get(0).(func(map[model.Code]model.URL, string, model.LinkOptions) error); ok {
+		r0 = rf(urls, userID, opts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -111,13 +223,14 @@ type MockURLRepository_CreateURLsBatch_Call struct {
 // CreateURLsBatch is a helper method to define mock.On call
 //   - urls map[model.Code]model.URL
 //   - userID string
-func (_e *MockURLRepository_Expecter) CreateURLsBatch(urls interface{}, userID interface{}) *MockURLRepository_CreateURLsBatch_Call {
-	return &MockURLRepository_CreateURLsBatch_Call{Call: _e.mock.On("CreateURLsBatch", urls, userID)}
+//   - opts model.LinkOptions
+func (_e *MockURLRepository_Expecter) CreateURLsBatch(urls interface{}, userID interface{}, opts interface{}) *MockURLRepository_CreateURLsBatch_Call {
+	return &MockURLRepository_CreateURLsBatch_Call{Call: _e.mock.On("CreateURLsBatch", urls, userID, opts)}
 }
 
-func (_c *MockURLRepository_CreateURLsBatch_Call) Run(run func(urls map[model.Code]model.URL, userID string)) *MockURLRepository_CreateURLsBatch_Call {
+func (_c *MockURLRepository_CreateURLsBatch_Call) Run(run func(urls map[model.Code]model.URL, userID string, opts model.LinkOptions)) *MockURLRepository_CreateURLsBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(map[model.Code]model.URL), args[1].(string))
+		run(args[0].(map[model.Code]model.URL), args[1].(string), args[2].(model.LinkOptions))
 	})
 	return _c
 }
@@ -127,7 +240,7 @@ func (_c *MockURLRepository_CreateURLsBatch_Call) Return(_a0 error) *MockURLRepo
 	return _c
 }
 
-func (_c *MockURLRepository_CreateURLsBatch_Call) RunAndReturn(run func(map[model.Code]model.URL, string) error) *MockURLRepository_CreateURLsBatch_Call {
+func (_c *MockURLRepository_CreateURLsBatch_Call) RunAndReturn(run func(map[model.Code]model.URL, string, model.LinkOptions) error) *MockURLRepository_CreateURLsBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -179,27 +292,29 @@ func (_c *MockURLRepository_DeleteURLsBatch_Call) RunAndReturn(run func([]model.
 	return _c
 }
 
-// GetStats provides a mock function with no fields
-func (_m *MockURLRepository) GetStats() (model.Stats, error) {
-	ret := _m.Called()
+// DeleteURLsByFilter provides a mock function with given fields: userID, filter
+func (_m *MockURLRepository) DeleteURLsByFilter(userID string, filter model.URLFilter) ([]model.Code, error) {
+	ret := _m.Called(userID, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStats")
+		panic("no return value specified for DeleteURLsByFilter")
 	}
 
-	var r0 model.Stats
+	var r0 []model.Code
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (model.Stats, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string, model.URLFilter) ([]model.Code, error)); ok {
+		return rf(userID, filter)
 	}
-	if rf, ok := ret.Get(0).(func() model.Stats); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string, model.URLFilter) []model.Code); ok {
+		r0 = rf(userID, filter)
 	} else {
-		r0 = ret.Get(0).(model.Stats)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Code)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, model.URLFilter) error); ok {
+		r1 = rf(userID, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -207,54 +322,56 @@ func (_m *MockURLRepository) GetStats() (model.Stats, error) {
 	return r0, r1
 }
 
-// MockURLRepository_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
-type MockURLRepository_GetStats_Call struct {
+// MockURLRepository_DeleteURLsByFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURLsByFilter'
+type MockURLRepository_DeleteURLsByFilter_Call struct {
 	*mock.Call
 }
 
-// GetStats is a helper method to define mock.On call
-func (_e *MockURLRepository_Expecter) GetStats() *MockURLRepository_GetStats_Call {
-	return &MockURLRepository_GetStats_Call{Call: _e.mock.On("GetStats")}
+// DeleteURLsByFilter is a helper method to define mock.On call
+//   - userID string
+//   - filter model.URLFilter
+func (_e *MockURLRepository_Expecter) DeleteURLsByFilter(userID interface{}, filter interface{}) *MockURLRepository_DeleteURLsByFilter_Call {
+	return &MockURLRepository_DeleteURLsByFilter_Call{Call: _e.mock.On("DeleteURLsByFilter", userID, filter)}
 }
 
-func (_c *MockURLRepository_GetStats_Call) Run(run func()) *MockURLRepository_GetStats_Call {
+func (_c *MockURLRepository_DeleteURLsByFilter_Call) Run(run func(userID string, filter model.URLFilter)) *MockURLRepository_DeleteURLsByFilter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(model.URLFilter))
 	})
 	return _c
 }
 
-func (_c *MockURLRepository_GetStats_Call) Return(_a0 model.Stats, _a1 error) *MockURLRepository_GetStats_Call {
+func (_c *MockURLRepository_DeleteURLsByFilter_Call) Return(_a0 []model.Code, _a1 error) *MockURLRepository_DeleteURLsByFilter_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockURLRepository_GetStats_Call) RunAndReturn(run func() (model.Stats, error)) *MockURLRepository_GetStats_Call {
+func (_c *MockURLRepository_DeleteURLsByFilter_Call) RunAndReturn(run func(string, model.URLFilter) ([]model.Code, error)) *MockURLRepository_DeleteURLsByFilter_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetURLByCode provides a mock function with given fields: code
-func (_m *MockURLRepository) GetURLByCode(code model.Code) (model.URL, error) {
-	ret := _m.Called(code)
+// FollowURL provides a mock function with given fields: code, unlocked
+func (_m *MockURLRepository) FollowURL(code model.Code, unlocked bool) (model.LinkTarget, error) {
+	ret := _m.Called(code, unlocked)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetURLByCode")
+		panic("no return value specified for FollowURL")
 	}
 
-	var r0 model.URL
+	var r0 model.LinkTarget
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.Code) (model.URL, error)); ok {
-		return rf(code)
+	if rf, ok := ret.Get(0).(func(model.Code, bool) (model.LinkTarget, error)); ok {
+		return rf(code, unlocked)
 	}
-	if rf, ok := ret.Get(0).(func(model.Code) model.URL); ok {
-		r0 = rf(code)
+	if rf, ok := ret.Get(0).(func(model.Code, bool) model.LinkTarget); ok {
+		r0 = rf(code, unlocked)
 	} else {
-		r0 = ret.Get(0).(model.URL)
+		r0 = ret.Get(0).(model.LinkTarget)
 	}
 
-	if rf, ok := ret.Get(1).(func(model.Code) error); ok {
-		r1 = rf(code)
+	if rf, ok := ret.Get(1).(func(model.Code, bool) error); ok {
+		r1 = rf(code, unlocked)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -262,57 +379,116 @@ func (_m *MockURLRepository) GetURLByCode(code model.Code) (model.URL, error) {
 	return r0, r1
 }
 
-// MockURLRepository_GetURLByCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLByCode'
-type MockURLRepository_GetURLByCode_Call struct {
+// MockURLRepository_FollowURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FollowURL'
+type MockURLRepository_FollowURL_Call struct {
 	*mock.Call
 }
 
-// GetURLByCode is a helper method to define mock.On call
+// FollowURL is a helper method to define mock.On call
 //   - code model.Code
-func (_e *MockURLRepository_Expecter) GetURLByCode(code interface{}) *MockURLRepository_GetURLByCode_Call {
-	return &MockURLRepository_GetURLByCode_Call{Call: _e.mock.On("GetURLByCode", code)}
+//   - unlocked bool
+func (_e *MockURLRepository_Expecter) FollowURL(code interface{}, unlocked interface{}) *MockURLRepository_FollowURL_Call {
+	return &MockURLRepository_FollowURL_Call{Call: _e.mock.On("FollowURL", code, unlocked)}
 }
 
-func (_c *MockURLRepository_GetURLByCode_Call) Run(run func(code model.Code)) *MockURLRepository_GetURLByCode_Call {
+func (_c *MockURLRepository_FollowURL_Call) Run(run func(code model.Code, unlocked bool)) *MockURLRepository_FollowURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(model.Code))
+		run(args[0].(model.Code), args[1].(bool))
 	})
 	return _c
 }
 
-func (_c *MockURLRepository_GetURLByCode_Call) Return(_a0 model.URL, _a1 error) *MockURLRepository_GetURLByCode_Call {
+func (_c *MockURLRepository_FollowURL_Call) Return(_a0 model.LinkTarget, _a1 error) *MockURLRepository_FollowURL_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockURLRepository_GetURLByCode_Call) RunAndReturn(run func(model.Code) (model.URL, error)) *MockURLRepository_GetURLByCode_Call {
+func (_c *MockURLRepository_FollowURL_Call) RunAndReturn(run func(model.Code, bool) (model.LinkTarget, error)) *MockURLRepository_FollowURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetURLsByUserID provides a mock function with given fields: userID, baseURL
-func (_m *MockURLRepository) GetURLsByUserID(userID string, baseURL string) ([]model.UserURLResponse, error) {
-	ret := _m.Called(userID, baseURL)
+// GetClickStats provides a mock function with given fields: code, from, to
+func (_m *MockURLRepository) GetClickStats(code model.Code, from time.Time, to time.Time) (model.URLStats, error) {
+	ret := _m.Called(code, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetURLsByUserID")
+		panic("no return value specified for GetClickStats")
+	}
+
+	var r0 model.URLStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.Code, time.Time, time.Time) (model.URLStats, error)); ok {
+		return rf(code, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(model.Code, time.Time, time.Time) model.URLStats); ok {
+		r0 = rf(code, from, to)
+	} else {
+		r0 = ret.Get(0).(model.URLStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(model.Code, time.Time, time.Time) error); ok {
+		r1 = rf(code, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_GetClickStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClickStats'
+type MockURLRepository_GetClickStats_Call struct {
+	*mock.Call
+}
+
+// GetClickStats is a helper method to define mock.On call
+//   - code model.Code
+//   - from time.Time
+//   - to time.Time
+func (_e *MockURLRepository_Expecter) GetClickStats(code interface{}, from interface{}, to interface{}) *MockURLRepository_GetClickStats_Call {
+	return &MockURLRepository_GetClickStats_Call{Call: _e.mock.On("GetClickStats", code, from, to)}
+}
+
+func (_c *MockURLRepository_GetClickStats_Call) Run(run func(code model.Code, from time.Time, to time.Time)) *MockURLRepository_GetClickStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_GetClickStats_Call) Return(_a0 model.URLStats, _a1 error) *MockURLRepository_GetClickStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_GetClickStats_Call) RunAndReturn(run func(model.Code, time.Time, time.Time) (model.URLStats, error)) *MockURLRepository_GetClickStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedURLsByUserID provides a mock function with given fields: userID, baseURL, deletedAfter
+func (_m *MockURLRepository) GetDeletedURLsByUserID(userID string, baseURL string, deletedAfter time.Time) ([]model.UserURLResponse, error) {
+	ret := _m.Called(userID, baseURL, deletedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedURLsByUserID")
 	}
 
 	var r0 []model.UserURLResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]model.UserURLResponse, error)); ok {
-		return rf(userID, baseURL)
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) ([]model.UserURLResponse, error)); ok {
+		return rf(userID, baseURL, deletedAfter)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []model.UserURLResponse); ok {
-		r0 = rf(userID, baseURL)
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) []model.UserURLResponse); ok {
+		r0 = rf(userID, baseURL, deletedAfter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.UserURLResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userID, baseURL)
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) error); ok {
+		r1 = rf(userID, baseURL, deletedAfter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -320,121 +496,462 @@ func (_m *MockURLRepository) GetURLsByUserID(userID string, baseURL string) ([]m
 	return r0, r1
 }
 
-// MockURLRepository_GetURLsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLsByUserID'
-type MockURLRepository_GetURLsByUserID_Call struct {
+// MockURLRepository_GetDeletedURLsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedURLsByUserID'
+type MockURLRepository_GetDeletedURLsByUserID_Call struct {
 	*mock.Call
 }
 
-// GetURLsByUserID is a helper method to define mock.On call
+// GetDeletedURLsByUserID is a helper method to define mock.On call
 //   - userID string
 //   - baseURL string
-func (_e *MockURLRepository_Expecter) GetURLsByUserID(userID interface{}, baseURL interface{}) *MockURLRepository_GetURLsByUserID_Call {
-	return &MockURLRepository_GetURLsByUserID_Call{Call: _e.mock.On("GetURLsByUserID", userID, baseURL)}
+//   - deletedAfter time.Time
+func (_e *MockURLRepository_Expecter) GetDeletedURLsByUserID(userID interface{}, baseURL interface{}, deletedAfter interface{}) *MockURLRepository_GetDeletedURLsByUserID_Call {
+	return &MockURLRepository_GetDeletedURLsByUserID_Call{Call: _e.mock.On("GetDeletedURLsByUserID", userID, baseURL, deletedAfter)}
 }
 
-func (_c *MockURLRepository_GetURLsByUserID_Call) Run(run func(userID string, baseURL string)) *MockURLRepository_GetURLsByUserID_Call {
+func (_c *MockURLRepository_GetDeletedURLsByUserID_Call) Run(run func(userID string, baseURL string, deletedAfter time.Time)) *MockURLRepository_GetDeletedURLsByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockURLRepository_GetURLsByUserID_Call) Return(_a0 []model.UserURLResponse, _a1 error) *MockURLRepository_GetURLsByUserID_Call {
+func (_c *MockURLRepository_GetDeletedURLsByUserID_Call) Return(_a0 []model.UserURLResponse, _a1 error) *MockURLRepository_GetDeletedURLsByUserID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockURLRepository_GetURLsByUserID_Call) RunAndReturn(run func(string, string) ([]model.UserURLResponse, error)) *MockURLRepository_GetURLsByUserID_Call {
+func (_c *MockURLRepository_GetDeletedURLsByUserID_Call) RunAndReturn(run func(string, string, time.Time) ([]model.UserURLResponse, error)) *MockURLRepository_GetDeletedURLsByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsCodeUnique provides a mock function with given fields: code
-func (_m *MockURLRepository) IsCodeUnique(code model.Code) bool {
-	ret := _m.Called(code)
+// GetStats provides a mock function with no fields
+func (_m *MockURLRepository) GetStats() (model.Stats, error) {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsCodeUnique")
+		panic("no return value specified for GetStats")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(model.Code) bool); ok {
-		r0 = rf(code)
+	var r0 model.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (model.Stats, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() model.Stats); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(model.Stats)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockURLRepository_IsCodeUnique_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsCodeUnique'
-type MockURLRepository_IsCodeUnique_Call struct {
+// MockURLRepository_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type MockURLRepository_GetStats_Call struct {
 	*mock.Call
 }
 
-// IsCodeUnique is a helper method to define mock.On call
-//   - code model.Code
-func (_e *MockURLRepository_Expecter) IsCodeUnique(code interface{}) *MockURLRepository_IsCodeUnique_Call {
-	return &MockURLRepository_IsCodeUnique_Call{Call: _e.mock.On("IsCodeUnique", code)}
+// GetStats is a helper method to define mock.On call
+func (_e *MockURLRepository_Expecter) GetStats() *MockURLRepository_GetStats_Call {
+	return &MockURLRepository_GetStats_Call{Call: _e.mock.On("GetStats")}
 }
 
-func (_c *MockURLRepository_IsCodeUnique_Call) Run(run func(code model.Code)) *MockURLRepository_IsCodeUnique_Call {
+func (_c *MockURLRepository_GetStats_Call) Run(run func()) *MockURLRepository_GetStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(model.Code))
+		run()
 	})
 	return _c
 }
 
-func (_c *MockURLRepository_IsCodeUnique_Call) Return(_a0 bool) *MockURLRepository_IsCodeUnique_Call {
-	_c.Call.Return(_a0)
+func (_c *MockURLRepository_GetStats_Call) Return(_a0 model.Stats, _a1 error) *MockURLRepository_GetStats_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockURLRepository_IsCodeUnique_Call) RunAndReturn(run func(model.Code) bool) *MockURLRepository_IsCodeUnique_Call {
+func (_c *MockURLRepository_GetStats_Call) RunAndReturn(run func() (model.Stats, error)) *MockURLRepository_GetStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsURLOwnedByUser provides a mock function with given fields: code, userID
-func (_m *MockURLRepository) IsURLOwnedByUser(code model.Code, userID string) bool {
-	ret := _m.Called(code, userID)
+// GetURLByCode provides a mock function with given fields: code
+func (_m *MockURLRepository) GetURLByCode(code model.Code) (model.URL, error) {
+	ret := _m.Called(code)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsURLOwnedByUser")
+		panic("no return value specified for GetURLByCode")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(model.Code, string) bool); ok {
-		r0 = rf(code, userID)
+	var r0 model.URL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.Code) (model.URL, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(model.Code) model.URL); ok {
+		r0 = rf(code)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(model.URL)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(model.Code) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockURLRepository_IsURLOwnedByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsURLOwnedByUser'
-type MockURLRepository_IsURLOwnedByUser_Call struct {
+// MockURLRepository_GetURLByCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLByCode'
+type MockURLRepository_GetURLByCode_Call struct {
 	*mock.Call
 }
 
-// IsURLOwnedByUser is a helper method to define mock.On call
+// GetURLByCode is a helper method to define mock.On call
 //   - code model.Code
-//   - userID string
-func (_e *MockURLRepository_Expecter) IsURLOwnedByUser(code interface{}, userID interface{}) *MockURLRepository_IsURLOwnedByUser_Call {
-	return &MockURLRepository_IsURLOwnedByUser_Call{Call: _e.mock.On("IsURLOwnedByUser", code, userID)}
+func (_e *MockURLRepository_Expecter) GetURLByCode(code interface{}) *MockURLRepository_GetURLByCode_Call {
+	return &MockURLRepository_GetURLByCode_Call{Call: _e.mock.On("GetURLByCode", code)}
 }
 
-func (_c *MockURLRepository_IsURLOwnedByUser_Call) Run(run func(code model.Code, userID string)) *MockURLRepository_IsURLOwnedByUser_Call {
+func (_c *MockURLRepository_GetURLByCode_Call) Run(run func(code model.Code)) *MockURLRepository_GetURLByCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(model.Code), args[1].(string))
+		run(args[0].(model.Code))
 	})
 	return _c
 }
 
-func (_c *MockURLRepository_IsURLOwnedByUser_Call) Return(_a0 bool) *MockURLRepository_IsURLOwnedByUser_Call {
-	_c.Call.Return(_a0)
-	return _c
+func (_c *MockURLRepository_GetURLByCode_Call) Return(_a0 model.URL, _a1 error) *MockURLRepository_GetURLByCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_GetURLByCode_Call) RunAndReturn(run func(model.Code) (model.URL, error)) *MockURLRepository_GetURLByCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLPasswordHash provides a mock function with given fields: code
+func (_m *MockURLRepository) GetURLPasswordHash(code model.Code) (string, error) {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLPasswordHash")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.Code) (string, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(model.Code) string); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(model.Code) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_GetURLPasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLPasswordHash'
+type MockURLRepository_GetURLPasswordHash_Call struct {
+	*mock.Call
+}
+
+// GetURLPasswordHash is a helper method to define mock.On call
+//   - code model.Code
+func (_e *MockURLRepository_Expecter) GetURLPasswordHash(code interface{}) *MockURLRepository_GetURLPasswordHash_Call {
+	return &MockURLRepository_GetURLPasswordHash_Call{Call: _e.mock.On("GetURLPasswordHash", code)}
+}
+
+func (_c *MockURLRepository_GetURLPasswordHash_Call) Run(run func(code model.Code)) *MockURLRepository_GetURLPasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_GetURLPasswordHash_Call) Return(_a0 string, _a1 error) *MockURLRepository_GetURLPasswordHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_GetURLPasswordHash_Call) RunAndReturn(run func(model.Code) (string, error)) *MockURLRepository_GetURLPasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLVersions provides a mock function with given fields: code
+func (_m *MockURLRepository) GetURLVersions(code model.Code) ([]model.URLVersion, error) {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLVersions")
+	}
+
+	var r0 []model.URLVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.Code) ([]model.URLVersion, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(model.Code) []model.URLVersion); ok {
+		r0 = rf(code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.URLVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.Code) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_GetURLVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLVersions'
+type MockURLRepository_GetURLVersions_Call struct {
+	*mock.Call
+}
+
+// GetURLVersions is a helper method to define mock.On call
+//   - code model.Code
+func (_e *MockURLRepository_Expecter) GetURLVersions(code interface{}) *MockURLRepository_GetURLVersions_Call {
+	return &MockURLRepository_GetURLVersions_Call{Call: _e.mock.On("GetURLVersions", code)}
+}
+
+func (_c *MockURLRepository_GetURLVersions_Call) Run(run func(code model.Code)) *MockURLRepository_GetURLVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_GetURLVersions_Call) Return(_a0 []model.URLVersion, _a1 error) *MockURLRepository_GetURLVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_GetURLVersions_Call) RunAndReturn(run func(model.Code) ([]model.URLVersion, error)) *MockURLRepository_GetURLVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLsByUserID provides a mock function with given fields: userID, baseURL, query
+func (_m *MockURLRepository) GetURLsByUserID(userID string, baseURL string, query model.URLQuery) (model.URLPage, error) {
+	ret := _m.Called(userID, baseURL, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLsByUserID")
+	}
+
+	var r0 model.URLPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, model.URLQuery) (model.URLPage, error)); ok {
+		return rf(userID, baseURL, query)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, model.URLQuery) model.URLPage); ok {
+		r0 = rf(userID, baseURL, query)
+	} else {
+		r0 = ret.Get(0).(model.URLPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, model.URLQuery) error); ok {
+		r1 = rf(userID, baseURL, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_GetURLsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLsByUserID'
+type MockURLRepository_GetURLsByUserID_Call struct {
+	*mock.Call
+}
+
+// GetURLsByUserID is a helper method to define mock.On call
+//   - userID string
+//   - baseURL string
+//   - query model.URLQuery
+func (_e *MockURLRepository_Expecter) GetURLsByUserID(userID interface{}, baseURL interface{}, query interface{}) *MockURLRepository_GetURLsByUserID_Call {
+	return &MockURLRepository_GetURLsByUserID_Call{Call: _e.mock.On("GetURLsByUserID", userID, baseURL, query)}
+}
+
+func (_c *MockURLRepository_GetURLsByUserID_Call) Run(run func(userID string, baseURL string, query model.URLQuery)) *MockURLRepository_GetURLsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(model.URLQuery))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_GetURLsByUserID_Call) Return(_a0 model.URLPage, _a1 error) *MockURLRepository_GetURLsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_GetURLsByUserID_Call) RunAndReturn(run func(string, string, model.URLQuery) (model.URLPage, error)) *MockURLRepository_GetURLsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InspectURL provides a mock function with given fields: code, unlocked
+func (_m *MockURLRepository) InspectURL(code model.Code, unlocked bool) (model.LinkInfo, error) {
+	ret := _m.Called(code, unlocked)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InspectURL")
+	}
+
+	var r0 model.LinkInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.Code, bool) (model.LinkInfo, error)); ok {
+		return rf(code, unlocked)
+	}
+	if rf, ok := ret.Get(0).(func(model.Code, bool) model.LinkInfo); ok {
+		r0 = rf(code, unlocked)
+	} else {
+		r0 = ret.Get(0).(model.LinkInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(model.Code, bool) error); ok {
+		r1 = rf(code, unlocked)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_InspectURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InspectURL'
+type MockURLRepository_InspectURL_Call struct {
+	*mock.Call
+}
+
+// InspectURL is a helper method to define mock.On call
+//   - code model.Code
+//   - unlocked bool
+func (_e *MockURLRepository_Expecter) InspectURL(code interface{}, unlocked interface{}) *MockURLRepository_InspectURL_Call {
+	return &MockURLRepository_InspectURL_Call{Call: _e.mock.On("InspectURL", code, unlocked)}
+}
+
+func (_c *MockURLRepository_InspectURL_Call) Run(run func(code model.Code, unlocked bool)) *MockURLRepository_InspectURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_InspectURL_Call) Return(_a0 model.LinkInfo, _a1 error) *MockURLRepository_InspectURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_InspectURL_Call) RunAndReturn(run func(model.Code, bool) (model.LinkInfo, error)) *MockURLRepository_InspectURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsCodeUnique provides a mock function with given fields: code
+func (_m *MockURLRepository) IsCodeUnique(code model.Code) bool {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsCodeUnique")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(model.Code) bool); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockURLRepository_IsCodeUnique_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsCodeUnique'
+type MockURLRepository_IsCodeUnique_Call struct {
+	*mock.Call
+}
+
+// IsCodeUnique is a helper method to define mock.On call
+//   - code model.Code
+func (_e *MockURLRepository_Expecter) IsCodeUnique(code interface{}) *MockURLRepository_IsCodeUnique_Call {
+	return &MockURLRepository_IsCodeUnique_Call{Call: _e.mock.On("IsCodeUnique", code)}
+}
+
+func (_c *MockURLRepository_IsCodeUnique_Call) Run(run func(code model.Code)) *MockURLRepository_IsCodeUnique_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_IsCodeUnique_Call) Return(_a0 bool) *MockURLRepository_IsCodeUnique_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLRepository_IsCodeUnique_Call) RunAndReturn(run func(model.Code) bool) *MockURLRepository_IsCodeUnique_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsURLOwnedByUser provides a mock function with given fields: code, userID
+func (_m *MockURLRepository) IsURLOwnedByUser(code model.Code, userID string) bool {
+	ret := _m.Called(code, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsURLOwnedByUser")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(model.Code, string) bool); ok {
+		r0 = rf(code, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockURLRepository_IsURLOwnedByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsURLOwnedByUser'
+type MockURLRepository_IsURLOwnedByUser_Call struct {
+	*mock.Call
+}
+
+// IsURLOwnedByUser is a helper method to define mock.On call
+//   - code model.Code
+//   - userID string
+func (_e *MockURLRepository_Expecter) IsURLOwnedByUser(code interface{}, userID interface{}) *MockURLRepository_IsURLOwnedByUser_Call {
+	return &MockURLRepository_IsURLOwnedByUser_Call{Call: _e.mock.On("IsURLOwnedByUser", code, userID)}
+}
+
+func (_c *MockURLRepository_IsURLOwnedByUser_Call) Run(run func(code model.Code, userID string)) *MockURLRepository_IsURLOwnedByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_IsURLOwnedByUser_Call) Return(_a0 bool) *MockURLRepository_IsURLOwnedByUser_Call {
+	_c.Call.Return(_a0)
+	return _c
 }
 
 func (_c *MockURLRepository_IsURLOwnedByUser_Call) RunAndReturn(run func(model.Code, string) bool) *MockURLRepository_IsURLOwnedByUser_Call {
@@ -442,6 +959,637 @@ func (_c *MockURLRepository_IsURLOwnedByUser_Call) RunAndReturn(run func(model.C
 	return _c
 }
 
+// MarkExpiredURLs provides a mock function with given fields: now
+func (_m *MockURLRepository) MarkExpiredURLs(now time.Time) ([]model.Code, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkExpiredURLs")
+	}
+
+	var r0 []model.Code
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]model.Code, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []model.Code); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Code)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_MarkExpiredURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkExpiredURLs'
+type MockURLRepository_MarkExpiredURLs_Call struct {
+	*mock.Call
+}
+
+// MarkExpiredURLs is a helper method to define mock.On call
+//   - now time.Time
+func (_e *MockURLRepository_Expecter) MarkExpiredURLs(now interface{}) *MockURLRepository_MarkExpiredURLs_Call {
+	return &MockURLRepository_MarkExpiredURLs_Call{Call: _e.mock.On("MarkExpiredURLs", now)}
+}
+
+func (_c *MockURLRepository_MarkExpiredURLs_Call) Run(run func(now time.Time)) *MockURLRepository_MarkExpiredURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_MarkExpiredURLs_Call) Return(_a0 []model.Code, _a1 error) *MockURLRepository_MarkExpiredURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_MarkExpiredURLs_Call) RunAndReturn(run func(time.Time) ([]model.Code, error)) *MockURLRepository_MarkExpiredURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PeekURL provides a mock function with given fields: code, unlocked
+func (_m *MockURLRepository) PeekURL(code model.Code, unlocked bool) (model.LinkTarget, error) {
+	ret := _m.Called(code, unlocked)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PeekURL")
+	}
+
+	var r0 model.LinkTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.Code, bool) (model.LinkTarget, error)); ok {
+		return rf(code, unlocked)
+	}
+	if rf, ok := ret.Get(0).(func(model.Code, bool) model.LinkTarget); ok {
+		r0 = rf(code, unlocked)
+	} else {
+		r0 = ret.Get(0).(model.LinkTarget)
+	}
+
+	if rf, ok := ret.Get(1).(func(model.Code, bool) error); ok {
+		r1 = rf(code, unlocked)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_PeekURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PeekURL'
+type MockURLRepository_PeekURL_Call struct {
+	*mock.Call
+}
+
+// PeekURL is a helper method to define mock.On call
+//   - code model.Code
+//   - unlocked bool
+func (_e *MockURLRepository_Expecter) PeekURL(code interface{}, unlocked interface{}) *MockURLRepository_PeekURL_Call {
+	return &MockURLRepository_PeekURL_Call{Call: _e.mock.On("PeekURL", code, unlocked)}
+}
+
+func (_c *MockURLRepository_PeekURL_Call) Run(run func(code model.Code, unlocked bool)) *MockURLRepository_PeekURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_PeekURL_Call) Return(_a0 model.LinkTarget, _a1 error) *MockURLRepository_PeekURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_PeekURL_Call) RunAndReturn(run func(model.Code, bool) (model.LinkTarget, error)) *MockURLRepository_PeekURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneURLVersions provides a mock function with given fields: before
+func (_m *MockURLRepository) PruneURLVersions(before time.Time) (int, error) {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneURLVersions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_PruneURLVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneURLVersions'
+type MockURLRepository_PruneURLVersions_Call struct {
+	*mock.Call
+}
+
+// PruneURLVersions is a helper method to define mock.On call
+//   - before time.Time
+func (_e *MockURLRepository_Expecter) PruneURLVersions(before interface{}) *MockURLRepository_PruneURLVersions_Call {
+	return &MockURLRepository_PruneURLVersions_Call{Call: _e.mock.On("PruneURLVersions", before)}
+}
+
+func (_c *MockURLRepository_PruneURLVersions_Call) Run(run func(before time.Time)) *MockURLRepository_PruneURLVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_PruneURLVersions_Call) Return(_a0 int, _a1 error) *MockURLRepository_PruneURLVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_PruneURLVersions_Call) RunAndReturn(run func(time.Time) (int, error)) *MockURLRepository_PruneURLVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedURLs provides a mock function with given fields: deletedBefore
+func (_m *MockURLRepository) PurgeDeletedURLs(deletedBefore time.Time) ([]model.Code, error) {
+	ret := _m.Called(deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedURLs")
+	}
+
+	var r0 []model.Code
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]model.Code, error)); ok {
+		return rf(deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []model.Code); ok {
+		r0 = rf(deletedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Code)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_PurgeDeletedURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedURLs'
+type MockURLRepository_PurgeDeletedURLs_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedURLs is a helper method to define mock.On call
+//   - deletedBefore time.Time
+func (_e *MockURLRepository_Expecter) PurgeDeletedURLs(deletedBefore interface{}) *MockURLRepository_PurgeDeletedURLs_Call {
+	return &MockURLRepository_PurgeDeletedURLs_Call{Call: _e.mock.On("PurgeDeletedURLs", deletedBefore)}
+}
+
+func (_c *MockURLRepository_PurgeDeletedURLs_Call) Run(run func(deletedBefore time.Time)) *MockURLRepository_PurgeDeletedURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_PurgeDeletedURLs_Call) Return(_a0 []model.Code, _a1 error) *MockURLRepository_PurgeDeletedURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_PurgeDeletedURLs_Call) RunAndReturn(run func(time.Time) ([]model.Code, error)) *MockURLRepository_PurgeDeletedURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordClicks provides a mock function with given fields: clicks
+func (_m *MockURLRepository) RecordClicks(clicks []model.Click) error {
+	ret := _m.Called(clicks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordClicks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]model.Click) error); ok {
+		r0 = rf(clicks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLRepository_RecordClicks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordClicks'
+type MockURLRepository_RecordClicks_Call struct {
+	*mock.Call
+}
+
+// RecordClicks is a helper method to define mock.On call
+//   - clicks []model.Click
+func (_e *MockURLRepository_Expecter) RecordClicks(clicks interface{}) *MockURLRepository_RecordClicks_Call {
+	return &MockURLRepository_RecordClicks_Call{Call: _e.mock.On("RecordClicks", clicks)}
+}
+
+func (_c *MockURLRepository_RecordClicks_Call) Run(run func(clicks []model.Click)) *MockURLRepository_RecordClicks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]model.Click))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_RecordClicks_Call) Return(_a0 error) *MockURLRepository_RecordClicks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLRepository_RecordClicks_Call) RunAndReturn(run func([]model.Click) error) *MockURLRepository_RecordClicks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseCodes provides a mock function with given fields: codes, availableAt
+func (_m *MockURLRepository) ReleaseCodes(codes []model.Code, availableAt time.Time) error {
+	ret := _m.Called(codes, availableAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]model.Code, time.Time) error); ok {
+		r0 = rf(codes, availableAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLRepository_ReleaseCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseCodes'
+type MockURLRepository_ReleaseCodes_Call struct {
+	*mock.Call
+}
+
+// ReleaseCodes is a helper method to define mock.On call
+//   - codes []model.Code
+//   - availableAt time.Time
+func (_e *MockURLRepository_Expecter) ReleaseCodes(codes interface{}, availableAt interface{}) *MockURLRepository_ReleaseCodes_Call {
+	return &MockURLRepository_ReleaseCodes_Call{Call: _e.mock.On("ReleaseCodes", codes, availableAt)}
+}
+
+func (_c *MockURLRepository_ReleaseCodes_Call) Run(run func(codes []model.Code, availableAt time.Time)) *MockURLRepository_ReleaseCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]model.Code), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_ReleaseCodes_Call) Return(_a0 error) *MockURLRepository_ReleaseCodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLRepository_ReleaseCodes_Call) RunAndReturn(run func([]model.Code, time.Time) error) *MockURLRepository_ReleaseCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreURLsBatch provides a mock function with given fields: codes, userID, deletedAfter
+func (_m *MockURLRepository) RestoreURLsBatch(codes []model.Code, userID string, deletedAfter time.Time) ([]model.Code, error) {
+	ret := _m.Called(codes, userID, deletedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreURLsBatch")
+	}
+
+	var r0 []model.Code
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]model.Code, string, time.Time) ([]model.Code, error)); ok {
+		return rf(codes, userID, deletedAfter)
+	}
+	if rf, ok := ret.Get(0).(func([]model.Code, string, time.Time) []model.Code); ok {
+		r0 = rf(codes, userID, deletedAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Code)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]model.Code, string, time.Time) error); ok {
+		r1 = rf(codes, userID, deletedAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_RestoreURLsBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreURLsBatch'
+type MockURLRepository_RestoreURLsBatch_Call struct {
+	*mock.Call
+}
+
+// RestoreURLsBatch is a helper method to define mock.On call
+//   - codes []model.Code
+//   - userID string
+//   - deletedAfter time.Time
+func (_e *MockURLRepository_Expecter) RestoreURLsBatch(codes interface{}, userID interface{}, deletedAfter interface{}) *MockURLRepository_RestoreURLsBatch_Call {
+	return &MockURLRepository_RestoreURLsBatch_Call{Call: _e.mock.On("RestoreURLsBatch", codes, userID, deletedAfter)}
+}
+
+func (_c *MockURLRepository_RestoreURLsBatch_Call) Run(run func(codes []model.Code, userID string, deletedAfter time.Time)) *MockURLRepository_RestoreURLsBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]model.Code), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_RestoreURLsBatch_Call) Return(_a0 []model.Code, _a1 error) *MockURLRepository_RestoreURLsBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_RestoreURLsBatch_Call) RunAndReturn(run func([]model.Code, string, time.Time) ([]model.Code, error)) *MockURLRepository_RestoreURLsBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLLabels provides a mock function with given fields: code, labels, userID
+func (_m *MockURLRepository) SetURLLabels(code model.Code, labels model.LinkLabels, userID string) error {
+	ret := _m.Called(code, labels, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.Code, model.LinkLabels, string) error); ok {
+		r0 = rf(code, labels, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLRepository_SetURLLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLLabels'
+type MockURLRepository_SetURLLabels_Call struct {
+	*mock.Call
+}
+
+// SetURLLabels is a helper method to define mock.On call
+//   - code model.Code
+//   - labels model.LinkLabels
+//   - userID string
+func (_e *MockURLRepository_Expecter) SetURLLabels(code interface{}, labels interface{}, userID interface{}) *MockURLRepository_SetURLLabels_Call {
+	return &MockURLRepository_SetURLLabels_Call{Call: _e.mock.On("SetURLLabels", code, labels, userID)}
+}
+
+func (_c *MockURLRepository_SetURLLabels_Call) Run(run func(code model.Code, labels model.LinkLabels, userID string)) *MockURLRepository_SetURLLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].(model.LinkLabels), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_SetURLLabels_Call) Return(_a0 error) *MockURLRepository_SetURLLabels_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLRepository_SetURLLabels_Call) RunAndReturn(run func(model.Code, model.LinkLabels, string) error) *MockURLRepository_SetURLLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLRules provides a mock function with given fields: code, rules, userID
+func (_m *MockURLRepository) SetURLRules(code model.Code, rules []model.RedirectRule, userID string) error {
+	ret := _m.Called(code, rules, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.Code, []model.RedirectRule, string) error); ok {
+		r0 = rf(code, rules, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLRepository_SetURLRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLRules'
+type MockURLRepository_SetURLRules_Call struct {
+	*mock.Call
+}
+
+// SetURLRules is a helper method to define mock.On call
+//   - code model.Code
+//   - rules []model.RedirectRule
+//   - userID string
+func (_e *MockURLRepository_Expecter) SetURLRules(code interface{}, rules interface{}, userID interface{}) *MockURLRepository_SetURLRules_Call {
+	return &MockURLRepository_SetURLRules_Call{Call: _e.mock.On("SetURLRules", code, rules, userID)}
+}
+
+func (_c *MockURLRepository_SetURLRules_Call) Run(run func(code model.Code, rules []model.RedirectRule, userID string)) *MockURLRepository_SetURLRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].([]model.RedirectRule), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_SetURLRules_Call) Return(_a0 error) *MockURLRepository_SetURLRules_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLRepository_SetURLRules_Call) RunAndReturn(run func(model.Code, []model.RedirectRule, string) error) *MockURLRepository_SetURLRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLUnsafe provides a mock function with given fields: code, unsafe
+func (_m *MockURLRepository) SetURLUnsafe(code model.Code, unsafe bool) error {
+	ret := _m.Called(code, unsafe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLUnsafe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.Code, bool) error); ok {
+		r0 = rf(code, unsafe)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLRepository_SetURLUnsafe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLUnsafe'
+type MockURLRepository_SetURLUnsafe_Call struct {
+	*mock.Call
+}
+
+// SetURLUnsafe is a helper method to define mock.On call
+//   - code model.Code
+//   - unsafe bool
+func (_e *MockURLRepository_Expecter) SetURLUnsafe(code interface{}, unsafe interface{}) *MockURLRepository_SetURLUnsafe_Call {
+	return &MockURLRepository_SetURLUnsafe_Call{Call: _e.mock.On("SetURLUnsafe", code, unsafe)}
+}
+
+func (_c *MockURLRepository_SetURLUnsafe_Call) Run(run func(code model.Code, unsafe bool)) *MockURLRepository_SetURLUnsafe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_SetURLUnsafe_Call) Return(_a0 error) *MockURLRepository_SetURLUnsafe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLRepository_SetURLUnsafe_Call) RunAndReturn(run func(model.Code, bool) error) *MockURLRepository_SetURLUnsafe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLVariants provides a mock function with given fields: code, variants, userID
+func (_m *MockURLRepository) SetURLVariants(code model.Code, variants []model.SplitVariant, userID string) error {
+	ret := _m.Called(code, variants, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLVariants")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.Code, []model.SplitVariant, string) error); ok {
+		r0 = rf(code, variants, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLRepository_SetURLVariants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLVariants'
+type MockURLRepository_SetURLVariants_Call struct {
+	*mock.Call
+}
+
+// SetURLVariants is a helper method to define mock.On call
+//   - code model.Code
+//   - variants []model.SplitVariant
+//   - userID string
+func (_e *MockURLRepository_Expecter) SetURLVariants(code interface{}, variants interface{}, userID interface{}) *MockURLRepository_SetURLVariants_Call {
+	return &MockURLRepository_SetURLVariants_Call{Call: _e.mock.On("SetURLVariants", code, variants, userID)}
+}
+
+func (_c *MockURLRepository_SetURLVariants_Call) Run(run func(code model.Code, variants []model.SplitVariant, userID string)) *MockURLRepository_SetURLVariants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].([]model.SplitVariant), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_SetURLVariants_Call) Return(_a0 error) *MockURLRepository_SetURLVariants_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLRepository_SetURLVariants_Call) RunAndReturn(run func(model.Code, []model.SplitVariant, string) error) *MockURLRepository_SetURLVariants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateURL provides a mock function with given fields: code, url, userID
+func (_m *MockURLRepository) UpdateURL(code model.Code, url model.URL, userID string) (model.URL, error) {
+	ret := _m.Called(code, url, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateURL")
+	}
+
+	var r0 model.URL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.Code, model.URL, string) (model.URL, error)); ok {
+		return rf(code, url, userID)
+	}
+	if rf, ok := ret.Get(0).(func(model.Code, model.URL, string) model.URL); ok {
+		r0 = rf(code, url, userID)
+	} else {
+		r0 = ret.Get(0).(model.URL)
+	}
+
+	if rf, ok := ret.Get(1).(func(model.Code, model.URL, string) error); ok {
+		r1 = rf(code, url, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLRepository_UpdateURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURL'
+type MockURLRepository_UpdateURL_Call struct {
+	*mock.Call
+}
+
+// UpdateURL is a helper method to define mock.On call
+//   - code model.Code
+//   - url model.URL
+//   - userID string
+func (_e *MockURLRepository_Expecter) UpdateURL(code interface{}, url interface{}, userID interface{}) *MockURLRepository_UpdateURL_Call {
+	return &MockURLRepository_UpdateURL_Call{Call: _e.mock.On("UpdateURL", code, url, userID)}
+}
+
+func (_c *MockURLRepository_UpdateURL_Call) Run(run func(code model.Code, url model.URL, userID string)) *MockURLRepository_UpdateURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.Code), args[1].(model.URL), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_UpdateURL_Call) Return(_a0 model.URL, _a1 error) *MockURLRepository_UpdateURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLRepository_UpdateURL_Call) RunAndReturn(run func(model.Code, model.URL, string) (model.URL, error)) *MockURLRepository_UpdateURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockURLRepository creates a new instance of MockURLRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockURLRepository(t interface {
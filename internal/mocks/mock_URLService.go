@@ -20,9 +20,9 @@ func (_m *MockURLService) EXPECT() *MockURLService_Expecter {
 	return &MockURLService_Expecter{mock: &_m.Mock}
 }
 
-// CreateShortURL provides a mock function with given fields: originalURL, userID
-func (_m *MockURLService) CreateShortURL(originalURL model.URL, userID string) (model.Code, bool, error) {
-	ret := _m.Called(originalURL, userID)
+// CreateShortURL provides a mock function with given fields: originalURL, userID, opts
+func (_m *MockURLService) CreateShortURL(originalURL model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error) {
+	ret := _m.Called(originalURL, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateShortURL")
@@ -31,23 +31,23 @@ func (_m *MockURLService) CreateShortURL(originalURL model.URL, userID string) (
 	var r0 model.Code
 	var r1 bool
 	var r2 error
-	if rf, ok := ret.Get(0).(func(model.URL, string) (model.Code, bool, error)); ok {
-		return rf(originalURL, userID)
+	if rf, ok := ret.Get(0).(func(model.URL, string, model.LinkOptions) (model.Code, bool, error)); ok {
+		return rf(originalURL, userID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(model.URL, string) model.Code); ok {
-		r0 = rf(originalURL, userID)
+	if rf, ok := ret.Get(0).(func(model.URL, string, model.LinkOptions) model.Code); ok {
+		r0 = rf(originalURL, userID, opts)
 	} else {
 		r0 = ret.Get(0).(model.Code)
 	}
 
-	if rf, ok := ret.Get(1).(func(model.URL, string) bool); ok {
-		r1 = rf(originalURL, userID)
+	if rf, ok := ret.Get(1).(func(model.URL, string, model.LinkOptions) bool); ok {
+		r1 = rf(originalURL, userID, opts)
 	} else {
 		r1 = ret.Get(1).(bool)
 	}
 
-	if rf, ok := ret.Get(2).(func(model.URL, string) error); ok {
-		r2 = rf(originalURL, userID)
+	if rf, ok := ret.Get(2).(func(model.URL, string, model.LinkOptions) error); ok {
+		r2 = rf(originalURL, userID, opts)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -63,13 +63,14 @@ type MockURLService_CreateShortURL_Call struct {
 // CreateShortURL is a helper method to define mock.On call
 //   - originalURL model.URL
 //   - userID string
-func (_e *MockURLService_Expecter) CreateShortURL(originalURL interface{}, userID interface{}) *MockURLService_CreateShortURL_Call {
-	return &MockURLService_CreateShortURL_Call{Call: _e.mock.On("CreateShortURL", originalURL, userID)}
+//   - opts model.LinkOptions
+func (_e *MockURLService_Expecter) CreateShortURL(originalURL interface{}, userID interface{}, opts interface{}) *MockURLService_CreateShortURL_Call {
+	return &MockURLService_CreateShortURL_Call{Call: _e.mock.On("CreateShortURL", originalURL, userID, opts)}
 }
 
-func (_c *MockURLService_CreateShortURL_Call) Run(run func(originalURL model.URL, userID string)) *MockURLService_CreateShortURL_Call {
+func (_c *MockURLService_CreateShortURL_Call) Run(run func(originalURL model.URL, userID string, opts model.LinkOptions)) *MockURLService_CreateShortURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(model.URL), args[1].(string))
+		run(args[0].(model.URL), args[1].(string), args[2].(model.LinkOptions))
 	})
 	return _c
 }
@@ -79,14 +80,14 @@ func (_c *MockURLService_CreateShortURL_Call) Return(_a0 model.Code, _a1 bool, _
 	return _c
 }
 
-func (_c *MockURLService_CreateShortURL_Call) RunAndReturn(run func(model.URL, string) (model.Code, bool, error)) *MockURLService_CreateShortURL_Call {
+func (_c *MockURLService_CreateShortURL_Call) RunAndReturn(run func(model.URL, string, model.LinkOptions) (model.Code, bool, error)) *MockURLService_CreateShortURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateShortURLsBatch provides a mock function with given fields: originalURLs, userID
-func (_m *MockURLService) CreateShortURLsBatch(originalURLs []model.URL, userID string) ([]model.Code, error) {
-	ret := _m.Called(originalURLs, userID)
+// CreateShortURLsBatch provides a mock function with given fields: originalURLs, userID, opts
+func (_m *MockURLService) CreateShortURLsBatch(originalURLs []model.URL, userID string, opts model.LinkOptions) ([]model.Code, error) {
+	ret := _m.Called(originalURLs, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateShortURLsBatch")
@@ -94,19 +95,19 @@ func (_m *MockURLService) CreateShortURLsBatch(originalURLs []model.URL, userID
 
 	var r0 []model.Code
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]model.URL, string) ([]model.Code, error)); ok {
-		return rf(originalURLs, userID)
+	if rf, ok := ret.Get(0).(func([]model.URL, string, model.LinkOptions) ([]model.Code, error)); ok {
+		return rf(originalURLs, userID, opts)
 	}
-	if rf, ok := ret.Get(0).(func([]model.URL, string) []model.Code); ok {
-		r0 = rf(originalURLs, userID)
+	if rf, ok := ret.Get(0).(func([]model.URL, string, model.LinkOptions) []model.Code); ok {
+		r0 = rf(originalURLs, userID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Code)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]model.URL, string) error); ok {
-		r1 = rf(originalURLs, userID)
+	if rf, ok := ret.Get(1).(func([]model.URL, string, model.LinkOptions) error); ok {
+		r1 = rf(originalURLs, userID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,13 +123,14 @@ type MockURLService_CreateShortURLsBatch_Call struct {
 // CreateShortURLsBatch is a helper method to define mock.On call
 //   - originalURLs []model.URL
 //   - userID string
-func (_e *MockURLService_Expecter) CreateShortURLsBatch(originalURLs interface{}, userID interface{}) *MockURLService_CreateShortURLsBatch_Call {
-	return &MockURLService_CreateShortURLsBatch_Call{Call: _e.mock.On("CreateShortURLsBatch", originalURLs, userID)}
+//   - opts model.LinkOptions
+func (_e *MockURLService_Expecter) CreateShortURLsBatch(originalURLs interface{}, userID interface{}, opts interface{}) *MockURLService_CreateShortURLsBatch_Call {
+	return &MockURLService_CreateShortURLsBatch_Call{Call: _e.mock.On("CreateShortURLsBatch", originalURLs, userID, opts)}
 }
 
-func (_c *MockURLService_CreateShortURLsBatch_Call) Run(run func(originalURLs []model.URL, userID string)) *MockURLService_CreateShortURLsBatch_Call {
+func (_c *MockURLService_CreateShortURLsBatch_Call) Run(run func(originalURLs []model.URL, userID string, opts model.LinkOptions)) *MockURLService_CreateShortURLsBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]model.URL), args[1].(string))
+		run(args[0].([]model.URL), args[1].(string), args[2].(model.LinkOptions))
 	})
 	return _c
 }
@@ -138,7 +140,7 @@ func (_c *MockURLService_CreateShortURLsBatch_Call) Return(_a0 []model.Code, _a1
 	return _c
 }
 
-func (_c *MockURLService_CreateShortURLsBatch_Call) RunAndReturn(run func([]model.URL, string) ([]model.Code, error)) *MockURLService_CreateShortURLsBatch_Call {
+func (_c *MockURLService_CreateShortURLsBatch_Call) RunAndReturn(run func([]model.URL, string, model.LinkOptions) ([]model.Code, error)) *MockURLService_CreateShortURLsBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -20,9 +20,72 @@ func (_m *MockURLUsecase) EXPECT() *MockURLUsecase_Expecter {
 	return &MockURLUsecase_Expecter{mock: &_m.Mock}
 }
 
-// CreateShortURLFromString provides a mock function with given fields: urlString, userID
-func (_m *MockURLUsecase) CreateShortURLFromString(urlString string, userID string) (string, error) {
-	ret := _m.Called(urlString, userID)
+// BlockDomain provides a mock function with given fields: pattern
+func (_m *MockURLUsecase) BlockDomain(pattern string) (model.BlockRule, bool, error) {
+	ret := _m.Called(pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockDomain")
+	}
+
+	var r0 model.BlockRule
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (model.BlockRule, bool, error)); ok {
+		return rf(pattern)
+	}
+	if rf, ok := ret.Get(0).(func(string) model.BlockRule); ok {
+		r0 = rf(pattern)
+	} else {
+		r0 = ret.Get(0).(model.BlockRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(pattern)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(pattern)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockURLUsecase_BlockDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockDomain'
+type MockURLUsecase_BlockDomain_Call struct {
+	*mock.Call
+}
+
+// BlockDomain is a helper method to define mock.On call
+//   - pattern string
+func (_e *MockURLUsecase_Expecter) BlockDomain(pattern interface{}) *MockURLUsecase_BlockDomain_Call {
+	return &MockURLUsecase_BlockDomain_Call{Call: _e.mock.On("BlockDomain", pattern)}
+}
+
+func (_c *MockURLUsecase_BlockDomain_Call) Run(run func(pattern string)) *MockURLUsecase_BlockDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_BlockDomain_Call) Return(_a0 model.BlockRule, _a1 bool, _a2 error) *MockURLUsecase_BlockDomain_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockURLUsecase_BlockDomain_Call) RunAndReturn(run func(string) (model.BlockRule, bool, error)) *MockURLUsecase_BlockDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateShortURLFromString provides a mock function with given fields: urlString, userID, opts
+func (_m *MockURLUsecase) CreateShortURLFromString(urlString string, userID string, opts model.LinkOptions) (string, error) {
+	ret := _m.Called(urlString, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateShortURLFromString")
@@ -30,17 +93,17 @@ func (_m *MockURLUsecase) CreateShortURLFromString(urlString string, userID stri
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return rf(urlString, userID)
+	if rf, ok := ret.Get(0).(func(string, string, model.LinkOptions) (string, error)); ok {
+		return rf(urlString, userID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(urlString, userID)
+	if rf, ok := ret.Get(0).(func(string, string, model.LinkOptions) string); ok {
+		r0 = rf(urlString, userID, opts)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(urlString, userID)
+	if rf, ok := ret.Get(1).(func(string, string, model.LinkOptions) error); ok {
+		r1 = rf(urlString, userID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,13 +119,14 @@ type MockURLUsecase_CreateShortURLFromString_Call struct {
 // CreateShortURLFromString is a helper method to define mock.On call
 //   - urlString string
 //   - userID string
-func (_e *MockURLUsecase_Expecter) CreateShortURLFromString(urlString interface{}, userID interface{}) *MockURLUsecase_CreateShortURLFromString_Call {
-	return &MockURLUsecase_CreateShortURLFromString_Call{Call: _e.mock.On("CreateShortURLFromString", urlString, userID)}
+//   - opts model.LinkOptions
+func (_e *MockURLUsecase_Expecter) CreateShortURLFromString(urlString interface{}, userID interface{}, opts interface{}) *MockURLUsecase_CreateShortURLFromString_Call {
+	return &MockURLUsecase_CreateShortURLFromString_Call{Call: _e.mock.On("CreateShortURLFromString", urlString, userID, opts)}
 }
 
-func (_c *MockURLUsecase_CreateShortURLFromString_Call) Run(run func(urlString string, userID string)) *MockURLUsecase_CreateShortURLFromString_Call {
+func (_c *MockURLUsecase_CreateShortURLFromString_Call) Run(run func(urlString string, userID string, opts model.LinkOptions)) *MockURLUsecase_CreateShortURLFromString_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(model.LinkOptions))
 	})
 	return _c
 }
@@ -72,14 +136,14 @@ func (_c *MockURLUsecase_CreateShortURLFromString_Call) Return(_a0 string, _a1 e
 	return _c
 }
 
-func (_c *MockURLUsecase_CreateShortURLFromString_Call) RunAndReturn(run func(string, string) (string, error)) *MockURLUsecase_CreateShortURLFromString_Call {
+func (_c *MockURLUsecase_CreateShortURLFromString_Call) RunAndReturn(run func(string, string, model.LinkOptions) (string, error)) *MockURLUsecase_CreateShortURLFromString_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateShortURLsBatch provides a mock function with given fields: urlStrings, userID
-func (_m *MockURLUsecase) CreateShortURLsBatch(urlStrings []string, userID string) ([]string, error) {
-	ret := _m.Called(urlStrings, userID)
+// CreateShortURLsBatch provides a mock function with given fields: urlStrings, userID, opts
+func (_m *MockURLUsecase) CreateShortURLsBatch(urlStrings []string, userID string, opts model.LinkOptions) ([]string, error) {
+	ret := _m.Called(urlStrings, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateShortURLsBatch")
@@ -87,19 +151,19 @@ func (_m *MockURLUsecase) CreateShortURLsBatch(urlStrings []string, userID strin
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]string, string) ([]string, error)); ok {
-		return rf(urlStrings, userID)
+	if rf, ok := ret.Get(0).(func([]string, string, model.LinkOptions) ([]string, error)); ok {
+		return rf(urlStrings, userID, opts)
 	}
-	if rf, ok := ret.Get(0).(func([]string, string) []string); ok {
-		r0 = rf(urlStrings, userID)
+	if rf, ok := ret.Get(0).(func([]string, string, model.LinkOptions) []string); ok {
+		r0 = rf(urlStrings, userID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]string, string) error); ok {
-		r1 = rf(urlStrings, userID)
+	if rf, ok := ret.Get(1).(func([]string, string, model.LinkOptions) error); ok {
+		r1 = rf(urlStrings, userID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,13 +179,14 @@ type MockURLUsecase_CreateShortURLsBatch_Call struct {
 // CreateShortURLsBatch is a helper method to define mock.On call
 //   - urlStrings []string
 //   - userID string
-func (_e *MockURLUsecase_Expecter) CreateShortURLsBatch(urlStrings interface{}, userID interface{}) *MockURLUsecase_CreateShortURLsBatch_Call {
-	return &MockURLUsecase_CreateShortURLsBatch_Call{Call: _e.mock.On("CreateShortURLsBatch", urlStrings, userID)}
+//   - opts model.LinkOptions
+func (_e *MockURLUsecase_Expecter) CreateShortURLsBatch(urlStrings interface{}, userID interface{}, opts interface{}) *MockURLUsecase_CreateShortURLsBatch_Call {
+	return &MockURLUsecase_CreateShortURLsBatch_Call{Call: _e.mock.On("CreateShortURLsBatch", urlStrings, userID, opts)}
 }
 
-func (_c *MockURLUsecase_CreateShortURLsBatch_Call) Run(run func(urlStrings []string, userID string)) *MockURLUsecase_CreateShortURLsBatch_Call {
+func (_c *MockURLUsecase_CreateShortURLsBatch_Call) Run(run func(urlStrings []string, userID string, opts model.LinkOptions)) *MockURLUsecase_CreateShortURLsBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]string), args[1].(string))
+		run(args[0].([]string), args[1].(string), args[2].(model.LinkOptions))
 	})
 	return _c
 }
@@ -131,7 +196,7 @@ func (_c *MockURLUsecase_CreateShortURLsBatch_Call) Return(_a0 []string, _a1 err
 	return _c
 }
 
-func (_c *MockURLUsecase_CreateShortURLsBatch_Call) RunAndReturn(run func([]string, string) ([]string, error)) *MockURLUsecase_CreateShortURLsBatch_Call {
+func (_c *MockURLUsecase_CreateShortURLsBatch_Call) RunAndReturn(run func([]string, string, model.LinkOptions) ([]string, error)) *MockURLUsecase_CreateShortURLsBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -183,27 +248,191 @@ func (_c *MockURLUsecase_DeleteURLs_Call) RunAndReturn(run func([]string, string
 	return _c
 }
 
-// GetOriginalURL provides a mock function with given fields: code
-func (_m *MockURLUsecase) GetOriginalURL(code string) (string, error) {
-	ret := _m.Called(code)
+// DeleteURLsByFilter provides a mock function with given fields: filter, userID
+func (_m *MockURLUsecase) DeleteURLsByFilter(filter model.URLFilter, userID string) ([]string, error) {
+	ret := _m.Called(filter, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOriginalURL")
+		panic("no return value specified for DeleteURLsByFilter")
 	}
 
-	var r0 string
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(code)
+	if rf, ok := ret.Get(0).(func(model.URLFilter, string) ([]string, error)); ok {
+		return rf(filter, userID)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(code)
+	if rf, ok := ret.Get(0).(func(model.URLFilter, string) []string); ok {
+		r0 = rf(filter, userID)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.URLFilter, string) error); ok {
+		r1 = rf(filter, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_DeleteURLsByFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteURLsByFilter'
+type MockURLUsecase_DeleteURLsByFilter_Call struct {
+	*mock.Call
+}
+
+// DeleteURLsByFilter is a helper method to define mock.On call
+//   - filter model.URLFilter
+//   - userID string
+func (_e *MockURLUsecase_Expecter) DeleteURLsByFilter(filter interface{}, userID interface{}) *MockURLUsecase_DeleteURLsByFilter_Call {
+	return &MockURLUsecase_DeleteURLsByFilter_Call{Call: _e.mock.On("DeleteURLsByFilter", filter, userID)}
+}
+
+func (_c *MockURLUsecase_DeleteURLsByFilter_Call) Run(run func(filter model.URLFilter, userID string)) *MockURLUsecase_DeleteURLsByFilter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.URLFilter), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_DeleteURLsByFilter_Call) Return(_a0 []string, _a1 error) *MockURLUsecase_DeleteURLsByFilter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_DeleteURLsByFilter_Call) RunAndReturn(run func(model.URLFilter, string) ([]string, error)) *MockURLUsecase_DeleteURLsByFilter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlockRules provides a mock function with no fields
+func (_m *MockURLUsecase) GetBlockRules() []model.BlockRule {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockRules")
+	}
+
+	var r0 []model.BlockRule
+	if rf, ok := ret.Get(0).(func() []model.BlockRule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.BlockRule)
+		}
+	}
+
+	return r0
+}
+
+// MockURLUsecase_GetBlockRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockRules'
+type MockURLUsecase_GetBlockRules_Call struct {
+	*mock.Call
+}
+
+// GetBlockRules is a helper method to define mock.On call
+func (_e *MockURLUsecase_Expecter) GetBlockRules() *MockURLUsecase_GetBlockRules_Call {
+	return &MockURLUsecase_GetBlockRules_Call{Call: _e.mock.On("GetBlockRules")}
+}
+
+func (_c *MockURLUsecase_GetBlockRules_Call) Run(run func()) *MockURLUsecase_GetBlockRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_GetBlockRules_Call) Return(_a0 []model.BlockRule) *MockURLUsecase_GetBlockRules_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLUsecase_GetBlockRules_Call) RunAndReturn(run func() []model.BlockRule) *MockURLUsecase_GetBlockRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedURLsByUserID provides a mock function with given fields: userID
+func (_m *MockURLUsecase) GetDeletedURLsByUserID(userID string) ([]model.UserURLResponse, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedURLsByUserID")
+	}
+
+	var r0 []model.UserURLResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]model.UserURLResponse, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []model.UserURLResponse); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserURLResponse)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(code)
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_GetDeletedURLsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedURLsByUserID'
+type MockURLUsecase_GetDeletedURLsByUserID_Call struct {
+	*mock.Call
+}
+
+// GetDeletedURLsByUserID is a helper method to define mock.On call
+//   - userID string
+func (_e *MockURLUsecase_Expecter) GetDeletedURLsByUserID(userID interface{}) *MockURLUsecase_GetDeletedURLsByUserID_Call {
+	return &MockURLUsecase_GetDeletedURLsByUserID_Call{Call: _e.mock.On("GetDeletedURLsByUserID", userID)}
+}
+
+func (_c *MockURLUsecase_GetDeletedURLsByUserID_Call) Run(run func(userID string)) *MockURLUsecase_GetDeletedURLsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_GetDeletedURLsByUserID_Call) Return(_a0 []model.UserURLResponse, _a1 error) *MockURLUsecase_GetDeletedURLsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_GetDeletedURLsByUserID_Call) RunAndReturn(run func(string) ([]model.UserURLResponse, error)) *MockURLUsecase_GetDeletedURLsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOriginalURL provides a mock function with given fields: code, access, visit
+func (_m *MockURLUsecase) GetOriginalURL(code string, access model.LinkAccess, visit model.Visit) (model.Redirect, error) {
+	ret := _m.Called(code, access, visit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOriginalURL")
+	}
+
+	var r0 model.Redirect
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, model.LinkAccess, model.Visit) (model.Redirect, error)); ok {
+		return rf(code, access, visit)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.LinkAccess, model.Visit) model.Redirect); ok {
+		r0 = rf(code, access, visit)
+	} else {
+		r0 = ret.Get(0).(model.Redirect)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.LinkAccess, model.Visit) error); ok {
+		r1 = rf(code, access, visit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -218,23 +447,82 @@ type MockURLUsecase_GetOriginalURL_Call struct {
 
 // GetOriginalURL is a helper method to define mock.On call
 //   - code string
-func (_e *MockURLUsecase_Expecter) GetOriginalURL(code interface{}) *MockURLUsecase_GetOriginalURL_Call {
-	return &MockURLUsecase_GetOriginalURL_Call{Call: _e.mock.On("GetOriginalURL", code)}
+//   - access model.LinkAccess
+//   - visit model.Visit
+func (_e *MockURLUsecase_Expecter) GetOriginalURL(code interface{}, access interface{}, visit interface{}) *MockURLUsecase_GetOriginalURL_Call {
+	return &MockURLUsecase_GetOriginalURL_Call{Call: _e.mock.On("GetOriginalURL", code, access, visit)}
 }
 
-func (_c *MockURLUsecase_GetOriginalURL_Call) Run(run func(code string)) *MockURLUsecase_GetOriginalURL_Call {
+func (_c *MockURLUsecase_GetOriginalURL_Call) Run(run func(code string, access model.LinkAccess, visit model.Visit)) *MockURLUsecase_GetOriginalURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(model.LinkAccess), args[2].(model.Visit))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_GetOriginalURL_Call) Return(_a0 model.Redirect, _a1 error) *MockURLUsecase_GetOriginalURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_GetOriginalURL_Call) RunAndReturn(run func(string, model.LinkAccess, model.Visit) (model.Redirect, error)) *MockURLUsecase_GetOriginalURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQRCode provides a mock function with given fields: code, opts
+func (_m *MockURLUsecase) GetQRCode(code string, opts model.QROptions) (model.QRImage, error) {
+	ret := _m.Called(code, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQRCode")
+	}
+
+	var r0 model.QRImage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, model.QROptions) (model.QRImage, error)); ok {
+		return rf(code, opts)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.QROptions) model.QRImage); ok {
+		r0 = rf(code, opts)
+	} else {
+		r0 = ret.Get(0).(model.QRImage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.QROptions) error); ok {
+		r1 = rf(code, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_GetQRCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQRCode'
+type MockURLUsecase_GetQRCode_Call struct {
+	*mock.Call
+}
+
+// GetQRCode is a helper method to define mock.On call
+//   - code string
+//   - opts model.QROptions
+func (_e *MockURLUsecase_Expecter) GetQRCode(code interface{}, opts interface{}) *MockURLUsecase_GetQRCode_Call {
+	return &MockURLUsecase_GetQRCode_Call{Call: _e.mock.On("GetQRCode", code, opts)}
+}
+
+func (_c *MockURLUsecase_GetQRCode_Call) Run(run func(code string, opts model.QROptions)) *MockURLUsecase_GetQRCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(model.QROptions))
 	})
 	return _c
 }
 
-func (_c *MockURLUsecase_GetOriginalURL_Call) Return(_a0 string, _a1 error) *MockURLUsecase_GetOriginalURL_Call {
+func (_c *MockURLUsecase_GetQRCode_Call) Return(_a0 model.QRImage, _a1 error) *MockURLUsecase_GetQRCode_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockURLUsecase_GetOriginalURL_Call) RunAndReturn(run func(string) (string, error)) *MockURLUsecase_GetOriginalURL_Call {
+func (_c *MockURLUsecase_GetQRCode_Call) RunAndReturn(run func(string, model.QROptions) (model.QRImage, error)) *MockURLUsecase_GetQRCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -294,29 +582,27 @@ func (_c *MockURLUsecase_GetStats_Call) RunAndReturn(run func() (model.Stats, er
 	return _c
 }
 
-// GetURLsByUserID provides a mock function with given fields: userID
-func (_m *MockURLUsecase) GetURLsByUserID(userID string) ([]model.UserURLResponse, error) {
-	ret := _m.Called(userID)
+// GetURLHistory provides a mock function with given fields: code, userID
+func (_m *MockURLUsecase) GetURLHistory(code string, userID string) (model.URLHistory, error) {
+	ret := _m.Called(code, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetURLsByUserID")
+		panic("no return value specified for GetURLHistory")
 	}
 
-	var r0 []model.UserURLResponse
+	var r0 model.URLHistory
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]model.UserURLResponse, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(string, string) (model.URLHistory, error)); ok {
+		return rf(code, userID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []model.UserURLResponse); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(string, string) model.URLHistory); ok {
+		r0 = rf(code, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.UserURLResponse)
-		}
+		r0 = ret.Get(0).(model.URLHistory)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(code, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -324,30 +610,749 @@ func (_m *MockURLUsecase) GetURLsByUserID(userID string) ([]model.UserURLRespons
 	return r0, r1
 }
 
-// MockURLUsecase_GetURLsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLsByUserID'
-type MockURLUsecase_GetURLsByUserID_Call struct {
+// MockURLUsecase_GetURLHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLHistory'
+type MockURLUsecase_GetURLHistory_Call struct {
 	*mock.Call
 }
 
-// GetURLsByUserID is a helper method to define mock.On call
+// GetURLHistory is a helper method to define mock.On call
+//   - code string
 //   - userID string
-func (_e *MockURLUsecase_Expecter) GetURLsByUserID(userID interface{}) *MockURLUsecase_GetURLsByUserID_Call {
-	return &MockURLUsecase_GetURLsByUserID_Call{Call: _e.mock.On("GetURLsByUserID", userID)}
+func (_e *MockURLUsecase_Expecter) GetURLHistory(code interface{}, userID interface{}) *MockURLUsecase_GetURLHistory_Call {
+	return &MockURLUsecase_GetURLHistory_Call{Call: _e.mock.On("GetURLHistory", code, userID)}
 }
 
-func (_c *MockURLUsecase_GetURLsByUserID_Call) Run(run func(userID string)) *MockURLUsecase_GetURLsByUserID_Call {
+func (_c *MockURLUsecase_GetURLHistory_Call) Run(run func(code string, userID string)) *MockURLUsecase_GetURLHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_GetURLHistory_Call) Return(_a0 model.URLHistory, _a1 error) *MockURLUsecase_GetURLHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_GetURLHistory_Call) RunAndReturn(run func(string, string) (model.URLHistory, error)) *MockURLUsecase_GetURLHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLStats provides a mock function with given fields: code, userID
+func (_m *MockURLUsecase) GetURLStats(code string, userID string) (model.URLStats, error) {
+	ret := _m.Called(code, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLStats")
+	}
+
+	var r0 model.URLStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (model.URLStats, error)); ok {
+		return rf(code, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) model.URLStats); ok {
+		r0 = rf(code, userID)
+	} else {
+		r0 = ret.Get(0).(model.URLStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(code, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_GetURLStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLStats'
+type MockURLUsecase_GetURLStats_Call struct {
+	*mock.Call
+}
+
+// GetURLStats is a helper method to define mock.On call
+//   - code string
+//   - userID string
+func (_e *MockURLUsecase_Expecter) GetURLStats(code interface{}, userID interface{}) *MockURLUsecase_GetURLStats_Call {
+	return &MockURLUsecase_GetURLStats_Call{Call: _e.mock.On("GetURLStats", code, userID)}
+}
+
+func (_c *MockURLUsecase_GetURLStats_Call) Run(run func(code string, userID string)) *MockURLUsecase_GetURLStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_GetURLStats_Call) Return(_a0 model.URLStats, _a1 error) *MockURLUsecase_GetURLStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_GetURLStats_Call) RunAndReturn(run func(string, string) (model.URLStats, error)) *MockURLUsecase_GetURLStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetURLsByUserID provides a mock function with given fields: userID, request
+func (_m *MockURLUsecase) GetURLsByUserID(userID string, request model.URLListRequest) (model.URLList, error) {
+	ret := _m.Called(userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLsByUserID")
+	}
+
+	var r0 model.URLList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, model.URLListRequest) (model.URLList, error)); ok {
+		return rf(userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.URLListRequest) model.URLList); ok {
+		r0 = rf(userID, request)
+	} else {
+		r0 = ret.Get(0).(model.URLList)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.URLListRequest) error); ok {
+		r1 = rf(userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_GetURLsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetURLsByUserID'
+type MockURLUsecase_GetURLsByUserID_Call struct {
+	*mock.Call
+}
+
+// GetURLsByUserID is a helper method to define mock.On call
+//   - userID string
+//   - request model.URLListRequest
+func (_e *MockURLUsecase_Expecter) GetURLsByUserID(userID interface{}, request interface{}) *MockURLUsecase_GetURLsByUserID_Call {
+	return &MockURLUsecase_GetURLsByUserID_Call{Call: _e.mock.On("GetURLsByUserID", userID, request)}
+}
+
+func (_c *MockURLUsecase_GetURLsByUserID_Call) Run(run func(userID string, request model.URLListRequest)) *MockURLUsecase_GetURLsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(model.URLListRequest))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_GetURLsByUserID_Call) Return(_a0 model.URLList, _a1 error) *MockURLUsecase_GetURLsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_GetURLsByUserID_Call) RunAndReturn(run func(string, model.URLListRequest) (model.URLList, error)) *MockURLUsecase_GetURLsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewURL provides a mock function with given fields: code, access
+func (_m *MockURLUsecase) PreviewURL(code string, access model.LinkAccess) (model.URLPreview, error) {
+	ret := _m.Called(code, access)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewURL")
+	}
+
+	var r0 model.URLPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, model.LinkAccess) (model.URLPreview, error)); ok {
+		return rf(code, access)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.LinkAccess) model.URLPreview); ok {
+		r0 = rf(code, access)
+	} else {
+		r0 = ret.Get(0).(model.URLPreview)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.LinkAccess) error); ok {
+		r1 = rf(code, access)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_PreviewURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewURL'
+type MockURLUsecase_PreviewURL_Call struct {
+	*mock.Call
+}
+
+// PreviewURL is a helper method to define mock.On call
+//   - code string
+//   - access model.LinkAccess
+func (_e *MockURLUsecase_Expecter) PreviewURL(code interface{}, access interface{}) *MockURLUsecase_PreviewURL_Call {
+	return &MockURLUsecase_PreviewURL_Call{Call: _e.mock.On("PreviewURL", code, access)}
+}
+
+func (_c *MockURLUsecase_PreviewURL_Call) Run(run func(code string, access model.LinkAccess)) *MockURLUsecase_PreviewURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(model.LinkAccess))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_PreviewURL_Call) Return(_a0 model.URLPreview, _a1 error) *MockURLUsecase_PreviewURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_PreviewURL_Call) RunAndReturn(run func(string, model.LinkAccess) (model.URLPreview, error)) *MockURLUsecase_PreviewURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordClick provides a mock function with given fields: code, visit
+func (_m *MockURLUsecase) RecordClick(code string, visit model.Visit) {
+	_m.Called(code, visit)
+}
+
+// MockURLUsecase_RecordClick_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordClick'
+type MockURLUsecase_RecordClick_Call struct {
+	*mock.Call
+}
+
+// RecordClick is a helper method to define mock.On call
+//   - code string
+//   - visit model.Visit
+func (_e *MockURLUsecase_Expecter) RecordClick(code interface{}, visit interface{}) *MockURLUsecase_RecordClick_Call {
+	return &MockURLUsecase_RecordClick_Call{Call: _e.mock.On("RecordClick", code, visit)}
+}
+
+func (_c *MockURLUsecase_RecordClick_Call) Run(run func(code string, visit model.Visit)) *MockURLUsecase_RecordClick_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(model.Visit))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_RecordClick_Call) Return() *MockURLUsecase_RecordClick_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockURLUsecase_RecordClick_Call) RunAndReturn(run func(string, model.Visit)) *MockURLUsecase_RecordClick_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RestoreURLs provides a mock function with given fields: codes, userID
+func (_m *MockURLUsecase) RestoreURLs(codes []string, userID string) ([]string, error) {
+	ret := _m.Called(codes, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreURLs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string, string) ([]string, error)); ok {
+		return rf(codes, userID)
+	}
+	if rf, ok := ret.Get(0).(func([]string, string) []string); ok {
+		r0 = rf(codes, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, string) error); ok {
+		r1 = rf(codes, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_RestoreURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreURLs'
+type MockURLUsecase_RestoreURLs_Call struct {
+	*mock.Call
+}
+
+// RestoreURLs is a helper method to define mock.On call
+//   - codes []string
+//   - userID string
+func (_e *MockURLUsecase_Expecter) RestoreURLs(codes interface{}, userID interface{}) *MockURLUsecase_RestoreURLs_Call {
+	return &MockURLUsecase_RestoreURLs_Call{Call: _e.mock.On("RestoreURLs", codes, userID)}
+}
+
+func (_c *MockURLUsecase_RestoreURLs_Call) Run(run func(codes []string, userID string)) *MockURLUsecase_RestoreURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_RestoreURLs_Call) Return(_a0 []string, _a1 error) *MockURLUsecase_RestoreURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_RestoreURLs_Call) RunAndReturn(run func([]string, string) ([]string, error)) *MockURLUsecase_RestoreURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackURL provides a mock function with given fields: code, version, userID
+func (_m *MockURLUsecase) RollbackURL(code string, version int, userID string) (model.URLUpdate, error) {
+	ret := _m.Called(code, version, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackURL")
+	}
+
+	var r0 model.URLUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, string) (model.URLUpdate, error)); ok {
+		return rf(code, version, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, string) model.URLUpdate); ok {
+		r0 = rf(code, version, userID)
+	} else {
+		r0 = ret.Get(0).(model.URLUpdate)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, string) error); ok {
+		r1 = rf(code, version, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_RollbackURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackURL'
+type MockURLUsecase_RollbackURL_Call struct {
+	*mock.Call
+}
+
+// RollbackURL is a helper method to define mock.On call
+//   - code string
+//   - version int
+//   - userID string
+func (_e *MockURLUsecase_Expecter) RollbackURL(code interface{}, version interface{}, userID interface{}) *MockURLUsecase_RollbackURL_Call {
+	return &MockURLUsecase_RollbackURL_Call{Call: _e.mock.On("RollbackURL", code, version, userID)}
+}
+
+func (_c *MockURLUsecase_RollbackURL_Call) Run(run func(code string, version int, userID string)) *MockURLUsecase_RollbackURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_RollbackURL_Call) Return(_a0 model.URLUpdate, _a1 error) *MockURLUsecase_RollbackURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_RollbackURL_Call) RunAndReturn(run func(string, int, string) (model.URLUpdate, error)) *MockURLUsecase_RollbackURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLLabels provides a mock function with given fields: code, labels, userID
+func (_m *MockURLUsecase) SetURLLabels(code string, labels model.LinkLabels, userID string) (model.LinkLabels, error) {
+	ret := _m.Called(code, labels, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLLabels")
+	}
+
+	var r0 model.LinkLabels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, model.LinkLabels, string) (model.LinkLabels, error)); ok {
+		return rf(code, labels, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.LinkLabels, string) model.LinkLabels); ok {
+		r0 = rf(code, labels, userID)
+	} else {
+		r0 = ret.Get(0).(model.LinkLabels)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.LinkLabels, string) error); ok {
+		r1 = rf(code, labels, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_SetURLLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLLabels'
+type MockURLUsecase_SetURLLabels_Call struct {
+	*mock.Call
+}
+
+// SetURLLabels is a helper method to define mock.On call
+//   - code string
+//   - labels model.LinkLabels
+//   - userID string
+func (_e *MockURLUsecase_Expecter) SetURLLabels(code interface{}, labels interface{}, userID interface{}) *MockURLUsecase_SetURLLabels_Call {
+	return &MockURLUsecase_SetURLLabels_Call{Call: _e.mock.On("SetURLLabels", code, labels, userID)}
+}
+
+func (_c *MockURLUsecase_SetURLLabels_Call) Run(run func(code string, labels model.LinkLabels, userID string)) *MockURLUsecase_SetURLLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(model.LinkLabels), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_SetURLLabels_Call) Return(_a0 model.LinkLabels, _a1 error) *MockURLUsecase_SetURLLabels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_SetURLLabels_Call) RunAndReturn(run func(string, model.LinkLabels, string) (model.LinkLabels, error)) *MockURLUsecase_SetURLLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLRules provides a mock function with given fields: code, rules, userID
+func (_m *MockURLUsecase) SetURLRules(code string, rules []model.RedirectRule, userID string) ([]model.RedirectRule, error) {
+	ret := _m.Called(code, rules, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLRules")
+	}
+
+	var r0 []model.RedirectRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []model.RedirectRule, string) ([]model.RedirectRule, error)); ok {
+		return rf(code, rules, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, []model.RedirectRule, string) []model.RedirectRule); ok {
+		r0 = rf(code, rules, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.RedirectRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []model.RedirectRule, string) error); ok {
+		r1 = rf(code, rules, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_SetURLRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLRules'
+type MockURLUsecase_SetURLRules_Call struct {
+	*mock.Call
+}
+
+// SetURLRules is a helper method to define mock.On call
+//   - code string
+//   - rules []model.RedirectRule
+//   - userID string
+func (_e *MockURLUsecase_Expecter) SetURLRules(code interface{}, rules interface{}, userID interface{}) *MockURLUsecase_SetURLRules_Call {
+	return &MockURLUsecase_SetURLRules_Call{Call: _e.mock.On("SetURLRules", code, rules, userID)}
+}
+
+func (_c *MockURLUsecase_SetURLRules_Call) Run(run func(code string, rules []model.RedirectRule, userID string)) *MockURLUsecase_SetURLRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]model.RedirectRule), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_SetURLRules_Call) Return(_a0 []model.RedirectRule, _a1 error) *MockURLUsecase_SetURLRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_SetURLRules_Call) RunAndReturn(run func(string, []model.RedirectRule, string) ([]model.RedirectRule, error)) *MockURLUsecase_SetURLRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLUnsafe provides a mock function with given fields: code, unsafe
+func (_m *MockURLUsecase) SetURLUnsafe(code string, unsafe bool) error {
+	ret := _m.Called(code, unsafe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLUnsafe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(code, unsafe)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockURLUsecase_SetURLUnsafe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLUnsafe'
+type MockURLUsecase_SetURLUnsafe_Call struct {
+	*mock.Call
+}
+
+// SetURLUnsafe is a helper method to define mock.On call
+//   - code string
+//   - unsafe bool
+func (_e *MockURLUsecase_Expecter) SetURLUnsafe(code interface{}, unsafe interface{}) *MockURLUsecase_SetURLUnsafe_Call {
+	return &MockURLUsecase_SetURLUnsafe_Call{Call: _e.mock.On("SetURLUnsafe", code, unsafe)}
+}
+
+func (_c *MockURLUsecase_SetURLUnsafe_Call) Run(run func(code string, unsafe bool)) *MockURLUsecase_SetURLUnsafe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_SetURLUnsafe_Call) Return(_a0 error) *MockURLUsecase_SetURLUnsafe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockURLUsecase_SetURLUnsafe_Call) RunAndReturn(run func(string, bool) error) *MockURLUsecase_SetURLUnsafe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetURLVariants provides a mock function with given fields: code, variants, userID
+func (_m *MockURLUsecase) SetURLVariants(code string, variants []model.SplitVariant, userID string) ([]model.SplitVariant, error) {
+	ret := _m.Called(code, variants, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLVariants")
+	}
+
+	var r0 []model.SplitVariant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []model.SplitVariant, string) ([]model.SplitVariant, error)); ok {
+		return rf(code, variants, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, []model.SplitVariant, string) []model.SplitVariant); ok {
+		r0 = rf(code, variants, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SplitVariant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []model.SplitVariant, string) error); ok {
+		r1 = rf(code, variants, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_SetURLVariants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetURLVariants'
+type MockURLUsecase_SetURLVariants_Call struct {
+	*mock.Call
+}
+
+// SetURLVariants is a helper method to define mock.On call
+//   - code string
+//   - variants []model.SplitVariant
+//   - userID string
+func (_e *MockURLUsecase_Expecter) SetURLVariants(code interface{}, variants interface{}, userID interface{}) *MockURLUsecase_SetURLVariants_Call {
+	return &MockURLUsecase_SetURLVariants_Call{Call: _e.mock.On("SetURLVariants", code, variants, userID)}
+}
+
+func (_c *MockURLUsecase_SetURLVariants_Call) Run(run func(code string, variants []model.SplitVariant, userID string)) *MockURLUsecase_SetURLVariants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]model.SplitVariant), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_SetURLVariants_Call) Return(_a0 []model.SplitVariant, _a1 error) *MockURLUsecase_SetURLVariants_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_SetURLVariants_Call) RunAndReturn(run func(string, []model.SplitVariant, string) ([]model.SplitVariant, error)) *MockURLUsecase_SetURLVariants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockDomain provides a mock function with given fields: pattern
+func (_m *MockURLUsecase) UnblockDomain(pattern string) (model.BlockRule, error) {
+	ret := _m.Called(pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockDomain")
+	}
+
+	var r0 model.BlockRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (model.BlockRule, error)); ok {
+		return rf(pattern)
+	}
+	if rf, ok := ret.Get(0).(func(string) model.BlockRule); ok {
+		r0 = rf(pattern)
+	} else {
+		r0 = ret.Get(0).(model.BlockRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_UnblockDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockDomain'
+type MockURLUsecase_UnblockDomain_Call struct {
+	*mock.Call
+}
+
+// UnblockDomain is a helper method to define mock.On call
+//   - pattern string
+func (_e *MockURLUsecase_Expecter) UnblockDomain(pattern interface{}) *MockURLUsecase_UnblockDomain_Call {
+	return &MockURLUsecase_UnblockDomain_Call{Call: _e.mock.On("UnblockDomain", pattern)}
+}
+
+func (_c *MockURLUsecase_UnblockDomain_Call) Run(run func(pattern string)) *MockURLUsecase_UnblockDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_UnblockDomain_Call) Return(_a0 model.BlockRule, _a1 error) *MockURLUsecase_UnblockDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_UnblockDomain_Call) RunAndReturn(run func(string) (model.BlockRule, error)) *MockURLUsecase_UnblockDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockURL provides a mock function with given fields: code, password
+func (_m *MockURLUsecase) UnlockURL(code string, password string) (string, error) {
+	ret := _m.Called(code, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(code, password)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(code, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(code, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_UnlockURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockURL'
+type MockURLUsecase_UnlockURL_Call struct {
+	*mock.Call
+}
+
+// UnlockURL is a helper method to define mock.On call
+//   - code string
+//   - password string
+func (_e *MockURLUsecase_Expecter) UnlockURL(code interface{}, password interface{}) *MockURLUsecase_UnlockURL_Call {
+	return &MockURLUsecase_UnlockURL_Call{Call: _e.mock.On("UnlockURL", code, password)}
+}
+
+func (_c *MockURLUsecase_UnlockURL_Call) Run(run func(code string, password string)) *MockURLUsecase_UnlockURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLUsecase_UnlockURL_Call) Return(_a0 string, _a1 error) *MockURLUsecase_UnlockURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockURLUsecase_UnlockURL_Call) RunAndReturn(run func(string, string) (string, error)) *MockURLUsecase_UnlockURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateURL provides a mock function with given fields: code, urlString, userID
+func (_m *MockURLUsecase) UpdateURL(code string, urlString string, userID string) (model.URLUpdate, error) {
+	ret := _m.Called(code, urlString, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateURL")
+	}
+
+	var r0 model.URLUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (model.URLUpdate, error)); ok {
+		return rf(code, urlString, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) model.URLUpdate); ok {
+		r0 = rf(code, urlString, userID)
+	} else {
+		r0 = ret.Get(0).(model.URLUpdate)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(code, urlString, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockURLUsecase_UpdateURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURL'
+type MockURLUsecase_UpdateURL_Call struct {
+	*mock.Call
+}
+
+// UpdateURL is a helper method to define mock.On call
+//   - code string
+//   - urlString string
+//   - userID string
+func (_e *MockURLUsecase_Expecter) UpdateURL(code interface{}, urlString interface{}, userID interface{}) *MockURLUsecase_UpdateURL_Call {
+	return &MockURLUsecase_UpdateURL_Call{Call: _e.mock.On("UpdateURL", code, urlString, userID)}
+}
+
+func (_c *MockURLUsecase_UpdateURL_Call) Run(run func(code string, urlString string, userID string)) *MockURLUsecase_UpdateURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockURLUsecase_GetURLsByUserID_Call) Return(_a0 []model.UserURLResponse, _a1 error) *MockURLUsecase_GetURLsByUserID_Call {
+func (_c *MockURLUsecase_UpdateURL_Call) Return(_a0 model.URLUpdate, _a1 error) *MockURLUsecase_UpdateURL_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockURLUsecase_GetURLsByUserID_Call) RunAndReturn(run func(string) ([]model.UserURLResponse, error)) *MockURLUsecase_GetURLsByUserID_Call {
+func (_c *MockURLUsecase_UpdateURL_Call) RunAndReturn(run func(string, string, string) (model.URLUpdate, error)) *MockURLUsecase_UpdateURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
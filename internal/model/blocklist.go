@@ -0,0 +1,20 @@
+package model
+
+// BlockRuleKind — способ сопоставления правила блокировки с именем хоста.
+type BlockRuleKind string
+
+const (
+	// BlockRuleExact блокирует только указанный хост.
+	BlockRuleExact BlockRuleKind = "exact"
+	// BlockRuleSuffix блокирует домен и все его поддомены; записывается как «*.example.com».
+	BlockRuleSuffix BlockRuleKind = "suffix"
+	// BlockRuleRegex блокирует хосты, совпавшие с регулярным выражением; записывается как «/выражение/».
+	BlockRuleRegex BlockRuleKind = "regex"
+)
+
+// BlockRule — правило блокировки доменов назначения. Pattern хранится в каноническом виде:
+// имена доменов в нижнем регистре и punycode.
+type BlockRule struct {
+	Pattern string        `json:"pattern"`
+	Kind    BlockRuleKind `json:"kind"`
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"net/url"
+	"time"
+)
+
+// Visit — сведения о переходе, полученные из запроса клиента.
+type Visit struct {
+	// Referrer — значение заголовка Referer.
+	Referrer string
+	// UserAgent — значение заголовка User-Agent.
+	UserAgent string
+	// IP — адрес клиента.
+	IP string
+	// AcceptLanguage — значение заголовка Accept-Language.
+	AcceptLanguage string
+	// Variant — номер варианта сплит-ссылки: при переходе — закреплённый за посетителем,
+	// при учёте перехода — выбранный; 0 — вариант не закреплён или не выбран.
+	Variant int
+	// Query — query-параметры запроса перехода без служебных параметров сокращателя;
+	// передаются в адрес назначения ссылкам с передачей параметров.
+	Query url.Values
+}
+
+// Click — учтённый переход по короткой ссылке.
+// Адрес клиента не сохраняется: для оценки уникальных посетителей
+// хранится только его необратимый отпечаток VisitorID.
+type Click struct {
+	Code      Code      `json:"code"`
+	At        time.Time `json:"at"`
+	Referrer  string    `json:"referrer,omitempty"`
+	UAFamily  string    `json:"ua_family,omitempty"`
+	Country   string    `json:"country,omitempty"`
+	VisitorID string    `json:"visitor_id,omitempty"`
+	Variant   int       `json:"variant,omitempty"`
+}
+
+// StatsBucket — число переходов за интервал, начинающийся в Start.
+type StatsBucket struct {
+	Start  time.Time `json:"start"`
+	Clicks int       `json:"clicks"`
+}
+
+// StatsCount — число переходов для одного значения измерения (источника, браузера, страны).
+type StatsCount struct {
+	Value  string `json:"value"`
+	Clicks int    `json:"clicks"`
+}
+
+// URLStats — статистика переходов по короткой ссылке за период From—To.
+// Бакеты содержат только интервалы с переходами и упорядочены по времени,
+// распределения — по убыванию числа переходов.
+type URLStats struct {
+	Code           string        `json:"code"`
+	From           time.Time     `json:"from"`
+	To             time.Time     `json:"to"`
+	TotalClicks    int           `json:"total_clicks"`
+	UniqueVisitors int           `json:"unique_visitors"`
+	Hourly         []StatsBucket `json:"hourly"`
+	Daily          []StatsBucket `json:"daily"`
+	Referrers      []StatsCount  `json:"referrers"`
+	Browsers       []StatsCount  `json:"browsers"`
+	Countries      []StatsCount  `json:"countries"`
+	// Variants — переходы по вариантам сплит-ссылки; пусто для ссылки без вариантов.
+	Variants []VariantCount `json:"variants"`
+}
+
+// VariantCount — число переходов по одному варианту сплит-ссылки.
+type VariantCount struct {
+	Variant int `json:"variant"`
+	Clicks  int `json:"clicks"`
+}
+
+// ClickCount — приращение счётчика переходов по ссылке, накопленное с прошлого сброса.
+type ClickCount struct {
+	Code           Code      `json:"code"`
+	Clicks         int64     `json:"clicks"`
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+}
@@ -0,0 +1,29 @@
+package model
+
+// Форматы изображения QR-кода
+const (
+	QRFormatPNG = "png"
+	QRFormatSVG = "svg"
+)
+
+// QROptions — параметры изображения QR-кода короткой ссылки.
+// Пустые и нулевые значения заменяются значениями по умолчанию.
+type QROptions struct {
+	// Format — QRFormatPNG или QRFormatSVG.
+	Format string
+	// Size — ширина изображения в пикселях. У PNG она округляется вниз до целого числа
+	// пикселей на модуль, но не меньше одного пикселя на модуль.
+	Size int
+	// Level — уровень коррекции ошибок: L, M, Q или H.
+	Level string
+	// Margin — ширина светлого поля в модулях; nil — поле по умолчанию.
+	Margin *int
+}
+
+// QRImage — готовое изображение QR-кода.
+type QRImage struct {
+	Data        []byte
+	ContentType string
+	// ETag — сильный валидатор содержимого для условных запросов.
+	ETag string
+}
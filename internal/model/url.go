@@ -1,6 +1,12 @@
 // Package model определяет доменные типы и структуры данных URL-сокращателя.
 package model
 
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
 // Code — тип короткого кода, идентифицирующего оригинальный URL в хранилище.
 type Code string
 
@@ -12,13 +18,391 @@ func (U URL) String() string {
 	return string(U)
 }
 
-// URLEntry представляет запись URL с уникальным идентификатором для хранения
-type URLEntry struct {
-	UUID        string `json:"uuid"`
+// CodeStyle — способ генерации короткого кода.
+type CodeStyle string
+
+const (
+	// CodeStyleRandom — случайный код из букв фиксированной длины (по умолчанию).
+	CodeStyleRandom CodeStyle = "random"
+	// CodeStyleWords — читаемый код из слов и числа, например "brave-otter-42".
+	CodeStyleWords CodeStyle = "words"
+)
+
+// IsValid сообщает, поддерживается ли стиль. Пустое значение означает стиль по умолчанию.
+func (s CodeStyle) IsValid() bool {
+	return s == "" || s == CodeStyleRandom || s == CodeStyleWords
+}
+
+// LinkOptions содержит необязательные параметры создания короткой ссылки.
+// Нулевое значение соответствует поведению по умолчанию.
+type LinkOptions struct {
+	// CodeStyle — способ генерации кода; пустое значение означает CodeStyleRandom.
+	CodeStyle CodeStyle
+	// TTL — срок жизни ссылки с момента создания. Взаимоисключающий с ExpiresAt;
+	// usecase-слой переводит его в ExpiresAt, дальше по слоям передаётся только ExpiresAt.
+	TTL time.Duration
+	// ExpiresAt — момент, после которого ссылка перестаёт работать; нулевое значение — бессрочно.
+	ExpiresAt time.Time
+	// MaxClicks — число переходов, после которого ссылка перестаёт работать; 0 — без ограничения.
+	MaxClicks int
+	// Password — пароль для перехода по ссылке; usecase-слой переводит его в PasswordHash,
+	// дальше по слоям передаётся только хеш.
+	Password string
+	// PasswordHash — хеш пароля защищённой ссылки; пустое значение — ссылка без пароля.
+	PasswordHash string
+	// Labels — теги и папка новой ссылки; при дедупликации существующей ссылке не назначаются.
+	Labels LinkLabels
+	// Redirect — код ответа и срок кэширования редиректа; ссылка с ними не дедуплицируется.
+	Redirect RedirectPolicy
+	// Query — передача query-параметров перехода и метки UTM, дописываемые к адресу назначения.
+	Query QueryPolicy
+	// Rules — правила условного редиректа по устройству, языку и стране посетителя;
+	// ссылка с правилами не дедуплицируется.
+	Rules []RedirectRule
+	// Variants — адреса сплит-теста с весами; сплит-ссылка не дедуплицируется.
+	Variants []SplitVariant
+	// DisplayURLs — исходные строки адресов, переданные пользователем, по каноническому адресу;
+	// только для адресов, изменённых нормализацией. Исходная строка показывается в списке ссылок.
+	DisplayURLs map[URL]string
+}
+
+// SplitVariant — вариант сплит-ссылки: адрес назначения и его вес. Посетители распределяются
+// между вариантами пропорционально весам; вариант с нулевым весом новым посетителям
+// не назначается. Варианты нумеруются с 1 в порядке перечисления.
+type SplitVariant struct {
+	URL    string `json:"url"`
+	Weight int    `json:"weight"`
+}
+
+// RedirectRule — правило условного редиректа: посетитель, подходящий под все заданные
+// условия правила, попадает на URL правила. Внутри условия достаточно совпадения
+// с любым из значений. Правила ссылки проверяются по порядку, первое подходящее выигрывает;
+// если не подошло ни одно, переход ведёт на оригинальный URL ссылки.
+type RedirectRule struct {
+	// Platforms — платформы устройства по User-Agent: "ios", "android", "windows", "macos", "linux", "other".
+	Platforms []string `json:"platforms,omitempty"`
+	// Languages — предпочитаемые языки посетителя по Accept-Language; "en" подходит и для "en-US".
+	Languages []string `json:"languages,omitempty"`
+	// Countries — двухбуквенные коды стран посетителя по базе GeoIP.
+	Countries []string `json:"countries,omitempty"`
+	// URL — адрес назначения при совпадении.
+	URL string `json:"url"`
+}
+
+// QueryPassthrough — способ передачи query-параметров перехода в адрес назначения.
+type QueryPassthrough string
+
+const (
+	// QueryPassthroughMerge добавляет параметры перехода, которых нет в адресе назначения.
+	QueryPassthroughMerge QueryPassthrough = "merge"
+	// QueryPassthroughOverride заменяет параметрами перехода одноимённые параметры адреса назначения.
+	QueryPassthroughOverride QueryPassthrough = "override"
+)
+
+// IsValid сообщает, поддерживается ли способ. Пустое значение означает, что параметры не передаются.
+func (p QueryPassthrough) IsValid() bool {
+	return p == "" || p == QueryPassthroughMerge || p == QueryPassthroughOverride
+}
+
+// UTMParams — метки кампании, которые дописываются к адресу назначения при переходе
+// как utm_source, utm_medium, utm_campaign, utm_term и utm_content. Пустые метки не добавляются.
+type UTMParams struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+	Term     string `json:"term,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+// IsZero сообщает, что ни одна метка не задана.
+func (p UTMParams) IsZero() bool {
+	return p == UTMParams{}
+}
+
+// Values возвращает заданные метки как query-параметры utm_*.
+func (p UTMParams) Values() url.Values {
+	values := url.Values{}
+	for key, value := range map[string]string{
+		"utm_source":   p.Source,
+		"utm_medium":   p.Medium,
+		"utm_campaign": p.Campaign,
+		"utm_term":     p.Term,
+		"utm_content":  p.Content,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return values
+}
+
+// QueryPolicy описывает, как переход по ссылке меняет query-строку адреса назначения.
+type QueryPolicy struct {
+	Passthrough QueryPassthrough
+	UTM         UTMParams
+}
+
+// IsZero сообщает, что адрес назначения не меняется.
+func (p QueryPolicy) IsZero() bool {
+	return p.Passthrough == "" && p.UTM.IsZero()
+}
+
+// RedirectPolicy — то, как ссылка отвечает на переход: код редиректа и срок кэширования ответа.
+// Нулевое значение поля означает значение по умолчанию из конфигурации.
+type RedirectPolicy struct {
+	// Status — код ответа: 301, 302, 307 или 308; 0 — по умолчанию.
+	Status int
+	// MaxAge — срок, на который клиенты и прокси могут кэшировать редирект;
+	// nil — по умолчанию, 0 — не кэшировать.
+	MaxAge *time.Duration
+}
+
+// IsZero сообщает, что ссылка использует параметры редиректа по умолчанию.
+func (p RedirectPolicy) IsZero() bool {
+	return p.Status == 0 && p.MaxAge == nil
+}
+
+// IsRedirectStatus сообщает, может ли status быть кодом ответа на переход по ссылке.
+func IsRedirectStatus(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
+}
+
+// LinkLabels содержит теги и папку, по которым пользователь группирует свои ссылки.
+// Ссылка может иметь несколько тегов и находиться не более чем в одной папке.
+type LinkLabels struct {
+	// Tags — теги ссылки в нижнем регистре, без повторов, по алфавиту.
+	Tags []string `json:"tags,omitempty"`
+	// Folder — папка ссылки; пустое значение — ссылка вне папок.
+	Folder string `json:"folder,omitempty"`
+}
+
+// URLFilter отбирает ссылки пользователя по тегу и папке.
+// Пустое поле не ограничивает выборку; нулевое значение отбирает все ссылки.
+type URLFilter struct {
+	Tag    string
+	Folder string
+}
+
+// IsZero сообщает, что фильтр не задан.
+func (f URLFilter) IsZero() bool {
+	return f.Tag == "" && f.Folder == ""
+}
+
+// URLSort — поле, по которому сортируется список ссылок пользователя.
+type URLSort string
+
+const (
+	// URLSortCreated — сортировка по времени создания (по умолчанию).
+	URLSortCreated URLSort = "created_at"
+	// URLSortClicks — сортировка по числу переходов.
+	URLSortClicks URLSort = "clicks"
+	// URLSortURL — сортировка по оригинальному URL.
+	URLSortURL URLSort = "url"
+)
+
+// IsValid сообщает, поддерживается ли сортировка. Пустое значение означает URLSortCreated.
+func (s URLSort) IsValid() bool {
+	return s == "" || s == URLSortCreated || s == URLSortClicks || s == URLSortURL
+}
+
+// URLListRequest — параметры запроса списка ссылок пользователя в том виде,
+// в котором они приходят от клиента; проверяет и разбирает их usecase-слой.
+type URLListRequest struct {
+	Filter URLFilter
+	// Search — подстрока оригинального URL без учёта регистра; пустое значение не ограничивает выборку.
+	Search string
+	// Sort — поле сортировки: "created_at" (по умолчанию), "clicks" или "url".
+	Sort string
+	// Order — направление сортировки: "asc" или "desc"; по умолчанию "asc" для url, иначе "desc".
+	Order string
+	// PageSize — размер страницы; 0 — весь список, а с PageToken — размер по умолчанию.
+	PageSize int
+	// PageToken — непрозрачный токен следующей страницы из предыдущего ответа.
+	PageToken string
+}
+
+// URLList — страница списка ссылок пользователя.
+type URLList struct {
+	URLs []UserURLResponse
+	// NextPageToken — токен следующей страницы; пустой на последней странице.
+	NextPageToken string
+}
+
+// URLQuery — разобранный запрос страницы ссылок пользователя для хранилища.
+// Порядок ссылок с равным значением поля сортировки задаётся их кодом,
+// поэтому постраничный обход по курсору не пропускает и не повторяет ссылки.
+type URLQuery struct {
+	Filter URLFilter
+	// Search — подстрока оригинального URL без учёта регистра.
+	Search string
+	Sort   URLSort
+	Desc   bool
+	// Limit — максимальное число ссылок на странице; 0 — без ограничения.
+	Limit int
+	// After — курсор последней ссылки предыдущей страницы; nil — первая страница.
+	After *URLCursor
+}
+
+// URLCursor — позиция ссылки в отсортированном списке: значение поля сортировки и код.
+// Используется только поле, соответствующее сортировке запроса.
+type URLCursor struct {
+	CreatedAt time.Time
+	Clicks    int64
+	URL       string
+	Code      Code
+}
+
+// URLPage — страница ссылок пользователя, возвращаемая хранилищем.
+type URLPage struct {
+	URLs []UserURLResponse
+	// Next — курсор последней ссылки страницы, если за ней есть ещё ссылки; иначе nil.
+	Next *URLCursor
+}
+
+// IsRestricted сообщает, ограничена ли ссылка по времени, числу переходов или паролем.
+// Ограниченные ссылки не дедуплицируются: повторное сокращение того же URL
+// создаёт новую ссылку, а не возвращает ту, что скоро перестанет работать
+// или открывается на других условиях.
+func (o LinkOptions) IsRestricted() bool {
+	return !o.ExpiresAt.IsZero() || o.MaxClicks > 0 || o.PasswordHash != ""
+}
+
+// IsDeduplicated сообщает, может ли повторное сокращение того же URL вернуть существующую ссылку.
+// Кроме ограниченных ссылок, заново создаются ссылки, меняющие query-строку адреса назначения:
+// на один URL обычно ведут ссылки разных кампаний, — а также ссылки со своими кодом ответа
+// или сроком кэширования, правилами условного редиректа и сплит-ссылки: иначе эти параметры
+// молча потерялись бы при возврате существующей ссылки.
+func (o LinkOptions) IsDeduplicated() bool {
+	return !o.IsRestricted() && o.Query.IsZero() && o.Redirect.IsZero() &&
+		len(o.Rules) == 0 && len(o.Variants) == 0
+}
+
+// LinkAccess содержит подтверждения доступа к ссылке: пароль или токен защищённой
+// паролем ссылки и согласие перейти по ссылке, помеченной небезопасной.
+// Для ссылки без пароля Password и Token игнорируются.
+type LinkAccess struct {
+	// Password — пароль, введённый при переходе.
+	Password string
+	// Token — подписанный токен, выданный после успешного ввода пароля.
+	Token string
+	// Confirmed — клиент API подтвердил переход по ссылке, помеченной небезопасной.
+	Confirmed bool
+	// ConfirmToken — подписанный токен, выданный со страницей предпросмотра небезопасной ссылки;
+	// действительный токен подтверждает переход так же, как Confirmed.
+	ConfirmToken string
+}
+
+// LinkTarget — то, что хранилище отдаёт при переходе по ссылке: адрес и сведения,
+// по которым usecase-слой выбирает код ответа и политику кэширования.
+type LinkTarget struct {
+	URL URL
+	// Redirect — параметры редиректа в том виде, в котором они сохранены у ссылки.
+	Redirect RedirectPolicy
+	// Query — изменения query-строки адреса назначения при переходе.
+	Query QueryPolicy
+	// Rules — правила условного редиректа в порядке проверки.
+	Rules []RedirectRule
+	// Variants — варианты сплит-ссылки.
+	Variants []SplitVariant
+	// Restricted — у ссылки есть срок жизни, лимит переходов или пароль.
+	Restricted bool
+	// Unsafe — ссылка помечена модерацией как небезопасная.
+	Unsafe bool
+}
+
+// Redirect — ответ на переход по ссылке.
+type Redirect struct {
+	URL string
+	// Status — код ответа: 301, 302, 307 или 308.
+	Status int
+	// MaxAge — срок кэширования ответа; 0 — ответ не кэшируется.
+	MaxAge time.Duration
+	// Variant — номер варианта сплит-ссылки, на который ведёт переход; 0 — ссылка без вариантов
+	// или переход выбран правилом условного редиректа.
+	Variant int
+}
+
+// LinkInfo — сведения о ссылке, которые хранилище отдаёт для предпросмотра.
+type LinkInfo struct {
+	// Code — код в том написании, в котором он сохранён.
+	Code Code
+	URL  URL
+	// CreatedAt — время создания; нулевое, если оно неизвестно.
+	CreatedAt time.Time
+	Clicks    int64
+	// Unsafe — ссылка помечена модерацией как небезопасная.
+	Unsafe bool
+}
+
+// URLPreview — сведения о ссылке, которые показываются вместо перехода по ней
+type URLPreview struct {
 	ShortURL    string `json:"short_url"`
 	OriginalURL string `json:"original_url"`
-	UserID      string `json:"user_id,omitempty"`
-	DeletedFlag bool   `json:"is_deleted,omitempty"`
+	// CreatedAt — время создания ссылки; nil, если оно неизвестно.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Clicks — число переходов; последние переходы учитываются с задержкой сброса счётчиков.
+	Clicks int64 `json:"clicks"`
+	// Unsafe — ссылка помечена как небезопасная.
+	Unsafe bool `json:"unsafe"`
+	// ConfirmToken — токен подтверждения перехода по небезопасной ссылке для страницы предпросмотра.
+	ConfirmToken string `json:"-"`
+}
+
+// URLEntry представляет запись URL с уникальным идентификатором для хранения.
+// Файл хранилища — журнал: более поздняя запись с тем же кодом заменяет предыдущую.
+// Запись с Purged означает окончательное удаление кода; если при этом задан
+// AvailableAt, код попадает в пул переиспользования и доступен с этого момента.
+// Запись с Click добавляет переход к статистике ссылки,
+// запись с ClickCount — приращение к счётчику переходов, запись с Version —
+// прежний адрес ссылки в её историю. Запись с PruneVersionsBefore без кода
+// удаляет из истории всех ссылок версии, изменённые раньше этого момента.
+type URLEntry struct {
+	UUID        string     `json:"uuid"`
+	ShortURL    string     `json:"short_url"`
+	OriginalURL string     `json:"original_url"`
+	UserID      string     `json:"user_id,omitempty"`
+	DeletedFlag bool       `json:"is_deleted,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	// RemainingClicks — оставшееся число переходов; nil — без ограничения.
+	RemainingClicks *int `json:"remaining_clicks,omitempty"`
+	// PasswordHash — хеш пароля защищённой ссылки.
+	PasswordHash string `json:"password_hash,omitempty"`
+	// CreatedAt — время создания ссылки; в записях старого формата отсутствует.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Tags и Folder — метки ссылки, которыми пользователь группирует свои ссылки.
+	Tags   []string `json:"tags,omitempty"`
+	Folder string   `json:"folder,omitempty"`
+	// Unsafe — ссылка помечена модерацией как небезопасная.
+	Unsafe bool `json:"unsafe,omitempty"`
+	// RedirectStatus — код ответа на переход; 0 — по умолчанию.
+	RedirectStatus int `json:"redirect_status,omitempty"`
+	// CacheMaxAge — срок кэширования редиректа в секундах; nil — по умолчанию.
+	CacheMaxAge *int64 `json:"cache_max_age,omitempty"`
+	// QueryPassthrough и UTM — изменения query-строки адреса назначения при переходе.
+	QueryPassthrough string     `json:"query_passthrough,omitempty"`
+	UTM              *UTMParams `json:"utm,omitempty"`
+	// RedirectRules — правила условного редиректа в порядке проверки.
+	RedirectRules []RedirectRule `json:"redirect_rules,omitempty"`
+	// SplitVariants — варианты сплит-ссылки.
+	SplitVariants []SplitVariant `json:"split_variants,omitempty"`
+	// DisplayURL — адрес в том виде, в каком его передал пользователь, если он отличается от OriginalURL.
+	DisplayURL string `json:"display_url,omitempty"`
+	// Click — учтённый переход по ссылке; такая запись не меняет состояние ссылки.
+	Click *Click `json:"click,omitempty"`
+	// ClickCount — приращение счётчика переходов; такая запись не меняет состояние ссылки.
+	ClickCount *ClickCount `json:"click_count,omitempty"`
+	// Version — прежний адрес ссылки для её истории; такая запись не меняет состояние ссылки.
+	Version *URLVersion `json:"version,omitempty"`
+	// PruneVersionsBefore — граница очистки истории версий всех ссылок.
+	PruneVersionsBefore *time.Time `json:"prune_versions_before,omitempty"`
+	Purged              bool       `json:"purged,omitempty"`
+	AvailableAt         *time.Time `json:"available_at,omitempty"`
 }
 
 // BatchShortenRequest представляет элемент запроса для батчевого сокращения URL
@@ -37,6 +421,44 @@ type BatchShortenResponse struct {
 type UserURLResponse struct {
 	ShortURL    string `json:"short_url"`
 	OriginalURL string `json:"original_url"`
+	// DisplayURL — адрес в том виде, в каком его передал пользователь; пустой,
+	// если он совпадает с каноническим OriginalURL.
+	DisplayURL string `json:"display_url,omitempty"`
+	// Clicks — число переходов по ссылке; последние переходы учитываются с задержкой сброса счётчиков.
+	Clicks int64 `json:"clicks"`
+	// LastAccessedAt — время последнего перехода; nil, если переходов не было.
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	// CreatedAt — время создания ссылки; nil, если оно неизвестно.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// DeletedAt — время удаления; заполняется только в списке удалённых ссылок.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Tags — теги ссылки по алфавиту.
+	Tags []string `json:"tags,omitempty"`
+	// Folder — папка ссылки; пустое значение — ссылка вне папок.
+	Folder string `json:"folder,omitempty"`
+}
+
+// URLUpdate описывает результат изменения адреса короткой ссылки
+type URLUpdate struct {
+	ShortURL    string `json:"short_url"`
+	OriginalURL string `json:"original_url"`
+	PreviousURL string `json:"previous_url"`
+}
+
+// URLVersion — прежний адрес ссылки, действовавший до изменения в момент ChangedAt.
+// Номера версий ссылки возрастают и не переиспользуются.
+type URLVersion struct {
+	Version     int       `json:"version"`
+	OriginalURL string    `json:"original_url"`
+	ChangedBy   string    `json:"changed_by"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+// URLHistory — текущий адрес ссылки и её прежние версии, от новых к старым.
+type URLHistory struct {
+	Code        string       `json:"code"`
+	OriginalURL string       `json:"original_url"`
+	Versions    []URLVersion `json:"versions"`
 }
 
 // Stats содержит агрегированную статистику сервиса.
@@ -9,6 +9,8 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -21,10 +23,23 @@ const (
 )
 
 type URLShortenRequest struct {
-	state          protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Url string                 `protobuf:"bytes,1,opt,name=url,proto3"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url              string                 `protobuf:"bytes,1,opt,name=url,proto3"`
+	xxx_hidden_CodeStyle        string                 `protobuf:"bytes,2,opt,name=code_style,json=codeStyle,proto3"`
+	xxx_hidden_Ttl              *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl,proto3"`
+	xxx_hidden_ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_MaxClicks        int32                  `protobuf:"varint,5,opt,name=max_clicks,json=maxClicks,proto3"`
+	xxx_hidden_Password         string                 `protobuf:"bytes,6,opt,name=password,proto3"`
+	xxx_hidden_Tags             []string               `protobuf:"bytes,7,rep,name=tags,proto3"`
+	xxx_hidden_Folder           string                 `protobuf:"bytes,8,opt,name=folder,proto3"`
+	xxx_hidden_RedirectStatus   int32                  `protobuf:"varint,9,opt,name=redirect_status,json=redirectStatus,proto3"`
+	xxx_hidden_CacheMaxAge      *durationpb.Duration   `protobuf:"bytes,10,opt,name=cache_max_age,json=cacheMaxAge,proto3"`
+	xxx_hidden_QueryPassthrough string                 `protobuf:"bytes,11,opt,name=query_passthrough,json=queryPassthrough,proto3"`
+	xxx_hidden_Utm              *UTMParams             `protobuf:"bytes,12,opt,name=utm,proto3"`
+	xxx_hidden_Rules            *[]*RedirectRule       `protobuf:"bytes,13,rep,name=rules,proto3"`
+	xxx_hidden_Variants         *[]*SplitVariant       `protobuf:"bytes,14,rep,name=variants,proto3"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
 }
 
 func (x *URLShortenRequest) Reset() {
@@ -59,46 +74,2189 @@ func (x *URLShortenRequest) GetUrl() string {
 	return ""
 }
 
+func (x *URLShortenRequest) GetCodeStyle() string {
+	if x != nil {
+		return x.xxx_hidden_CodeStyle
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Ttl
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetMaxClicks() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxClicks
+	}
+	return 0
+}
+
+func (x *URLShortenRequest) GetPassword() string {
+	if x != nil {
+		return x.xxx_hidden_Password
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetRedirectStatus() int32 {
+	if x != nil {
+		return x.xxx_hidden_RedirectStatus
+	}
+	return 0
+}
+
+func (x *URLShortenRequest) GetCacheMaxAge() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_CacheMaxAge
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetQueryPassthrough() string {
+	if x != nil {
+		return x.xxx_hidden_QueryPassthrough
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetUtm() *UTMParams {
+	if x != nil {
+		return x.xxx_hidden_Utm
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetRules() []*RedirectRule {
+	if x != nil {
+		if x.xxx_hidden_Rules != nil {
+			return *x.xxx_hidden_Rules
+		}
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetVariants() []*SplitVariant {
+	if x != nil {
+		if x.xxx_hidden_Variants != nil {
+			return *x.xxx_hidden_Variants
+		}
+	}
+	return nil
+}
+
 func (x *URLShortenRequest) SetUrl(v string) {
 	x.xxx_hidden_Url = v
 }
 
-type URLShortenRequest_builder struct {
+func (x *URLShortenRequest) SetCodeStyle(v string) {
+	x.xxx_hidden_CodeStyle = v
+}
+
+func (x *URLShortenRequest) SetTtl(v *durationpb.Duration) {
+	x.xxx_hidden_Ttl = v
+}
+
+func (x *URLShortenRequest) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *URLShortenRequest) SetMaxClicks(v int32) {
+	x.xxx_hidden_MaxClicks = v
+}
+
+func (x *URLShortenRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = v
+}
+
+func (x *URLShortenRequest) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *URLShortenRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *URLShortenRequest) SetRedirectStatus(v int32) {
+	x.xxx_hidden_RedirectStatus = v
+}
+
+func (x *URLShortenRequest) SetCacheMaxAge(v *durationpb.Duration) {
+	x.xxx_hidden_CacheMaxAge = v
+}
+
+func (x *URLShortenRequest) SetQueryPassthrough(v string) {
+	x.xxx_hidden_QueryPassthrough = v
+}
+
+func (x *URLShortenRequest) SetUtm(v *UTMParams) {
+	x.xxx_hidden_Utm = v
+}
+
+func (x *URLShortenRequest) SetRules(v []*RedirectRule) {
+	x.xxx_hidden_Rules = &v
+}
+
+func (x *URLShortenRequest) SetVariants(v []*SplitVariant) {
+	x.xxx_hidden_Variants = &v
+}
+
+func (x *URLShortenRequest) HasTtl() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Ttl != nil
+}
+
+func (x *URLShortenRequest) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *URLShortenRequest) HasCacheMaxAge() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CacheMaxAge != nil
+}
+
+func (x *URLShortenRequest) HasUtm() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Utm != nil
+}
+
+func (x *URLShortenRequest) ClearTtl() {
+	x.xxx_hidden_Ttl = nil
+}
+
+func (x *URLShortenRequest) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *URLShortenRequest) ClearCacheMaxAge() {
+	x.xxx_hidden_CacheMaxAge = nil
+}
+
+func (x *URLShortenRequest) ClearUtm() {
+	x.xxx_hidden_Utm = nil
+}
+
+type URLShortenRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url string
+	// code_style selects the code generator: "random" (default) or "words".
+	CodeStyle string
+	// ttl limits the link lifetime from creation; mutually exclusive with expires_at.
+	Ttl *durationpb.Duration
+	// expires_at is the moment after which the link stops working.
+	ExpiresAt *timestamppb.Timestamp
+	// max_clicks burns the link after the given number of visits; 0 means unlimited.
+	MaxClicks int32
+	// password protects the link: following it requires the same password.
+	Password string
+	// tags and folder group the new link among the owner's links.
+	Tags   []string
+	Folder string
+	// redirect_status is the HTTP status of the redirect: 301, 302, 307 or 308; 0 means the server default.
+	RedirectStatus int32
+	// cache_max_age is how long clients may cache the redirect; unset means the server default,
+	// zero disables caching. It must not exceed 24h.
+	CacheMaxAge *durationpb.Duration
+	// query_passthrough forwards the visit's query parameters to the destination: "merge" keeps
+	// the destination's own values, "override" replaces them; empty means they are dropped.
+	QueryPassthrough string
+	// utm is appended to the destination on every redirect and wins over any other utm_* values.
+	Utm *UTMParams
+	// rules are the ordered conditional redirect rules of the new link.
+	Rules []*RedirectRule
+	// variants turn the new link into a weighted A/B split between 2 to 10 destinations.
+	Variants []*SplitVariant
+}
+
+func (b0 URLShortenRequest_builder) Build() *URLShortenRequest {
+	m0 := &URLShortenRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = b.Url
+	x.xxx_hidden_CodeStyle = b.CodeStyle
+	x.xxx_hidden_Ttl = b.Ttl
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_MaxClicks = b.MaxClicks
+	x.xxx_hidden_Password = b.Password
+	x.xxx_hidden_Tags = b.Tags
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_RedirectStatus = b.RedirectStatus
+	x.xxx_hidden_CacheMaxAge = b.CacheMaxAge
+	x.xxx_hidden_QueryPassthrough = b.QueryPassthrough
+	x.xxx_hidden_Utm = b.Utm
+	x.xxx_hidden_Rules = &b.Rules
+	x.xxx_hidden_Variants = &b.Variants
+	return m0
+}
+
+// SplitVariant is one destination of a split link. New visitors are assigned to variants
+// in proportion to their weights (0..1000); a zero-weight variant keeps only visitors
+// already pinned to it. Variants are numbered from 1 in list order.
+type SplitVariant struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url    string                 `protobuf:"bytes,1,opt,name=url,proto3"`
+	xxx_hidden_Weight int32                  `protobuf:"varint,2,opt,name=weight,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SplitVariant) Reset() {
+	*x = SplitVariant{}
+	mi := &file_shortener_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitVariant) ProtoMessage() {}
+
+func (x *SplitVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SplitVariant) GetUrl() string {
+	if x != nil {
+		return x.xxx_hidden_Url
+	}
+	return ""
+}
+
+func (x *SplitVariant) GetWeight() int32 {
+	if x != nil {
+		return x.xxx_hidden_Weight
+	}
+	return 0
+}
+
+func (x *SplitVariant) SetUrl(v string) {
+	x.xxx_hidden_Url = v
+}
+
+func (x *SplitVariant) SetWeight(v int32) {
+	x.xxx_hidden_Weight = v
+}
+
+type SplitVariant_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url    string
+	Weight int32
+}
+
+func (b0 SplitVariant_builder) Build() *SplitVariant {
+	m0 := &SplitVariant{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = b.Url
+	x.xxx_hidden_Weight = b.Weight
+	return m0
+}
+
+// RedirectRule sends visitors matching all of its non-empty conditions to url; within one condition
+// any value matches. Rules are checked in order and visitors matching none go to the link's URL.
+type RedirectRule struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Platforms []string               `protobuf:"bytes,1,rep,name=platforms,proto3"`
+	xxx_hidden_Languages []string               `protobuf:"bytes,2,rep,name=languages,proto3"`
+	xxx_hidden_Countries []string               `protobuf:"bytes,3,rep,name=countries,proto3"`
+	xxx_hidden_Url       string                 `protobuf:"bytes,4,opt,name=url,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RedirectRule) Reset() {
+	*x = RedirectRule{}
+	mi := &file_shortener_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedirectRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectRule) ProtoMessage() {}
+
+func (x *RedirectRule) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RedirectRule) GetPlatforms() []string {
+	if x != nil {
+		return x.xxx_hidden_Platforms
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetLanguages() []string {
+	if x != nil {
+		return x.xxx_hidden_Languages
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetCountries() []string {
+	if x != nil {
+		return x.xxx_hidden_Countries
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetUrl() string {
+	if x != nil {
+		return x.xxx_hidden_Url
+	}
+	return ""
+}
+
+func (x *RedirectRule) SetPlatforms(v []string) {
+	x.xxx_hidden_Platforms = v
+}
+
+func (x *RedirectRule) SetLanguages(v []string) {
+	x.xxx_hidden_Languages = v
+}
+
+func (x *RedirectRule) SetCountries(v []string) {
+	x.xxx_hidden_Countries = v
+}
+
+func (x *RedirectRule) SetUrl(v string) {
+	x.xxx_hidden_Url = v
+}
+
+type RedirectRule_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// platforms by User-Agent: "ios", "android", "windows", "macos", "linux" or "other".
+	Platforms []string
+	// languages by the most preferred Accept-Language tag; "en" also matches "en-US".
+	Languages []string
+	// countries are ISO 3166-1 alpha-2 codes resolved from the visitor's IP.
+	Countries []string
+	Url       string
+}
+
+func (b0 RedirectRule_builder) Build() *RedirectRule {
+	m0 := &RedirectRule{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Platforms = b.Platforms
+	x.xxx_hidden_Languages = b.Languages
+	x.xxx_hidden_Countries = b.Countries
+	x.xxx_hidden_Url = b.Url
+	return m0
+}
+
+type UTMParams struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Source   string                 `protobuf:"bytes,1,opt,name=source,proto3"`
+	xxx_hidden_Medium   string                 `protobuf:"bytes,2,opt,name=medium,proto3"`
+	xxx_hidden_Campaign string                 `protobuf:"bytes,3,opt,name=campaign,proto3"`
+	xxx_hidden_Term     string                 `protobuf:"bytes,4,opt,name=term,proto3"`
+	xxx_hidden_Content  string                 `protobuf:"bytes,5,opt,name=content,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UTMParams) Reset() {
+	*x = UTMParams{}
+	mi := &file_shortener_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UTMParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UTMParams) ProtoMessage() {}
+
+func (x *UTMParams) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UTMParams) GetSource() string {
+	if x != nil {
+		return x.xxx_hidden_Source
+	}
+	return ""
+}
+
+func (x *UTMParams) GetMedium() string {
+	if x != nil {
+		return x.xxx_hidden_Medium
+	}
+	return ""
+}
+
+func (x *UTMParams) GetCampaign() string {
+	if x != nil {
+		return x.xxx_hidden_Campaign
+	}
+	return ""
+}
+
+func (x *UTMParams) GetTerm() string {
+	if x != nil {
+		return x.xxx_hidden_Term
+	}
+	return ""
+}
+
+func (x *UTMParams) GetContent() string {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return ""
+}
+
+func (x *UTMParams) SetSource(v string) {
+	x.xxx_hidden_Source = v
+}
+
+func (x *UTMParams) SetMedium(v string) {
+	x.xxx_hidden_Medium = v
+}
+
+func (x *UTMParams) SetCampaign(v string) {
+	x.xxx_hidden_Campaign = v
+}
+
+func (x *UTMParams) SetTerm(v string) {
+	x.xxx_hidden_Term = v
+}
+
+func (x *UTMParams) SetContent(v string) {
+	x.xxx_hidden_Content = v
+}
+
+type UTMParams_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Source   string
+	Medium   string
+	Campaign string
+	Term     string
+	Content  string
+}
+
+func (b0 UTMParams_builder) Build() *UTMParams {
+	m0 := &UTMParams{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Source = b.Source
+	x.xxx_hidden_Medium = b.Medium
+	x.xxx_hidden_Campaign = b.Campaign
+	x.xxx_hidden_Term = b.Term
+	x.xxx_hidden_Content = b.Content
+	return m0
+}
+
+type URLShortenResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result string                 `protobuf:"bytes,1,opt,name=result,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *URLShortenResponse) Reset() {
+	*x = URLShortenResponse{}
+	mi := &file_shortener_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLShortenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLShortenResponse) ProtoMessage() {}
+
+func (x *URLShortenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLShortenResponse) GetResult() string {
+	if x != nil {
+		return x.xxx_hidden_Result
+	}
+	return ""
+}
+
+func (x *URLShortenResponse) SetResult(v string) {
+	x.xxx_hidden_Result = v
+}
+
+type URLShortenResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Result string
+}
+
+func (b0 URLShortenResponse_builder) Build() *URLShortenResponse {
+	m0 := &URLShortenResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Result = b.Result
+	return m0
+}
+
+type URLExpandRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id             string                 `protobuf:"bytes,1,opt,name=id,proto3"`
+	xxx_hidden_Password       string                 `protobuf:"bytes,2,opt,name=password,proto3"`
+	xxx_hidden_Confirmed      bool                   `protobuf:"varint,3,opt,name=confirmed,proto3"`
+	xxx_hidden_Query          string                 `protobuf:"bytes,4,opt,name=query,proto3"`
+	xxx_hidden_UserAgent      string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3"`
+	xxx_hidden_AcceptLanguage string                 `protobuf:"bytes,6,opt,name=accept_language,json=acceptLanguage,proto3"`
+	xxx_hidden_ClientIp       string                 `protobuf:"bytes,7,opt,name=client_ip,json=clientIp,proto3"`
+	xxx_hidden_Variant        int32                  `protobuf:"varint,8,opt,name=variant,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *URLExpandRequest) Reset() {
+	*x = URLExpandRequest{}
+	mi := &file_shortener_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLExpandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLExpandRequest) ProtoMessage() {}
+
+func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLExpandRequest) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetPassword() string {
+	if x != nil {
+		return x.xxx_hidden_Password
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetConfirmed() bool {
+	if x != nil {
+		return x.xxx_hidden_Confirmed
+	}
+	return false
+}
+
+func (x *URLExpandRequest) GetQuery() string {
+	if x != nil {
+		return x.xxx_hidden_Query
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetUserAgent() string {
+	if x != nil {
+		return x.xxx_hidden_UserAgent
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetAcceptLanguage() string {
+	if x != nil {
+		return x.xxx_hidden_AcceptLanguage
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetClientIp() string {
+	if x != nil {
+		return x.xxx_hidden_ClientIp
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetVariant() int32 {
+	if x != nil {
+		return x.xxx_hidden_Variant
+	}
+	return 0
+}
+
+func (x *URLExpandRequest) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *URLExpandRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = v
+}
+
+func (x *URLExpandRequest) SetConfirmed(v bool) {
+	x.xxx_hidden_Confirmed = v
+}
+
+func (x *URLExpandRequest) SetQuery(v string) {
+	x.xxx_hidden_Query = v
+}
+
+func (x *URLExpandRequest) SetUserAgent(v string) {
+	x.xxx_hidden_UserAgent = v
+}
+
+func (x *URLExpandRequest) SetAcceptLanguage(v string) {
+	x.xxx_hidden_AcceptLanguage = v
+}
+
+func (x *URLExpandRequest) SetClientIp(v string) {
+	x.xxx_hidden_ClientIp = v
+}
+
+func (x *URLExpandRequest) SetVariant(v int32) {
+	x.xxx_hidden_Variant = v
+}
+
+type URLExpandRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id string
+	// password unlocks a password-protected link.
+	Password string
+	// confirmed acknowledges the warning for a link flagged as unsafe; without it such a link
+	// fails with FailedPrecondition (PREVIEW_REQUIRED) when unsafe links are previewed by default.
+	Confirmed bool
+	// query is the raw query string of the visit, forwarded according to the link's query_passthrough.
+	Query string
+	// user_agent, accept_language and client_ip describe the visitor for the link's redirect rules.
+	UserAgent      string
+	AcceptLanguage string
+	ClientIp       string
+	// variant is the split variant pinned to the visitor by an earlier expand; 0 picks one by weight.
+	Variant int32
+}
+
+func (b0 URLExpandRequest_builder) Build() *URLExpandRequest {
+	m0 := &URLExpandRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Password = b.Password
+	x.xxx_hidden_Confirmed = b.Confirmed
+	x.xxx_hidden_Query = b.Query
+	x.xxx_hidden_UserAgent = b.UserAgent
+	x.xxx_hidden_AcceptLanguage = b.AcceptLanguage
+	x.xxx_hidden_ClientIp = b.ClientIp
+	x.xxx_hidden_Variant = b.Variant
+	return m0
+}
+
+type URLExpandResponse struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result         string                 `protobuf:"bytes,1,opt,name=result,proto3"`
+	xxx_hidden_RedirectStatus int32                  `protobuf:"varint,2,opt,name=redirect_status,json=redirectStatus,proto3"`
+	xxx_hidden_CacheMaxAge    *durationpb.Duration   `protobuf:"bytes,3,opt,name=cache_max_age,json=cacheMaxAge,proto3"`
+	xxx_hidden_Variant        int32                  `protobuf:"varint,4,opt,name=variant,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *URLExpandResponse) Reset() {
+	*x = URLExpandResponse{}
+	mi := &file_shortener_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLExpandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLExpandResponse) ProtoMessage() {}
+
+func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLExpandResponse) GetResult() string {
+	if x != nil {
+		return x.xxx_hidden_Result
+	}
+	return ""
+}
+
+func (x *URLExpandResponse) GetRedirectStatus() int32 {
+	if x != nil {
+		return x.xxx_hidden_RedirectStatus
+	}
+	return 0
+}
+
+func (x *URLExpandResponse) GetCacheMaxAge() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_CacheMaxAge
+	}
+	return nil
+}
+
+func (x *URLExpandResponse) GetVariant() int32 {
+	if x != nil {
+		return x.xxx_hidden_Variant
+	}
+	return 0
+}
+
+func (x *URLExpandResponse) SetResult(v string) {
+	x.xxx_hidden_Result = v
+}
+
+func (x *URLExpandResponse) SetRedirectStatus(v int32) {
+	x.xxx_hidden_RedirectStatus = v
+}
+
+func (x *URLExpandResponse) SetCacheMaxAge(v *durationpb.Duration) {
+	x.xxx_hidden_CacheMaxAge = v
+}
+
+func (x *URLExpandResponse) SetVariant(v int32) {
+	x.xxx_hidden_Variant = v
+}
+
+func (x *URLExpandResponse) HasCacheMaxAge() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CacheMaxAge != nil
+}
+
+func (x *URLExpandResponse) ClearCacheMaxAge() {
+	x.xxx_hidden_CacheMaxAge = nil
+}
+
+type URLExpandResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Result string
+	// redirect_status is the HTTP status the short link answers with.
+	RedirectStatus int32
+	// cache_max_age is how long the redirect may be cached; zero means it must not be cached.
+	CacheMaxAge *durationpb.Duration
+	// variant is the split variant the visit was sent to; 0 for links without variants
+	// or when a redirect rule matched. Pass it back as URLExpandRequest.variant to keep the visitor on it.
+	Variant int32
+}
+
+func (b0 URLExpandResponse_builder) Build() *URLExpandResponse {
+	m0 := &URLExpandResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Result = b.Result
+	x.xxx_hidden_RedirectStatus = b.RedirectStatus
+	x.xxx_hidden_CacheMaxAge = b.CacheMaxAge
+	x.xxx_hidden_Variant = b.Variant
+	return m0
+}
+
+// ListUserURLsRequest lists the caller's links page by page.
+// deleted=true returns the trash: deleted links that can still be restored, unpaginated.
+type ListUserURLsRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Deleted   bool                   `protobuf:"varint,1,opt,name=deleted,proto3"`
+	xxx_hidden_Tag       string                 `protobuf:"bytes,2,opt,name=tag,proto3"`
+	xxx_hidden_Folder    string                 `protobuf:"bytes,3,opt,name=folder,proto3"`
+	xxx_hidden_Search    string                 `protobuf:"bytes,4,opt,name=search,proto3"`
+	xxx_hidden_Sort      string                 `protobuf:"bytes,5,opt,name=sort,proto3"`
+	xxx_hidden_Order     string                 `protobuf:"bytes,6,opt,name=order,proto3"`
+	xxx_hidden_PageSize  int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3"`
+	xxx_hidden_PageToken string                 `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ListUserURLsRequest) Reset() {
+	*x = ListUserURLsRequest{}
+	mi := &file_shortener_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserURLsRequest) ProtoMessage() {}
+
+func (x *ListUserURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListUserURLsRequest) GetDeleted() bool {
+	if x != nil {
+		return x.xxx_hidden_Deleted
+	}
+	return false
+}
+
+func (x *ListUserURLsRequest) GetTag() string {
+	if x != nil {
+		return x.xxx_hidden_Tag
+	}
+	return ""
+}
+
+func (x *ListUserURLsRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *ListUserURLsRequest) GetSearch() string {
+	if x != nil {
+		return x.xxx_hidden_Search
+	}
+	return ""
+}
+
+func (x *ListUserURLsRequest) GetSort() string {
+	if x != nil {
+		return x.xxx_hidden_Sort
+	}
+	return ""
+}
+
+func (x *ListUserURLsRequest) GetOrder() string {
+	if x != nil {
+		return x.xxx_hidden_Order
+	}
+	return ""
+}
+
+func (x *ListUserURLsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_PageSize
+	}
+	return 0
+}
+
+func (x *ListUserURLsRequest) GetPageToken() string {
+	if x != nil {
+		return x.xxx_hidden_PageToken
+	}
+	return ""
+}
+
+func (x *ListUserURLsRequest) SetDeleted(v bool) {
+	x.xxx_hidden_Deleted = v
+}
+
+func (x *ListUserURLsRequest) SetTag(v string) {
+	x.xxx_hidden_Tag = v
+}
+
+func (x *ListUserURLsRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *ListUserURLsRequest) SetSearch(v string) {
+	x.xxx_hidden_Search = v
+}
+
+func (x *ListUserURLsRequest) SetSort(v string) {
+	x.xxx_hidden_Sort = v
+}
+
+func (x *ListUserURLsRequest) SetOrder(v string) {
+	x.xxx_hidden_Order = v
+}
+
+func (x *ListUserURLsRequest) SetPageSize(v int32) {
+	x.xxx_hidden_PageSize = v
+}
+
+func (x *ListUserURLsRequest) SetPageToken(v string) {
+	x.xxx_hidden_PageToken = v
+}
+
+type ListUserURLsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Deleted bool
+	// tag and folder narrow the list to links with the tag and in the folder.
+	Tag    string
+	Folder string
+	// search keeps links whose destination contains the substring, case-insensitively.
+	Search string
+	// sort is "created_at" (default), "clicks" or "url"; order is "asc" or "desc".
+	Sort  string
+	Order string
+	// page_size is capped at 1000; without page_size and page_token the whole list is returned,
+	// and a page_token alone continues with pages of 100.
+	PageSize int32
+	// page_token is next_page_token of the previous response; sort and order must not change.
+	PageToken string
+}
+
+func (b0 ListUserURLsRequest_builder) Build() *ListUserURLsRequest {
+	m0 := &ListUserURLsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Deleted = b.Deleted
+	x.xxx_hidden_Tag = b.Tag
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_Search = b.Search
+	x.xxx_hidden_Sort = b.Sort
+	x.xxx_hidden_Order = b.Order
+	x.xxx_hidden_PageSize = b.PageSize
+	x.xxx_hidden_PageToken = b.PageToken
+	return m0
+}
+
+type UserURLsResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url           *[]*URLData            `protobuf:"bytes,1,rep,name=url,proto3"`
+	xxx_hidden_NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *UserURLsResponse) Reset() {
+	*x = UserURLsResponse{}
+	mi := &file_shortener_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserURLsResponse) ProtoMessage() {}
+
+func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserURLsResponse) GetUrl() []*URLData {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+	}
+	return nil
+}
+
+func (x *UserURLsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.xxx_hidden_NextPageToken
+	}
+	return ""
+}
+
+func (x *UserURLsResponse) SetUrl(v []*URLData) {
+	x.xxx_hidden_Url = &v
+}
+
+func (x *UserURLsResponse) SetNextPageToken(v string) {
+	x.xxx_hidden_NextPageToken = v
+}
+
+type UserURLsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url []*URLData
+	// next_page_token is empty on the last page.
+	NextPageToken string
+}
+
+func (b0 UserURLsResponse_builder) Build() *UserURLsResponse {
+	m0 := &UserURLsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = &b.Url
+	x.xxx_hidden_NextPageToken = b.NextPageToken
+	return m0
+}
+
+type URLData struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrl       string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3"`
+	xxx_hidden_OriginalUrl    string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3"`
+	xxx_hidden_DeletedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deleted_at,json=deletedAt,proto3"`
+	xxx_hidden_Tags           []string               `protobuf:"bytes,4,rep,name=tags,proto3"`
+	xxx_hidden_Folder         string                 `protobuf:"bytes,5,opt,name=folder,proto3"`
+	xxx_hidden_CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_Clicks         int64                  `protobuf:"varint,7,opt,name=clicks,proto3"`
+	xxx_hidden_LastAccessedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_accessed_at,json=lastAccessedAt,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *URLData) Reset() {
+	*x = URLData{}
+	mi := &file_shortener_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLData) ProtoMessage() {}
+
+func (x *URLData) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLData) GetShortUrl() string {
+	if x != nil {
+		return x.xxx_hidden_ShortUrl
+	}
+	return ""
+}
+
+func (x *URLData) GetOriginalUrl() string {
+	if x != nil {
+		return x.xxx_hidden_OriginalUrl
+	}
+	return ""
+}
+
+func (x *URLData) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_DeletedAt
+	}
+	return nil
+}
+
+func (x *URLData) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *URLData) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *URLData) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *URLData) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
+}
+
+func (x *URLData) GetLastAccessedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_LastAccessedAt
+	}
+	return nil
+}
+
+func (x *URLData) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = v
+}
+
+func (x *URLData) SetOriginalUrl(v string) {
+	x.xxx_hidden_OriginalUrl = v
+}
+
+func (x *URLData) SetDeletedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_DeletedAt = v
+}
+
+func (x *URLData) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *URLData) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *URLData) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *URLData) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+}
+
+func (x *URLData) SetLastAccessedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_LastAccessedAt = v
+}
+
+func (x *URLData) HasDeletedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_DeletedAt != nil
+}
+
+func (x *URLData) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *URLData) HasLastAccessedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_LastAccessedAt != nil
+}
+
+func (x *URLData) ClearDeletedAt() {
+	x.xxx_hidden_DeletedAt = nil
+}
+
+func (x *URLData) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *URLData) ClearLastAccessedAt() {
+	x.xxx_hidden_LastAccessedAt = nil
+}
+
+type URLData_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ShortUrl    string
+	OriginalUrl string
+	// deleted_at is set only for links listed from the trash.
+	DeletedAt *timestamppb.Timestamp
+	Tags      []string
+	Folder    string
+	CreatedAt *timestamppb.Timestamp
+	Clicks    int64
+	// last_accessed_at is unset for links that have never been followed.
+	LastAccessedAt *timestamppb.Timestamp
+}
+
+func (b0 URLData_builder) Build() *URLData {
+	m0 := &URLData{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ShortUrl = b.ShortUrl
+	x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	x.xxx_hidden_DeletedAt = b.DeletedAt
+	x.xxx_hidden_Tags = b.Tags
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_Clicks = b.Clicks
+	x.xxx_hidden_LastAccessedAt = b.LastAccessedAt
+	return m0
+}
+
+// URLStatsRequest asks for click statistics of a link owned by the caller.
+type URLStatsRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code string                 `protobuf:"bytes,1,opt,name=code,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *URLStatsRequest) Reset() {
+	*x = URLStatsRequest{}
+	mi := &file_shortener_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLStatsRequest) ProtoMessage() {}
+
+func (x *URLStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLStatsRequest) GetCode() string {
+	if x != nil {
+		return x.xxx_hidden_Code
+	}
+	return ""
+}
+
+func (x *URLStatsRequest) SetCode(v string) {
+	x.xxx_hidden_Code = v
+}
+
+type URLStatsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Code string
+}
+
+func (b0 URLStatsRequest_builder) Build() *URLStatsRequest {
+	m0 := &URLStatsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Code = b.Code
+	return m0
+}
+
+// URLStatsResponse covers the period from..to; buckets contain only intervals with clicks.
+type URLStatsResponse struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_From           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3"`
+	xxx_hidden_To             *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3"`
+	xxx_hidden_TotalClicks    int64                  `protobuf:"varint,3,opt,name=total_clicks,json=totalClicks,proto3"`
+	xxx_hidden_UniqueVisitors int64                  `protobuf:"varint,4,opt,name=unique_visitors,json=uniqueVisitors,proto3"`
+	xxx_hidden_Hourly         *[]*StatsBucket        `protobuf:"bytes,5,rep,name=hourly,proto3"`
+	xxx_hidden_Daily          *[]*StatsBucket        `protobuf:"bytes,6,rep,name=daily,proto3"`
+	xxx_hidden_Referrers      *[]*StatsCount         `protobuf:"bytes,7,rep,name=referrers,proto3"`
+	xxx_hidden_Browsers       *[]*StatsCount         `protobuf:"bytes,8,rep,name=browsers,proto3"`
+	xxx_hidden_Countries      *[]*StatsCount         `protobuf:"bytes,9,rep,name=countries,proto3"`
+	xxx_hidden_Variants       *[]*VariantCount       `protobuf:"bytes,10,rep,name=variants,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *URLStatsResponse) Reset() {
+	*x = URLStatsResponse{}
+	mi := &file_shortener_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLStatsResponse) ProtoMessage() {}
+
+func (x *URLStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLStatsResponse) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_From
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_To
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) GetTotalClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalClicks
+	}
+	return 0
+}
+
+func (x *URLStatsResponse) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.xxx_hidden_UniqueVisitors
+	}
+	return 0
+}
+
+func (x *URLStatsResponse) GetHourly() []*StatsBucket {
+	if x != nil {
+		if x.xxx_hidden_Hourly != nil {
+			return *x.xxx_hidden_Hourly
+		}
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) GetDaily() []*StatsBucket {
+	if x != nil {
+		if x.xxx_hidden_Daily != nil {
+			return *x.xxx_hidden_Daily
+		}
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) GetReferrers() []*StatsCount {
+	if x != nil {
+		if x.xxx_hidden_Referrers != nil {
+			return *x.xxx_hidden_Referrers
+		}
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) GetBrowsers() []*StatsCount {
+	if x != nil {
+		if x.xxx_hidden_Browsers != nil {
+			return *x.xxx_hidden_Browsers
+		}
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) GetCountries() []*StatsCount {
+	if x != nil {
+		if x.xxx_hidden_Countries != nil {
+			return *x.xxx_hidden_Countries
+		}
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) GetVariants() []*VariantCount {
+	if x != nil {
+		if x.xxx_hidden_Variants != nil {
+			return *x.xxx_hidden_Variants
+		}
+	}
+	return nil
+}
+
+func (x *URLStatsResponse) SetFrom(v *timestamppb.Timestamp) {
+	x.xxx_hidden_From = v
+}
+
+func (x *URLStatsResponse) SetTo(v *timestamppb.Timestamp) {
+	x.xxx_hidden_To = v
+}
+
+func (x *URLStatsResponse) SetTotalClicks(v int64) {
+	x.xxx_hidden_TotalClicks = v
+}
+
+func (x *URLStatsResponse) SetUniqueVisitors(v int64) {
+	x.xxx_hidden_UniqueVisitors = v
+}
+
+func (x *URLStatsResponse) SetHourly(v []*StatsBucket) {
+	x.xxx_hidden_Hourly = &v
+}
+
+func (x *URLStatsResponse) SetDaily(v []*StatsBucket) {
+	x.xxx_hidden_Daily = &v
+}
+
+func (x *URLStatsResponse) SetReferrers(v []*StatsCount) {
+	x.xxx_hidden_Referrers = &v
+}
+
+func (x *URLStatsResponse) SetBrowsers(v []*StatsCount) {
+	x.xxx_hidden_Browsers = &v
+}
+
+func (x *URLStatsResponse) SetCountries(v []*StatsCount) {
+	x.xxx_hidden_Countries = &v
+}
+
+func (x *URLStatsResponse) SetVariants(v []*VariantCount) {
+	x.xxx_hidden_Variants = &v
+}
+
+func (x *URLStatsResponse) HasFrom() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_From != nil
+}
+
+func (x *URLStatsResponse) HasTo() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_To != nil
+}
+
+func (x *URLStatsResponse) ClearFrom() {
+	x.xxx_hidden_From = nil
+}
+
+func (x *URLStatsResponse) ClearTo() {
+	x.xxx_hidden_To = nil
+}
+
+type URLStatsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	From           *timestamppb.Timestamp
+	To             *timestamppb.Timestamp
+	TotalClicks    int64
+	UniqueVisitors int64
+	Hourly         []*StatsBucket
+	Daily          []*StatsBucket
+	Referrers      []*StatsCount
+	Browsers       []*StatsCount
+	Countries      []*StatsCount
+	// variants counts clicks per split variant; empty for links without variants.
+	Variants []*VariantCount
+}
+
+func (b0 URLStatsResponse_builder) Build() *URLStatsResponse {
+	m0 := &URLStatsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_From = b.From
+	x.xxx_hidden_To = b.To
+	x.xxx_hidden_TotalClicks = b.TotalClicks
+	x.xxx_hidden_UniqueVisitors = b.UniqueVisitors
+	x.xxx_hidden_Hourly = &b.Hourly
+	x.xxx_hidden_Daily = &b.Daily
+	x.xxx_hidden_Referrers = &b.Referrers
+	x.xxx_hidden_Browsers = &b.Browsers
+	x.xxx_hidden_Countries = &b.Countries
+	x.xxx_hidden_Variants = &b.Variants
+	return m0
+}
+
+type VariantCount struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Variant int32                  `protobuf:"varint,1,opt,name=variant,proto3"`
+	xxx_hidden_Clicks  int64                  `protobuf:"varint,2,opt,name=clicks,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *VariantCount) Reset() {
+	*x = VariantCount{}
+	mi := &file_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantCount) ProtoMessage() {}
+
+func (x *VariantCount) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *VariantCount) GetVariant() int32 {
+	if x != nil {
+		return x.xxx_hidden_Variant
+	}
+	return 0
+}
+
+func (x *VariantCount) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
+}
+
+func (x *VariantCount) SetVariant(v int32) {
+	x.xxx_hidden_Variant = v
+}
+
+func (x *VariantCount) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+}
+
+type VariantCount_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Variant int32
+	Clicks  int64
+}
+
+func (b0 VariantCount_builder) Build() *VariantCount {
+	m0 := &VariantCount{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Variant = b.Variant
+	x.xxx_hidden_Clicks = b.Clicks
+	return m0
+}
+
+type StatsBucket struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Start  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3"`
+	xxx_hidden_Clicks int64                  `protobuf:"varint,2,opt,name=clicks,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StatsBucket) Reset() {
+	*x = StatsBucket{}
+	mi := &file_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsBucket) ProtoMessage() {}
+
+func (x *StatsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *StatsBucket) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Start
+	}
+	return nil
+}
+
+func (x *StatsBucket) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
+}
+
+func (x *StatsBucket) SetStart(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Start = v
+}
+
+func (x *StatsBucket) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+}
+
+func (x *StatsBucket) HasStart() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Start != nil
+}
+
+func (x *StatsBucket) ClearStart() {
+	x.xxx_hidden_Start = nil
+}
+
+type StatsBucket_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Start  *timestamppb.Timestamp
+	Clicks int64
+}
+
+func (b0 StatsBucket_builder) Build() *StatsBucket {
+	m0 := &StatsBucket{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Start = b.Start
+	x.xxx_hidden_Clicks = b.Clicks
+	return m0
+}
+
+type StatsCount struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Value  string                 `protobuf:"bytes,1,opt,name=value,proto3"`
+	xxx_hidden_Clicks int64                  `protobuf:"varint,2,opt,name=clicks,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StatsCount) Reset() {
+	*x = StatsCount{}
+	mi := &file_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsCount) ProtoMessage() {}
+
+func (x *StatsCount) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *StatsCount) GetValue() string {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return ""
+}
+
+func (x *StatsCount) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
+}
+
+func (x *StatsCount) SetValue(v string) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *StatsCount) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+}
+
+type StatsCount_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Value  string
+	Clicks int64
+}
+
+func (b0 StatsCount_builder) Build() *StatsCount {
+	m0 := &StatsCount{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_Clicks = b.Clicks
+	return m0
+}
+
+// UpdateURLRequest changes the destination of a link owned by the caller.
+type UpdateURLRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code string                 `protobuf:"bytes,1,opt,name=code,proto3"`
+	xxx_hidden_Url  string                 `protobuf:"bytes,2,opt,name=url,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateURLRequest) Reset() {
+	*x = UpdateURLRequest{}
+	mi := &file_shortener_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateURLRequest) ProtoMessage() {}
+
+func (x *UpdateURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UpdateURLRequest) GetCode() string {
+	if x != nil {
+		return x.xxx_hidden_Code
+	}
+	return ""
+}
+
+func (x *UpdateURLRequest) GetUrl() string {
+	if x != nil {
+		return x.xxx_hidden_Url
+	}
+	return ""
+}
+
+func (x *UpdateURLRequest) SetCode(v string) {
+	x.xxx_hidden_Code = v
+}
+
+func (x *UpdateURLRequest) SetUrl(v string) {
+	x.xxx_hidden_Url = v
+}
+
+type UpdateURLRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Code string
+	Url  string
+}
+
+func (b0 UpdateURLRequest_builder) Build() *UpdateURLRequest {
+	m0 := &UpdateURLRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Code = b.Code
+	x.xxx_hidden_Url = b.Url
+	return m0
+}
+
+type UpdateURLResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3"`
+	xxx_hidden_OriginalUrl string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3"`
+	xxx_hidden_PreviousUrl string                 `protobuf:"bytes,3,opt,name=previous_url,json=previousUrl,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UpdateURLResponse) Reset() {
+	*x = UpdateURLResponse{}
+	mi := &file_shortener_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateURLResponse) ProtoMessage() {}
+
+func (x *UpdateURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UpdateURLResponse) GetShortUrl() string {
+	if x != nil {
+		return x.xxx_hidden_ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateURLResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.xxx_hidden_OriginalUrl
+	}
+	return ""
+}
+
+func (x *UpdateURLResponse) GetPreviousUrl() string {
+	if x != nil {
+		return x.xxx_hidden_PreviousUrl
+	}
+	return ""
+}
+
+func (x *UpdateURLResponse) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = v
+}
+
+func (x *UpdateURLResponse) SetOriginalUrl(v string) {
+	x.xxx_hidden_OriginalUrl = v
+}
+
+func (x *UpdateURLResponse) SetPreviousUrl(v string) {
+	x.xxx_hidden_PreviousUrl = v
+}
+
+type UpdateURLResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ShortUrl    string
+	OriginalUrl string
+	PreviousUrl string
+}
+
+func (b0 UpdateURLResponse_builder) Build() *UpdateURLResponse {
+	m0 := &UpdateURLResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ShortUrl = b.ShortUrl
+	x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	x.xxx_hidden_PreviousUrl = b.PreviousUrl
+	return m0
+}
+
+// RestoreURLsRequest restores deleted links owned by the caller.
+type RestoreURLsRequest struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Codes []string               `protobuf:"bytes,1,rep,name=codes,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestoreURLsRequest) Reset() {
+	*x = RestoreURLsRequest{}
+	mi := &file_shortener_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreURLsRequest) ProtoMessage() {}
+
+func (x *RestoreURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreURLsRequest) GetCodes() []string {
+	if x != nil {
+		return x.xxx_hidden_Codes
+	}
+	return nil
+}
+
+func (x *RestoreURLsRequest) SetCodes(v []string) {
+	x.xxx_hidden_Codes = v
+}
+
+type RestoreURLsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Codes []string
+}
+
+func (b0 RestoreURLsRequest_builder) Build() *RestoreURLsRequest {
+	m0 := &RestoreURLsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Codes = b.Codes
+	return m0
+}
+
+// RestoreURLsResponse lists the codes that were actually restored.
+type RestoreURLsResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Codes []string               `protobuf:"bytes,1,rep,name=codes,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestoreURLsResponse) Reset() {
+	*x = RestoreURLsResponse{}
+	mi := &file_shortener_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreURLsResponse) ProtoMessage() {}
+
+func (x *RestoreURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreURLsResponse) GetCodes() []string {
+	if x != nil {
+		return x.xxx_hidden_Codes
+	}
+	return nil
+}
+
+func (x *RestoreURLsResponse) SetCodes(v []string) {
+	x.xxx_hidden_Codes = v
+}
+
+type RestoreURLsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Codes []string
+}
+
+func (b0 RestoreURLsResponse_builder) Build() *RestoreURLsResponse {
+	m0 := &RestoreURLsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Codes = b.Codes
+	return m0
+}
+
+// SetURLLabels replaces the tags and folder of a link owned by the caller.
+type SetURLLabelsRequest struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code   string                 `protobuf:"bytes,1,opt,name=code,proto3"`
+	xxx_hidden_Tags   []string               `protobuf:"bytes,2,rep,name=tags,proto3"`
+	xxx_hidden_Folder string                 `protobuf:"bytes,3,opt,name=folder,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetURLLabelsRequest) Reset() {
+	*x = SetURLLabelsRequest{}
+	mi := &file_shortener_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetURLLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetURLLabelsRequest) ProtoMessage() {}
+
+func (x *SetURLLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetURLLabelsRequest) GetCode() string {
+	if x != nil {
+		return x.xxx_hidden_Code
+	}
+	return ""
+}
+
+func (x *SetURLLabelsRequest) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *SetURLLabelsRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *SetURLLabelsRequest) SetCode(v string) {
+	x.xxx_hidden_Code = v
+}
+
+func (x *SetURLLabelsRequest) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *SetURLLabelsRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+type SetURLLabelsRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Url string
+	Code   string
+	Tags   []string
+	Folder string
 }
 
-func (b0 URLShortenRequest_builder) Build() *URLShortenRequest {
-	m0 := &URLShortenRequest{}
+func (b0 SetURLLabelsRequest_builder) Build() *SetURLLabelsRequest {
+	m0 := &SetURLLabelsRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Url = b.Url
+	x.xxx_hidden_Code = b.Code
+	x.xxx_hidden_Tags = b.Tags
+	x.xxx_hidden_Folder = b.Folder
 	return m0
 }
 
-type URLShortenResponse struct {
+// SetURLLabelsResponse returns the labels as stored: tags lowercased, sorted and deduplicated.
+type SetURLLabelsResponse struct {
 	state             protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Result string                 `protobuf:"bytes,1,opt,name=result,proto3"`
+	xxx_hidden_Tags   []string               `protobuf:"bytes,1,rep,name=tags,proto3"`
+	xxx_hidden_Folder string                 `protobuf:"bytes,2,opt,name=folder,proto3"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
-func (x *URLShortenResponse) Reset() {
-	*x = URLShortenResponse{}
-	mi := &file_shortener_proto_msgTypes[1]
+func (x *SetURLLabelsResponse) Reset() {
+	*x = SetURLLabelsResponse{}
+	mi := &file_shortener_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLShortenResponse) String() string {
+func (x *SetURLLabelsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLShortenResponse) ProtoMessage() {}
+func (*SetURLLabelsResponse) ProtoMessage() {}
 
-func (x *URLShortenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_proto_msgTypes[1]
+func (x *SetURLLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -109,53 +2267,69 @@ func (x *URLShortenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLShortenResponse) GetResult() string {
+func (x *SetURLLabelsResponse) GetTags() []string {
 	if x != nil {
-		return x.xxx_hidden_Result
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *SetURLLabelsResponse) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
 	}
 	return ""
 }
 
-func (x *URLShortenResponse) SetResult(v string) {
-	x.xxx_hidden_Result = v
+func (x *SetURLLabelsResponse) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
 }
 
-type URLShortenResponse_builder struct {
+func (x *SetURLLabelsResponse) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+type SetURLLabelsResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Result string
+	Tags   []string
+	Folder string
 }
 
-func (b0 URLShortenResponse_builder) Build() *URLShortenResponse {
-	m0 := &URLShortenResponse{}
+func (b0 SetURLLabelsResponse_builder) Build() *SetURLLabelsResponse {
+	m0 := &SetURLLabelsResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Result = b.Result
+	x.xxx_hidden_Tags = b.Tags
+	x.xxx_hidden_Folder = b.Folder
 	return m0
 }
 
-type URLExpandRequest struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id string                 `protobuf:"bytes,1,opt,name=id,proto3"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// SetURLRulesRequest replaces the redirect rules of a link owned by the caller;
+// an empty list removes them.
+type SetURLRulesRequest struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code  string                 `protobuf:"bytes,1,opt,name=code,proto3"`
+	xxx_hidden_Rules *[]*RedirectRule       `protobuf:"bytes,2,rep,name=rules,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *URLExpandRequest) Reset() {
-	*x = URLExpandRequest{}
-	mi := &file_shortener_proto_msgTypes[2]
+func (x *SetURLRulesRequest) Reset() {
+	*x = SetURLRulesRequest{}
+	mi := &file_shortener_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLExpandRequest) String() string {
+func (x *SetURLRulesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLExpandRequest) ProtoMessage() {}
+func (*SetURLRulesRequest) ProtoMessage() {}
 
-func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_proto_msgTypes[2]
+func (x *SetURLRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -166,53 +2340,69 @@ func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLExpandRequest) GetId() string {
+func (x *SetURLRulesRequest) GetCode() string {
 	if x != nil {
-		return x.xxx_hidden_Id
+		return x.xxx_hidden_Code
 	}
 	return ""
 }
 
-func (x *URLExpandRequest) SetId(v string) {
-	x.xxx_hidden_Id = v
+func (x *SetURLRulesRequest) GetRules() []*RedirectRule {
+	if x != nil {
+		if x.xxx_hidden_Rules != nil {
+			return *x.xxx_hidden_Rules
+		}
+	}
+	return nil
 }
 
-type URLExpandRequest_builder struct {
+func (x *SetURLRulesRequest) SetCode(v string) {
+	x.xxx_hidden_Code = v
+}
+
+func (x *SetURLRulesRequest) SetRules(v []*RedirectRule) {
+	x.xxx_hidden_Rules = &v
+}
+
+type SetURLRulesRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id string
+	Code  string
+	Rules []*RedirectRule
 }
 
-func (b0 URLExpandRequest_builder) Build() *URLExpandRequest {
-	m0 := &URLExpandRequest{}
+func (b0 SetURLRulesRequest_builder) Build() *SetURLRulesRequest {
+	m0 := &SetURLRulesRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_Code = b.Code
+	x.xxx_hidden_Rules = &b.Rules
 	return m0
 }
 
-type URLExpandResponse struct {
-	state             protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Result string                 `protobuf:"bytes,1,opt,name=result,proto3"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// SetURLRulesResponse returns the rules as stored: languages lowercased, countries uppercased.
+type SetURLRulesResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Rules *[]*RedirectRule       `protobuf:"bytes,1,rep,name=rules,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *URLExpandResponse) Reset() {
-	*x = URLExpandResponse{}
-	mi := &file_shortener_proto_msgTypes[3]
+func (x *SetURLRulesResponse) Reset() {
+	*x = SetURLRulesResponse{}
+	mi := &file_shortener_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLExpandResponse) String() string {
+func (x *SetURLRulesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLExpandResponse) ProtoMessage() {}
+func (*SetURLRulesResponse) ProtoMessage() {}
 
-func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_proto_msgTypes[3]
+func (x *SetURLRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,54 +2413,58 @@ func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLExpandResponse) GetResult() string {
+func (x *SetURLRulesResponse) GetRules() []*RedirectRule {
 	if x != nil {
-		return x.xxx_hidden_Result
+		if x.xxx_hidden_Rules != nil {
+			return *x.xxx_hidden_Rules
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *URLExpandResponse) SetResult(v string) {
-	x.xxx_hidden_Result = v
+func (x *SetURLRulesResponse) SetRules(v []*RedirectRule) {
+	x.xxx_hidden_Rules = &v
 }
 
-type URLExpandResponse_builder struct {
+type SetURLRulesResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Result string
+	Rules []*RedirectRule
 }
 
-func (b0 URLExpandResponse_builder) Build() *URLExpandResponse {
-	m0 := &URLExpandResponse{}
+func (b0 SetURLRulesResponse_builder) Build() *SetURLRulesResponse {
+	m0 := &SetURLRulesResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Result = b.Result
+	x.xxx_hidden_Rules = &b.Rules
 	return m0
 }
 
-// ListUserURLsRequest is intentionally empty for now.
-// Fields (e.g. pagination, filters) can be added here without breaking backward compatibility.
-type ListUserURLsRequest struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// SetURLVariantsRequest replaces the split variants of a link owned by the caller;
+// an empty list turns the split off.
+type SetURLVariantsRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code     string                 `protobuf:"bytes,1,opt,name=code,proto3"`
+	xxx_hidden_Variants *[]*SplitVariant       `protobuf:"bytes,2,rep,name=variants,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *ListUserURLsRequest) Reset() {
-	*x = ListUserURLsRequest{}
-	mi := &file_shortener_proto_msgTypes[4]
+func (x *SetURLVariantsRequest) Reset() {
+	*x = SetURLVariantsRequest{}
+	mi := &file_shortener_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUserURLsRequest) String() string {
+func (x *SetURLVariantsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUserURLsRequest) ProtoMessage() {}
+func (*SetURLVariantsRequest) ProtoMessage() {}
 
-func (x *ListUserURLsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_proto_msgTypes[4]
+func (x *SetURLVariantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,40 +2475,69 @@ func (x *ListUserURLsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-type ListUserURLsRequest_builder struct {
+func (x *SetURLVariantsRequest) GetCode() string {
+	if x != nil {
+		return x.xxx_hidden_Code
+	}
+	return ""
+}
+
+func (x *SetURLVariantsRequest) GetVariants() []*SplitVariant {
+	if x != nil {
+		if x.xxx_hidden_Variants != nil {
+			return *x.xxx_hidden_Variants
+		}
+	}
+	return nil
+}
+
+func (x *SetURLVariantsRequest) SetCode(v string) {
+	x.xxx_hidden_Code = v
+}
+
+func (x *SetURLVariantsRequest) SetVariants(v []*SplitVariant) {
+	x.xxx_hidden_Variants = &v
+}
+
+type SetURLVariantsRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
+	Code     string
+	Variants []*SplitVariant
 }
 
-func (b0 ListUserURLsRequest_builder) Build() *ListUserURLsRequest {
-	m0 := &ListUserURLsRequest{}
+func (b0 SetURLVariantsRequest_builder) Build() *SetURLVariantsRequest {
+	m0 := &SetURLVariantsRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
+	x.xxx_hidden_Code = b.Code
+	x.xxx_hidden_Variants = &b.Variants
 	return m0
 }
 
-type UserURLsResponse struct {
-	state          protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Url *[]*URLData            `protobuf:"bytes,1,rep,name=url,proto3"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// SetURLVariantsResponse returns the variants as stored.
+type SetURLVariantsResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Variants *[]*SplitVariant       `protobuf:"bytes,1,rep,name=variants,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *UserURLsResponse) Reset() {
-	*x = UserURLsResponse{}
-	mi := &file_shortener_proto_msgTypes[5]
+func (x *SetURLVariantsResponse) Reset() {
+	*x = SetURLVariantsResponse{}
+	mi := &file_shortener_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserURLsResponse) String() string {
+func (x *SetURLVariantsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserURLsResponse) ProtoMessage() {}
+func (*SetURLVariantsResponse) ProtoMessage() {}
 
-func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_proto_msgTypes[5]
+func (x *SetURLVariantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,56 +2548,193 @@ func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *UserURLsResponse) GetUrl() []*URLData {
+func (x *SetURLVariantsResponse) GetVariants() []*SplitVariant {
 	if x != nil {
-		if x.xxx_hidden_Url != nil {
-			return *x.xxx_hidden_Url
+		if x.xxx_hidden_Variants != nil {
+			return *x.xxx_hidden_Variants
 		}
 	}
 	return nil
 }
 
-func (x *UserURLsResponse) SetUrl(v []*URLData) {
-	x.xxx_hidden_Url = &v
+func (x *SetURLVariantsResponse) SetVariants(v []*SplitVariant) {
+	x.xxx_hidden_Variants = &v
 }
 
-type UserURLsResponse_builder struct {
+type SetURLVariantsResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Url []*URLData
+	Variants []*SplitVariant
 }
 
-func (b0 UserURLsResponse_builder) Build() *UserURLsResponse {
-	m0 := &UserURLsResponse{}
+func (b0 SetURLVariantsResponse_builder) Build() *SetURLVariantsResponse {
+	m0 := &SetURLVariantsResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Url = &b.Url
+	x.xxx_hidden_Variants = &b.Variants
 	return m0
 }
 
-type URLData struct {
+// QRCodeRequest asks for a QR code of the full short URL of a link.
+type QRCodeRequest struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code            string                 `protobuf:"bytes,1,opt,name=code,proto3"`
+	xxx_hidden_Format          string                 `protobuf:"bytes,2,opt,name=format,proto3"`
+	xxx_hidden_Size            int32                  `protobuf:"varint,3,opt,name=size,proto3"`
+	xxx_hidden_ErrorCorrection string                 `protobuf:"bytes,4,opt,name=error_correction,json=errorCorrection,proto3"`
+	xxx_hidden_Margin          int32                  `protobuf:"varint,5,opt,name=margin,proto3,oneof"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *QRCodeRequest) Reset() {
+	*x = QRCodeRequest{}
+	mi := &file_shortener_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QRCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QRCodeRequest) ProtoMessage() {}
+
+func (x *QRCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *QRCodeRequest) GetCode() string {
+	if x != nil {
+		return x.xxx_hidden_Code
+	}
+	return ""
+}
+
+func (x *QRCodeRequest) GetFormat() string {
+	if x != nil {
+		return x.xxx_hidden_Format
+	}
+	return ""
+}
+
+func (x *QRCodeRequest) GetSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *QRCodeRequest) GetErrorCorrection() string {
+	if x != nil {
+		return x.xxx_hidden_ErrorCorrection
+	}
+	return ""
+}
+
+func (x *QRCodeRequest) GetMargin() int32 {
+	if x != nil {
+		return x.xxx_hidden_Margin
+	}
+	return 0
+}
+
+func (x *QRCodeRequest) SetCode(v string) {
+	x.xxx_hidden_Code = v
+}
+
+func (x *QRCodeRequest) SetFormat(v string) {
+	x.xxx_hidden_Format = v
+}
+
+func (x *QRCodeRequest) SetSize(v int32) {
+	x.xxx_hidden_Size = v
+}
+
+func (x *QRCodeRequest) SetErrorCorrection(v string) {
+	x.xxx_hidden_ErrorCorrection = v
+}
+
+func (x *QRCodeRequest) SetMargin(v int32) {
+	x.xxx_hidden_Margin = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *QRCodeRequest) HasMargin() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *QRCodeRequest) ClearMargin() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Margin = 0
+}
+
+type QRCodeRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Code string
+	// format is "png" (default) or "svg".
+	Format string
+	// size is the image width in pixels; 0 means the default.
+	Size int32
+	// error_correction is one of L, M (default), Q or H.
+	ErrorCorrection string
+	// margin is the quiet zone width in modules; unset means the default.
+	Margin *int32
+}
+
+func (b0 QRCodeRequest_builder) Build() *QRCodeRequest {
+	m0 := &QRCodeRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Code = b.Code
+	x.xxx_hidden_Format = b.Format
+	x.xxx_hidden_Size = b.Size
+	x.xxx_hidden_ErrorCorrection = b.ErrorCorrection
+	if b.Margin != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Margin = *b.Margin
+	}
+	return m0
+}
+
+type QRCodeResponse struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3"`
-	xxx_hidden_OriginalUrl string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3"`
+	xxx_hidden_Image       []byte                 `protobuf:"bytes,1,opt,name=image,proto3"`
+	xxx_hidden_ContentType string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3"`
+	xxx_hidden_Etag        string                 `protobuf:"bytes,3,opt,name=etag,proto3"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *URLData) Reset() {
-	*x = URLData{}
-	mi := &file_shortener_proto_msgTypes[6]
+func (x *QRCodeResponse) Reset() {
+	*x = QRCodeResponse{}
+	mi := &file_shortener_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLData) String() string {
+func (x *QRCodeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLData) ProtoMessage() {}
+func (*QRCodeResponse) ProtoMessage() {}
 
-func (x *URLData) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_proto_msgTypes[6]
+func (x *QRCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -385,41 +2745,58 @@ func (x *URLData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLData) GetShortUrl() string {
+func (x *QRCodeResponse) GetImage() []byte {
 	if x != nil {
-		return x.xxx_hidden_ShortUrl
+		return x.xxx_hidden_Image
+	}
+	return nil
+}
+
+func (x *QRCodeResponse) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
 	}
 	return ""
 }
 
-func (x *URLData) GetOriginalUrl() string {
+func (x *QRCodeResponse) GetEtag() string {
 	if x != nil {
-		return x.xxx_hidden_OriginalUrl
+		return x.xxx_hidden_Etag
 	}
 	return ""
 }
 
-func (x *URLData) SetShortUrl(v string) {
-	x.xxx_hidden_ShortUrl = v
+func (x *QRCodeResponse) SetImage(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Image = v
 }
 
-func (x *URLData) SetOriginalUrl(v string) {
-	x.xxx_hidden_OriginalUrl = v
+func (x *QRCodeResponse) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
 }
 
-type URLData_builder struct {
+func (x *QRCodeResponse) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
+type QRCodeResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	ShortUrl    string
-	OriginalUrl string
+	Image       []byte
+	ContentType string
+	// etag identifies the image content; it changes only with the short URL and parameters.
+	Etag string
 }
 
-func (b0 URLData_builder) Build() *URLData {
-	m0 := &URLData{}
+func (b0 QRCodeResponse_builder) Build() *QRCodeResponse {
+	m0 := &QRCodeResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_ShortUrl = b.ShortUrl
-	x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	x.xxx_hidden_Image = b.Image
+	x.xxx_hidden_ContentType = b.ContentType
+	x.xxx_hidden_Etag = b.Etag
 	return m0
 }
 
@@ -427,50 +2804,239 @@ var File_shortener_proto protoreflect.FileDescriptor
 
 const file_shortener_proto_rawDesc = "" +
 	"\n" +
-	"\x0fshortener.proto\x12\fshortener.v1\"%\n" +
+	"\x0fshortener.proto\x12\fshortener.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbd\x04\n" +
 	"\x11URLShortenRequest\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\",\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1d\n" +
+	"\n" +
+	"code_style\x18\x02 \x01(\tR\tcodeStyle\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"max_clicks\x18\x05 \x01(\x05R\tmaxClicks\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\b \x01(\tR\x06folder\x12'\n" +
+	"\x0fredirect_status\x18\t \x01(\x05R\x0eredirectStatus\x12=\n" +
+	"\rcache_max_age\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\vcacheMaxAge\x12+\n" +
+	"\x11query_passthrough\x18\v \x01(\tR\x10queryPassthrough\x12)\n" +
+	"\x03utm\x18\f \x01(\v2\x17.shortener.v1.UTMParamsR\x03utm\x120\n" +
+	"\x05rules\x18\r \x03(\v2\x1a.shortener.v1.RedirectRuleR\x05rules\x126\n" +
+	"\bvariants\x18\x0e \x03(\v2\x1a.shortener.v1.SplitVariantR\bvariants\"8\n" +
+	"\fSplitVariant\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x05R\x06weight\"z\n" +
+	"\fRedirectRule\x12\x1c\n" +
+	"\tplatforms\x18\x01 \x03(\tR\tplatforms\x12\x1c\n" +
+	"\tlanguages\x18\x02 \x03(\tR\tlanguages\x12\x1c\n" +
+	"\tcountries\x18\x03 \x03(\tR\tcountries\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\"\x85\x01\n" +
+	"\tUTMParams\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06medium\x18\x02 \x01(\tR\x06medium\x12\x1a\n" +
+	"\bcampaign\x18\x03 \x01(\tR\bcampaign\x12\x12\n" +
+	"\x04term\x18\x04 \x01(\tR\x04term\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\",\n" +
 	"\x12URLShortenResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\"\"\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\"\xf1\x01\n" +
 	"\x10URLExpandRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1c\n" +
+	"\tconfirmed\x18\x03 \x01(\bR\tconfirmed\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x12'\n" +
+	"\x0faccept_language\x18\x06 \x01(\tR\x0eacceptLanguage\x12\x1b\n" +
+	"\tclient_ip\x18\a \x01(\tR\bclientIp\x12\x18\n" +
+	"\avariant\x18\b \x01(\x05R\avariant\"\xad\x01\n" +
 	"\x11URLExpandResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\"\x15\n" +
-	"\x13ListUserURLsRequest\";\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\x12'\n" +
+	"\x0fredirect_status\x18\x02 \x01(\x05R\x0eredirectStatus\x12=\n" +
+	"\rcache_max_age\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vcacheMaxAge\x12\x18\n" +
+	"\avariant\x18\x04 \x01(\x05R\avariant\"\xd7\x01\n" +
+	"\x13ListUserURLsRequest\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x16\n" +
+	"\x06folder\x18\x03 \x01(\tR\x06folder\x12\x16\n" +
+	"\x06search\x18\x04 \x01(\tR\x06search\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x06 \x01(\tR\x05order\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\"c\n" +
 	"\x10UserURLsResponse\x12'\n" +
-	"\x03url\x18\x01 \x03(\v2\x15.shortener.v1.URLDataR\x03url\"I\n" +
+	"\x03url\x18\x01 \x03(\v2\x15.shortener.v1.URLDataR\x03url\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc9\x02\n" +
 	"\aURLData\x12\x1b\n" +
 	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
-	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl2\x84\x02\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x129\n" +
+	"\n" +
+	"deleted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\x05 \x01(\tR\x06folder\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06clicks\x18\a \x01(\x03R\x06clicks\x12D\n" +
+	"\x10last_accessed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0elastAccessedAt\"%\n" +
+	"\x0fURLStatsRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\xfc\x03\n" +
+	"\x10URLStatsResponse\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12!\n" +
+	"\ftotal_clicks\x18\x03 \x01(\x03R\vtotalClicks\x12'\n" +
+	"\x0funique_visitors\x18\x04 \x01(\x03R\x0euniqueVisitors\x121\n" +
+	"\x06hourly\x18\x05 \x03(\v2\x19.shortener.v1.StatsBucketR\x06hourly\x12/\n" +
+	"\x05daily\x18\x06 \x03(\v2\x19.shortener.v1.StatsBucketR\x05daily\x126\n" +
+	"\treferrers\x18\a \x03(\v2\x18.shortener.v1.StatsCountR\treferrers\x124\n" +
+	"\bbrowsers\x18\b \x03(\v2\x18.shortener.v1.StatsCountR\bbrowsers\x126\n" +
+	"\tcountries\x18\t \x03(\v2\x18.shortener.v1.StatsCountR\tcountries\x126\n" +
+	"\bvariants\x18\n" +
+	" \x03(\v2\x1a.shortener.v1.VariantCountR\bvariants\"@\n" +
+	"\fVariantCount\x12\x18\n" +
+	"\avariant\x18\x01 \x01(\x05R\avariant\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"W\n" +
+	"\vStatsBucket\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\":\n" +
+	"\n" +
+	"StatsCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"8\n" +
+	"\x10UpdateURLRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"v\n" +
+	"\x11UpdateURLResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12!\n" +
+	"\fprevious_url\x18\x03 \x01(\tR\vpreviousUrl\"*\n" +
+	"\x12RestoreURLsRequest\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"+\n" +
+	"\x13RestoreURLsResponse\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"U\n" +
+	"\x13SetURLLabelsRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\x03 \x01(\tR\x06folder\"B\n" +
+	"\x14SetURLLabelsResponse\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\x02 \x01(\tR\x06folder\"Z\n" +
+	"\x12SetURLRulesRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x120\n" +
+	"\x05rules\x18\x02 \x03(\v2\x1a.shortener.v1.RedirectRuleR\x05rules\"G\n" +
+	"\x13SetURLRulesResponse\x120\n" +
+	"\x05rules\x18\x01 \x03(\v2\x1a.shortener.v1.RedirectRuleR\x05rules\"c\n" +
+	"\x15SetURLVariantsRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x126\n" +
+	"\bvariants\x18\x02 \x03(\v2\x1a.shortener.v1.SplitVariantR\bvariants\"P\n" +
+	"\x16SetURLVariantsResponse\x126\n" +
+	"\bvariants\x18\x01 \x03(\v2\x1a.shortener.v1.SplitVariantR\bvariants\"\xa2\x01\n" +
+	"\rQRCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12)\n" +
+	"\x10error_correction\x18\x04 \x01(\tR\x0ferrorCorrection\x12\x1b\n" +
+	"\x06margin\x18\x05 \x01(\x05H\x00R\x06margin\x88\x01\x01B\t\n" +
+	"\a_margin\"]\n" +
+	"\x0eQRCodeResponse\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\fR\x05image\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag2\xc4\x06\n" +
 	"\x10ShortenerService\x12O\n" +
 	"\n" +
 	"ShortenURL\x12\x1f.shortener.v1.URLShortenRequest\x1a .shortener.v1.URLShortenResponse\x12L\n" +
 	"\tExpandURL\x12\x1e.shortener.v1.URLExpandRequest\x1a\x1f.shortener.v1.URLExpandResponse\x12Q\n" +
-	"\fListUserURLs\x12!.shortener.v1.ListUserURLsRequest\x1a\x1e.shortener.v1.UserURLsResponseB1Z/github.com/avc-dev/url-shortener/internal/protob\x06proto3"
-
-var file_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+	"\fListUserURLs\x12!.shortener.v1.ListUserURLsRequest\x1a\x1e.shortener.v1.UserURLsResponse\x12L\n" +
+	"\vGetURLStats\x12\x1d.shortener.v1.URLStatsRequest\x1a\x1e.shortener.v1.URLStatsResponse\x12L\n" +
+	"\tUpdateURL\x12\x1e.shortener.v1.UpdateURLRequest\x1a\x1f.shortener.v1.UpdateURLResponse\x12R\n" +
+	"\vRestoreURLs\x12 .shortener.v1.RestoreURLsRequest\x1a!.shortener.v1.RestoreURLsResponse\x12U\n" +
+	"\fSetURLLabels\x12!.shortener.v1.SetURLLabelsRequest\x1a\".shortener.v1.SetURLLabelsResponse\x12R\n" +
+	"\vSetURLRules\x12 .shortener.v1.SetURLRulesRequest\x1a!.shortener.v1.SetURLRulesResponse\x12[\n" +
+	"\x0eSetURLVariants\x12#.shortener.v1.SetURLVariantsRequest\x1a$.shortener.v1.SetURLVariantsResponse\x12F\n" +
+	"\tGetQRCode\x12\x1b.shortener.v1.QRCodeRequest\x1a\x1c.shortener.v1.QRCodeResponseB1Z/github.com/avc-dev/url-shortener/internal/protob\x06proto3"
+
+var file_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_shortener_proto_goTypes = []any{
-	(*URLShortenRequest)(nil),   // 0: shortener.v1.URLShortenRequest
-	(*URLShortenResponse)(nil),  // 1: shortener.v1.URLShortenResponse
-	(*URLExpandRequest)(nil),    // 2: shortener.v1.URLExpandRequest
-	(*URLExpandResponse)(nil),   // 3: shortener.v1.URLExpandResponse
-	(*ListUserURLsRequest)(nil), // 4: shortener.v1.ListUserURLsRequest
-	(*UserURLsResponse)(nil),    // 5: shortener.v1.UserURLsResponse
-	(*URLData)(nil),             // 6: shortener.v1.URLData
+	(*URLShortenRequest)(nil),      // 0: shortener.v1.URLShortenRequest
+	(*SplitVariant)(nil),           // 1: shortener.v1.SplitVariant
+	(*RedirectRule)(nil),           // 2: shortener.v1.RedirectRule
+	(*UTMParams)(nil),              // 3: shortener.v1.UTMParams
+	(*URLShortenResponse)(nil),     // 4: shortener.v1.URLShortenResponse
+	(*URLExpandRequest)(nil),       // 5: shortener.v1.URLExpandRequest
+	(*URLExpandResponse)(nil),      // 6: shortener.v1.URLExpandResponse
+	(*ListUserURLsRequest)(nil),    // 7: shortener.v1.ListUserURLsRequest
+	(*UserURLsResponse)(nil),       // 8: shortener.v1.UserURLsResponse
+	(*URLData)(nil),                // 9: shortener.v1.URLData
+	(*URLStatsRequest)(nil),        // 10: shortener.v1.URLStatsRequest
+	(*URLStatsResponse)(nil),       // 11: shortener.v1.URLStatsResponse
+	(*VariantCount)(nil),           // 12: shortener.v1.VariantCount
+	(*StatsBucket)(nil),            // 13: shortener.v1.StatsBucket
+	(*StatsCount)(nil),             // 14: shortener.v1.StatsCount
+	(*UpdateURLRequest)(nil),       // 15: shortener.v1.UpdateURLRequest
+	(*UpdateURLResponse)(nil),      // 16: shortener.v1.UpdateURLResponse
+	(*RestoreURLsRequest)(nil),     // 17: shortener.v1.RestoreURLsRequest
+	(*RestoreURLsResponse)(nil),    // 18: shortener.v1.RestoreURLsResponse
+	(*SetURLLabelsRequest)(nil),    // 19: shortener.v1.SetURLLabelsRequest
+	(*SetURLLabelsResponse)(nil),   // 20: shortener.v1.SetURLLabelsResponse
+	(*SetURLRulesRequest)(nil),     // 21: shortener.v1.SetURLRulesRequest
+	(*SetURLRulesResponse)(nil),    // 22: shortener.v1.SetURLRulesResponse
+	(*SetURLVariantsRequest)(nil),  // 23: shortener.v1.SetURLVariantsRequest
+	(*SetURLVariantsResponse)(nil), // 24: shortener.v1.SetURLVariantsResponse
+	(*QRCodeRequest)(nil),          // 25: shortener.v1.QRCodeRequest
+	(*QRCodeResponse)(nil),         // 26: shortener.v1.QRCodeResponse
+	(*durationpb.Duration)(nil),    // 27: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),  // 28: google.protobuf.Timestamp
 }
 var file_shortener_proto_depIdxs = []int32{
-	6, // 0: shortener.v1.UserURLsResponse.url:type_name -> shortener.v1.URLData
-	0, // 1: shortener.v1.ShortenerService.ShortenURL:input_type -> shortener.v1.URLShortenRequest
-	2, // 2: shortener.v1.ShortenerService.ExpandURL:input_type -> shortener.v1.URLExpandRequest
-	4, // 3: shortener.v1.ShortenerService.ListUserURLs:input_type -> shortener.v1.ListUserURLsRequest
-	1, // 4: shortener.v1.ShortenerService.ShortenURL:output_type -> shortener.v1.URLShortenResponse
-	3, // 5: shortener.v1.ShortenerService.ExpandURL:output_type -> shortener.v1.URLExpandResponse
-	5, // 6: shortener.v1.ShortenerService.ListUserURLs:output_type -> shortener.v1.UserURLsResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	27, // 0: shortener.v1.URLShortenRequest.ttl:type_name -> google.protobuf.Duration
+	28, // 1: shortener.v1.URLShortenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	27, // 2: shortener.v1.URLShortenRequest.cache_max_age:type_name -> google.protobuf.Duration
+	3,  // 3: shortener.v1.URLShortenRequest.utm:type_name -> shortener.v1.UTMParams
+	2,  // 4: shortener.v1.URLShortenRequest.rules:type_name -> shortener.v1.RedirectRule
+	1,  // 5: shortener.v1.URLShortenRequest.variants:type_name -> shortener.v1.SplitVariant
+	27, // 6: shortener.v1.URLExpandResponse.cache_max_age:type_name -> google.protobuf.Duration
+	9,  // 7: shortener.v1.UserURLsResponse.url:type_name -> shortener.v1.URLData
+	28, // 8: shortener.v1.URLData.deleted_at:type_name -> google.protobuf.Timestamp
+	28, // 9: shortener.v1.URLData.created_at:type_name -> google.protobuf.Timestamp
+	28, // 10: shortener.v1.URLData.last_accessed_at:type_name -> google.protobuf.Timestamp
+	28, // 11: shortener.v1.URLStatsResponse.from:type_name -> google.protobuf.Timestamp
+	28, // 12: shortener.v1.URLStatsResponse.to:type_name -> google.protobuf.Timestamp
+	13, // 13: shortener.v1.URLStatsResponse.hourly:type_name -> shortener.v1.StatsBucket
+	13, // 14: shortener.v1.URLStatsResponse.daily:type_name -> shortener.v1.StatsBucket
+	14, // 15: shortener.v1.URLStatsResponse.referrers:type_name -> shortener.v1.StatsCount
+	14, // 16: shortener.v1.URLStatsResponse.browsers:type_name -> shortener.v1.StatsCount
+	14, // 17: shortener.v1.URLStatsResponse.countries:type_name -> shortener.v1.StatsCount
+	12, // 18: shortener.v1.URLStatsResponse.variants:type_name -> shortener.v1.VariantCount
+	28, // 19: shortener.v1.StatsBucket.start:type_name -> google.protobuf.Timestamp
+	2,  // 20: shortener.v1.SetURLRulesRequest.rules:type_name -> shortener.v1.RedirectRule
+	2,  // 21: shortener.v1.SetURLRulesResponse.rules:type_name -> shortener.v1.RedirectRule
+	1,  // 22: shortener.v1.SetURLVariantsRequest.variants:type_name -> shortener.v1.SplitVariant
+	1,  // 23: shortener.v1.SetURLVariantsResponse.variants:type_name -> shortener.v1.SplitVariant
+	0,  // 24: shortener.v1.ShortenerService.ShortenURL:input_type -> shortener.v1.URLShortenRequest
+	5,  // 25: shortener.v1.ShortenerService.ExpandURL:input_type -> shortener.v1.URLExpandRequest
+	7,  // 26: shortener.v1.ShortenerService.ListUserURLs:input_type -> shortener.v1.ListUserURLsRequest
+	10, // 27: shortener.v1.ShortenerService.GetURLStats:input_type -> shortener.v1.URLStatsRequest
+	15, // 28: shortener.v1.ShortenerService.UpdateURL:input_type -> shortener.v1.UpdateURLRequest
+	17, // 29: shortener.v1.ShortenerService.RestoreURLs:input_type -> shortener.v1.RestoreURLsRequest
+	19, // 30: shortener.v1.ShortenerService.SetURLLabels:input_type -> shortener.v1.SetURLLabelsRequest
+	21, // 31: shortener.v1.ShortenerService.SetURLRules:input_type -> shortener.v1.SetURLRulesRequest
+	23, // 32: shortener.v1.ShortenerService.SetURLVariants:input_type -> shortener.v1.SetURLVariantsRequest
+	25, // 33: shortener.v1.ShortenerService.GetQRCode:input_type -> shortener.v1.QRCodeRequest
+	4,  // 34: shortener.v1.ShortenerService.ShortenURL:output_type -> shortener.v1.URLShortenResponse
+	6,  // 35: shortener.v1.ShortenerService.ExpandURL:output_type -> shortener.v1.URLExpandResponse
+	8,  // 36: shortener.v1.ShortenerService.ListUserURLs:output_type -> shortener.v1.UserURLsResponse
+	11, // 37: shortener.v1.ShortenerService.GetURLStats:output_type -> shortener.v1.URLStatsResponse
+	16, // 38: shortener.v1.ShortenerService.UpdateURL:output_type -> shortener.v1.UpdateURLResponse
+	18, // 39: shortener.v1.ShortenerService.RestoreURLs:output_type -> shortener.v1.RestoreURLsResponse
+	20, // 40: shortener.v1.ShortenerService.SetURLLabels:output_type -> shortener.v1.SetURLLabelsResponse
+	22, // 41: shortener.v1.ShortenerService.SetURLRules:output_type -> shortener.v1.SetURLRulesResponse
+	24, // 42: shortener.v1.ShortenerService.SetURLVariants:output_type -> shortener.v1.SetURLVariantsResponse
+	26, // 43: shortener.v1.ShortenerService.GetQRCode:output_type -> shortener.v1.QRCodeResponse
+	34, // [34:44] is the sub-list for method output_type
+	24, // [24:34] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_shortener_proto_init() }
@@ -478,13 +3044,14 @@ func file_shortener_proto_init() {
 	if File_shortener_proto != nil {
 		return
 	}
+	file_shortener_proto_msgTypes[25].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shortener_proto_rawDesc), len(file_shortener_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
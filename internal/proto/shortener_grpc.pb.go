@@ -19,9 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShortenerService_ShortenURL_FullMethodName   = "/shortener.v1.ShortenerService/ShortenURL"
-	ShortenerService_ExpandURL_FullMethodName    = "/shortener.v1.ShortenerService/ExpandURL"
-	ShortenerService_ListUserURLs_FullMethodName = "/shortener.v1.ShortenerService/ListUserURLs"
+	ShortenerService_ShortenURL_FullMethodName     = "/shortener.v1.ShortenerService/ShortenURL"
+	ShortenerService_ExpandURL_FullMethodName      = "/shortener.v1.ShortenerService/ExpandURL"
+	ShortenerService_ListUserURLs_FullMethodName   = "/shortener.v1.ShortenerService/ListUserURLs"
+	ShortenerService_GetURLStats_FullMethodName    = "/shortener.v1.ShortenerService/GetURLStats"
+	ShortenerService_UpdateURL_FullMethodName      = "/shortener.v1.ShortenerService/UpdateURL"
+	ShortenerService_RestoreURLs_FullMethodName    = "/shortener.v1.ShortenerService/RestoreURLs"
+	ShortenerService_SetURLLabels_FullMethodName   = "/shortener.v1.ShortenerService/SetURLLabels"
+	ShortenerService_SetURLRules_FullMethodName    = "/shortener.v1.ShortenerService/SetURLRules"
+	ShortenerService_SetURLVariants_FullMethodName = "/shortener.v1.ShortenerService/SetURLVariants"
+	ShortenerService_GetQRCode_FullMethodName      = "/shortener.v1.ShortenerService/GetQRCode"
 )
 
 // ShortenerServiceClient is the client API for ShortenerService service.
@@ -31,6 +38,13 @@ type ShortenerServiceClient interface {
 	ShortenURL(ctx context.Context, in *URLShortenRequest, opts ...grpc.CallOption) (*URLShortenResponse, error)
 	ExpandURL(ctx context.Context, in *URLExpandRequest, opts ...grpc.CallOption) (*URLExpandResponse, error)
 	ListUserURLs(ctx context.Context, in *ListUserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	GetURLStats(ctx context.Context, in *URLStatsRequest, opts ...grpc.CallOption) (*URLStatsResponse, error)
+	UpdateURL(ctx context.Context, in *UpdateURLRequest, opts ...grpc.CallOption) (*UpdateURLResponse, error)
+	RestoreURLs(ctx context.Context, in *RestoreURLsRequest, opts ...grpc.CallOption) (*RestoreURLsResponse, error)
+	SetURLLabels(ctx context.Context, in *SetURLLabelsRequest, opts ...grpc.CallOption) (*SetURLLabelsResponse, error)
+	SetURLRules(ctx context.Context, in *SetURLRulesRequest, opts ...grpc.CallOption) (*SetURLRulesResponse, error)
+	SetURLVariants(ctx context.Context, in *SetURLVariantsRequest, opts ...grpc.CallOption) (*SetURLVariantsResponse, error)
+	GetQRCode(ctx context.Context, in *QRCodeRequest, opts ...grpc.CallOption) (*QRCodeResponse, error)
 }
 
 type shortenerServiceClient struct {
@@ -71,6 +85,76 @@ func (c *shortenerServiceClient) ListUserURLs(ctx context.Context, in *ListUserU
 	return out, nil
 }
 
+func (c *shortenerServiceClient) GetURLStats(ctx context.Context, in *URLStatsRequest, opts ...grpc.CallOption) (*URLStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLStatsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetURLStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) UpdateURL(ctx context.Context, in *UpdateURLRequest, opts ...grpc.CallOption) (*UpdateURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateURLResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_UpdateURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) RestoreURLs(ctx context.Context, in *RestoreURLsRequest, opts ...grpc.CallOption) (*RestoreURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreURLsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_RestoreURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) SetURLLabels(ctx context.Context, in *SetURLLabelsRequest, opts ...grpc.CallOption) (*SetURLLabelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetURLLabelsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_SetURLLabels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) SetURLRules(ctx context.Context, in *SetURLRulesRequest, opts ...grpc.CallOption) (*SetURLRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetURLRulesResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_SetURLRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) SetURLVariants(ctx context.Context, in *SetURLVariantsRequest, opts ...grpc.CallOption) (*SetURLVariantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetURLVariantsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_SetURLVariants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) GetQRCode(ctx context.Context, in *QRCodeRequest, opts ...grpc.CallOption) (*QRCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QRCodeResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetQRCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortenerServiceServer is the server API for ShortenerService service.
 // All implementations must embed UnimplementedShortenerServiceServer
 // for forward compatibility.
@@ -78,6 +162,13 @@ type ShortenerServiceServer interface {
 	ShortenURL(context.Context, *URLShortenRequest) (*URLShortenResponse, error)
 	ExpandURL(context.Context, *URLExpandRequest) (*URLExpandResponse, error)
 	ListUserURLs(context.Context, *ListUserURLsRequest) (*UserURLsResponse, error)
+	GetURLStats(context.Context, *URLStatsRequest) (*URLStatsResponse, error)
+	UpdateURL(context.Context, *UpdateURLRequest) (*UpdateURLResponse, error)
+	RestoreURLs(context.Context, *RestoreURLsRequest) (*RestoreURLsResponse, error)
+	SetURLLabels(context.Context, *SetURLLabelsRequest) (*SetURLLabelsResponse, error)
+	SetURLRules(context.Context, *SetURLRulesRequest) (*SetURLRulesResponse, error)
+	SetURLVariants(context.Context, *SetURLVariantsRequest) (*SetURLVariantsResponse, error)
+	GetQRCode(context.Context, *QRCodeRequest) (*QRCodeResponse, error)
 	mustEmbedUnimplementedShortenerServiceServer()
 }
 
@@ -97,6 +188,27 @@ func (UnimplementedShortenerServiceServer) ExpandURL(context.Context, *URLExpand
 func (UnimplementedShortenerServiceServer) ListUserURLs(context.Context, *ListUserURLsRequest) (*UserURLsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUserURLs not implemented")
 }
+func (UnimplementedShortenerServiceServer) GetURLStats(context.Context, *URLStatsRequest) (*URLStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetURLStats not implemented")
+}
+func (UnimplementedShortenerServiceServer) UpdateURL(context.Context, *UpdateURLRequest) (*UpdateURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateURL not implemented")
+}
+func (UnimplementedShortenerServiceServer) RestoreURLs(context.Context, *RestoreURLsRequest) (*RestoreURLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) SetURLLabels(context.Context, *SetURLLabelsRequest) (*SetURLLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetURLLabels not implemented")
+}
+func (UnimplementedShortenerServiceServer) SetURLRules(context.Context, *SetURLRulesRequest) (*SetURLRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetURLRules not implemented")
+}
+func (UnimplementedShortenerServiceServer) SetURLVariants(context.Context, *SetURLVariantsRequest) (*SetURLVariantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetURLVariants not implemented")
+}
+func (UnimplementedShortenerServiceServer) GetQRCode(context.Context, *QRCodeRequest) (*QRCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQRCode not implemented")
+}
 func (UnimplementedShortenerServiceServer) mustEmbedUnimplementedShortenerServiceServer() {}
 func (UnimplementedShortenerServiceServer) testEmbeddedByValue()                          {}
 
@@ -172,6 +284,132 @@ func _ShortenerService_ListUserURLs_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_GetURLStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetURLStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetURLStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetURLStats(ctx, req.(*URLStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_UpdateURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).UpdateURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_UpdateURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).UpdateURL(ctx, req.(*UpdateURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_RestoreURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).RestoreURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_RestoreURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).RestoreURLs(ctx, req.(*RestoreURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_SetURLLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetURLLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).SetURLLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_SetURLLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).SetURLLabels(ctx, req.(*SetURLLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_SetURLRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetURLRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).SetURLRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_SetURLRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).SetURLRules(ctx, req.(*SetURLRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_SetURLVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetURLVariantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).SetURLVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_SetURLVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).SetURLVariants(ctx, req.(*SetURLVariantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_GetQRCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QRCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetQRCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetQRCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetQRCode(ctx, req.(*QRCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortenerService_ServiceDesc is the grpc.ServiceDesc for ShortenerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,6 +429,34 @@ var ShortenerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUserURLs",
 			Handler:    _ShortenerService_ListUserURLs_Handler,
 		},
+		{
+			MethodName: "GetURLStats",
+			Handler:    _ShortenerService_GetURLStats_Handler,
+		},
+		{
+			MethodName: "UpdateURL",
+			Handler:    _ShortenerService_UpdateURL_Handler,
+		},
+		{
+			MethodName: "RestoreURLs",
+			Handler:    _ShortenerService_RestoreURLs_Handler,
+		},
+		{
+			MethodName: "SetURLLabels",
+			Handler:    _ShortenerService_SetURLLabels_Handler,
+		},
+		{
+			MethodName: "SetURLRules",
+			Handler:    _ShortenerService_SetURLRules_Handler,
+		},
+		{
+			MethodName: "SetURLVariants",
+			Handler:    _ShortenerService_SetURLVariants_Handler,
+		},
+		{
+			MethodName: "GetQRCode",
+			Handler:    _ShortenerService_GetQRCode_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "shortener.proto",
@@ -0,0 +1,309 @@
+package qrcode
+
+// Штрафы за признаки, мешающие распознаванию, при выборе маски
+const (
+	penaltyRun     = 3  // пять и более модулей одного цвета подряд
+	penaltyBox     = 3  // квадрат 2×2 одного цвета
+	penaltyFinder  = 40 // последовательность, похожая на поисковый узор
+	penaltyBalance = 10 // каждые 5% отклонения доли тёмных модулей от половины
+)
+
+// set задаёт цвет модуля; вызывающий отвечает за координаты
+func (c *Code) set(x, y int, dark bool) {
+	c.modules[y*c.size+x] = dark
+}
+
+// setFunction задаёт цвет служебного модуля и исключает его из размещения данных и маски
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.set(x, y, dark)
+	c.function[y*c.size+x] = true
+}
+
+// drawFunctionPatterns рисует поисковые, синхронизирующие и выравнивающие узоры
+// и резервирует места служебной информации
+func (c *Code) drawFunctionPatterns() {
+	for i := range c.size {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(c.size-4, 3)
+	c.drawFinderPattern(3, c.size-4)
+
+	positions := c.alignmentPatternPositions()
+	last := len(positions) - 1
+	for i, y := range positions {
+		for j, x := range positions {
+			// Углы с поисковыми узорами пропускаются
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignmentPattern(x, y)
+		}
+	}
+
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+// drawFinderPattern рисует поисковый узор 7×7 с центром в (x, y) и светлой рамкой вокруг
+func (c *Code) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= c.size || yy < 0 || yy >= c.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern рисует выравнивающий узор 5×5 с центром в (x, y)
+func (c *Code) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// alignmentPatternPositions возвращает координаты центров выравнивающих узоров по одной оси
+func (c *Code) alignmentPatternPositions() []int {
+	if c.version == 1 {
+		return nil
+	}
+	numAlign := c.version/7 + 2
+	step := (c.version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+
+	result := make([]int, numAlign)
+	result[0] = 6
+	for i, pos := numAlign-1, c.size-7; i >= 1; i, pos = i-1, pos-step {
+		result[i] = pos
+	}
+	return result
+}
+
+// drawFormatBits рисует обе копии информации об уровне коррекции и маске
+func (c *Code) drawFormatBits(mask int) {
+	data := c.level.formatBits()<<3 | mask
+	rem := data
+	for range 10 {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	// Первая копия — вокруг левого верхнего поискового узора
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	// Вторая копия — у правого верхнего и левого нижнего узоров
+	for i := range 8 {
+		c.setFunction(c.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.size-8, true) // всегда тёмный модуль
+}
+
+// drawVersion рисует обе копии информации о версии; нужна начиная с версии 7
+func (c *Code) drawVersion() {
+	if c.version < 7 {
+		return
+	}
+
+	rem := c.version
+	for range 12 {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.version<<12 | rem
+
+	for i := range 18 {
+		a, b := c.size-11+i%3, i/3
+		c.setFunction(a, b, bit(bits, i))
+		c.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords размещает кодовые слова зигзагом парами столбцов справа налево,
+// обходя служебные модули
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		// Вертикальный синхронизирующий узор занимает столбец 6
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if upward {
+					y = c.size - 1 - vert
+				}
+				if !c.function[y*c.size+x] && i < len(data)*8 {
+					c.set(x, y, bit(int(data[i/8]), 7-i%8))
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask инвертирует модули данных по маске; повторное применение отменяет маску
+func (c *Code) applyMask(mask int) {
+	for y := range c.size {
+		for x := range c.size {
+			if !c.function[y*c.size+x] && maskBit(mask, x, y) {
+				c.modules[y*c.size+x] = !c.modules[y*c.size+x]
+			}
+		}
+	}
+}
+
+// applyBestMask выбирает маску с наименьшим штрафом и рисует информацию о формате
+func (c *Code) applyBestMask() {
+	best, bestPenalty := 0, int(^uint(0)>>1)
+	for mask := range 8 {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask)
+	}
+	c.applyMask(best)
+	c.drawFormatBits(best)
+}
+
+// maskBit сообщает, инвертируется ли модуль (x, y) маской
+func maskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// penalty оценивает, насколько код труден для распознавания
+func (c *Code) penalty() int {
+	result := 0
+	for y := range c.size {
+		result += c.linePenalty(func(i int) bool { return c.Dark(i, y) })
+	}
+	for x := range c.size {
+		result += c.linePenalty(func(i int) bool { return c.Dark(x, i) })
+	}
+
+	for y := range c.size - 1 {
+		for x := range c.size - 1 {
+			dark := c.Dark(x, y)
+			if dark == c.Dark(x+1, y) && dark == c.Dark(x, y+1) && dark == c.Dark(x+1, y+1) {
+				result += penaltyBox
+			}
+		}
+	}
+
+	dark := 0
+	for _, m := range c.modules {
+		if m {
+			dark++
+		}
+	}
+	total := c.size * c.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return result + k*penaltyBalance
+}
+
+// linePenalty считает штрафы за длинные серии и похожие на поисковый узор
+// последовательности в одной строке или столбце
+func (c *Code) linePenalty(dark func(i int) bool) int {
+	result := 0
+	runColor, runLen := false, 0
+	var history runHistory
+	for i := range c.size {
+		if dark(i) == runColor {
+			runLen++
+			if runLen == 5 {
+				result += penaltyRun
+			} else if runLen > 5 {
+				result++
+			}
+			continue
+		}
+		history.add(runLen, c.size)
+		if !runColor {
+			result += history.finderPatterns() * penaltyFinder
+		}
+		runColor, runLen = dark(i), 1
+	}
+
+	// Серия у края продолжается светлым полем вокруг кода
+	if runColor {
+		history.add(runLen, c.size)
+		runLen = 0
+	}
+	history.add(runLen+c.size, c.size)
+	return result + history.finderPatterns()*penaltyFinder
+}
+
+// runHistory — длины последних серий модулей, от последней к более ранним
+type runHistory [7]int
+
+// add добавляет длину завершившейся серии; первая серия продолжается полем перед кодом
+func (h *runHistory) add(runLen, size int) {
+	if h[0] == 0 {
+		runLen += size
+	}
+	copy(h[1:], h[:6])
+	h[0] = runLen
+}
+
+// finderPatterns считает последовательности 1:1:3:1:1 со светлым полем хотя бы с одной стороны
+func (h *runHistory) finderPatterns() int {
+	n := h[1]
+	core := n > 0 && h[2] == n && h[3] == n*3 && h[4] == n && h[5] == n
+	count := 0
+	if core && h[0] >= n*4 && h[6] >= n {
+		count++
+	}
+	if core && h[6] >= n*4 && h[0] >= n {
+		count++
+	}
+	return count
+}
+
+// bit возвращает i-й бит x
+func bit(x, i int) bool {
+	return (x>>i)&1 != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
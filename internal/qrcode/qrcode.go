@@ -0,0 +1,284 @@
+// Package qrcode кодирует данные в QR-код (ISO/IEC 18004) без внешних зависимостей.
+// Поддерживается байтовый режим кодирования, версии 1–40 и все четыре уровня коррекции ошибок;
+// версия выбирается минимальной, вмещающей данные, маска — с наименьшим штрафом.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Level — уровень коррекции ошибок: доля кода, которую можно восстановить при повреждении.
+type Level int
+
+const (
+	// LevelL восстанавливает около 7% кода.
+	LevelL Level = iota
+	// LevelM восстанавливает около 15% кода.
+	LevelM
+	// LevelQ восстанавливает около 25% кода.
+	LevelQ
+	// LevelH восстанавливает около 30% кода.
+	LevelH
+)
+
+const (
+	minVersion = 1
+	maxVersion = 40
+)
+
+// ErrDataTooLong возвращается, когда данные не помещаются в QR-код версии 40 с заданным уровнем.
+var ErrDataTooLong = errors.New("qrcode: data too long")
+
+// ParseLevel разбирает уровень коррекции ошибок по букве L, M, Q или H без учёта регистра.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(s) {
+	case "L":
+		return LevelL, nil
+	case "M":
+		return LevelM, nil
+	case "Q":
+		return LevelQ, nil
+	case "H":
+		return LevelH, nil
+	default:
+		return 0, fmt.Errorf("qrcode: unknown error correction level %q", s)
+	}
+}
+
+// String возвращает букву уровня коррекции ошибок.
+func (l Level) String() string {
+	return [...]string{"L", "M", "Q", "H"}[l]
+}
+
+// formatBits — код уровня в служебной информации о формате
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+// eccCodewordsPerBlock — число кодовых слов коррекции в каждом блоке по уровню и версии
+var eccCodewordsPerBlock = [4][maxVersion + 1]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// numErrorCorrectionBlocks — число блоков, на которые делятся данные, по уровню и версии
+var numErrorCorrectionBlocks = [4][maxVersion + 1]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// Code — готовый QR-код: квадратная матрица тёмных и светлых модулей без поля.
+type Code struct {
+	version  int
+	level    Level
+	size     int
+	modules  []bool // тёмные модули построчно
+	function []bool // служебные модули, не затрагиваемые маской
+}
+
+// Encode кодирует data в QR-код минимальной подходящей версии с уровнем коррекции level.
+func Encode(data []byte, level Level) (*Code, error) {
+	if level < LevelL || level > LevelH {
+		return nil, fmt.Errorf("qrcode: invalid error correction level %d", level)
+	}
+
+	version := 0
+	for v := minVersion; v <= maxVersion; v++ {
+		if dataBitsLen(v, len(data)) <= numDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%w: %d bytes at level %s", ErrDataTooLong, len(data), level)
+	}
+
+	c := &Code{version: version, level: level, size: version*4 + 17}
+	c.modules = make([]bool, c.size*c.size)
+	c.function = make([]bool, c.size*c.size)
+
+	c.drawFunctionPatterns()
+	c.drawCodewords(c.addECCAndInterleave(encodeData(data, version, level)))
+	c.applyBestMask()
+	return c, nil
+}
+
+// Size возвращает ширину кода в модулях без поля.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Version возвращает версию кода от 1 до 40.
+func (c *Code) Version() int {
+	return c.version
+}
+
+// Dark сообщает, тёмный ли модуль в столбце x и строке y; вне кода модули светлые.
+func (c *Code) Dark(x, y int) bool {
+	return x >= 0 && x < c.size && y >= 0 && y < c.size && c.modules[y*c.size+x]
+}
+
+// charCountBits — длина поля числа байтов в байтовом режиме
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// dataBitsLen — число битов сегмента данных длиной n байт: режим, счётчик и данные
+func dataBitsLen(version, n int) int {
+	if n >= 1<<charCountBits(version) {
+		return int(^uint(0) >> 1)
+	}
+	return 4 + charCountBits(version) + n*8
+}
+
+// numRawDataModules — число модулей под данные и коррекцию после служебных шаблонов
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// numDataCodewords — число кодовых слов данных для версии и уровня
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 -
+		eccCodewordsPerBlock[level][version]*numErrorCorrectionBlocks[level][version]
+}
+
+// encodeData формирует кодовые слова данных: байтовый сегмент, терминатор и байты заполнения
+func encodeData(data []byte, version int, level Level) []byte {
+	capacity := numDataCodewords(version, level) * 8
+
+	var bb bitBuffer
+	bb.append(0x4, 4) // байтовый режим
+	bb.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	bb.append(0, min(4, capacity-bb.len()))
+	bb.append(0, (8-bb.len()%8)%8)
+	for pad := 0xEC; bb.len() < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+	return bb.bytes()
+}
+
+// bitBuffer — последовательность битов, дописываемых старшим битом вперёд
+type bitBuffer []bool
+
+// append дописывает n младших битов value
+func (bb *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*bb = append(*bb, (value>>i)&1 != 0)
+	}
+}
+
+func (bb *bitBuffer) len() int {
+	return len(*bb)
+}
+
+// bytes упаковывает биты в байты; длина буфера кратна 8
+func (bb *bitBuffer) bytes() []byte {
+	result := make([]byte, len(*bb)/8)
+	for i, bit := range *bb {
+		if bit {
+			result[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return result
+}
+
+// addECCAndInterleave делит данные на блоки, дописывает к каждому коды Рида — Соломона
+// и перемежает кодовые слова блоков
+func (c *Code) addECCAndInterleave(data []byte) []byte {
+	numBlocks := numErrorCorrectionBlocks[c.level][c.version]
+	blockECCLen := eccCodewordsPerBlock[c.level][c.version]
+	rawCodewords := numRawDataModules(c.version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(blockECCLen)
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		datLen := shortBlockLen - blockECCLen
+		if i >= numShortBlocks {
+			datLen++
+		}
+		dat := data[k : k+datLen]
+		k += datLen
+
+		// Короткие блоки дополняются одним пустым словом, чтобы все блоки были одной длины
+		block := make([]byte, shortBlockLen+1)
+		copy(block, dat)
+		copy(block[len(block)-blockECCLen:], reedSolomonRemainder(dat, divisor))
+		blocks[i] = block
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i <= shortBlockLen; i++ {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// reedSolomonDivisor возвращает порождающий многочлен степени degree
+// без старшего коэффициента, от старших степеней к младшим
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder возвращает кодовые слова коррекции для данных
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply умножает элементы поля GF(2^8) по модулю x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
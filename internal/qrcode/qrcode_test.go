@@ -0,0 +1,264 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// Кодовые слова «HELLO WORLD» версии 1-M и их коды коррекции из примера стандарта
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	assert.Equal(t, want, reedSolomonRemainder(data, reedSolomonDivisor(10)))
+}
+
+func TestAlignmentPatternPositions(t *testing.T) {
+	tests := []struct {
+		version int
+		want    []int
+	}{
+		{version: 1, want: nil},
+		{version: 2, want: []int{6, 18}},
+		{version: 7, want: []int{6, 22, 38}},
+		{version: 32, want: []int{6, 34, 60, 86, 112, 138}},
+		{version: 40, want: []int{6, 30, 58, 86, 114, 142, 170}},
+	}
+
+	for _, tt := range tests {
+		c := &Code{version: tt.version, size: tt.version*4 + 17}
+		assert.Equal(t, tt.want, c.alignmentPatternPositions(), "version %d", tt.version)
+	}
+}
+
+func TestNumDataCodewords(t *testing.T) {
+	assert.Equal(t, 19, numDataCodewords(1, LevelL))
+	assert.Equal(t, 9, numDataCodewords(1, LevelH))
+	assert.Equal(t, 2956, numDataCodewords(40, LevelL))
+	assert.Equal(t, 1276, numDataCodewords(40, LevelH))
+}
+
+func TestEncode_FormatAndVersionBits(t *testing.T) {
+	t.Run("format bits", func(t *testing.T) {
+		c := &Code{version: 1, level: LevelL, size: 21}
+		c.modules = make([]bool, c.size*c.size)
+		c.function = make([]bool, c.size*c.size)
+		c.drawFormatBits(0)
+
+		// L с маской 0 — 111011111000100 по таблице стандарта
+		assert.Equal(t, 0x77C4, readFormatBits(c))
+	})
+
+	t.Run("version bits", func(t *testing.T) {
+		code, err := Encode(bytes.Repeat([]byte("a"), 110), LevelM)
+		require.NoError(t, err)
+		require.Equal(t, 7, code.Version())
+
+		// Версия 7 — 000111110010010100 по таблице стандарта
+		got := 0
+		for i := 17; i >= 0; i-- {
+			got <<= 1
+			if code.Dark(code.Size()-11+i%3, i/3) {
+				got |= 1
+			}
+		}
+		assert.Equal(t, 0x07C94, got)
+	})
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		level   Level
+		version int
+	}{
+		{name: "short URL", data: "http://localhost:8080/abc123", level: LevelM, version: 3},
+		{name: "high correction", data: "https://sho.rt/x", level: LevelH, version: 3},
+		{name: "empty", data: "", level: LevelL, version: 1},
+		{name: "multi-block with version info", data: strings.Repeat("https://example.com/", 20), level: LevelQ, version: 19},
+		{name: "largest version", data: strings.Repeat("z", 2900), level: LevelL, version: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode([]byte(tt.data), tt.level)
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, code.Version())
+			assert.Equal(t, tt.version*4+17, code.Size())
+
+			assert.Equal(t, tt.data, string(decode(t, code)))
+		})
+	}
+}
+
+func TestEncode_TooLong(t *testing.T) {
+	_, err := Encode(bytes.Repeat([]byte("z"), 1300), LevelH)
+	assert.ErrorIs(t, err, ErrDataTooLong)
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("q")
+	require.NoError(t, err)
+	assert.Equal(t, LevelQ, level)
+
+	_, err = ParseLevel("X")
+	assert.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	code, err := Encode([]byte("http://localhost:8080/abc"), LevelM)
+	require.NoError(t, err)
+
+	t.Run("PNG", func(t *testing.T) {
+		data, err := code.PNG(3, 4)
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		side := (code.Size() + 8) * 3
+		assert.Equal(t, side, img.Bounds().Dx())
+
+		// Поле светлое, левый верхний угол поискового узора тёмный
+		r, _, _, _ := img.At(0, 0).RGBA()
+		assert.Equal(t, uint32(0xFFFF), r)
+		r, _, _, _ = img.At(4*3, 4*3).RGBA()
+		assert.Equal(t, uint32(0), r)
+	})
+
+	t.Run("SVG", func(t *testing.T) {
+		data, err := code.SVG(200, 2)
+		require.NoError(t, err)
+
+		svg := string(data)
+		assert.Contains(t, svg, `width="200" height="200"`)
+		assert.Contains(t, svg, `viewBox="0 0 29 29"`)
+		assert.Contains(t, svg, "M2,2h1v1h-1z")
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, err := code.PNG(0, 4)
+		assert.Error(t, err)
+		_, err = code.SVG(100, -1)
+		assert.Error(t, err)
+	})
+}
+
+// readFormatBits читает вторую копию информации о формате
+func readFormatBits(c *Code) int {
+	bits := 0
+	for i := 14; i >= 0; i-- {
+		bits <<= 1
+		var dark bool
+		if i < 8 {
+			dark = c.Dark(c.size-1-i, 8)
+		} else {
+			dark = c.Dark(8, c.size-15+i)
+		}
+		if dark {
+			bits |= 1
+		}
+	}
+	return bits
+}
+
+// decode читает данные кода: снимает маску по информации о формате, собирает кодовые слова,
+// проверяет синдромы Рида — Соломона каждого блока и разбирает байтовый сегмент
+func decode(t *testing.T, c *Code) []byte {
+	t.Helper()
+
+	format := readFormatBits(c) ^ 0x5412
+	require.Equal(t, c.level.formatBits(), format>>13)
+	mask := (format >> 10) & 7
+
+	// Служебные модули той же версии определяются заново по пустому коду
+	layout := &Code{version: c.version, level: c.level, size: c.size}
+	layout.modules = make([]bool, c.size*c.size)
+	layout.function = make([]bool, c.size*c.size)
+	layout.drawFunctionPatterns()
+
+	var bits []bool
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range c.size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.size - 1 - vert
+				}
+				if !layout.function[y*c.size+x] {
+					bits = append(bits, c.Dark(x, y) != maskBit(mask, x, y))
+				}
+			}
+		}
+	}
+	raw := make([]byte, numRawDataModules(c.version)/8)
+	for i := range raw {
+		for j := range 8 {
+			if bits[i*8+j] {
+				raw[i] |= 0x80 >> j
+			}
+		}
+	}
+
+	numBlocks := numErrorCorrectionBlocks[c.level][c.version]
+	eccLen := eccCodewordsPerBlock[c.level][c.version]
+	numShort := numBlocks - len(raw)%numBlocks
+	shortLen := len(raw) / numBlocks
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i <= shortLen; i++ {
+		for j := range blocks {
+			if i == shortLen-eccLen && j < numShort {
+				continue
+			}
+			blocks[j] = append(blocks[j], raw[k])
+			k++
+		}
+	}
+
+	var data []byte
+	for _, block := range blocks {
+		// Все синдромы верного кодового слова равны нулю: многочлен делится на (x - α^i)
+		alpha := byte(1)
+		for range eccLen {
+			var syndrome byte
+			for _, b := range block {
+				syndrome = gfMultiply(syndrome, alpha) ^ b
+			}
+			require.Zero(t, syndrome)
+			alpha = gfMultiply(alpha, 2)
+		}
+		data = append(data, block[:len(block)-eccLen]...)
+	}
+
+	var stream bitBuffer
+	for _, b := range data {
+		stream.append(int(b), 8)
+	}
+	read := func(n int) int {
+		v := 0
+		for _, b := range stream[:n] {
+			v <<= 1
+			if b {
+				v |= 1
+			}
+		}
+		stream = stream[n:]
+		return v
+	}
+	require.Equal(t, 0x4, read(4))
+	n := read(charCountBits(c.version))
+	result := make([]byte, n)
+	for i := range result {
+		result[i] = byte(read(8))
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// PNG рисует код в монохромный PNG: каждый модуль — квадрат scale×scale пикселей,
+// вокруг кода светлое поле шириной margin модулей.
+func (c *Code) PNG(scale, margin int) ([]byte, error) {
+	if scale < 1 || margin < 0 {
+		return nil, fmt.Errorf("qrcode: invalid scale %d or margin %d", scale, margin)
+	}
+
+	side := (c.size + 2*margin) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for y := range c.size {
+		for x := range c.size {
+			if !c.Dark(x, y) {
+				continue
+			}
+			left, top := (x+margin)*scale, (y+margin)*scale
+			for py := top; py < top+scale; py++ {
+				row := img.Pix[py*img.Stride:]
+				for px := left; px < left+scale; px++ {
+					row[px] = 1
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("qrcode: encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG рисует код в SVG шириной и высотой size пикселей со светлым полем шириной margin модулей.
+// Координаты задаются в модулях, поэтому изображение масштабируется без потери чёткости.
+func (c *Code) SVG(size, margin int) ([]byte, error) {
+	if size < 1 || margin < 0 {
+		return nil, fmt.Errorf("qrcode: invalid size %d or margin %d", size, margin)
+	}
+
+	side := c.size + 2*margin
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%[1]d" height="%[1]d" viewBox="0 0 %[2]d %[2]d" shape-rendering="crispEdges">
+<rect width="%[2]d" height="%[2]d" fill="#FFFFFF"/>
+<path fill="#000000" d="`, size, side)
+	for y := range c.size {
+		for x := range c.size {
+			if c.Dark(x, y) {
+				fmt.Fprintf(&buf, "M%d,%dh1v1h-1z", x+margin, y+margin)
+			}
+		}
+	}
+	buf.WriteString("\"/>\n</svg>\n")
+	return buf.Bytes(), nil
+}
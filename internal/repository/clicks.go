@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// RecordClicks сохраняет пачку переходов по ссылкам.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) RecordClicks(clicks []model.Click) error {
+	if err := r.underlying.RecordClicks(clicks); err != nil {
+		return fmt.Errorf("failed to record clicks: %w", err)
+	}
+
+	return nil
+}
+
+// GetClickStats возвращает статистику переходов по ссылке за период from—to.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) GetClickStats(code model.Code, from, to time.Time) (model.URLStats, error) {
+	stats, err := r.underlying.GetClickStats(code, from, to)
+	if err != nil {
+		return model.URLStats{}, fmt.Errorf("failed to get click stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// AddClickCounts добавляет приращения к счётчикам переходов.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) AddClickCounts(counts []model.ClickCount) error {
+	if err := r.underlying.AddClickCounts(counts); err != nil {
+		return fmt.Errorf("failed to add click counts: %w", err)
+	}
+
+	return nil
+}
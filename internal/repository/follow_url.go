@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// FollowURL возвращает адрес и параметры редиректа для перехода по короткому коду,
+// расходуя один переход у ссылки с лимитом переходов.
+// unlocked подтверждает, что пароль защищённой ссылки уже проверен.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) FollowURL(code model.Code, unlocked bool) (model.LinkTarget, error) {
+	target, err := r.underlying.Follow(code, unlocked)
+
+	if err != nil {
+		return model.LinkTarget{}, fmt.Errorf("failed to follow URL: %w", err)
+	}
+
+	return target, nil
+}
+
+// PeekURL возвращает то же, что FollowURL, не расходуя переход.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) PeekURL(code model.Code, unlocked bool) (model.LinkTarget, error) {
+	target, err := r.underlying.Peek(code, unlocked)
+
+	if err != nil {
+		return model.LinkTarget{}, fmt.Errorf("failed to peek URL: %w", err)
+	}
+
+	return target, nil
+}
+
+// GetURLPasswordHash возвращает хеш пароля ссылки; пустая строка означает ссылку без пароля.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) GetURLPasswordHash(code model.Code) (string, error) {
+	hash, err := r.underlying.PasswordHash(code)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL password hash: %w", err)
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// InspectURL возвращает сведения о ссылке для предпросмотра, не расходуя переход.
+// unlocked подтверждает, что пароль защищённой ссылки уже проверен.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) InspectURL(code model.Code, unlocked bool) (model.LinkInfo, error) {
+	info, err := r.underlying.Inspect(code, unlocked)
+
+	if err != nil {
+		return model.LinkInfo{}, fmt.Errorf("failed to inspect URL: %w", err)
+	}
+
+	return info, nil
+}
+
+// SetURLUnsafe помечает ссылку небезопасной или снимает пометку.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) SetURLUnsafe(code model.Code, unsafe bool) error {
+	if err := r.underlying.SetURLUnsafe(code, unsafe); err != nil {
+		return fmt.Errorf("failed to set unsafe flag: %w", err)
+	}
+	return nil
+}
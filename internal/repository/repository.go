@@ -5,6 +5,7 @@ package repository
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/model"
 )
@@ -14,23 +15,69 @@ import (
 type Store interface {
 	// Read возвращает оригинальный URL по короткому коду.
 	Read(key model.Code) (model.URL, error)
+	// Follow возвращает адрес и параметры редиректа для перехода и атомарно расходует один переход
+	// у ссылки с лимитом переходов. Защищённая паролем ссылка открывается только с unlocked.
+	Follow(key model.Code, unlocked bool) (model.LinkTarget, error)
+	// Peek возвращает то же, что Follow, не расходуя переход.
+	Peek(key model.Code, unlocked bool) (model.LinkTarget, error)
+	// Inspect возвращает сведения о ссылке для предпросмотра, не расходуя переход.
+	Inspect(key model.Code, unlocked bool) (model.LinkInfo, error)
+	// SetURLUnsafe помечает ссылку небезопасной или снимает пометку.
+	SetURLUnsafe(key model.Code, unsafe bool) error
+	// PasswordHash возвращает хеш пароля ссылки; пустая строка — ссылка без пароля.
+	PasswordHash(key model.Code) (string, error)
 	// Write сохраняет пару код→URL с привязкой к пользователю.
 	Write(key model.Code, value model.URL, userID string) error
-	// WriteBatch сохраняет несколько пар код→URL для одного пользователя.
-	WriteBatch(urls map[model.Code]model.URL, userID string) error
+	// WriteBatch сохраняет несколько пар код→URL для одного пользователя с общими параметрами.
+	WriteBatch(urls map[model.Code]model.URL, userID string, opts model.LinkOptions) error
 	// CreateOrGetURL атомарно создаёт запись или возвращает код уже существующего URL.
 	// Второй возвращаемый параметр true означает, что запись была создана.
-	CreateOrGetURL(code model.Code, url model.URL, userID string) (model.Code, bool, error)
+	CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error)
+	// UpdateURL атомарно меняет адрес ссылки владельца и возвращает прежний адрес.
+	UpdateURL(code model.Code, url model.URL, userID string) (model.URL, error)
+	// GetURLVersions возвращает прежние адреса ссылки от новых к старым.
+	GetURLVersions(code model.Code) ([]model.URLVersion, error)
+	// PruneURLVersions удаляет версии, изменённые раньше before, кроме последней версии каждой ссылки.
+	PruneURLVersions(before time.Time) (int, error)
 	// IsCodeUnique возвращает true, если код ещё не занят.
 	IsCodeUnique(code model.Code) bool
-	// GetURLsByUserID возвращает все короткие ссылки пользователя с полными URL.
-	GetURLsByUserID(userID string, baseURL string) ([]model.UserURLResponse, error)
+	// GetURLsByUserID возвращает страницу коротких ссылок пользователя с полными URL.
+	GetURLsByUserID(userID string, baseURL string, query model.URLQuery) (model.URLPage, error)
+	// SetURLLabels заменяет теги и папку ссылки владельца.
+	SetURLLabels(code model.Code, labels model.LinkLabels, userID string) error
+	// SetURLRules заменяет правила условного редиректа ссылки владельца.
+	SetURLRules(code model.Code, rules []model.RedirectRule, userID string) error
+	// SetURLVariants заменяет варианты сплит-ссылки владельца.
+	SetURLVariants(code model.Code, variants []model.SplitVariant, userID string) error
 	// DeleteURLsBatch помечает несколько кодов как удалённые для данного пользователя.
 	DeleteURLsBatch(codes []model.Code, userID string) error
+	// DeleteURLsByFilter помечает удалёнными ссылки пользователя, подходящие под фильтр, и возвращает их коды.
+	DeleteURLsByFilter(userID string, filter model.URLFilter) ([]model.Code, error)
+	// GetDeletedURLsByUserID возвращает удалённые ссылки пользователя, удалённые не раньше deletedAfter
+	// и ещё не истёкшие.
+	GetDeletedURLsByUserID(userID string, baseURL string, deletedAfter time.Time) ([]model.UserURLResponse, error)
+	// RestoreURLsBatch снимает пометку удаления со ссылок пользователя, удалённых не раньше deletedAfter,
+	// и возвращает восстановленные коды.
+	RestoreURLsBatch(codes []model.Code, userID string, deletedAfter time.Time) ([]model.Code, error)
 	// IsURLOwnedByUser проверяет, что код принадлежит указанному пользователю.
 	IsURLOwnedByUser(code model.Code, userID string) bool
 	// GetStats возвращает количество активных URL и уникальных пользователей.
 	GetStats() (model.Stats, error)
+	// RecordClicks сохраняет пачку переходов по ссылкам.
+	RecordClicks(clicks []model.Click) error
+	// GetClickStats возвращает статистику переходов по ссылке за период from—to.
+	GetClickStats(code model.Code, from, to time.Time) (model.URLStats, error)
+	// AddClickCounts добавляет приращения к счётчикам переходов.
+	AddClickCounts(counts []model.ClickCount) error
+	// MarkExpiredURLs помечает удалёнными ссылки, срок жизни которых истёк к моменту now.
+	MarkExpiredURLs(now time.Time) ([]model.Code, error)
+	// PurgeDeletedURLs окончательно удаляет ссылки, мягко удалённые раньше deletedBefore,
+	// и возвращает освободившиеся коды.
+	PurgeDeletedURLs(deletedBefore time.Time) ([]model.Code, error)
+	// ReleaseCodes помещает освободившиеся коды в пул переиспользования до availableAt.
+	ReleaseCodes(codes []model.Code, availableAt time.Time) error
+	// AcquireRecycledCode извлекает из пула код, карантин которого истёк к моменту now.
+	AcquireRecycledCode(now time.Time) (model.Code, bool, error)
 }
 
 // Repository адаптирует Store к интерфейсу, ожидаемому usecase-слоем.
@@ -59,8 +106,8 @@ func (r Repository) Write(code model.Code, url model.URL, userID string) error {
 }
 
 // CreateOrGetURL атомарно создаёт запись или возвращает код существующего URL.
-func (r Repository) CreateOrGetURL(code model.Code, url model.URL, userID string) (model.Code, bool, error) {
-	finalCode, created, err := r.underlying.CreateOrGetURL(code, url, userID)
+func (r Repository) CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error) {
+	finalCode, created, err := r.underlying.CreateOrGetURL(code, url, userID, opts)
 	if err != nil {
 		return "", false, fmt.Errorf("failed to create or get URL: %w", err)
 	}
@@ -69,21 +116,45 @@ func (r Repository) CreateOrGetURL(code model.Code, url model.URL, userID string
 }
 
 // CreateURLsBatch сохраняет несколько пар код→URL для одного пользователя.
-func (r Repository) CreateURLsBatch(urls map[model.Code]model.URL, userID string) error {
-	err := r.underlying.WriteBatch(urls, userID)
+func (r Repository) CreateURLsBatch(urls map[model.Code]model.URL, userID string, opts model.LinkOptions) error {
+	err := r.underlying.WriteBatch(urls, userID, opts)
 	if err != nil {
 		return fmt.Errorf("failed to create URLs batch: %w", err)
 	}
 	return nil
 }
 
-// GetURLsByUserID возвращает все короткие ссылки пользователя с полными URL.
-func (r Repository) GetURLsByUserID(userID string, baseURL string) ([]model.UserURLResponse, error) {
-	urls, err := r.underlying.GetURLsByUserID(userID, baseURL)
+// GetURLsByUserID возвращает страницу коротких ссылок пользователя с полными URL.
+func (r Repository) GetURLsByUserID(userID string, baseURL string, query model.URLQuery) (model.URLPage, error) {
+	page, err := r.underlying.GetURLsByUserID(userID, baseURL, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get URLs by user ID: %w", err)
+		return model.URLPage{}, fmt.Errorf("failed to get URLs by user ID: %w", err)
 	}
-	return urls, nil
+	return page, nil
+}
+
+// SetURLLabels заменяет теги и папку ссылки владельца.
+func (r Repository) SetURLLabels(code model.Code, labels model.LinkLabels, userID string) error {
+	if err := r.underlying.SetURLLabels(code, labels, userID); err != nil {
+		return fmt.Errorf("failed to set URL labels: %w", err)
+	}
+	return nil
+}
+
+// SetURLRules заменяет правила условного редиректа ссылки владельца.
+func (r Repository) SetURLRules(code model.Code, rules []model.RedirectRule, userID string) error {
+	if err := r.underlying.SetURLRules(code, rules, userID); err != nil {
+		return fmt.Errorf("failed to set URL rules: %w", err)
+	}
+	return nil
+}
+
+// SetURLVariants заменяет варианты сплит-ссылки владельца.
+func (r Repository) SetURLVariants(code model.Code, variants []model.SplitVariant, userID string) error {
+	if err := r.underlying.SetURLVariants(code, variants, userID); err != nil {
+		return fmt.Errorf("failed to set URL variants: %w", err)
+	}
+	return nil
 }
 
 // DeleteURLsBatch помечает несколько URL как удалённые для данного пользователя.
@@ -95,6 +166,33 @@ func (r Repository) DeleteURLsBatch(codes []model.Code, userID string) error {
 	return nil
 }
 
+// DeleteURLsByFilter помечает удалёнными ссылки пользователя, подходящие под фильтр, и возвращает их коды.
+func (r Repository) DeleteURLsByFilter(userID string, filter model.URLFilter) ([]model.Code, error) {
+	deleted, err := r.underlying.DeleteURLsByFilter(userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete URLs by filter: %w", err)
+	}
+	return deleted, nil
+}
+
+// GetDeletedURLsByUserID возвращает удалённые ссылки пользователя, которые ещё можно восстановить.
+func (r Repository) GetDeletedURLsByUserID(userID string, baseURL string, deletedAfter time.Time) ([]model.UserURLResponse, error) {
+	urls, err := r.underlying.GetDeletedURLsByUserID(userID, baseURL, deletedAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted URLs by user ID: %w", err)
+	}
+	return urls, nil
+}
+
+// RestoreURLsBatch восстанавливает удалённые ссылки пользователя и возвращает восстановленные коды.
+func (r Repository) RestoreURLsBatch(codes []model.Code, userID string, deletedAfter time.Time) ([]model.Code, error) {
+	restored, err := r.underlying.RestoreURLsBatch(codes, userID, deletedAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore URLs batch: %w", err)
+	}
+	return restored, nil
+}
+
 // IsURLOwnedByUser проверяет, что код принадлежит указанному пользователю.
 func (r Repository) IsURLOwnedByUser(code model.Code, userID string) bool {
 	return r.underlying.IsURLOwnedByUser(code, userID)
@@ -108,3 +206,39 @@ func (r Repository) GetStats() (model.Stats, error) {
 	}
 	return stats, nil
 }
+
+// MarkExpiredURLs помечает удалёнными ссылки с истёкшим сроком жизни.
+func (r Repository) MarkExpiredURLs(now time.Time) ([]model.Code, error) {
+	codes, err := r.underlying.MarkExpiredURLs(now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark expired URLs: %w", err)
+	}
+	return codes, nil
+}
+
+// PurgeDeletedURLs окончательно удаляет ссылки, мягко удалённые раньше deletedBefore.
+func (r Repository) PurgeDeletedURLs(deletedBefore time.Time) ([]model.Code, error) {
+	codes, err := r.underlying.PurgeDeletedURLs(deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted URLs: %w", err)
+	}
+	return codes, nil
+}
+
+// ReleaseCodes помещает освободившиеся коды в пул переиспользования.
+func (r Repository) ReleaseCodes(codes []model.Code, availableAt time.Time) error {
+	err := r.underlying.ReleaseCodes(codes, availableAt)
+	if err != nil {
+		return fmt.Errorf("failed to release codes: %w", err)
+	}
+	return nil
+}
+
+// AcquireRecycledCode извлекает из пула код, доступный для повторной выдачи.
+func (r Repository) AcquireRecycledCode(now time.Time) (model.Code, bool, error) {
+	code, ok, err := r.underlying.AcquireRecycledCode(now)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to acquire recycled code: %w", err)
+	}
+	return code, ok, nil
+}
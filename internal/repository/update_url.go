@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// UpdateURL меняет адрес ссылки владельца и возвращает прежний адрес.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) UpdateURL(code model.Code, url model.URL, userID string) (model.URL, error) {
+	previous, err := r.underlying.UpdateURL(code, url, userID)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to update URL: %w", err)
+	}
+
+	return previous, nil
+}
+
+// GetURLVersions возвращает прежние адреса ссылки от новых к старым.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) GetURLVersions(code model.Code) ([]model.URLVersion, error) {
+	versions, err := r.underlying.GetURLVersions(code)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get URL versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// PruneURLVersions удаляет устаревшие версии адресов ссылок и возвращает их количество.
+// Оборачивает ошибку хранилища с контекстом.
+func (r Repository) PruneURLVersions(before time.Time) (int, error) {
+	pruned, err := r.underlying.PruneURLVersions(before)
+
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune URL versions: %w", err)
+	}
+
+	return pruned, nil
+}
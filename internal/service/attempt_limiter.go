@@ -0,0 +1,106 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// AttemptLimiter ограничивает число неудачных попыток для ключа в фиксированном окне.
+// Окно открывается первой неудачей; после maxFailures неудач ключ блокируется
+// до конца окна. Успешная попытка сбрасывает счётчик.
+// Безопасен для конкурентного использования.
+type AttemptLimiter struct {
+	mu          sync.Mutex
+	maxFailures int
+	window      time.Duration
+	attempts    map[string]attemptWindow
+}
+
+// attemptWindow — неудачи ключа в текущем окне
+type attemptWindow struct {
+	failures int
+	start    time.Time
+}
+
+// NewAttemptLimiter создаёт ограничитель, допускающий maxFailures неудач за window
+func NewAttemptLimiter(maxFailures int, window time.Duration) *AttemptLimiter {
+	return &AttemptLimiter{
+		maxFailures: maxFailures,
+		window:      window,
+		attempts:    make(map[string]attemptWindow),
+	}
+}
+
+// Allow сообщает, разрешена ли попытка для ключа в момент now
+func (l *AttemptLimiter) Allow(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.attempts[key]
+	if !ok {
+		return true
+	}
+	if now.Sub(w.start) >= l.window {
+		delete(l.attempts, key)
+		return true
+	}
+	return w.failures < l.maxFailures
+}
+
+// RecordFailure учитывает неудачную попытку для ключа в момент now
+func (l *AttemptLimiter) RecordFailure(key string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.attempts[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = attemptWindow{start: now}
+	}
+	w.failures++
+	l.attempts[key] = w
+}
+
+// Reserve атомарно проверяет лимит и учитывает попытку для ключа в момент now.
+// Попытка считается неудачной ещё до проверки, поэтому параллельные попытки
+// не превышают лимит, пока идёт проверка. Возвращает false, если лимит исчерпан.
+// Успешную попытку завершает Reset, а несостоявшуюся — Release.
+func (l *AttemptLimiter) Reserve(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.attempts[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = attemptWindow{start: now}
+	}
+	if w.failures >= l.maxFailures {
+		return false
+	}
+	w.failures++
+	l.attempts[key] = w
+	return true
+}
+
+// Release возвращает попытку, учтённую Reserve, если проверка не состоялась
+func (l *AttemptLimiter) Release(key string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.attempts[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		return
+	}
+	w.failures--
+	if w.failures <= 0 {
+		delete(l.attempts, key)
+		return
+	}
+	l.attempts[key] = w
+}
+
+// Reset сбрасывает неудачи ключа после успешной попытки
+func (l *AttemptLimiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, key)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAttemptLimiter проверяет блокировку ключа после неудач и её снятие
+func TestAttemptLimiter(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("blocks after max failures within window", func(t *testing.T) {
+		limiter := NewAttemptLimiter(3, time.Minute)
+		for range 3 {
+			assert.True(t, limiter.Allow("abc", start))
+			limiter.RecordFailure("abc", start)
+		}
+
+		assert.False(t, limiter.Allow("abc", start.Add(30*time.Second)))
+		assert.True(t, limiter.Allow("other", start), "keys are throttled independently")
+	})
+
+	t.Run("window expiry unblocks key", func(t *testing.T) {
+		limiter := NewAttemptLimiter(1, time.Minute)
+		limiter.RecordFailure("abc", start)
+		assert.False(t, limiter.Allow("abc", start))
+
+		assert.True(t, limiter.Allow("abc", start.Add(time.Minute)))
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		limiter := NewAttemptLimiter(2, time.Minute)
+		limiter.RecordFailure("abc", start)
+		limiter.Reset("abc")
+		limiter.RecordFailure("abc", start)
+
+		assert.True(t, limiter.Allow("abc", start))
+	})
+}
+
+// TestAttemptLimiter_Reserve проверяет учёт попыток до их проверки
+func TestAttemptLimiter_Reserve(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("concurrent reservations do not exceed limit", func(t *testing.T) {
+		limiter := NewAttemptLimiter(3, time.Minute)
+
+		var wg sync.WaitGroup
+		var reserved atomic.Int32
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if limiter.Reserve("abc", start) {
+					reserved.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(3), reserved.Load())
+	})
+
+	t.Run("released attempt is not counted", func(t *testing.T) {
+		limiter := NewAttemptLimiter(1, time.Minute)
+		assert.True(t, limiter.Reserve("abc", start))
+		limiter.Release("abc", start)
+
+		assert.True(t, limiter.Reserve("abc", start))
+		assert.False(t, limiter.Reserve("abc", start))
+		assert.True(t, limiter.Reserve("abc", start.Add(time.Minute)), "window expiry unblocks key")
+	})
+}
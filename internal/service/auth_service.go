@@ -61,6 +61,71 @@ func (a *AuthService) ValidateJWT(tokenString string) (string, error) {
 	return "", fmt.Errorf("invalid token")
 }
 
+// linkAccessClaim — claim токена доступа к защищённой ссылке.
+// Токен пользователя его не содержит, поэтому не может быть использован вместо токена доступа.
+const linkAccessClaim = "link_code"
+
+// previewConfirmClaim — claim токена подтверждения перехода по небезопасной ссылке.
+// Отличается от linkAccessClaim, поэтому токены доступа и подтверждения не взаимозаменяемы.
+const previewConfirmClaim = "confirm_code"
+
+// GenerateLinkAccessToken создаёт подписанный токен, подтверждающий ввод пароля
+// защищённой ссылки с кодом code; токен действителен в течение ttl
+func (a *AuthService) GenerateLinkAccessToken(code string, ttl time.Duration) (string, error) {
+	return a.generateCodeToken(linkAccessClaim, code, ttl)
+}
+
+// ValidateLinkAccessToken проверяет, что токен подписан сервисом, не истёк
+// и выдан для ссылки с кодом code
+func (a *AuthService) ValidateLinkAccessToken(tokenString, code string) bool {
+	return a.validateCodeToken(linkAccessClaim, tokenString, code)
+}
+
+// GeneratePreviewConfirmToken создаёт подписанный токен, которым страница предпросмотра
+// подтверждает переход по небезопасной ссылке с кодом code; токен действителен в течение ttl
+func (a *AuthService) GeneratePreviewConfirmToken(code string, ttl time.Duration) (string, error) {
+	return a.generateCodeToken(previewConfirmClaim, code, ttl)
+}
+
+// ValidatePreviewConfirmToken проверяет, что токен подтверждения подписан сервисом,
+// не истёк и выдан для ссылки с кодом code
+func (a *AuthService) ValidatePreviewConfirmToken(tokenString, code string) bool {
+	return a.validateCodeToken(previewConfirmClaim, tokenString, code)
+}
+
+// generateCodeToken создаёт подписанный токен с кодом ссылки в claim; токен действителен в течение ttl
+func (a *AuthService) generateCodeToken(claim, code string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		claim: code,
+		"exp": now.Add(ttl).Unix(),
+		"iat": now.Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(a.jwtSecret)
+}
+
+// validateCodeToken проверяет, что токен подписан сервисом, не истёк и содержит code в claim
+func (a *AuthService) validateCodeToken(claim, tokenString, code string) bool {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return a.jwtSecret, nil
+	})
+	if err != nil || !token.Valid {
+		return false
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+	tokenCode, ok := claims[claim].(string)
+	return ok && tokenCode == code
+}
+
 // GetOrCreateUserFromCookie извлекает user_id из куки или создает нового пользователя
 func (a *AuthService) GetOrCreateUserFromCookie(r *http.Request, w http.ResponseWriter) (string, error) {
 	cookie, err := r.Cookie("user_token")
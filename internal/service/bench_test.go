@@ -42,7 +42,7 @@ func BenchmarkURLServiceCreateShortURL(b *testing.B) {
 	for b.Loop() {
 		n++
 		url := model.URL("https://example.com/bench/" + model.URL(string(rune('a'+n%26))))
-		_, _, _ = svc.CreateShortURL(url, "user1")
+		_, _, _ = svc.CreateShortURL(url, "user1", model.LinkOptions{})
 	}
 }
 
@@ -55,12 +55,12 @@ func BenchmarkURLServiceCreateShortURL_Duplicate(b *testing.B) {
 	svc := NewURLService(repo, cfg)
 
 	const existingURL = model.URL("https://example.com/existing")
-	_, _, _ = svc.CreateShortURL(existingURL, "user1")
+	_, _, _ = svc.CreateShortURL(existingURL, "user1", model.LinkOptions{})
 
 	b.ReportAllocs()
 	b.ResetTimer()
 	for b.Loop() {
-		_, _, _ = svc.CreateShortURL(existingURL, "user1")
+		_, _, _ = svc.CreateShortURL(existingURL, "user1", model.LinkOptions{})
 	}
 }
 
@@ -82,6 +82,6 @@ func BenchmarkURLServiceCreateShortURLsBatch(b *testing.B) {
 		// Создаём новый набор URL каждый раз, чтобы избежать конфликтов
 		batch := make([]model.URL, len(urls))
 		copy(batch, urls)
-		_, _ = svc.CreateShortURLsBatch(batch, "user2")
+		_, _ = svc.CreateShortURLsBatch(batch, "user2", model.LinkOptions{})
 	}
 }
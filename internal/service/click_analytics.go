@@ -0,0 +1,66 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// visitorIDLength — длина отпечатка посетителя в hex-символах (64 бита)
+const visitorIDLength = 16
+
+// ClickEnricher превращает сведения о запросе в запись о переходе:
+// определяет источник, семейство браузера, страну и отпечаток посетителя.
+type ClickEnricher struct {
+	geo    *GeoIP
+	secret []byte
+}
+
+// NewClickEnricher создаёт ClickEnricher. geo может быть nil — тогда страна не определяется.
+// secret — ключ HMAC для отпечатков посетителей: без него по отпечатку нельзя
+// перебором восстановить IP-адрес.
+func NewClickEnricher(geo *GeoIP, secret string) *ClickEnricher {
+	return &ClickEnricher{geo: geo, secret: []byte(secret)}
+}
+
+// Enrich возвращает запись о переходе по ссылке code в момент at
+func (e *ClickEnricher) Enrich(code model.Code, at time.Time, visit model.Visit) model.Click {
+	return model.Click{
+		Code:      code,
+		At:        at.UTC(),
+		Referrer:  referrerHost(visit.Referrer),
+		UAFamily:  UserAgentFamily(visit.UserAgent),
+		Country:   e.geo.Country(visit.IP),
+		VisitorID: e.visitorID(visit),
+		Variant:   visit.Variant,
+	}
+}
+
+// visitorID вычисляет отпечаток посетителя по адресу и User-Agent.
+// Разные устройства за одним NAT с одинаковым браузером неразличимы,
+// поэтому число уникальных посетителей — оценка снизу.
+func (e *ClickEnricher) visitorID(visit model.Visit) string {
+	mac := hmac.New(sha256.New, e.secret)
+	mac.Write([]byte(visit.IP))
+	mac.Write([]byte{0})
+	mac.Write([]byte(visit.UserAgent))
+	return hex.EncodeToString(mac.Sum(nil))[:visitorIDLength]
+}
+
+// referrerHost оставляет от Referer только хост: путь и параметры могут содержать
+// персональные данные и не нужны для статистики источников
+func referrerHost(referrer string) string {
+	if referrer == "" {
+		return ""
+	}
+	u, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
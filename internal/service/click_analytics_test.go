@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClickEnricher_Enrich проверяет заполнение записи о переходе
+func TestClickEnricher_Enrich(t *testing.T) {
+	geo, err := parseGeoIP(strings.NewReader("203.0.113.0,203.0.113.255,NL\n"))
+	require.NoError(t, err)
+	enricher := NewClickEnricher(geo, "secret")
+	at := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	visit := model.Visit{
+		Referrer:  "https://News.Example.org/post?id=1",
+		UserAgent: "Mozilla/5.0 Firefox/128.0",
+		IP:        "203.0.113.7",
+		Variant:   2,
+	}
+	click := enricher.Enrich("abc", at, visit)
+
+	assert.Equal(t, model.Code("abc"), click.Code)
+	assert.Equal(t, at, click.At)
+	assert.Equal(t, "news.example.org", click.Referrer)
+	assert.Equal(t, UAFamilyFirefox, click.UAFamily)
+	assert.Equal(t, "NL", click.Country)
+	assert.Equal(t, 2, click.Variant)
+	assert.Len(t, click.VisitorID, visitorIDLength)
+	assert.NotContains(t, click.VisitorID, "203.0.113.7")
+
+	// Отпечаток стабилен для одного посетителя и различается для разных
+	assert.Equal(t, click.VisitorID, enricher.Enrich("abc", at, visit).VisitorID)
+	visit.IP = "203.0.113.8"
+	assert.NotEqual(t, click.VisitorID, enricher.Enrich("abc", at, visit).VisitorID)
+}
+
+// recordingSink — ClickSink, запоминающий сохранённые пачки
+type recordingSink struct {
+	mu      sync.Mutex
+	batches [][]model.Click
+}
+
+func (s *recordingSink) RecordClicks(clicks []model.Click) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, clicks)
+	return nil
+}
+
+// TestClickRecorder проверяет запись пачками и сброс накопленного при закрытии
+func TestClickRecorder(t *testing.T) {
+	sink := &recordingSink{}
+	recorder := NewClickRecorder(sink, 10, 2, time.Hour, func(error, int) {})
+
+	for _, code := range []model.Code{"a", "b", "c"} {
+		assert.True(t, recorder.Record(model.Click{Code: code}))
+	}
+	recorder.Close()
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	require.Len(t, sink.batches, 2, "full batch of two and the rest flushed on close")
+	assert.Len(t, sink.batches[0], 2)
+	assert.Equal(t, []model.Click{{Code: "c"}}, sink.batches[1])
+}
+
+// blockingSink — ClickSink, блокирующий запись до закрытия release
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (s *blockingSink) RecordClicks([]model.Click) error {
+	<-s.release
+	return nil
+}
+
+// TestClickRecorder_DropsWhenFull проверяет, что переполненный буфер не блокирует переход
+func TestClickRecorder_DropsWhenFull(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+	var dropped int
+	var mu sync.Mutex
+	recorder := NewClickRecorder(sink, 1, 1, time.Hour, func(_ error, n int) {
+		mu.Lock()
+		dropped += n
+		mu.Unlock()
+	})
+
+	// Первый переход занимает воркер, второй — буфер; дальше переходы отбрасываются
+	accepted := 0
+	for range 10 {
+		if recorder.Record(model.Click{Code: "a"}) {
+			accepted++
+		}
+	}
+	close(sink.release)
+	recorder.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 10, accepted+dropped)
+	assert.Positive(t, dropped)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// ClickCountSink применяет накопленные приращения счётчиков переходов
+type ClickCountSink interface {
+	AddClickCounts(counts []model.ClickCount) error
+}
+
+// ClickCounter накапливает в памяти приращения счётчиков переходов по кодам
+// и периодически сбрасывает их в ClickCountSink одной операцией.
+// Так редирект не обращается к хранилищу, а хранилище получает одно
+// обновление на код за интервал вместо обновления на каждый переход.
+// При ошибке сброса приращения возвращаются в накопитель до следующей попытки.
+type ClickCounter struct {
+	sink          ClickCountSink
+	flushInterval time.Duration
+	onError       func(err error, lost int)
+
+	mu      sync.Mutex
+	pending map[model.Code]model.ClickCount
+
+	flushMu   sync.Mutex
+	stop      chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewClickCounter создаёт ClickCounter и запускает периодический сброс.
+// onError вызывается при ошибке сброса; lost — число кодов, приращения
+// которых потеряны (ненулевое только при ошибке последнего сброса в Close).
+func NewClickCounter(sink ClickCountSink, flushInterval time.Duration, onError func(err error, lost int)) *ClickCounter {
+	c := &ClickCounter{
+		sink:          sink,
+		flushInterval: flushInterval,
+		onError:       onError,
+		pending:       make(map[model.Code]model.ClickCount),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go c.run()
+	return c
+}
+
+// Increment учитывает переход по коду в момент at
+func (c *ClickCounter) Increment(code model.Code, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.merge(model.ClickCount{Code: code, Clicks: 1, LastAccessedAt: at})
+}
+
+// Flush сбрасывает накопленные приращения в хранилище.
+// При ошибке приращения остаются в накопителе и будут сброшены позже.
+func (c *ClickCounter) Flush() error {
+	// Сбросы выполняются по очереди, чтобы вернувшиеся после ошибки приращения
+	// не обгоняли следующий сброс
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	c.mu.Lock()
+	if len(c.pending) == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	counts := make([]model.ClickCount, 0, len(c.pending))
+	for _, count := range c.pending {
+		counts = append(counts, count)
+	}
+	c.pending = make(map[model.Code]model.ClickCount)
+	c.mu.Unlock()
+
+	if err := c.sink.AddClickCounts(counts); err != nil {
+		c.mu.Lock()
+		for _, count := range counts {
+			c.merge(count)
+		}
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Close останавливает периодический сброс и сбрасывает оставшиеся приращения.
+// Переходы, учтённые после Close, будут сброшены только явным вызовом Flush.
+func (c *ClickCounter) Close() {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+	})
+	<-c.done
+}
+
+// merge добавляет приращение к накопленному; вызывается под c.mu
+func (c *ClickCounter) merge(count model.ClickCount) {
+	current, ok := c.pending[count.Code]
+	if !ok {
+		c.pending[count.Code] = count
+		return
+	}
+	current.Clicks += count.Clicks
+	if count.LastAccessedAt.After(current.LastAccessedAt) {
+		current.LastAccessedAt = count.LastAccessedAt
+	}
+	c.pending[count.Code] = current
+}
+
+// run периодически сбрасывает приращения, а при остановке — выполняет последний сброс
+func (c *ClickCounter) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Flush(); err != nil {
+				c.onError(err, 0)
+			}
+		case <-c.stop:
+			if err := c.Flush(); err != nil {
+				c.mu.Lock()
+				lost := len(c.pending)
+				c.mu.Unlock()
+				c.onError(err, lost)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSink — ClickCountSink, запоминающий сброшенные приращения
+type countingSink struct {
+	mu    sync.Mutex
+	fail  bool
+	calls int
+	total map[model.Code]model.ClickCount
+}
+
+func (s *countingSink) AddClickCounts(counts []model.ClickCount) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.fail {
+		return errors.New("storage unavailable")
+	}
+	for _, count := range counts {
+		current := s.total[count.Code]
+		current.Code = count.Code
+		current.Clicks += count.Clicks
+		if count.LastAccessedAt.After(current.LastAccessedAt) {
+			current.LastAccessedAt = count.LastAccessedAt
+		}
+		s.total[count.Code] = current
+	}
+	return nil
+}
+
+// TestClickCounter_Flush проверяет суммирование переходов по коду в одно приращение
+func TestClickCounter_Flush(t *testing.T) {
+	sink := &countingSink{total: make(map[model.Code]model.ClickCount)}
+	counter := NewClickCounter(sink, time.Hour, func(error, int) {})
+	defer counter.Close()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	counter.Increment("a", base.Add(time.Minute))
+	counter.Increment("a", base)
+	counter.Increment("b", base)
+
+	require.NoError(t, counter.Flush())
+	require.NoError(t, counter.Flush(), "empty flush does not call the sink")
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	assert.Equal(t, 1, sink.calls)
+	assert.Equal(t, model.ClickCount{Code: "a", Clicks: 2, LastAccessedAt: base.Add(time.Minute)}, sink.total["a"])
+	assert.Equal(t, int64(1), sink.total["b"].Clicks)
+}
+
+// TestClickCounter_RetryAfterError проверяет, что приращения не теряются при ошибке сброса
+func TestClickCounter_RetryAfterError(t *testing.T) {
+	sink := &countingSink{total: make(map[model.Code]model.ClickCount), fail: true}
+	counter := NewClickCounter(sink, time.Hour, func(error, int) {})
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	counter.Increment("a", at)
+	assert.Error(t, counter.Flush())
+
+	counter.Increment("a", at)
+	sink.mu.Lock()
+	sink.fail = false
+	sink.mu.Unlock()
+
+	// Оставшиеся приращения сбрасываются при закрытии
+	counter.Close()
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	assert.Equal(t, int64(2), sink.total["a"].Clicks)
+}
+
+// TestClickCounter_CloseReportsLost проверяет сообщение о потере приращений при последнем сбросе
+func TestClickCounter_CloseReportsLost(t *testing.T) {
+	sink := &countingSink{total: make(map[model.Code]model.ClickCount), fail: true}
+	var lost int
+	counter := NewClickCounter(sink, time.Hour, func(_ error, n int) { lost = n })
+
+	counter.Increment("a", time.Now())
+	counter.Increment("b", time.Now())
+	counter.Close()
+
+	assert.Equal(t, 2, lost)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// ClickSink сохраняет пачку переходов
+type ClickSink interface {
+	RecordClicks(clicks []model.Click) error
+}
+
+// ClickRecorder асинхронно записывает переходы пачками, не задерживая редирект.
+// Переходы копятся в буферизованном канале; фоновая горутина сбрасывает их
+// в ClickSink, когда набирается batchSize или проходит flushInterval.
+// При переполнении буфера переход отбрасывается: статистика не должна
+// замедлять редиректы при перегрузке хранилища.
+type ClickRecorder struct {
+	sink          ClickSink
+	clicks        chan model.Click
+	batchSize     int
+	flushInterval time.Duration
+	onError       func(err error, dropped int)
+	closeOnce     sync.Once
+	done          chan struct{}
+}
+
+// NewClickRecorder создаёт ClickRecorder и запускает фоновую запись.
+// onError вызывается при ошибке сохранения пачки или отбрасывании перехода.
+func NewClickRecorder(sink ClickSink, bufferSize, batchSize int, flushInterval time.Duration,
+	onError func(err error, dropped int)) *ClickRecorder {
+	r := &ClickRecorder{
+		sink:          sink,
+		clicks:        make(chan model.Click, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		onError:       onError,
+		done:          make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Record ставит переход в очередь на запись и не блокируется.
+// Возвращает false, если буфер переполнен и переход отброшен.
+func (r *ClickRecorder) Record(click model.Click) bool {
+	select {
+	case r.clicks <- click:
+		return true
+	default:
+		r.onError(nil, 1)
+		return false
+	}
+}
+
+// Close прекращает приём переходов и дожидается записи всех накопленных.
+// После Close вызывать Record нельзя.
+func (r *ClickRecorder) Close() {
+	r.closeOnce.Do(func() {
+		close(r.clicks)
+	})
+	<-r.done
+}
+
+// run собирает переходы в пачки и сбрасывает их в хранилище
+func (r *ClickRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]model.Click, 0, r.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.sink.RecordClicks(batch); err != nil {
+			r.onError(err, len(batch))
+		}
+		batch = make([]model.Click, 0, r.batchSize)
+	}
+
+	for {
+		select {
+		case click, ok := <-r.clicks:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, click)
+			if len(batch) >= r.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// ReservedCodes — пути верхнего уровня, занятые маршрутами роутера.
+// Короткий код, совпадающий с одним из них, перекрывался бы маршрутом и был бы недоступен.
+// При добавлении нового маршрута верхнего уровня его нужно добавить и сюда.
+// Все коды выдаются генераторами, и при текущих форматах (CodeLength букв или слова
+// через дефис) совпадение невозможно; проверка страхует от смены формата кодов.
+var ReservedCodes = []string{"ping", "api"}
+
+// leetReplacer приводит распространённые leetspeak-замены к буквам.
+// Цифра 1 обрабатывается отдельно: она может обозначать как «i», так и «l».
+var leetReplacer = strings.NewReplacer(
+	"0", "o",
+	"3", "e",
+	"4", "a",
+	"5", "s",
+	"7", "t",
+	"8", "b",
+	"9", "g",
+	"@", "a",
+	"$", "s",
+	"|", "l",
+)
+
+// CodeFilter отбраковывает сгенерированные коды, которые содержат слова из deny-листа
+// или совпадают с зарезервированными путями роутера.
+// После создания не изменяется и безопасен для конкурентного использования.
+type CodeFilter struct {
+	denyWords []string
+	reserved  map[string]struct{}
+}
+
+// NewCodeFilter создаёт фильтр с указанными запрещёнными словами и встроенным
+// списком ReservedCodes. Слова нормализуются так же, как проверяемые коды.
+func NewCodeFilter(denyWords []string) *CodeFilter {
+	f := &CodeFilter{
+		reserved: make(map[string]struct{}, len(ReservedCodes)),
+	}
+	for _, code := range ReservedCodes {
+		f.reserved[strings.ToLower(code)] = struct{}{}
+	}
+	for _, word := range denyWords {
+		for _, variant := range normalizeForFilter(word) {
+			if variant != "" {
+				f.denyWords = append(f.denyWords, variant)
+			}
+		}
+	}
+	return f
+}
+
+// LoadCodeFilter читает deny-лист из файла (одно слово на строку, строки,
+// начинающиеся с '#', и пустые строки игнорируются) и создаёт фильтр.
+// При пустом пути возвращает фильтр только со встроенным списком ReservedCodes.
+func LoadCodeFilter(path string) (*CodeFilter, error) {
+	if path == "" {
+		return NewCodeFilter(nil), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open code deny list: %w", err)
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read code deny list: %w", err)
+	}
+
+	return NewCodeFilter(words), nil
+}
+
+// Allow возвращает true, если код можно выдать пользователю.
+// Код отклоняется, если без учёта регистра совпадает с зарезервированным путём
+// или после leetspeak-нормализации содержит запрещённое слово как подстроку.
+func (f *CodeFilter) Allow(code model.Code) bool {
+	if _, reserved := f.reserved[strings.ToLower(string(code))]; reserved {
+		return false
+	}
+	if len(f.denyWords) == 0 {
+		return true
+	}
+
+	for _, variant := range normalizeForFilter(string(code)) {
+		for _, word := range f.denyWords {
+			if strings.Contains(variant, word) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// normalizeForFilter приводит строку к нижнему регистру, раскрывает leetspeak-замены
+// и удаляет разделители, чтобы «b-a-d» и «b4d» совпадали со словом «bad».
+// Цифры без leetspeak-значения не удаляются, а разрывают слово: «ba2d» не содержит «bad».
+// Возвращает два варианта, так как 1 может заменять как «i», так и «l».
+func normalizeForFilter(s string) []string {
+	s = leetReplacer.Replace(strings.ToLower(s))
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case (r >= 'a' && r <= 'z') || r == '1':
+			return r
+		case r >= '0' && r <= '9':
+			return ' '
+		default:
+			return -1
+		}
+	}, s)
+
+	if !strings.Contains(s, "1") {
+		return []string{s}
+	}
+	return []string{
+		strings.ReplaceAll(s, "1", "i"),
+		strings.ReplaceAll(s, "1", "l"),
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCodeFilter_Allow проверяет отбраковку кодов по deny-листу и зарезервированным путям
+func TestCodeFilter_Allow(t *testing.T) {
+	filter := NewCodeFilter([]string{"bad", "Evil", "h3ll"})
+
+	tests := []struct {
+		name    string
+		code    model.Code
+		allowed bool
+	}{
+		{name: "Clean code", code: "qwErtyUi", allowed: true},
+		{name: "Exact deny word", code: "bad", allowed: false},
+		{name: "Deny word as substring", code: "xxBADyyy", allowed: false},
+		{name: "Deny word in mixed case", code: "abEvILcd", allowed: false},
+		{name: "Leetspeak digits", code: "xb4dxxxx", allowed: false},
+		{name: "Leetspeak one as i", code: "ev1lxxxx", allowed: false},
+		{name: "Leetspeak one as l", code: "he1lxxxx", allowed: false},
+		{name: "Separated by hyphens", code: "brave-b-a-d-42", allowed: false},
+		{name: "Non-leet digit breaks the word", code: "xba2dxxx", allowed: true},
+		{name: "Non-leet digit next to the word", code: "xbad26xx", allowed: false},
+		{name: "Leetspeak in deny list entry", code: "hellyeah", allowed: false},
+		{name: "Reserved route", code: "ping", allowed: false},
+		{name: "Reserved route in other case", code: "API", allowed: false},
+		{name: "Reserved route as prefix is allowed", code: "pingpong", allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, filter.Allow(tt.code))
+		})
+	}
+}
+
+// TestLoadCodeFilter проверяет загрузку deny-листа из файла
+func TestLoadCodeFilter(t *testing.T) {
+	t.Run("Empty path keeps only reserved codes", func(t *testing.T) {
+		filter, err := LoadCodeFilter("")
+
+		require.NoError(t, err)
+		assert.True(t, filter.Allow("badcode"))
+		assert.False(t, filter.Allow("ping"))
+	})
+
+	t.Run("File with comments and blank lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "deny.txt")
+		content := "# offensive words\nbad\n\n  evil  \n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		filter, err := LoadCodeFilter(path)
+
+		require.NoError(t, err)
+		assert.False(t, filter.Allow("xxbadxxx"))
+		assert.False(t, filter.Allow("xxevilxx"))
+		assert.True(t, filter.Allow("offensiv"))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadCodeFilter(filepath.Join(t.TempDir(), "missing.txt"))
+
+		assert.Error(t, err)
+	})
+}
@@ -9,17 +9,27 @@ import (
 const (
 	CodeLength   = 8
 	AllowedChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// SingleCaseChars — алфавит для режима кодов без учёта регистра.
+	// Цифры компенсируют уменьшение пространства кодов из-за отказа от заглавных букв.
+	SingleCaseChars = "abcdefghijklmnopqrstuvwxyz0123456789"
 )
 
 // CodeGenerator реализует генератор кодов с использованием вероятностного подхода
 type CodeGenerator struct {
-	random *rand.Rand
+	random   *rand.Rand
+	alphabet string
 }
 
 // NewCodeGenerator создает новый генератор кодов
 func NewCodeGenerator() *CodeGenerator {
+	return NewCodeGeneratorWithAlphabet(AllowedChars)
+}
+
+// NewCodeGeneratorWithAlphabet создает генератор кодов из символов указанного алфавита
+func NewCodeGeneratorWithAlphabet(alphabet string) *CodeGenerator {
 	return &CodeGenerator{
-		random: rand.New(rand.NewSource(rand.Int63())),
+		random:   rand.New(rand.NewSource(rand.Int63())),
+		alphabet: alphabet,
 	}
 }
 
@@ -44,7 +54,7 @@ func (g *CodeGenerator) generateRandomString() string {
 	var result [CodeLength]byte
 
 	for i := range result {
-		result[i] = AllowedChars[g.random.Intn(len(AllowedChars))]
+		result[i] = g.alphabet[g.random.Intn(len(g.alphabet))]
 	}
 
 	return string(result[:])
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCodeGenerator_Alphabet проверяет, что коды состоят только из символов алфавита
+func TestCodeGenerator_Alphabet(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     *CodeGenerator
+		pattern string
+	}{
+		{name: "Default mixed-case alphabet", gen: NewCodeGenerator(), pattern: `^[a-zA-Z]{8}$`},
+		{name: "Single-case alphabet", gen: NewCodeGeneratorWithAlphabet(SingleCaseChars), pattern: `^[a-z0-9]{8}$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				assert.Regexp(t, tt.pattern, string(tt.gen.GenerateCode()))
+			}
+		})
+	}
+}
+
+// TestNewURLService_CaseInsensitiveCodes проверяет выбор алфавита по конфигурации
+func TestNewURLService_CaseInsensitiveCodes(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.CaseInsensitiveCodes = true
+
+	service := NewURLService(nil, cfg)
+
+	gen, ok := service.codeGenerator.(*CodeGenerator)
+	if assert.True(t, ok) {
+		assert.Equal(t, SingleCaseChars, gen.alphabet)
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// ErrInvalidBlockRule возвращается, когда шаблон правила блокировки не удалось разобрать.
+var ErrInvalidBlockRule = errors.New("invalid block rule")
+
+// blockRegex — правило-регулярное выражение вместе со скомпилированным выражением
+type blockRegex struct {
+	rule model.BlockRule
+	re   *regexp.Regexp
+}
+
+// DomainBlocklist блокирует домены назначения по правилам трёх видов:
+// «example.com» — только этот хост, «*.example.com» — домен и все его поддомены,
+// «/выражение/» — хосты, совпавшие с регулярным выражением (выражение не якорится
+// автоматически). Хосты сравниваются в нижнем регистре и punycode.
+// Если задан файл, список загружается из него и перезаписывается при каждом изменении.
+// Безопасен для конкурентного использования; нулевой указатель ничего не блокирует.
+type DomainBlocklist struct {
+	mu      sync.RWMutex
+	path    string
+	rules   []model.BlockRule
+	exact   map[string]model.BlockRule
+	suffix  map[string]model.BlockRule
+	regexps []blockRegex
+}
+
+// NewDomainBlocklist создаёт пустой список блокировки, который хранится только в памяти.
+func NewDomainBlocklist() *DomainBlocklist {
+	b := &DomainBlocklist{}
+	b.rebuild()
+	return b
+}
+
+// LoadDomainBlocklist читает правила из файла (одно правило на строку, строки,
+// начинающиеся с '#', и пустые строки игнорируются). Отсутствующий файл создаётся
+// при первом изменении списка. Пустой путь означает список, который хранится только в памяти.
+func LoadDomainBlocklist(path string) (*DomainBlocklist, error) {
+	b := NewDomainBlocklist()
+	if path == "" {
+		return b, nil
+	}
+	b.path = path
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open domain blocklist: %w", err)
+	}
+	defer f.Close()
+
+	rules, err := parseBlockRules(f)
+	if err != nil {
+		return nil, err
+	}
+	b.rules = rules
+	b.rebuild()
+	return b, nil
+}
+
+// parseBlockRules разбирает файл правил, пропуская повторы
+func parseBlockRules(r io.Reader) ([]model.BlockRule, error) {
+	var rules []model.BlockRule
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		rule, err := ParseBlockRule(text)
+		if err != nil {
+			return nil, fmt.Errorf("domain blocklist line %d: %w", line, err)
+		}
+		if !slices.Contains(rules, rule) {
+			rules = append(rules, rule)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read domain blocklist: %w", err)
+	}
+	return rules, nil
+}
+
+// ParseBlockRule разбирает шаблон правила блокировки и приводит его к каноническому виду.
+func ParseBlockRule(pattern string) (model.BlockRule, error) {
+	pattern = strings.TrimSpace(pattern)
+
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		if _, err := regexp.Compile(pattern[1 : len(pattern)-1]); err != nil {
+			return model.BlockRule{}, fmt.Errorf("%w: %w", ErrInvalidBlockRule, err)
+		}
+		return model.BlockRule{Pattern: pattern, Kind: model.BlockRuleRegex}, nil
+	}
+
+	kind := model.BlockRuleExact
+	domain := pattern
+	if rest, ok := strings.CutPrefix(pattern, "*."); ok {
+		kind, domain = model.BlockRuleSuffix, rest
+	}
+	domain, err := blockDomain(domain)
+	if err != nil {
+		return model.BlockRule{}, fmt.Errorf("%w: %q: %w", ErrInvalidBlockRule, pattern, err)
+	}
+	if kind == model.BlockRuleSuffix {
+		return model.BlockRule{Pattern: "*." + domain, Kind: kind}, nil
+	}
+	return model.BlockRule{Pattern: domain, Kind: kind}, nil
+}
+
+// blockDomain приводит имя домена к нижнему регистру и punycode без завершающей точки
+func blockDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	if domain == "" || strings.ContainsAny(domain, "*/:@ ") {
+		return "", errors.New("not a domain name")
+	}
+	return hostProfile.ToASCII(domain)
+}
+
+// Match возвращает правило, блокирующее хост, если такое есть.
+// Точные правила проверяются первыми, затем правила доменов от самого длинного, затем выражения.
+func (b *DomainBlocklist) Match(host string) (model.BlockRule, bool) {
+	if b == nil {
+		return model.BlockRule{}, false
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if ascii, err := hostProfile.ToASCII(host); err == nil {
+		host = ascii
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if rule, ok := b.exact[host]; ok {
+		return rule, true
+	}
+	for domain := host; domain != ""; {
+		if rule, ok := b.suffix[domain]; ok {
+			return rule, true
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			break
+		}
+		domain = parent
+	}
+	for _, r := range b.regexps {
+		if r.re.MatchString(host) {
+			return r.rule, true
+		}
+	}
+	return model.BlockRule{}, false
+}
+
+// Len возвращает число правил; у нулевого списка правил нет.
+func (b *DomainBlocklist) Len() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.rules)
+}
+
+// Rules возвращает правила в порядке добавления.
+func (b *DomainBlocklist) Rules() []model.BlockRule {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return slices.Clone(b.rules)
+}
+
+// Add добавляет правило и сохраняет список. Возвращает правило в каноническом виде
+// и false, если такое правило уже было.
+func (b *DomainBlocklist) Add(pattern string) (model.BlockRule, bool, error) {
+	rule, err := ParseBlockRule(pattern)
+	if err != nil {
+		return model.BlockRule{}, false, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if slices.Contains(b.rules, rule) {
+		return rule, false, nil
+	}
+	return rule, true, b.update(append(slices.Clone(b.rules), rule))
+}
+
+// Remove удаляет правило и сохраняет список. Возвращает false, если правила не было.
+func (b *DomainBlocklist) Remove(pattern string) (bool, error) {
+	rule, err := ParseBlockRule(pattern)
+	if err != nil {
+		return false, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := slices.Index(b.rules, rule)
+	if i < 0 {
+		return false, nil
+	}
+	return true, b.update(slices.Delete(slices.Clone(b.rules), i, i+1))
+}
+
+// update сохраняет новый список правил в файл и применяет его.
+// При ошибке записи действующий список не меняется. Вызывается под b.mu.
+func (b *DomainBlocklist) update(rules []model.BlockRule) error {
+	if err := b.save(rules); err != nil {
+		return err
+	}
+	b.rules = rules
+	b.rebuild()
+	return nil
+}
+
+// save атомарно перезаписывает файл списка: комментарии исходного файла при этом не сохраняются
+func (b *DomainBlocklist) save(rules []model.BlockRule) error {
+	if b.path == "" {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save domain blocklist: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	for _, rule := range rules {
+		w.WriteString(rule.Pattern)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save domain blocklist: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save domain blocklist: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), b.path); err != nil {
+		return fmt.Errorf("failed to save domain blocklist: %w", err)
+	}
+	return nil
+}
+
+// rebuild пересоздаёт индексы правил. Шаблоны уже проверены ParseBlockRule.
+// Вызывается под b.mu или до публикации списка.
+func (b *DomainBlocklist) rebuild() {
+	b.exact = make(map[string]model.BlockRule)
+	b.suffix = make(map[string]model.BlockRule)
+	b.regexps = nil
+	for _, rule := range b.rules {
+		switch rule.Kind {
+		case model.BlockRuleExact:
+			b.exact[rule.Pattern] = rule
+		case model.BlockRuleSuffix:
+			b.suffix[strings.TrimPrefix(rule.Pattern, "*.")] = rule
+		case model.BlockRuleRegex:
+			re := regexp.MustCompile(rule.Pattern[1 : len(rule.Pattern)-1])
+			b.regexps = append(b.regexps, blockRegex{rule: rule, re: re})
+		}
+	}
+}
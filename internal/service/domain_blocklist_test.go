@@ -0,0 +1,110 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDomainBlocklist_Match проверяет сопоставление хостов с правилами всех видов
+func TestDomainBlocklist_Match(t *testing.T) {
+	blocklist := NewDomainBlocklist()
+	for _, pattern := range []string{"Bad.example", "*.evil.test", `/^phish-[a-z]+\.com$/`, "*.пример.рф"} {
+		_, added, err := blocklist.Add(pattern)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	tests := []struct {
+		name     string
+		host     string
+		wantRule string
+	}{
+		{name: "Exact host", host: "bad.example", wantRule: "bad.example"},
+		{name: "Exact host case and trailing dot", host: "BAD.Example.", wantRule: "bad.example"},
+		{name: "Exact rule does not cover subdomain", host: "www.bad.example"},
+		{name: "Suffix rule covers domain itself", host: "evil.test", wantRule: "*.evil.test"},
+		{name: "Suffix rule covers subdomains", host: "a.b.evil.test", wantRule: "*.evil.test"},
+		{name: "Suffix rule respects label boundary", host: "notevil.test"},
+		{name: "Regex rule", host: "phish-bank.com", wantRule: `/^phish-[a-z]+\.com$/`},
+		{name: "Regex rule not matched", host: "phish-1.com"},
+		{name: "IDN host", host: "www.Пример.рф", wantRule: "*.xn--e1afmkfd.xn--p1ai"},
+		{name: "Unrelated host", host: "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, blocked := blocklist.Match(tt.host)
+			assert.Equal(t, tt.wantRule != "", blocked)
+			assert.Equal(t, tt.wantRule, rule.Pattern)
+		})
+	}
+}
+
+// TestDomainBlocklist_Nil проверяет, что нулевой список ничего не блокирует
+func TestDomainBlocklist_Nil(t *testing.T) {
+	var blocklist *DomainBlocklist
+	_, blocked := blocklist.Match("example.com")
+	assert.False(t, blocked)
+}
+
+// TestParseBlockRule_Invalid проверяет отказ для некорректных шаблонов
+func TestParseBlockRule_Invalid(t *testing.T) {
+	for _, pattern := range []string{"", "*.", "*", "example.com/path", "a.*.example.com", "user@example.com", "/[/"} {
+		t.Run(pattern, func(t *testing.T) {
+			_, err := ParseBlockRule(pattern)
+			assert.ErrorIs(t, err, ErrInvalidBlockRule)
+		})
+	}
+}
+
+// TestDomainBlocklist_Persistence проверяет загрузку списка из файла и сохранение изменений
+func TestDomainBlocklist_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# abuse reports\nbad.example\n\n*.Evil.test\nbad.example\n"), 0o600))
+
+	blocklist, err := LoadDomainBlocklist(path)
+	require.NoError(t, err)
+	assert.Equal(t, []model.BlockRule{
+		{Pattern: "bad.example", Kind: model.BlockRuleExact},
+		{Pattern: "*.evil.test", Kind: model.BlockRuleSuffix},
+	}, blocklist.Rules())
+
+	rule, added, err := blocklist.Add(`/^x\./`)
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, model.BlockRule{Pattern: `/^x\./`, Kind: model.BlockRuleRegex}, rule)
+
+	_, added, err = blocklist.Add("BAD.example")
+	require.NoError(t, err)
+	assert.False(t, added, "existing rule is not duplicated")
+
+	removed, err := blocklist.Remove("*.evil.test")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = blocklist.Remove("*.evil.test")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	reloaded, err := LoadDomainBlocklist(path)
+	require.NoError(t, err)
+	assert.Equal(t, blocklist.Rules(), reloaded.Rules())
+}
+
+// TestLoadDomainBlocklist проверяет загрузку отсутствующего и некорректного файла
+func TestLoadDomainBlocklist(t *testing.T) {
+	dir := t.TempDir()
+
+	blocklist, err := LoadDomainBlocklist(filepath.Join(dir, "missing.txt"))
+	require.NoError(t, err)
+	assert.Empty(t, blocklist.Rules())
+
+	invalid := filepath.Join(dir, "invalid.txt")
+	require.NoError(t, os.WriteFile(invalid, []byte("ok.example\n/[/\n"), 0o600))
+	_, err = LoadDomainBlocklist(invalid)
+	assert.ErrorContains(t, err, "line 2")
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// geoRange — диапазон адресов одной страны
+type geoRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+// GeoIP определяет страну по IP-адресу по локальной базе диапазонов.
+// После создания не изменяется и безопасен для конкурентного использования.
+// Нулевой указатель допустим и ни для какого адреса не находит страну.
+type GeoIP struct {
+	ranges []geoRange
+}
+
+// LoadGeoIP загружает базу из CSV-файла со строками «начало,конец,код страны»,
+// например «1.0.0.0,1.0.0.255,AU» (формат бесплатной базы DB-IP Country Lite).
+// Поддерживаются адреса IPv4 и IPv6. Пустой путь означает, что база не используется.
+func LoadGeoIP(path string) (*GeoIP, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+	defer f.Close()
+
+	return parseGeoIP(f)
+}
+
+// parseGeoIP разбирает CSV-базу диапазонов
+func parseGeoIP(r io.Reader) (*GeoIP, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+
+	var ranges []geoRange
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GeoIP database: %w", err)
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("GeoIP database line %d: expected start,end,country", line)
+		}
+
+		start, err := netip.ParseAddr(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("GeoIP database line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("GeoIP database line %d: %w", line, err)
+		}
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return nil, fmt.Errorf("GeoIP database line %d: invalid range %s-%s", line, start, end)
+		}
+
+		ranges = append(ranges, geoRange{
+			start:   start,
+			end:     end,
+			country: strings.ToUpper(strings.TrimSpace(record[2])),
+		})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start.Less(ranges[j].start) })
+
+	return &GeoIP{ranges: ranges}, nil
+}
+
+// Country возвращает двухбуквенный код страны для адреса или пустую строку, если он не найден
+func (g *GeoIP) Country(ip string) string {
+	if g == nil {
+		return ""
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	// Последний диапазон, начинающийся не позже адреса
+	i := sort.Search(len(g.ranges), func(i int) bool { return addr.Less(g.ranges[i].start) }) - 1
+	if i < 0 {
+		return ""
+	}
+	r := g.ranges[i]
+	if addr.Is4() != r.start.Is4() || r.end.Less(addr) {
+		return ""
+	}
+	return r.country
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeoIP_Country проверяет поиск страны по диапазонам IPv4 и IPv6
+func TestGeoIP_Country(t *testing.T) {
+	geo, err := parseGeoIP(strings.NewReader(`# start,end,country
+1.0.0.0,1.0.0.255,au
+8.8.8.0,8.8.8.255,US
+2001:db8::,2001:db8::ffff,DE
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{name: "Range start", ip: "1.0.0.0", want: "AU"},
+		{name: "Inside range", ip: "8.8.8.8", want: "US"},
+		{name: "Range end", ip: "8.8.8.255", want: "US"},
+		{name: "Between ranges", ip: "5.5.5.5", want: ""},
+		{name: "Before first range", ip: "0.0.0.1", want: ""},
+		{name: "IPv4-mapped IPv6", ip: "::ffff:8.8.8.8", want: "US"},
+		{name: "IPv6", ip: "2001:db8::1", want: "DE"},
+		{name: "Invalid address", ip: "not-an-ip", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, geo.Country(tt.ip))
+		})
+	}
+}
+
+// TestLoadGeoIP проверяет загрузку базы из файла и ошибки формата
+func TestLoadGeoIP(t *testing.T) {
+	geo, err := LoadGeoIP("")
+	require.NoError(t, err)
+	assert.Nil(t, geo)
+	assert.Empty(t, geo.Country("8.8.8.8"), "nil database finds nothing")
+
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	require.NoError(t, os.WriteFile(path, []byte("8.8.8.0,8.8.8.255,US\n"), 0644))
+	geo, err = LoadGeoIP(path)
+	require.NoError(t, err)
+	assert.Equal(t, "US", geo.Country("8.8.8.8"))
+
+	_, err = parseGeoIP(strings.NewReader("8.8.8.255,8.8.8.0,US\n"))
+	assert.Error(t, err, "reversed range")
+
+	_, err = parseGeoIP(strings.NewReader("8.8.8.0,2001:db8::,US\n"))
+	assert.Error(t, err, "mixed address families")
+
+	_, err = LoadGeoIP(filepath.Join(t.TempDir(), "missing.csv"))
+	assert.Error(t, err)
+}
@@ -2,25 +2,31 @@
 package service
 
 import (
+	"time"
+
 	"github.com/avc-dev/url-shortener/internal/model"
 )
 
 // URLRepository определяет методы для работы с хранилищем URL
 type URLRepository interface {
 	// CreateOrGetURL создает новую запись или возвращает код существующей для данного URL и пользователя
-	CreateOrGetURL(code model.Code, url model.URL, userID string) (model.Code, bool, error)
-	// CreateURLsBatch сохраняет несколько пар код-URL для пользователя
-	CreateURLsBatch(urls map[model.Code]model.URL, userID string) error
+	CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error)
+	// CreateURLsBatch сохраняет несколько пар код-URL для пользователя с общими параметрами
+	CreateURLsBatch(urls map[model.Code]model.URL, userID string, opts model.LinkOptions) error
 	// GetURLByCode возвращает оригинальный URL по короткому коду
 	GetURLByCode(code model.Code) (model.URL, error)
-	// GetURLsByUserID возвращает все URL для указанного пользователя
-	GetURLsByUserID(userID string, baseURL string) ([]model.UserURLResponse, error)
+	// GetURLsByUserID возвращает страницу URL указанного пользователя
+	GetURLsByUserID(userID string, baseURL string, query model.URLQuery) (model.URLPage, error)
 	// IsCodeUnique проверяет, свободен ли код
 	IsCodeUnique(code model.Code) bool
 	// DeleteURLsBatch помечает несколько URL как удалённые для указанного пользователя
 	DeleteURLsBatch(codes []model.Code, userID string) error
 	// IsURLOwnedByUser проверяет, принадлежит ли URL указанному пользователю
 	IsURLOwnedByUser(code model.Code, userID string) bool
+	// ReleaseCodes помещает коды в пул переиспользования; они доступны с availableAt
+	ReleaseCodes(codes []model.Code, availableAt time.Time) error
+	// AcquireRecycledCode извлекает из пула код, карантин которого истёк к моменту now
+	AcquireRecycledCode(now time.Time) (model.Code, bool, error)
 }
 
 // Generator определяет интерфейс для генерации кодов
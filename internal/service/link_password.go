@@ -0,0 +1,30 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrLinkPasswordTooLong возвращается, когда пароль ссылки длиннее, чем допускает bcrypt (72 байта).
+var ErrLinkPasswordTooLong = errors.New("link password too long")
+
+// HashLinkPassword возвращает bcrypt-хеш пароля ссылки.
+// bcrypt включает случайную соль в результат и намеренно медленный,
+// поэтому утёкший хеш не позволяет быстро перебрать пароль.
+func HashLinkPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", ErrLinkPasswordTooLong
+		}
+		return "", fmt.Errorf("failed to hash link password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// CheckLinkPassword сообщает, соответствует ли пароль хешу, полученному из HashLinkPassword
+func CheckLinkPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
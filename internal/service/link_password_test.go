@@ -0,0 +1,51 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLinkPassword проверяет хеширование и проверку пароля ссылки
+func TestLinkPassword(t *testing.T) {
+	hash, err := HashLinkPassword("secret")
+	require.NoError(t, err)
+	assert.NotContains(t, hash, "secret")
+
+	assert.True(t, CheckLinkPassword(hash, "secret"))
+	assert.False(t, CheckLinkPassword(hash, "Secret"))
+
+	// Соль случайна: одинаковые пароли дают разные хеши
+	other, err := HashLinkPassword("secret")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	_, err = HashLinkPassword(strings.Repeat("x", 73))
+	assert.ErrorIs(t, err, ErrLinkPasswordTooLong)
+}
+
+// TestLinkAccessToken проверяет, что токен доступа привязан к коду и не подменяет токен пользователя
+func TestLinkAccessToken(t *testing.T) {
+	auth := NewAuthService("test-secret")
+
+	token, err := auth.GenerateLinkAccessToken("abc", time.Minute)
+	require.NoError(t, err)
+
+	assert.True(t, auth.ValidateLinkAccessToken(token, "abc"))
+	assert.False(t, auth.ValidateLinkAccessToken(token, "xyz"))
+	assert.False(t, NewAuthService("other-secret").ValidateLinkAccessToken(token, "abc"))
+
+	expired, err := auth.GenerateLinkAccessToken("abc", -time.Minute)
+	require.NoError(t, err)
+	assert.False(t, auth.ValidateLinkAccessToken(expired, "abc"))
+
+	_, err = auth.ValidateJWT(token)
+	assert.Error(t, err, "link access token must not authenticate a user")
+
+	userToken, err := auth.GenerateJWT("user-1")
+	require.NoError(t, err)
+	assert.False(t, auth.ValidateLinkAccessToken(userToken, "abc"))
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// Платформы устройства, по которым выбирается правило условного редиректа.
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformWindows = "windows"
+	PlatformMacOS   = "macos"
+	PlatformLinux   = "linux"
+	PlatformOther   = "other"
+)
+
+// IsPlatform сообщает, может ли platform быть условием правила редиректа.
+func IsPlatform(platform string) bool {
+	switch platform {
+	case PlatformIOS, PlatformAndroid, PlatformWindows, PlatformMacOS, PlatformLinux, PlatformOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// UserAgentPlatform определяет платформу устройства по заголовку User-Agent.
+// Проверки идут от частного к общему: User-Agent Android содержит «Linux»,
+// а iPhone и iPad — «like Mac OS X».
+func UserAgentPlatform(userAgent string) string {
+	switch {
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"),
+		strings.Contains(userAgent, "iPod"):
+		return PlatformIOS
+	case strings.Contains(userAgent, "Android"):
+		return PlatformAndroid
+	case strings.Contains(userAgent, "Windows"):
+		return PlatformWindows
+	case strings.Contains(userAgent, "Macintosh"), strings.Contains(userAgent, "Mac OS X"):
+		return PlatformMacOS
+	case strings.Contains(userAgent, "Linux"), strings.Contains(userAgent, "X11"):
+		return PlatformLinux
+	default:
+		return PlatformOther
+	}
+}
+
+// PreferredLanguage возвращает язык с наибольшим весом из заголовка Accept-Language
+// в нижнем регистре; при равных весах выигрывает указанный раньше.
+// Для пустого заголовка и «*» возвращает пустую строку.
+func PreferredLanguage(acceptLanguage string) string {
+	best, bestWeight := "", 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight > bestWeight {
+			best, bestWeight = tag, weight
+		}
+	}
+	return best
+}
+
+// RedirectClient — признаки посетителя, по которым выбирается правило редиректа.
+type RedirectClient struct {
+	// Platform — платформа устройства, см. UserAgentPlatform.
+	Platform string
+	// Language — предпочитаемый язык в нижнем регистре, см. PreferredLanguage.
+	Language string
+	// Country — двухбуквенный код страны; пустой, если страна не определена.
+	Country string
+}
+
+// NewRedirectClient определяет признаки посетителя по сведениям о переходе.
+// geo может быть nil — тогда страна не определяется и правила по странам не срабатывают.
+func NewRedirectClient(visit model.Visit, geo *GeoIP) RedirectClient {
+	return RedirectClient{
+		Platform: UserAgentPlatform(visit.UserAgent),
+		Language: PreferredLanguage(visit.AcceptLanguage),
+		Country:  geo.Country(visit.IP),
+	}
+}
+
+// MatchRedirectRule возвращает первое по порядку правило, все условия которого выполняются
+// для client. Языки и страны правил должны быть в каноническом виде: языки в нижнем регистре,
+// страны — в верхнем.
+func MatchRedirectRule(rules []model.RedirectRule, client RedirectClient) (model.RedirectRule, bool) {
+	for _, rule := range rules {
+		if len(rule.Platforms) > 0 && !slices.Contains(rule.Platforms, client.Platform) {
+			continue
+		}
+		if len(rule.Languages) > 0 && !slices.ContainsFunc(rule.Languages, func(lang string) bool {
+			return matchLanguage(lang, client.Language)
+		}) {
+			continue
+		}
+		if len(rule.Countries) > 0 && !slices.Contains(rule.Countries, client.Country) {
+			continue
+		}
+		return rule, true
+	}
+	return model.RedirectRule{}, false
+}
+
+// matchLanguage сообщает, подходит ли язык посетителя под язык правила:
+// "en" подходит для "en" и "en-us", "en-us" — только для "en-us"
+func matchLanguage(rule, language string) bool {
+	if language == "" {
+		return false
+	}
+	return language == rule || strings.HasPrefix(language, rule+"-")
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserAgentPlatform проверяет определение платформы устройства по User-Agent
+func TestUserAgentPlatform(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{name: "iPhone", userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1", want: PlatformIOS},
+		{name: "Android", userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36", want: PlatformAndroid},
+		{name: "Windows", userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36", want: PlatformWindows},
+		{name: "macOS", userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Safari/605.1.15", want: PlatformMacOS},
+		{name: "Linux", userAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0", want: PlatformLinux},
+		{name: "Unknown client", userAgent: "curl/8.5.0", want: PlatformOther},
+		{name: "Empty", userAgent: "", want: PlatformOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UserAgentPlatform(tt.userAgent))
+		})
+	}
+}
+
+// TestPreferredLanguage проверяет выбор языка с наибольшим весом из Accept-Language
+func TestPreferredLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "ru-RU,ru;q=0.9,en-US;q=0.8", want: "ru-ru"},
+		{header: "en;q=0.5, de", want: "de"},
+		{header: "fr;q=0.7, es;q=0.7", want: "fr"},
+		{header: "*, pt-BR;q=0.3", want: "pt-br"},
+		{header: "it;q=0, nl;q=bad", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, PreferredLanguage(tt.header))
+		})
+	}
+}
+
+// TestMatchRedirectRule проверяет, что выбирается первое правило, все условия которого выполняются
+func TestMatchRedirectRule(t *testing.T) {
+	rules := []model.RedirectRule{
+		{Platforms: []string{PlatformIOS}, URL: "https://apps.apple.com/app"},
+		{Platforms: []string{PlatformAndroid}, Countries: []string{"DE", "AT"}, URL: "https://play.google.com/de"},
+		{Platforms: []string{PlatformAndroid}, URL: "https://play.google.com/app"},
+		{Languages: []string{"pt"}, URL: "https://example.com/pt"},
+	}
+
+	tests := []struct {
+		name    string
+		client  RedirectClient
+		wantURL string
+	}{
+		{name: "Platform", client: RedirectClient{Platform: PlatformIOS, Country: "DE"}, wantURL: "https://apps.apple.com/app"},
+		{name: "Platform and country", client: RedirectClient{Platform: PlatformAndroid, Country: "AT"}, wantURL: "https://play.google.com/de"},
+		{name: "Later rule when country differs", client: RedirectClient{Platform: PlatformAndroid, Country: "FR"}, wantURL: "https://play.google.com/app"},
+		{name: "Language subtag", client: RedirectClient{Platform: PlatformWindows, Language: "pt-br"}, wantURL: "https://example.com/pt"},
+		{name: "Language prefix is not a subtag", client: RedirectClient{Platform: PlatformWindows, Language: "ptx"}},
+		{name: "No match", client: RedirectClient{Platform: PlatformMacOS, Language: "en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := MatchRedirectRule(rules, tt.client)
+			assert.Equal(t, tt.wantURL != "", ok)
+			assert.Equal(t, tt.wantURL, rule.URL)
+		})
+	}
+}
+
+func TestNewRedirectClient(t *testing.T) {
+	geo, err := parseGeoIP(strings.NewReader("8.8.8.0,8.8.8.255,US\n"))
+	require.NoError(t, err)
+
+	client := NewRedirectClient(model.Visit{
+		UserAgent:      "Mozilla/5.0 (Linux; Android 14; Pixel 8) Chrome/126.0.0.0 Mobile Safari/537.36",
+		AcceptLanguage: "en-GB,en;q=0.8",
+		IP:             "8.8.8.8",
+	}, geo)
+	assert.Equal(t, RedirectClient{Platform: PlatformAndroid, Language: "en-gb", Country: "US"}, client)
+
+	// Без базы GeoIP страна не определяется
+	assert.Empty(t, NewRedirectClient(model.Visit{IP: "8.8.8.8"}, nil).Country)
+}
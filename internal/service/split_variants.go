@@ -0,0 +1,43 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// SplitVisitorKey возвращает ключ посетителя сплит-ссылки code: посетитель с тем же адресом
+// и User-Agent без куки варианта попадает на тот же вариант.
+func SplitVisitorKey(code string, visit model.Visit) string {
+	return code + "\x00" + visit.IP + "\x00" + visit.UserAgent
+}
+
+// PickSplitVariant выбирает вариант сплит-ссылки и возвращает его номер, начиная с 1.
+// Закреплённый за посетителем вариант pinned сохраняется, пока он существует и его вес
+// не равен нулю; иначе вариант выбирается пропорционально весам по хешу key, поэтому
+// выбор для одного ключа не меняется, пока не изменятся веса.
+// Возвращает 0, если вариантов нет или сумма весов равна нулю.
+func PickSplitVariant(variants []model.SplitVariant, pinned int, key string) int {
+	if pinned >= 1 && pinned <= len(variants) && variants[pinned-1].Weight > 0 {
+		return pinned
+	}
+
+	total := 0
+	for _, variant := range variants {
+		total += variant.Weight
+	}
+	if total <= 0 {
+		return 0
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	point := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+	for i, variant := range variants {
+		if point < variant.Weight {
+			return i + 1
+		}
+		point -= variant.Weight
+	}
+	return 0
+}
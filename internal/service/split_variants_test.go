@@ -0,0 +1,54 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPickSplitVariant проверяет закрепление варианта и выбор по весам
+func TestPickSplitVariant(t *testing.T) {
+	variants := []model.SplitVariant{
+		{URL: "https://a.example.com", Weight: 1},
+		{URL: "https://b.example.com", Weight: 0},
+		{URL: "https://c.example.com", Weight: 3},
+	}
+
+	tests := []struct {
+		name     string
+		variants []model.SplitVariant
+		pinned   int
+		want     int
+	}{
+		{name: "Pinned variant kept", variants: variants, pinned: 1, want: 1},
+		{name: "Pinned variant with zero weight ignored", variants: []model.SplitVariant{{Weight: 0}, {Weight: 5}}, pinned: 1, want: 2},
+		{name: "Pinned variant out of range ignored", variants: []model.SplitVariant{{Weight: 5}}, pinned: 4, want: 1},
+		{name: "No variants", variants: nil, want: 0},
+		{name: "Zero total weight", variants: []model.SplitVariant{{Weight: 0}, {Weight: 0}}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PickSplitVariant(tt.variants, tt.pinned, "key"))
+		})
+	}
+}
+
+// TestPickSplitVariant_Distribution проверяет стабильность выбора и распределение посетителей по весам
+func TestPickSplitVariant_Distribution(t *testing.T) {
+	variants := []model.SplitVariant{{Weight: 1}, {Weight: 0}, {Weight: 3}}
+
+	counts := make(map[int]int)
+	for i := range 4000 {
+		key := SplitVisitorKey("abc", model.Visit{IP: "198.51.100." + strconv.Itoa(i%256), UserAgent: strconv.Itoa(i)})
+		variant := PickSplitVariant(variants, 0, key)
+		assert.Equal(t, variant, PickSplitVariant(variants, 0, key), "same visitor gets the same variant")
+		counts[variant]++
+	}
+
+	assert.Zero(t, counts[2], "zero-weight variant is never assigned")
+	assert.InDelta(t, 1000, counts[1], 150)
+	assert.InDelta(t, 3000, counts[3], 150)
+}
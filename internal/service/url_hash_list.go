@@ -0,0 +1,288 @@
+package service
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/netip"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Границы длины хеша в списке: от 4-байтового префикса до полного SHA-256
+const (
+	minURLHashLen = 4
+	maxURLHashLen = sha256.Size
+)
+
+// Ограничения числа выражений для одного адреса, как в Safe Browsing
+const (
+	maxHostSuffixes = 4
+	maxPathPrefixes = 4
+)
+
+// URLHashList проверяет адреса по локальному списку хешей вредоносных URL в формате
+// Safe Browsing: из адреса составляются выражения «суффикс хоста + префикс пути»
+// (например, «b.c/1/» для http://a.b.c/1/2.html), и адрес считается вредоносным,
+// если SHA-256 одного из выражений начинается с хеша из списка.
+// Файл можно заменить целиком; Reload подхватывает новую версию.
+// Безопасен для конкурентного использования; нулевой указатель ничего не находит.
+type URLHashList struct {
+	path string
+
+	mu      sync.RWMutex
+	hashes  map[int]map[string]struct{} // длина хеша в байтах → хеши этой длины
+	lengths []int
+	count   int
+	modTime time.Time
+	size    int64
+}
+
+// LoadURLHashList загружает список из файла: один хеш в шестнадцатеричном виде
+// (от 8 до 64 символов) на строку, строки, начинающиеся с '#', и пустые строки игнорируются.
+// Пустой путь означает, что список не используется.
+func LoadURLHashList(path string) (*URLHashList, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	l := &URLHashList{path: path}
+	if _, err := l.Reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Reload перечитывает файл, если с прошлой загрузки изменились время изменения или размер.
+// Возвращает true, если список обновлён. При ошибке остаётся прежний список.
+func (l *URLHashList) Reload() (bool, error) {
+	if l == nil {
+		return false, nil
+	}
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open URL hash list: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, fmt.Errorf("failed to stat URL hash list: %w", err)
+	}
+
+	l.mu.RLock()
+	unchanged := info.ModTime().Equal(l.modTime) && info.Size() == l.size
+	l.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	hashes, count, err := parseURLHashes(f)
+	if err != nil {
+		return false, err
+	}
+
+	lengths := make([]int, 0, len(hashes))
+	for n := range hashes {
+		lengths = append(lengths, n)
+	}
+	slices.Sort(lengths)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hashes = hashes
+	l.lengths = lengths
+	l.count = count
+	l.modTime = info.ModTime()
+	l.size = info.Size()
+	return true, nil
+}
+
+// parseURLHashes разбирает файл хешей, группируя хеши по длине
+func parseURLHashes(r io.Reader) (map[int]map[string]struct{}, int, error) {
+	hashes := make(map[int]map[string]struct{})
+	count := 0
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		hash, err := hex.DecodeString(text)
+		if err != nil {
+			return nil, 0, fmt.Errorf("URL hash list line %d: %w", line, err)
+		}
+		if len(hash) < minURLHashLen || len(hash) > maxURLHashLen {
+			return nil, 0, fmt.Errorf("URL hash list line %d: hash must be %d to %d bytes, got %d",
+				line, minURLHashLen, maxURLHashLen, len(hash))
+		}
+
+		set, ok := hashes[len(hash)]
+		if !ok {
+			set = make(map[string]struct{})
+			hashes[len(hash)] = set
+		}
+		if _, dup := set[string(hash)]; !dup {
+			set[string(hash)] = struct{}{}
+			count++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read URL hash list: %w", err)
+	}
+	return hashes, count, nil
+}
+
+// Len возвращает число хешей в списке
+func (l *URLHashList) Len() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.count
+}
+
+// CheckURL сообщает, есть ли адрес в списке. Вместе с результатом возвращает
+// совпавшее выражение. Ошибки не возвращает: список проверяется локально.
+func (l *URLHashList) CheckURL(target *url.URL) (string, bool, error) {
+	if l == nil {
+		return "", false, nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.count == 0 {
+		return "", false, nil
+	}
+
+	for _, expr := range urlHashExpressions(target) {
+		sum := sha256.Sum256([]byte(expr))
+		for _, n := range l.lengths {
+			if _, ok := l.hashes[n][string(sum[:n])]; ok {
+				return expr, true, nil
+			}
+		}
+	}
+	return "", false, nil
+}
+
+// urlHashExpressions составляет выражения адреса для поиска в списке: точный хост
+// и до четырёх его суффиксов из последних пяти компонентов (без домена верхнего уровня),
+// в сочетании с полным путём с запросом, путём без запроса и до четырёх префиксов пути.
+// Схема, порт, данные пользователя и фрагмент в выражения не входят.
+func urlHashExpressions(target *url.URL) []string {
+	host := canonicalHashHost(target.Hostname())
+	if host == "" {
+		return nil
+	}
+
+	hosts := []string{host}
+	if !isHashHostIP(host) {
+		parts := strings.Split(host, ".")
+		for i := max(1, len(parts)-maxHostSuffixes-1); i <= len(parts)-2; i++ {
+			hosts = append(hosts, strings.Join(parts[i:], "."))
+		}
+	}
+
+	p := canonicalHashPath(target)
+	var paths []string
+	if target.RawQuery != "" {
+		paths = append(paths, p+"?"+escapeHashComponent(unescapeFully(target.RawQuery)))
+	}
+	paths = append(paths, p)
+	for i, prefixes := 0, 0; i+1 < len(p) && prefixes < maxPathPrefixes; i++ {
+		if p[i] == '/' {
+			paths = append(paths, p[:i+1])
+			prefixes++
+		}
+	}
+
+	exprs := make([]string, 0, len(hosts)*len(paths))
+	for _, h := range hosts {
+		for _, pp := range paths {
+			expr := h + pp
+			if !slices.Contains(exprs, expr) {
+				exprs = append(exprs, expr)
+			}
+		}
+	}
+	return exprs
+}
+
+// canonicalHashHost приводит хост к каноническому виду: нижний регистр, punycode,
+// без точек по краям и повторяющихся точек, IPv4 в десятичной записи с точками
+func canonicalHashHost(hostname string) string {
+	host := strings.ToLower(unescapeFully(hostname))
+	host = strings.Trim(host, ".")
+	for strings.Contains(host, "..") {
+		host = strings.ReplaceAll(host, "..", ".")
+	}
+	if host == "" {
+		return ""
+	}
+	if addr, ok := parseIPv4Literal(host); ok {
+		return addr.String()
+	}
+	if ascii, err := hostProfile.ToASCII(host); err == nil {
+		host = ascii
+	}
+	return escapeHashComponent(host)
+}
+
+// isHashHostIP сообщает, является ли канонический хост IP-адресом
+func isHashHostIP(host string) bool {
+	_, err := netip.ParseAddr(host)
+	return err == nil
+}
+
+// canonicalHashPath возвращает путь адреса без «.» и «..» и повторяющихся «/»,
+// с сохранением завершающего «/»
+func canonicalHashPath(target *url.URL) string {
+	p := unescapeFully(target.EscapedPath())
+	if p == "" {
+		return "/"
+	}
+	cleaned := path.Clean("/" + p)
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return escapeHashComponent(cleaned)
+}
+
+// unescapeFully снимает процентное кодирование, пока строка меняется
+func unescapeFully(s string) string {
+	for strings.Contains(s, "%") {
+		unescaped, err := url.PathUnescape(s)
+		if err != nil || unescaped == s {
+			break
+		}
+		s = unescaped
+	}
+	return s
+}
+
+// escapeHashComponent кодирует управляющие символы, пробел, байты вне ASCII, «#» и «%»
+func escapeHashComponent(s string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= 0x20 || c >= 0x7f || c == '#' || c == '%' {
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&0x0f])
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// urlHash возвращает первые n байт SHA-256 выражения в шестнадцатеричном виде
+func urlHash(expr string, n int) string {
+	sum := sha256.Sum256([]byte(expr))
+	return hex.EncodeToString(sum[:n])
+}
+
+// writeURLHashList записывает файл списка хешей
+func writeURLHashList(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644))
+}
+
+// TestURLHashExpressions проверяет составление выражений по примерам Safe Browsing
+func TestURLHashExpressions(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want []string
+	}{
+		{
+			name: "Host suffixes and path prefixes",
+			url:  "http://a.b.c/1/2.html?param=1",
+			want: []string{
+				"a.b.c/1/2.html?param=1", "a.b.c/1/2.html", "a.b.c/", "a.b.c/1/",
+				"b.c/1/2.html?param=1", "b.c/1/2.html", "b.c/", "b.c/1/",
+			},
+		},
+		{
+			name: "At most four host suffixes from last five components",
+			url:  "http://a.b.c.d.e.f.g/1.html",
+			want: []string{
+				"a.b.c.d.e.f.g/1.html", "a.b.c.d.e.f.g/",
+				"c.d.e.f.g/1.html", "c.d.e.f.g/",
+				"d.e.f.g/1.html", "d.e.f.g/",
+				"e.f.g/1.html", "e.f.g/",
+				"f.g/1.html", "f.g/",
+			},
+		},
+		{
+			name: "IP host has no suffixes",
+			url:  "http://1.2.3.4/1/",
+			want: []string{"1.2.3.4/1/", "1.2.3.4/"},
+		},
+		{
+			name: "Canonical form",
+			url:  "HTTPS://User@WWW.Evil.TEST.:8443/a/./b/../c//d.html#frag",
+			want: []string{
+				"www.evil.test/a/c/d.html", "www.evil.test/", "www.evil.test/a/", "www.evil.test/a/c/",
+				"evil.test/a/c/d.html", "evil.test/", "evil.test/a/", "evil.test/a/c/",
+			},
+		},
+		{
+			name: "Repeated escaping",
+			url:  "http://evil.test/%2541%2542",
+			want: []string{"evil.test/AB", "evil.test/"},
+		},
+		{
+			name: "Hex IPv4 host",
+			url:  "http://0x7f.1/",
+			want: []string{"127.0.0.1/"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, urlHashExpressions(u))
+		})
+	}
+}
+
+// TestURLHashList_CheckURL проверяет совпадения по полным хешам и префиксам хешей
+func TestURLHashList_CheckURL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hashes.txt")
+	writeURLHashList(t, path,
+		"# malicious URL prefixes",
+		urlHash("evil.test/", 32),
+		urlHash("phish.example/login/", 4),
+		strings.ToUpper(urlHash("bad.example/exact.html", 8)),
+	)
+
+	list, err := LoadURLHashList(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, list.Len())
+
+	tests := []struct {
+		name      string
+		url       string
+		wantMatch string
+	}{
+		{name: "Whole domain", url: "https://www.evil.test/any/path?q=1", wantMatch: "evil.test/"},
+		{name: "Path prefix", url: "http://phish.example/login/step2.php", wantMatch: "phish.example/login/"},
+		{name: "Path prefix not matched", url: "http://phish.example/about.html"},
+		{name: "Exact path", url: "http://bad.example/exact.html?x=1", wantMatch: "bad.example/exact.html"},
+		{name: "Exact path does not cover siblings", url: "http://bad.example/other.html"},
+		{name: "Unrelated", url: "https://example.com/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+			match, malicious, err := list.CheckURL(u)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMatch != "", malicious)
+			assert.Equal(t, tt.wantMatch, match)
+		})
+	}
+}
+
+// TestURLHashList_Reload проверяет подхват заменённого файла и сохранение списка при ошибке
+func TestURLHashList_Reload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hashes.txt")
+	writeURLHashList(t, path, urlHash("evil.test/", 32))
+
+	list, err := LoadURLHashList(path)
+	require.NoError(t, err)
+
+	evil, _ := url.Parse("https://evil.test/")
+	other, _ := url.Parse("https://other.test/")
+
+	reloaded, err := list.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "unchanged file is not reloaded")
+
+	// Замена файла переименованием, как при атомарном обновлении списка
+	replacement := filepath.Join(dir, "hashes.new")
+	writeURLHashList(t, replacement, urlHash("other.test/", 32), urlHash("more.test/", 32))
+	require.NoError(t, os.Chtimes(replacement, time.Now(), time.Now().Add(time.Minute)))
+	require.NoError(t, os.Rename(replacement, path))
+
+	reloaded, err = list.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, 2, list.Len())
+	_, malicious, _ := list.CheckURL(evil)
+	assert.False(t, malicious)
+	_, malicious, _ = list.CheckURL(other)
+	assert.True(t, malicious)
+
+	writeURLHashList(t, path, "not-a-hash")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	_, err = list.Reload()
+	require.Error(t, err)
+	_, malicious, _ = list.CheckURL(other)
+	assert.True(t, malicious, "previous list is kept after a failed reload")
+}
+
+// TestLoadURLHashList_Errors проверяет отказ для некорректных файлов
+func TestLoadURLHashList_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadURLHashList(filepath.Join(dir, "missing.txt"))
+	assert.Error(t, err)
+
+	for name, line := range map[string]string{
+		"Not hex":   "zz" + urlHash("evil.test/", 4),
+		"Too short": urlHash("evil.test/", 3),
+		"Too long":  urlHash("evil.test/", 32) + "00",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, "hashes.txt")
+			writeURLHashList(t, path, line)
+			_, err := LoadURLHashList(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestURLHashList_Nil проверяет, что нулевой список ничего не находит
+func TestURLHashList_Nil(t *testing.T) {
+	var list *URLHashList
+	u, _ := url.Parse("https://evil.test/")
+	_, malicious, err := list.CheckURL(u)
+	require.NoError(t, err)
+	assert.False(t, malicious)
+
+	list, err = LoadURLHashList("")
+	require.NoError(t, err)
+	assert.Nil(t, list)
+}
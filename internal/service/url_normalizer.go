@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultPorts — порты по умолчанию, которые не входят в канонический вид адреса
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// trackingParams — параметры отслеживания, не влияющие на содержимое страницы.
+// Кроме перечисленных удаляются все параметры с префиксом utm_.
+var trackingParams = map[string]struct{}{
+	"fbclid":  {},
+	"gclid":   {},
+	"dclid":   {},
+	"gbraid":  {},
+	"wbraid":  {},
+	"msclkid": {},
+	"yclid":   {},
+	"igshid":  {},
+	"mc_cid":  {},
+	"mc_eid":  {},
+	"_ga":     {},
+	"_gl":     {},
+}
+
+// hostProfile переводит интернационализированные имена хостов в punycode.
+// Строгая проверка имени отключена: в реальных именах хостов встречается «_».
+var hostProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.StrictDomainName(false))
+
+// URLNormalizer приводит адреса к каноническому виду, чтобы записи одного адреса
+// в разном написании считались одинаковыми при дедупликации.
+type URLNormalizer struct {
+	sortQuery     bool
+	stripTracking bool
+}
+
+// NewURLNormalizer создаёт URLNormalizer. sortQuery сортирует query-параметры по ключу,
+// stripTracking удаляет известные параметры отслеживания.
+func NewURLNormalizer(sortQuery, stripTracking bool) *URLNormalizer {
+	return &URLNormalizer{sortQuery: sortQuery, stripTracking: stripTracking}
+}
+
+// Normalize возвращает канонический вид абсолютного адреса u: схема и хост в нижнем регистре,
+// хост в punycode, без порта по умолчанию, путь без «.», «..» и повторных «/».
+// Кодирование пути, значения query-параметров и фрагмент сохраняются как есть.
+// nil-нормализатор возвращает адрес без изменений.
+func (n *URLNormalizer) Normalize(u *url.URL) (*url.URL, error) {
+	if n == nil {
+		return u, nil
+	}
+
+	normalized := *u
+	normalized.Scheme = strings.ToLower(u.Scheme)
+
+	host, err := normalizeHost(u.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	if port := u.Port(); port != "" && port != defaultPorts[normalized.Scheme] {
+		host += ":" + port
+	}
+	normalized.Host = host
+
+	if err := cleanPath(&normalized); err != nil {
+		return nil, err
+	}
+
+	normalized.RawQuery = n.normalizeQuery(u.RawQuery)
+	normalized.ForceQuery = false
+	return &normalized, nil
+}
+
+// normalizeHost приводит имя хоста к нижнему регистру и punycode; IPv6-адрес возвращается в скобках
+func normalizeHost(hostname string) (string, error) {
+	if strings.Contains(hostname, ":") {
+		return "[" + strings.ToLower(hostname) + "]", nil
+	}
+	ascii, err := hostProfile.ToASCII(hostname)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", hostname, err)
+	}
+	return ascii, nil
+}
+
+// cleanPath убирает из пути «.», «..» и повторные «/», сохраняя завершающий «/» и кодирование.
+// Пустой путь HTTP(S)-адреса заменяется на «/»: для сервера это один и тот же ресурс.
+func cleanPath(u *url.URL) error {
+	escaped := u.EscapedPath()
+	if escaped == "" {
+		if _, ok := defaultPorts[u.Scheme]; ok {
+			u.Path, u.RawPath = "/", ""
+		}
+		return nil
+	}
+
+	cleaned := path.Clean(escaped)
+	if strings.HasSuffix(escaped, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	unescaped, err := url.PathUnescape(cleaned)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	u.Path, u.RawPath = unescaped, cleaned
+	return nil
+}
+
+// normalizeQuery удаляет пустые пары и, если настроено, параметры отслеживания,
+// а затем сортирует пары по ключу, сохраняя порядок одноимённых параметров
+func (n *URLNormalizer) normalizeQuery(rawQuery string) string {
+	var pairs []string
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" || n.stripTracking && isTrackingParam(queryPairKey(pair)) {
+			continue
+		}
+		pairs = append(pairs, pair)
+	}
+	if n.sortQuery {
+		slices.SortStableFunc(pairs, func(a, b string) int {
+			return strings.Compare(queryPairKey(a), queryPairKey(b))
+		})
+	}
+	return strings.Join(pairs, "&")
+}
+
+// isTrackingParam сообщает, является ли key параметром отслеживания
+func isTrackingParam(key string) bool {
+	key = strings.ToLower(key)
+	if strings.HasPrefix(key, "utm_") {
+		return true
+	}
+	_, ok := trackingParams[key]
+	return ok
+}
+
+// queryPairKey возвращает раскодированный ключ пары query-строки; нераскодируемый ключ сравнивается как есть
+func queryPairKey(pair string) string {
+	key, _, _ := strings.Cut(pair, "=")
+	if decoded, err := url.QueryUnescape(key); err == nil {
+		return decoded
+	}
+	return key
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestURLNormalizer_Normalize проверяет приведение адресов к каноническому виду
+func TestURLNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer *URLNormalizer
+		input      string
+		want       string
+	}{
+		{
+			name:       "Scheme and host lowercased, default port dropped",
+			normalizer: NewURLNormalizer(false, false),
+			input:      "HTTP://Example.COM:80/Path?b=1&a=2",
+			want:       "http://example.com/Path?b=1&a=2",
+		},
+		{
+			name:       "Non-default port kept",
+			normalizer: NewURLNormalizer(false, false),
+			input:      "https://example.com:8443/",
+			want:       "https://example.com:8443/",
+		},
+		{
+			name:       "Empty path becomes root",
+			normalizer: NewURLNormalizer(false, false),
+			input:      "https://example.com?q=1",
+			want:       "https://example.com/?q=1",
+		},
+		{
+			name:       "Path cleaned, trailing slash and encoding kept",
+			normalizer: NewURLNormalizer(false, false),
+			input:      "https://example.com/a//b/./c/../d%2Fe/",
+			want:       "https://example.com/a/b/d%2Fe/",
+		},
+		{
+			name:       "IDN converted to punycode",
+			normalizer: NewURLNormalizer(false, false),
+			input:      "https://Пример.рф/путь",
+			want:       "https://xn--e1afmkfd.xn--p1ai/%D0%BF%D1%83%D1%82%D1%8C",
+		},
+		{
+			name:       "IPv6 host",
+			normalizer: NewURLNormalizer(false, false),
+			input:      "http://[2001:DB8::1]:80/",
+			want:       "http://[2001:db8::1]/",
+		},
+		{
+			name:       "Query sorted by key, repeated keys keep order",
+			normalizer: NewURLNormalizer(true, false),
+			input:      "http://example.com/a?b=1&a=2&b=0",
+			want:       "http://example.com/a?a=2&b=1&b=0",
+		},
+		{
+			name:       "Tracking parameters stripped",
+			normalizer: NewURLNormalizer(false, true),
+			input:      "https://example.com/?id=7&utm_source=tw&UTM_Medium=x&fbclid=abc&gclid=1#top",
+			want:       "https://example.com/?id=7#top",
+		},
+		{
+			name:       "Nil normalizer keeps address",
+			normalizer: nil,
+			input:      "http://Example.com:80",
+			want:       "http://Example.com:80",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := url.Parse(tt.input)
+			require.NoError(t, err)
+			before := parsed.String()
+
+			normalized, err := tt.normalizer.Normalize(parsed)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, normalized.String())
+			assert.Equal(t, before, parsed.String(), "input is not modified")
+		})
+	}
+}
+
+// TestURLNormalizer_SameCanonicalForm проверяет, что разные написания одного адреса совпадают
+func TestURLNormalizer_SameCanonicalForm(t *testing.T) {
+	normalizer := NewURLNormalizer(true, false)
+
+	first, err := url.Parse("HTTP://Example.com:80/a?b=1&a=2")
+	require.NoError(t, err)
+	second, err := url.Parse("http://example.com/a?a=2&b=1")
+	require.NoError(t, err)
+
+	firstNormalized, err := normalizer.Normalize(first)
+	require.NoError(t, err)
+	secondNormalized, err := normalizer.Normalize(second)
+	require.NoError(t, err)
+	assert.Equal(t, secondNormalized.String(), firstNormalized.String())
+}
+
+// TestURLNormalizer_InvalidHost проверяет отказ для имени хоста, которое нельзя перевести в punycode
+func TestURLNormalizer_InvalidHost(t *testing.T) {
+	parsed, err := url.Parse("https://xn--a.example/")
+	require.NoError(t, err)
+
+	_, err = NewURLNormalizer(false, false).Normalize(parsed)
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrSchemeNotAllowed возвращается, когда схема адреса не входит в разрешённый список.
+	ErrSchemeNotAllowed = errors.New("scheme is not allowed")
+	// ErrURLUserInfo возвращается, когда адрес содержит имя пользователя или пароль.
+	ErrURLUserInfo = errors.New("credentials in URL are not allowed")
+	// ErrPrivateHost возвращается, когда адрес указывает на localhost или частную сеть.
+	ErrPrivateHost = errors.New("localhost and private network targets are not allowed")
+	// ErrSelfReference возвращается, когда адрес указывает на сам сервис и привёл бы к циклу редиректов.
+	ErrSelfReference = errors.New("URL points back to the shortener")
+)
+
+// sharedAddressSpace — диапазон CGNAT (RFC 6598), не маршрутизируемый в интернете
+// и не входящий в netip.Addr.IsPrivate.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// URLPolicy проверяет адреса назначения перед сохранением: разрешённые схемы,
+// отсутствие учётных данных, отсутствие ссылок на localhost, частные сети и сам сервис.
+// Имена хостов не резолвятся: проверяются только литералы IP-адресов и локальные имена.
+type URLPolicy struct {
+	schemes      map[string]struct{}
+	allowPrivate bool
+	selfHost     string
+}
+
+// NewURLPolicy создаёт URLPolicy. schemes — разрешённые схемы, allowPrivate разрешает
+// адреса localhost и частных сетей, baseURL — собственный адрес сервиса.
+func NewURLPolicy(schemes []string, allowPrivate bool, baseURL string) *URLPolicy {
+	p := &URLPolicy{
+		schemes:      make(map[string]struct{}, len(schemes)),
+		allowPrivate: allowPrivate,
+	}
+	for _, scheme := range schemes {
+		p.schemes[strings.ToLower(scheme)] = struct{}{}
+	}
+	if base, err := url.Parse(baseURL); err == nil && base.Host != "" {
+		p.selfHost = hostKey(base)
+	}
+	return p
+}
+
+// Check проверяет абсолютный адрес u и возвращает ошибку, оборачивающую одну из
+// ErrSchemeNotAllowed, ErrURLUserInfo, ErrSelfReference или ErrPrivateHost.
+func (p *URLPolicy) Check(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if _, ok := p.schemes[scheme]; !ok {
+		return fmt.Errorf("%w: %q", ErrSchemeNotAllowed, scheme)
+	}
+	if u.User != nil {
+		return ErrURLUserInfo
+	}
+	if p.selfHost != "" && hostKey(u) == p.selfHost {
+		return fmt.Errorf("%w: %s", ErrSelfReference, u.Host)
+	}
+	if !p.allowPrivate && isPrivateHost(u.Hostname()) {
+		return fmt.Errorf("%w: %s", ErrPrivateHost, u.Hostname())
+	}
+	return nil
+}
+
+// hostKey возвращает хост адреса в виде для сравнения: нижний регистр, punycode,
+// без завершающей точки, с явным портом (порт по умолчанию подставляется по схеме)
+func hostKey(u *url.URL) string {
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if ascii, err := hostProfile.ToASCII(host); err == nil {
+		host = ascii
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPorts[strings.ToLower(u.Scheme)]
+	}
+	return host + ":" + port
+}
+
+// isPrivateHost сообщает, указывает ли имя хоста на localhost, локальную сеть
+// или литерал IP-адреса из немаршрутизируемого диапазона
+func isPrivateHost(hostname string) bool {
+	host := strings.TrimSuffix(strings.ToLower(hostname), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		var ok bool
+		if addr, ok = parseIPv4Literal(host); !ok {
+			return false
+		}
+	}
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		sharedAddressSpace.Contains(addr)
+}
+
+// parseIPv4Literal разбирает сокращённые и нестандартные записи IPv4, которые браузеры
+// понимают как адрес (например, «127.1», «0x7f.0.0.1», «2130706433»)
+func parseIPv4Literal(host string) (netip.Addr, bool) {
+	parts := strings.Split(host, ".")
+	if len(parts) > 4 {
+		return netip.Addr{}, false
+	}
+
+	values := make([]uint64, len(parts))
+	for i, part := range parts {
+		value, err := parseIPv4Part(part)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		values[i] = value
+	}
+
+	last := len(values) - 1
+	var ip uint64
+	for _, value := range values[:last] {
+		if value > 255 {
+			return netip.Addr{}, false
+		}
+		ip = ip<<8 | value
+	}
+	// Последняя часть занимает все оставшиеся байты адреса
+	restBits := uint(8 * (4 - last))
+	if values[last] >= 1<<restBits {
+		return netip.Addr{}, false
+	}
+	ip = ip<<restBits | values[last]
+
+	return netip.AddrFrom4([4]byte{byte(ip >> 24), byte(ip >> 16), byte(ip >> 8), byte(ip)}), true
+}
+
+// parseIPv4Part разбирает часть IPv4-литерала в десятичной, восьмеричной («0» в начале)
+// или шестнадцатеричной («0x» в начале) записи
+func parseIPv4Part(part string) (uint64, error) {
+	base := 10
+	switch {
+	case len(part) > 2 && (strings.HasPrefix(part, "0x") || strings.HasPrefix(part, "0X")):
+		part, base = part[2:], 16
+	case len(part) > 1 && part[0] == '0':
+		part, base = part[1:], 8
+	}
+	return strconv.ParseUint(part, base, 32)
+}
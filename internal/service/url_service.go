@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/config"
 	"github.com/avc-dev/url-shortener/internal/model"
@@ -11,30 +12,78 @@ import (
 type URLService struct {
 	repo          URLRepository
 	codeGenerator Generator
+	wordGenerator Generator
+	codeFilter    *CodeFilter
+	recycleCodes  bool
 	cfg           *config.Config
 }
 
+// URLServiceOption настраивает необязательные зависимости URLService.
+type URLServiceOption func(*URLService)
+
+// WithCodeFilter задаёт фильтр, через который проходят все сгенерированные коды.
+// По умолчанию используется фильтр только со встроенным списком ReservedCodes.
+func WithCodeFilter(filter *CodeFilter) URLServiceOption {
+	return func(s *URLService) {
+		s.codeFilter = filter
+	}
+}
+
+// WithWordGenerator задаёт генератор для стиля model.CodeStyleWords.
+// По умолчанию используется WordCodeGenerator со встроенным списком слов.
+func WithWordGenerator(generator Generator) URLServiceOption {
+	return func(s *URLService) {
+		s.wordGenerator = generator
+	}
+}
+
+// WithCodeRecycling включает выдачу кодов из пула переиспользования.
+// Коды из пула выдаются только для стиля по умолчанию: пользователь,
+// запросивший читаемый код, должен получить код из слов.
+func WithCodeRecycling() URLServiceOption {
+	return func(s *URLService) {
+		s.recycleCodes = true
+	}
+}
+
 // NewURLService создает новый экземпляр URLService
-func NewURLService(repo URLRepository, cfg *config.Config) *URLService {
+func NewURLService(repo URLRepository, cfg *config.Config, opts ...URLServiceOption) *URLService {
 	codeGenerator := NewCodeGenerator()
-	return &URLService{
+	if cfg.CaseInsensitiveCodes {
+		codeGenerator = NewCodeGeneratorWithAlphabet(SingleCaseChars)
+	}
+	s := &URLService{
 		repo:          repo,
 		codeGenerator: codeGenerator,
+		wordGenerator: NewWordCodeGenerator(DefaultWordList(), DefaultWordCount),
+		codeFilter:    NewCodeFilter(nil),
 		cfg:           cfg,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CreateShortURL - основная бизнес-логика для создания короткого URL
-// Генерирует уникальный код и сохраняет его вместе с оригинальным URL и userID
-func (s *URLService) CreateShortURL(originalURL model.URL, userID string) (model.Code, bool, error) {
-	// Генерируем уникальный код
-	code, err := s.generateUniqueCode()
-	if err != nil {
-		return "", false, fmt.Errorf("failed to generate unique code: %w", err)
+// Генерирует уникальный код в стиле opts.CodeStyle и сохраняет его вместе с оригинальным URL и userID
+func (s *URLService) CreateShortURL(originalURL model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error) {
+	// Берём код из пула переиспользования или генерируем новый уникальный код
+	code, recycled := s.acquireRecycledCode(opts.CodeStyle, nil)
+	if !recycled {
+		var err error
+		code, err = s.generateUniqueCode(s.generatorFor(opts.CodeStyle))
+		if err != nil {
+			return "", false, fmt.Errorf("failed to generate unique code: %w", err)
+		}
 	}
 
 	// Создаем запись или получаем существующую для данного URL и пользователя
-	finalCode, created, err := s.repo.CreateOrGetURL(code, originalURL, userID)
+	finalCode, created, err := s.repo.CreateOrGetURL(code, originalURL, userID, opts)
+	if recycled && (err != nil || !created) {
+		// Код из пула не пригодился — возвращаем его, карантин он уже прошёл
+		s.returnRecycledCodes([]model.Code{code})
+	}
 	if err != nil {
 		return "", false, fmt.Errorf("failed to create or get URL: %w", err)
 	}
@@ -42,10 +91,14 @@ func (s *URLService) CreateShortURL(originalURL model.URL, userID string) (model
 	return finalCode, created, nil
 }
 
-// generateUniqueCode генерирует уникальный код, проверяя его через IsCodeUnique
-func (s *URLService) generateUniqueCode() (model.Code, error) {
+// generateUniqueCode генерирует уникальный код, проверяя его через фильтр и IsCodeUnique.
+// Код, отклонённый фильтром, расходует попытку так же, как занятый код.
+func (s *URLService) generateUniqueCode(generator Generator) (model.Code, error) {
 	for attempt := 0; attempt < s.cfg.Retry.MaxAttempts; attempt++ {
-		code := s.codeGenerator.GenerateCode()
+		code := generator.GenerateCode()
+		if !s.codeFilter.Allow(code) {
+			continue
+		}
 		if s.repo.IsCodeUnique(code) {
 			return code, nil
 		}
@@ -55,12 +108,12 @@ func (s *URLService) generateUniqueCode() (model.Code, error) {
 }
 
 // generateUniqueCodeForBatch генерирует уникальный код для батча, учитывая уже использованные коды в рамках батча
-func (s *URLService) generateUniqueCodeForBatch(usedInBatch map[model.Code]bool) (model.Code, error) {
+func (s *URLService) generateUniqueCodeForBatch(generator Generator, usedInBatch map[model.Code]bool) (model.Code, error) {
 	for attempt := 0; attempt < s.cfg.Retry.MaxAttempts; attempt++ {
-		code := s.codeGenerator.GenerateCode()
+		code := generator.GenerateCode()
 
-		// Проверяем конфликт в рамках батча
-		if usedInBatch[code] {
+		// Проверяем конфликт в рамках батча и запрещённые коды
+		if usedInBatch[code] || !s.codeFilter.Allow(code) {
 			continue
 		}
 
@@ -73,20 +126,72 @@ func (s *URLService) generateUniqueCodeForBatch(usedInBatch map[model.Code]bool)
 	return "", fmt.Errorf("failed to generate unique code for batch after %d attempts: %w", s.cfg.Retry.MaxAttempts, ErrMaxRetriesExceeded)
 }
 
+// acquireRecycledCode извлекает код из пула переиспользования, если он включён
+// и запрошен стиль по умолчанию. Код из пула проходит те же проверки, что и сгенерированный;
+// отклонённый код из пула выбрасывается. Ошибка пула не мешает созданию ссылки:
+// в этом случае код генерируется обычным способом.
+func (s *URLService) acquireRecycledCode(style model.CodeStyle, usedInBatch map[model.Code]bool) (model.Code, bool) {
+	if !s.recycleCodes || style == model.CodeStyleWords {
+		return "", false
+	}
+
+	code, ok, err := s.repo.AcquireRecycledCode(time.Now())
+	if err != nil || !ok {
+		return "", false
+	}
+	if usedInBatch[code] || !s.codeFilter.Allow(code) || !s.repo.IsCodeUnique(code) {
+		return "", false
+	}
+
+	return code, true
+}
+
+// returnRecycledCodes возвращает неиспользованные коды в пул.
+// Ошибка игнорируется: потерянный код просто не будет переиспользован.
+func (s *URLService) returnRecycledCodes(codes []model.Code) {
+	if len(codes) == 0 {
+		return
+	}
+	_ = s.repo.ReleaseCodes(codes, time.Now())
+}
+
+// generatorFor возвращает генератор для указанного стиля кода
+func (s *URLService) generatorFor(style model.CodeStyle) Generator {
+	if style == model.CodeStyleWords && s.wordGenerator != nil {
+		return s.wordGenerator
+	}
+	return s.codeGenerator
+}
+
 // CreateShortURLsBatch создает короткие URL для нескольких оригинальных URL.
-// Генерирует уникальные коды для каждого URL и сохраняет их в одной транзакции.
+// Генерирует уникальные коды в стиле opts.CodeStyle для каждого URL и сохраняет их в одной транзакции.
 // Использует обратную карту codeForURL для O(n) восстановления порядка
 // вместо O(n²) двойного перебора.
-func (s *URLService) CreateShortURLsBatch(originalURLs []model.URL, userID string) ([]model.Code, error) {
+func (s *URLService) CreateShortURLsBatch(originalURLs []model.URL, userID string, opts model.LinkOptions) ([]model.Code, error) {
+	generator := s.generatorFor(opts.CodeStyle)
 	urlMap := make(map[model.Code]model.URL, len(originalURLs))
 	codeForURL := make(map[model.URL]model.Code, len(originalURLs))
 	usedCodes := make(map[model.Code]bool, len(originalURLs))
+	var recycledCodes []model.Code
+	usePool := true
 
-	// Генерируем уникальные коды для каждого URL
+	// Берём коды из пула переиспользования, пока он не опустеет, затем генерируем уникальные
 	for _, url := range originalURLs {
-		code, err := s.generateUniqueCodeForBatch(usedCodes)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate unique code for batch: %w", err)
+		var code model.Code
+		recycled := false
+		if usePool {
+			code, recycled = s.acquireRecycledCode(opts.CodeStyle, usedCodes)
+			usePool = recycled
+		}
+		if recycled {
+			recycledCodes = append(recycledCodes, code)
+		} else {
+			var err error
+			code, err = s.generateUniqueCodeForBatch(generator, usedCodes)
+			if err != nil {
+				s.returnRecycledCodes(recycledCodes)
+				return nil, fmt.Errorf("failed to generate unique code for batch: %w", err)
+			}
 		}
 		urlMap[code] = url
 		codeForURL[url] = code
@@ -94,8 +199,9 @@ func (s *URLService) CreateShortURLsBatch(originalURLs []model.URL, userID strin
 	}
 
 	// Сохраняем все URL в одной транзакции
-	err := s.repo.CreateURLsBatch(urlMap, userID)
+	err := s.repo.CreateURLsBatch(urlMap, userID, opts)
 	if err != nil {
+		s.returnRecycledCodes(recycledCodes)
 		return nil, fmt.Errorf("failed to create URLs batch: %w", err)
 	}
 
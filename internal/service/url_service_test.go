@@ -1,12 +1,14 @@
 package service
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/avc-dev/url-shortener/internal/config"
 	"github.com/avc-dev/url-shortener/internal/mocks"
 	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,7 +33,7 @@ func TestCreateShortURL_Success(t *testing.T) {
 
 	// Создание или получение URL - создается новая запись
 	mockRepo.EXPECT().
-		CreateOrGetURL(expectedCode, model.URL("https://example.com"), "test-user").
+		CreateOrGetURL(expectedCode, model.URL("https://example.com"), "test-user", model.LinkOptions{}).
 		Return(expectedCode, true, nil). // true = создана новая запись
 		Once()
 
@@ -44,7 +46,7 @@ func TestCreateShortURL_Success(t *testing.T) {
 	originalURL := model.URL("https://example.com")
 
 	// Act
-	code, created, err := service.CreateShortURL(originalURL, "test-user")
+	code, created, err := service.CreateShortURL(originalURL, "test-user", model.LinkOptions{})
 
 	// Assert
 	require.NoError(t, err)
@@ -74,7 +76,7 @@ func TestCreateShortURL_URLAlreadyExists(t *testing.T) {
 
 	// URL уже существует - возвращается существующий код
 	mockRepo.EXPECT().
-		CreateOrGetURL(newCode, model.URL("https://example.com"), "test-user").
+		CreateOrGetURL(newCode, model.URL("https://example.com"), "test-user", model.LinkOptions{}).
 		Return(existingCode, false, nil). // false = запись уже существовала
 		Once()
 
@@ -87,10 +89,229 @@ func TestCreateShortURL_URLAlreadyExists(t *testing.T) {
 	originalURL := model.URL("https://example.com")
 
 	// Act
-	code, created, err := service.CreateShortURL(originalURL, "test-user")
+	code, created, err := service.CreateShortURL(originalURL, "test-user", model.LinkOptions{})
 
 	// Assert
 	require.NoError(t, err) // теперь ошибки не должно быть
 	assert.Equal(t, existingCode, code)
 	assert.False(t, created) // запись уже существовала
 }
+
+// TestCreateShortURL_FilteredCodeIsRetried проверяет, что отклонённый фильтром код
+// расходует попытку и не проверяется на уникальность в хранилище
+func TestCreateShortURL_FilteredCodeIsRetried(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockGenerator := mocks.NewMockGenerator(t)
+	goodCode := model.Code("qwertyui")
+
+	// Сначала генератор выдаёт запрещённый код, затем допустимый
+	mockGenerator.EXPECT().GenerateCode().Return(model.Code("xxbadxxx")).Once()
+	mockGenerator.EXPECT().GenerateCode().Return(goodCode).Once()
+
+	// IsCodeUnique вызывается только для допустимого кода
+	mockRepo.EXPECT().
+		IsCodeUnique(goodCode).
+		Return(true).
+		Once()
+
+	mockRepo.EXPECT().
+		CreateOrGetURL(goodCode, model.URL("https://example.com"), "test-user", model.LinkOptions{}).
+		Return(goodCode, true, nil).
+		Once()
+
+	cfg := config.NewDefaultConfig()
+	service := NewURLService(mockRepo, cfg, WithCodeFilter(NewCodeFilter([]string{"bad"})))
+	service.codeGenerator = mockGenerator
+
+	// Act
+	code, created, err := service.CreateShortURL("https://example.com", "test-user", model.LinkOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, goodCode, code)
+	assert.True(t, created)
+}
+
+// TestCreateShortURL_AllCodesFiltered проверяет исчерпание попыток, когда фильтр отклоняет все коды
+func TestCreateShortURL_AllCodesFiltered(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockGenerator := mocks.NewMockGenerator(t)
+
+	cfg := config.NewDefaultConfig()
+	cfg.Retry.MaxAttempts = 3
+
+	// Генератор всегда выдаёт зарезервированный путь
+	mockGenerator.EXPECT().GenerateCode().Return(model.Code("ping")).Times(3)
+
+	service := NewURLService(mockRepo, cfg)
+	service.codeGenerator = mockGenerator
+
+	// Act
+	_, _, err := service.CreateShortURL("https://example.com", "test-user", model.LinkOptions{})
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrMaxRetriesExceeded)
+}
+
+// TestCreateShortURL_WordCodeStyle проверяет выбор генератора по стилю кода
+func TestCreateShortURL_WordCodeStyle(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	randomGenerator := mocks.NewMockGenerator(t)
+	wordGenerator := mocks.NewMockGenerator(t)
+	wordCode := model.Code("brave-otter-42")
+
+	// Случайный генератор не должен вызываться
+	wordGenerator.EXPECT().GenerateCode().Return(wordCode).Once()
+
+	mockRepo.EXPECT().IsCodeUnique(wordCode).Return(true).Once()
+	mockRepo.EXPECT().
+		CreateOrGetURL(wordCode, model.URL("https://example.com"), "test-user", model.LinkOptions{CodeStyle: model.CodeStyleWords}).
+		Return(wordCode, true, nil).
+		Once()
+
+	cfg := config.NewDefaultConfig()
+	service := NewURLService(mockRepo, cfg, WithWordGenerator(wordGenerator))
+	service.codeGenerator = randomGenerator
+
+	// Act
+	code, created, err := service.CreateShortURL("https://example.com", "test-user",
+		model.LinkOptions{CodeStyle: model.CodeStyleWords})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, wordCode, code)
+	assert.True(t, created)
+}
+
+// TestCreateShortURLsBatch_WordCodeStyle проверяет генерацию читаемых кодов для батча
+func TestCreateShortURLsBatch_WordCodeStyle(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	wordGenerator := mocks.NewMockGenerator(t)
+	first, second := model.Code("brave-otter-42"), model.Code("calm-heron-17")
+
+	// Повтор кода внутри батча отбрасывается и расходует попытку
+	wordGenerator.EXPECT().GenerateCode().Return(first).Twice()
+	wordGenerator.EXPECT().GenerateCode().Return(second).Once()
+
+	mockRepo.EXPECT().IsCodeUnique(first).Return(true).Once()
+	mockRepo.EXPECT().IsCodeUnique(second).Return(true).Once()
+	mockRepo.EXPECT().
+		CreateURLsBatch(map[model.Code]model.URL{
+			first:  "https://a.example.com",
+			second: "https://b.example.com",
+		}, "test-user", model.LinkOptions{CodeStyle: model.CodeStyleWords}).
+		Return(nil).
+		Once()
+
+	cfg := config.NewDefaultConfig()
+	service := NewURLService(mockRepo, cfg, WithWordGenerator(wordGenerator))
+
+	// Act
+	codes, err := service.CreateShortURLsBatch(
+		[]model.URL{"https://a.example.com", "https://b.example.com"},
+		"test-user",
+		model.LinkOptions{CodeStyle: model.CodeStyleWords},
+	)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []model.Code{first, second}, codes)
+}
+
+// TestCreateShortURL_CodeRecycling проверяет выдачу кодов из пула переиспользования
+func TestCreateShortURL_CodeRecycling(t *testing.T) {
+	recycledCode := model.Code("recycled")
+	originalURL := model.URL("https://example.com")
+
+	t.Run("recycled code is used", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().AcquireRecycledCode(mock.Anything).Return(recycledCode, true, nil).Once()
+		mockRepo.EXPECT().IsCodeUnique(recycledCode).Return(true).Once()
+		mockRepo.EXPECT().CreateOrGetURL(recycledCode, originalURL, "test-user", model.LinkOptions{}).Return(recycledCode, true, nil).Once()
+
+		service := NewURLService(mockRepo, config.NewDefaultConfig(), WithCodeRecycling())
+		service.codeGenerator = mocks.NewMockGenerator(t)
+
+		code, created, err := service.CreateShortURL(originalURL, "test-user", model.LinkOptions{})
+
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, recycledCode, code)
+	})
+
+	t.Run("empty pool falls back to generator", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockGenerator := mocks.NewMockGenerator(t)
+		mockRepo.EXPECT().AcquireRecycledCode(mock.Anything).Return("", false, nil).Once()
+		mockGenerator.EXPECT().GenerateCode().Return(model.Code("generated")).Once()
+		mockRepo.EXPECT().IsCodeUnique(model.Code("generated")).Return(true).Once()
+		mockRepo.EXPECT().CreateOrGetURL(model.Code("generated"), originalURL, "test-user", model.LinkOptions{}).Return(model.Code("generated"), true, nil).Once()
+
+		service := NewURLService(mockRepo, config.NewDefaultConfig(), WithCodeRecycling())
+		service.codeGenerator = mockGenerator
+
+		code, _, err := service.CreateShortURL(originalURL, "test-user", model.LinkOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, model.Code("generated"), code)
+	})
+
+	t.Run("unused recycled code is returned to pool", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().AcquireRecycledCode(mock.Anything).Return(recycledCode, true, nil).Once()
+		mockRepo.EXPECT().IsCodeUnique(recycledCode).Return(true).Once()
+		mockRepo.EXPECT().CreateOrGetURL(recycledCode, originalURL, "test-user", model.LinkOptions{}).Return(model.Code("existing"), false, nil).Once()
+		mockRepo.EXPECT().ReleaseCodes([]model.Code{recycledCode}, mock.Anything).Return(nil).Once()
+
+		service := NewURLService(mockRepo, config.NewDefaultConfig(), WithCodeRecycling())
+
+		code, created, err := service.CreateShortURL(originalURL, "test-user", model.LinkOptions{})
+
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, model.Code("existing"), code)
+	})
+
+	t.Run("word style bypasses pool", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockGenerator := mocks.NewMockGenerator(t)
+		mockGenerator.EXPECT().GenerateCode().Return(model.Code("brave-otter-42")).Once()
+		mockRepo.EXPECT().IsCodeUnique(model.Code("brave-otter-42")).Return(true).Once()
+		mockRepo.EXPECT().CreateOrGetURL(model.Code("brave-otter-42"), originalURL, "test-user", model.LinkOptions{CodeStyle: model.CodeStyleWords}).Return(model.Code("brave-otter-42"), true, nil).Once()
+
+		service := NewURLService(mockRepo, config.NewDefaultConfig(), WithCodeRecycling(), WithWordGenerator(mockGenerator))
+
+		code, _, err := service.CreateShortURL(originalURL, "test-user", model.LinkOptions{CodeStyle: model.CodeStyleWords})
+
+		require.NoError(t, err)
+		assert.Equal(t, model.Code("brave-otter-42"), code)
+	})
+}
+
+// TestCreateShortURLsBatch_CodeRecycling проверяет, что батч берёт коды из пула, пока он не опустеет
+func TestCreateShortURLsBatch_CodeRecycling(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockGenerator := mocks.NewMockGenerator(t)
+	urls := []model.URL{"https://a.com", "https://b.com", "https://c.com"}
+
+	mockRepo.EXPECT().AcquireRecycledCode(mock.Anything).Return(model.Code("recycled"), true, nil).Once()
+	mockRepo.EXPECT().IsCodeUnique(model.Code("recycled")).Return(true).Once()
+	mockRepo.EXPECT().AcquireRecycledCode(mock.Anything).Return("", false, nil).Once()
+	mockGenerator.EXPECT().GenerateCode().Return(model.Code("gen1")).Once()
+	mockGenerator.EXPECT().GenerateCode().Return(model.Code("gen2")).Once()
+	mockRepo.EXPECT().IsCodeUnique(mock.Anything).Return(true).Twice()
+	mockRepo.EXPECT().CreateURLsBatch(mock.Anything, "test-user", model.LinkOptions{}).Return(errors.New("db error")).Once()
+	mockRepo.EXPECT().ReleaseCodes([]model.Code{"recycled"}, mock.Anything).Return(nil).Once()
+
+	service := NewURLService(mockRepo, config.NewDefaultConfig(), WithCodeRecycling())
+	service.codeGenerator = mockGenerator
+
+	_, err := service.CreateShortURLsBatch(urls, "test-user", model.LinkOptions{})
+
+	require.Error(t, err)
+}
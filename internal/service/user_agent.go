@@ -0,0 +1,49 @@
+package service
+
+import "strings"
+
+// Семейства браузеров, на которые разбивается статистика переходов.
+const (
+	UAFamilyBot     = "Bot"
+	UAFamilyEdge    = "Edge"
+	UAFamilyOpera   = "Opera"
+	UAFamilyFirefox = "Firefox"
+	UAFamilyChrome  = "Chrome"
+	UAFamilySafari  = "Safari"
+	UAFamilyOther   = "Other"
+	UAFamilyUnknown = "Unknown"
+)
+
+// botMarkers — подстроки User-Agent автоматических клиентов
+var botMarkers = []string{"bot", "crawler", "spider", "curl/", "wget/", "python-requests", "go-http-client"}
+
+// UserAgentFamily определяет семейство браузера по заголовку User-Agent.
+// Проверки идут от частного к общему: User-Agent Edge и Opera содержат «Chrome»,
+// а Chrome — «Safari».
+func UserAgentFamily(userAgent string) string {
+	if userAgent == "" {
+		return UAFamilyUnknown
+	}
+
+	lower := strings.ToLower(userAgent)
+	for _, marker := range botMarkers {
+		if strings.Contains(lower, marker) {
+			return UAFamilyBot
+		}
+	}
+
+	switch {
+	case strings.Contains(userAgent, "Edg/"), strings.Contains(userAgent, "EdgA/"), strings.Contains(userAgent, "EdgiOS/"):
+		return UAFamilyEdge
+	case strings.Contains(userAgent, "OPR/"), strings.Contains(userAgent, "Opera"):
+		return UAFamilyOpera
+	case strings.Contains(userAgent, "Firefox/"), strings.Contains(userAgent, "FxiOS/"):
+		return UAFamilyFirefox
+	case strings.Contains(userAgent, "Chrome/"), strings.Contains(userAgent, "CriOS/"):
+		return UAFamilyChrome
+	case strings.Contains(userAgent, "Safari/"):
+		return UAFamilySafari
+	default:
+		return UAFamilyOther
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+const (
+	// DefaultWordCount — количество слов в коде по умолчанию ("brave-otter-42").
+	DefaultWordCount = 2
+	// MaxWordCount — максимальное количество слов в коде; ограничено длиной колонки code в БД.
+	MaxWordCount = 4
+	// maxWordLength — максимальная длина слова в списке, чтобы код гарантированно
+	// помещался в колонку code (MaxWordCount слов, разделители и число).
+	maxWordLength = 12
+)
+
+//go:embed wordlist.txt
+var embeddedWordList string
+
+// ErrInvalidWordList возвращается, если список слов пуст или содержит недопустимые слова.
+var ErrInvalidWordList = errors.New("invalid word list")
+
+// WordCodeGenerator генерирует читаемые коды из слов и двузначного числа,
+// например "brave-otter-42". Такие коды удобно диктовать и печатать.
+type WordCodeGenerator struct {
+	words     []string
+	wordCount int
+	random    *rand.Rand
+	mu        sync.Mutex // rand.Rand не безопасен для конкурентного использования
+}
+
+// NewWordCodeGenerator создаёт генератор по списку слов.
+// wordCount вне диапазона [1, MaxWordCount] заменяется на DefaultWordCount.
+func NewWordCodeGenerator(words []string, wordCount int) *WordCodeGenerator {
+	if wordCount < 1 || wordCount > MaxWordCount {
+		wordCount = DefaultWordCount
+	}
+	return &WordCodeGenerator{
+		words:     words,
+		wordCount: wordCount,
+		random:    rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+// DefaultWordList возвращает встроенный в бинарник список слов.
+func DefaultWordList() []string {
+	words, _ := parseWordList(embeddedWordList)
+	return words
+}
+
+// LoadWordList читает список слов из файла (одно слово на строку).
+// При пустом пути возвращает встроенный список.
+func LoadWordList(path string) ([]string, error) {
+	if path == "" {
+		return DefaultWordList(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+
+	return parseWordList(string(data))
+}
+
+// GenerateCode генерирует код вида "word-word-NN"
+func (g *WordCodeGenerator) GenerateCode() model.Code {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var sb strings.Builder
+	for i := 0; i < g.wordCount; i++ {
+		sb.WriteString(g.words[g.random.Intn(len(g.words))])
+		sb.WriteByte('-')
+	}
+	sb.WriteString(strconv.Itoa(10 + g.random.Intn(90)))
+
+	return model.Code(sb.String())
+}
+
+// GenerateBatchCodes генерирует указанное количество кодов
+func (g *WordCodeGenerator) GenerateBatchCodes(count int) []model.Code {
+	codes := make([]model.Code, count)
+	for i := 0; i < count; i++ {
+		codes[i] = g.GenerateCode()
+	}
+	return codes
+}
+
+// parseWordList разбирает список слов: пустые строки и строки с '#' пропускаются,
+// слова приводятся к нижнему регистру. Допускаются только латинские буквы,
+// чтобы код оставался валидным сегментом пути без экранирования.
+func parseWordList(data string) ([]string, error) {
+	var words []string
+	for _, line := range strings.Split(data, "\n") {
+		word := strings.ToLower(strings.TrimSpace(line))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if len(word) > maxWordLength {
+			return nil, fmt.Errorf("%w: word %q is longer than %d characters", ErrInvalidWordList, word, maxWordLength)
+		}
+		for _, r := range word {
+			if r < 'a' || r > 'z' {
+				return nil, fmt.Errorf("%w: word %q contains non-latin characters", ErrInvalidWordList, word)
+			}
+		}
+		words = append(words, word)
+	}
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("%w: no words found", ErrInvalidWordList)
+	}
+
+	return words, nil
+}
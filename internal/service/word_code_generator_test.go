@@ -0,0 +1,101 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWordCodeGenerator_GenerateCode проверяет формат читаемых кодов
+func TestWordCodeGenerator_GenerateCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		wordCount int
+		pattern   string
+	}{
+		{name: "Default word count", wordCount: DefaultWordCount, pattern: `^[a-z]+-[a-z]+-[1-9][0-9]$`},
+		{name: "Single word", wordCount: 1, pattern: `^[a-z]+-[1-9][0-9]$`},
+		{name: "Max word count", wordCount: MaxWordCount, pattern: `^([a-z]+-){4}[1-9][0-9]$`},
+		{name: "Out of range falls back to default", wordCount: 10, pattern: `^[a-z]+-[a-z]+-[1-9][0-9]$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewWordCodeGenerator(DefaultWordList(), tt.wordCount)
+			re := regexp.MustCompile(tt.pattern)
+
+			for i := 0; i < 100; i++ {
+				code := string(gen.GenerateCode())
+				assert.Regexp(t, re, code)
+				assert.LessOrEqual(t, len(code), 64, "code must fit into the code column")
+			}
+		})
+	}
+}
+
+// TestWordCodeGenerator_GenerateBatchCodes проверяет генерацию пакета кодов
+func TestWordCodeGenerator_GenerateBatchCodes(t *testing.T) {
+	gen := NewWordCodeGenerator([]string{"brave", "otter"}, 2)
+
+	codes := gen.GenerateBatchCodes(5)
+
+	require.Len(t, codes, 5)
+	for _, code := range codes {
+		parts := strings.Split(string(code), "-")
+		require.Len(t, parts, 3)
+		assert.Contains(t, []string{"brave", "otter"}, parts[0])
+		assert.Contains(t, []string{"brave", "otter"}, parts[1])
+	}
+}
+
+// TestLoadWordList проверяет загрузку встроенного и пользовательского списков слов
+func TestLoadWordList(t *testing.T) {
+	t.Run("Embedded list", func(t *testing.T) {
+		words, err := LoadWordList("")
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, words)
+	})
+
+	t.Run("Custom list is normalized", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "words.txt")
+		require.NoError(t, os.WriteFile(path, []byte("# animals\nOtter\n\n badger \n"), 0644))
+
+		words, err := LoadWordList(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"otter", "badger"}, words)
+	})
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "Empty list", content: "# nothing here\n\n"},
+		{name: "Non-latin word", content: "выдра\n"},
+		{name: "Word with digits", content: "otter42\n"},
+		{name: "Too long word", content: "extraordinarily\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "words.txt")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			_, err := LoadWordList(path)
+
+			assert.ErrorIs(t, err, ErrInvalidWordList)
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadWordList(filepath.Join(t.TempDir(), "missing.txt"))
+
+		assert.Error(t, err)
+	})
+}
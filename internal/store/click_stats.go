@@ -0,0 +1,115 @@
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// clickStats накапливает число переходов по бакетам и измерениям статистики.
+// In-memory хранилище заполняет его по отдельным переходам, PostgreSQL — готовыми группами.
+type clickStats struct {
+	total     int
+	visitors  int
+	hourly    map[time.Time]int
+	daily     map[time.Time]int
+	referrers map[string]int
+	browsers  map[string]int
+	countries map[string]int
+	variants  map[int]int
+}
+
+// newClickStats создаёт пустой накопитель статистики
+func newClickStats() *clickStats {
+	return &clickStats{
+		hourly:    make(map[time.Time]int),
+		daily:     make(map[time.Time]int),
+		referrers: make(map[string]int),
+		browsers:  make(map[string]int),
+		countries: make(map[string]int),
+		variants:  make(map[int]int),
+	}
+}
+
+// aggregateClicks строит статистику ссылки code по переходам за период from—to.
+// Переходы вне периода игнорируются; бакеты считаются в UTC.
+func aggregateClicks(code model.Code, clicks []model.Click, from, to time.Time) model.URLStats {
+	stats := newClickStats()
+	visitors := make(map[string]struct{})
+
+	for _, click := range clicks {
+		if click.At.Before(from) || click.At.After(to) {
+			continue
+		}
+		at := click.At.UTC()
+		stats.total++
+		stats.hourly[at.Truncate(time.Hour)]++
+		stats.daily[time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)]++
+		stats.referrers[click.Referrer]++
+		stats.browsers[click.UAFamily]++
+		stats.countries[click.Country]++
+		if click.VisitorID != "" {
+			visitors[click.VisitorID] = struct{}{}
+		}
+		if click.Variant > 0 {
+			stats.variants[click.Variant]++
+		}
+	}
+	stats.visitors = len(visitors)
+
+	return stats.build(code, from, to)
+}
+
+// build собирает накопленные счётчики в статистику ссылки
+func (c *clickStats) build(code model.Code, from, to time.Time) model.URLStats {
+	return model.URLStats{
+		Code:           string(code),
+		From:           from,
+		To:             to,
+		TotalClicks:    c.total,
+		UniqueVisitors: c.visitors,
+		Hourly:         sortedBuckets(c.hourly),
+		Daily:          sortedBuckets(c.daily),
+		Referrers:      sortedCounts(c.referrers),
+		Browsers:       sortedCounts(c.browsers),
+		Countries:      sortedCounts(c.countries),
+		Variants:       sortedVariants(c.variants),
+	}
+}
+
+// sortedBuckets упорядочивает бакеты по времени
+func sortedBuckets(counts map[time.Time]int) []model.StatsBucket {
+	buckets := make([]model.StatsBucket, 0, len(counts))
+	for start, clicks := range counts {
+		buckets = append(buckets, model.StatsBucket{Start: start, Clicks: clicks})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Start.Before(buckets[j].Start) })
+	return buckets
+}
+
+// sortedVariants упорядочивает переходы по номеру варианта
+func sortedVariants(counts map[int]int) []model.VariantCount {
+	result := make([]model.VariantCount, 0, len(counts))
+	for variant, clicks := range counts {
+		result = append(result, model.VariantCount{Variant: variant, Clicks: clicks})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Variant < result[j].Variant })
+	return result
+}
+
+// sortedCounts упорядочивает значения по убыванию числа переходов, при равенстве — по значению.
+// Пустое значение (прямой переход, неизвестная страна) тоже учитывается.
+func sortedCounts(counts map[string]int) []model.StatsCount {
+	result := make([]model.StatsCount, 0, len(counts))
+	for value, clicks := range counts {
+		result = append(result, model.StatsCount{Value: value, Clicks: clicks})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Clicks != result[j].Clicks {
+			return result[i].Clicks > result[j].Clicks
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAggregateClicks проверяет бакеты, распределения и оценку уникальных посетителей
+func TestAggregateClicks(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(72 * time.Hour)
+
+	clicks := []model.Click{
+		{At: from.Add(10*time.Hour + 5*time.Minute), Referrer: "a.com", UAFamily: "Chrome", Country: "US", VisitorID: "v1", Variant: 2},
+		{At: from.Add(10*time.Hour + 50*time.Minute), Referrer: "a.com", UAFamily: "Chrome", Country: "US", VisitorID: "v1", Variant: 2},
+		{At: from.Add(26 * time.Hour), Referrer: "", UAFamily: "Firefox", Country: "DE", VisitorID: "v2", Variant: 1},
+		{At: from.Add(-time.Minute), Referrer: "old.com", VisitorID: "v3", Variant: 1},
+	}
+
+	stats := aggregateClicks("abc", clicks, from, to)
+
+	assert.Equal(t, "abc", stats.Code)
+	assert.Equal(t, 3, stats.TotalClicks)
+	assert.Equal(t, 2, stats.UniqueVisitors)
+	assert.Equal(t, []model.StatsBucket{
+		{Start: from.Add(10 * time.Hour), Clicks: 2},
+		{Start: from.Add(26 * time.Hour), Clicks: 1},
+	}, stats.Hourly)
+	assert.Equal(t, []model.StatsBucket{
+		{Start: from, Clicks: 2},
+		{Start: from.Add(24 * time.Hour), Clicks: 1},
+	}, stats.Daily)
+	assert.Equal(t, []model.StatsCount{{Value: "a.com", Clicks: 2}, {Value: "", Clicks: 1}}, stats.Referrers)
+	assert.Equal(t, []model.StatsCount{{Value: "Chrome", Clicks: 2}, {Value: "Firefox", Clicks: 1}}, stats.Browsers)
+	assert.Equal(t, []model.StatsCount{{Value: "US", Clicks: 2}, {Value: "DE", Clicks: 1}}, stats.Countries)
+	assert.Equal(t, []model.VariantCount{{Variant: 1, Clicks: 1}, {Variant: 2, Clicks: 2}}, stats.Variants)
+}
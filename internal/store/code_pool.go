@@ -0,0 +1,67 @@
+package store
+
+import (
+	"slices"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// recycledCode — освободившийся код и момент, с которого его можно выдать повторно.
+type recycledCode struct {
+	code        model.Code
+	availableAt time.Time
+}
+
+// codePool — пул освободившихся кодов, упорядоченный по времени окончания карантина.
+// Упорядоченность позволяет выдавать коды, не просматривая весь пул:
+// первый элемент с ещё не истёкшим карантином означает, что доступных кодов нет.
+// Не потокобезопасен: синхронизация — на стороне владельца.
+type codePool struct {
+	codes   []recycledCode
+	members map[model.Code]time.Time
+}
+
+func newCodePool() codePool {
+	return codePool{members: make(map[model.Code]time.Time)}
+}
+
+// add помещает код в пул. Если код уже в пуле, остаётся более поздний срок
+// окончания карантина: повторное освобождение не может его сократить.
+func (p *codePool) add(code model.Code, availableAt time.Time) {
+	if current, exists := p.members[code]; exists {
+		if !availableAt.After(current) {
+			return
+		}
+		p.remove(code)
+	}
+
+	i, _ := slices.BinarySearchFunc(p.codes, availableAt, func(rc recycledCode, t time.Time) int {
+		return rc.availableAt.Compare(t)
+	})
+	p.codes = slices.Insert(p.codes, i, recycledCode{code: code, availableAt: availableAt})
+	p.members[code] = availableAt
+}
+
+// remove удаляет код из пула, если он там есть.
+func (p *codePool) remove(code model.Code) {
+	if _, exists := p.members[code]; !exists {
+		return
+	}
+	delete(p.members, code)
+	p.codes = slices.DeleteFunc(p.codes, func(rc recycledCode) bool { return rc.code == code })
+}
+
+// take извлекает первый код, карантин которого истёк к моменту now.
+// Коды, для которых isTaken возвращает true, выбрасываются из пула: они уже заняты.
+func (p *codePool) take(now time.Time, isTaken func(model.Code) bool) (model.Code, bool) {
+	for len(p.codes) > 0 && !p.codes[0].availableAt.After(now) {
+		rc := p.codes[0]
+		p.codes = p.codes[1:]
+		delete(p.members, rc.code)
+		if !isTaken(rc.code) {
+			return rc.code, true
+		}
+	}
+	return "", false
+}
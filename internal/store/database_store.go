@@ -2,23 +2,32 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/config/db"
 	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// uniqueViolation — код ошибки PostgreSQL при нарушении уникального индекса
+const uniqueViolation = "23505"
+
 // DatabaseStore реализует Store интерфейс для PostgreSQL
 type DatabaseStore struct {
-	pool *pgxpool.Pool
+	pool            *pgxpool.Pool
+	caseInsensitive bool
 }
 
 // NewDatabaseStore создает новый DatabaseStore
-func NewDatabaseStore(database db.Database) *DatabaseStore {
+func NewDatabaseStore(database db.Database, opts ...Option) *DatabaseStore {
 	// Получаем pgxpool.Pool из адаптера
 	adapter, ok := database.(*db.DBAdapter)
 	if !ok {
@@ -26,22 +35,50 @@ func NewDatabaseStore(database db.Database) *DatabaseStore {
 	}
 
 	return &DatabaseStore{
-		pool: adapter.Pool,
+		pool:            adapter.Pool,
+		caseInsensitive: applyOptions(opts).caseInsensitiveCodes,
+	}
+}
+
+// codeEquals возвращает SQL-условие сравнения колонки code с параметром $param.
+// В режиме без учёта регистра сравнение идёт по lower(code) и использует
+// функциональный индекс idx_urls_code_lower.
+func (ds *DatabaseStore) codeEquals(param int) string {
+	return ds.codeMatches(fmt.Sprintf("$%d", param))
+}
+
+// codeTaken возвращает SQL-условие занятости кода $param. Уникальный индекс
+// idx_urls_code_lower не допускает кодов, различающихся только регистром, в любом режиме,
+// поэтому код занят, если совпадает с существующим хотя бы без учёта регистра.
+func codeTaken(param int) string {
+	return fmt.Sprintf("lower(code) = lower($%d)", param)
+}
+
+// codeMatches возвращает SQL-условие сравнения колонки code с выражением expr
+// с учётом режима сравнения кодов.
+func (ds *DatabaseStore) codeMatches(expr string) string {
+	if ds.caseInsensitive {
+		return fmt.Sprintf("lower(code) = lower(%s)", expr)
 	}
+	return fmt.Sprintf("code = %s", expr)
 }
 
 // Read читает оригинальный URL по короткому коду
 func (ds *DatabaseStore) Read(key model.Code) (model.URL, error) {
 	var originalURL string
-	var isDeleted bool
+	var isDeleted, isExpired bool
 
-	query := `
-		SELECT original_url, is_deleted
+	// Точное совпадение регистра имеет приоритет над совпадением без учёта регистра,
+	// среди остальных выбирается самая ранняя запись
+	query := fmt.Sprintf(`
+		SELECT original_url, is_deleted, COALESCE(expires_at <= CURRENT_TIMESTAMP, false)
 		FROM urls
-		WHERE code = $1
-	`
+		WHERE %s
+		ORDER BY code = $1 DESC, id
+		LIMIT 1
+	`, ds.codeEquals(1))
 
-	err := ds.pool.QueryRow(context.Background(), query, string(key)).Scan(&originalURL, &isDeleted)
+	err := ds.pool.QueryRow(context.Background(), query, string(key)).Scan(&originalURL, &isDeleted, &isExpired)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return "", fmt.Errorf("key %s: %w", key, ErrNotFound)
@@ -49,6 +86,11 @@ func (ds *DatabaseStore) Read(key model.Code) (model.URL, error) {
 		return "", fmt.Errorf("failed to read from database: %w", err)
 	}
 
+	// Истёкшая ссылка помечается удалённой фоновой очисткой, поэтому срок проверяется первым
+	if isExpired {
+		return "", fmt.Errorf("key %s: %w", key, ErrURLExpired)
+	}
+
 	if isDeleted {
 		return "", fmt.Errorf("key %s: %w", key, ErrURLDeleted)
 	}
@@ -56,6 +98,220 @@ func (ds *DatabaseStore) Read(key model.Code) (model.URL, error) {
 	return model.URL(originalURL), nil
 }
 
+// Follow возвращает оригинальный URL для перехода по ссылке и расходует один переход,
+// если число переходов ограничено. Уменьшение счётчика выполняется одним UPDATE
+// с условием remaining_clicks > 0, поэтому параллельные переходы не превышают лимит.
+// Переход по защищённой паролем ссылке разрешён только с unlocked.
+func (ds *DatabaseStore) Follow(key model.Code, unlocked bool) (model.LinkTarget, error) {
+	return ds.linkTarget(key, unlocked, `
+			UPDATE urls SET remaining_clicks = urls.remaining_clicks - 1
+			FROM target
+			WHERE urls.id = target.id
+				AND NOT target.is_deleted AND NOT target.is_expired
+				AND (NOT target.is_protected OR $2::boolean)
+				AND urls.remaining_clicks > 0
+			RETURNING urls.id`)
+}
+
+// Peek возвращает то же, что Follow, но не расходует переход: по нему адрес назначения
+// проверяется до перехода. Ссылка, по которой перейти нельзя, даёт ту же ошибку, что и Follow.
+func (ds *DatabaseStore) Peek(key model.Code, unlocked bool) (model.LinkTarget, error) {
+	return ds.linkTarget(key, unlocked, `
+			SELECT urls.id
+			FROM urls, target
+			WHERE urls.id = target.id
+				AND NOT target.is_deleted AND NOT target.is_expired
+				AND (NOT target.is_protected OR $2::boolean)
+				AND urls.remaining_clicks > 0`)
+}
+
+// linkTarget возвращает адрес и параметры редиректа ссылки. consumedQuery — подзапрос,
+// возвращающий id ссылки с лимитом, если переход по ней доступен; у Follow он же расходует переход.
+func (ds *DatabaseStore) linkTarget(key model.Code, unlocked bool, consumedQuery string) (model.LinkTarget, error) {
+	var target model.LinkTarget
+	var originalURL string
+	var redirectStatus *int16
+	var cacheMaxAge *int32
+	var queryPassthrough, utmParams *string
+	var redirectRules, splitVariants []byte
+	var isDeleted, isExpired, isLimited, isProtected, consumed bool
+
+	query := fmt.Sprintf(`
+		WITH target AS (
+			SELECT id, original_url, is_deleted,
+				COALESCE(expires_at <= CURRENT_TIMESTAMP, false) AS is_expired,
+				remaining_clicks IS NOT NULL AS is_limited,
+				password_hash IS NOT NULL AS is_protected,
+				expires_at IS NOT NULL AS is_expiring,
+				unsafe, redirect_status, cache_max_age, query_passthrough, utm_params, redirect_rules,
+				split_variants
+			FROM urls
+			WHERE %s
+			ORDER BY code = $1 DESC, id
+			LIMIT 1
+		),
+		consumed AS (%s
+		)
+		SELECT target.original_url, target.is_deleted, target.is_expired, target.is_limited,
+			target.is_protected, EXISTS (SELECT 1 FROM consumed),
+			target.is_expiring, target.unsafe, target.redirect_status, target.cache_max_age,
+			target.query_passthrough, target.utm_params, target.redirect_rules, target.split_variants
+		FROM target
+	`, ds.codeEquals(1), consumedQuery)
+
+	var isExpiring bool
+	err := ds.pool.QueryRow(context.Background(), query, string(key), unlocked).
+		Scan(&originalURL, &isDeleted, &isExpired, &isLimited, &isProtected, &consumed,
+			&isExpiring, &target.Unsafe, &redirectStatus, &cacheMaxAge, &queryPassthrough, &utmParams,
+			&redirectRules, &splitVariants)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return model.LinkTarget{}, fmt.Errorf("key %s: %w", key, ErrNotFound)
+		}
+		return model.LinkTarget{}, fmt.Errorf("failed to follow URL: %w", err)
+	}
+
+	if isExpired {
+		return model.LinkTarget{}, fmt.Errorf("key %s: %w", key, ErrURLExpired)
+	}
+
+	if isDeleted {
+		return model.LinkTarget{}, fmt.Errorf("key %s: %w", key, ErrURLDeleted)
+	}
+
+	if isProtected && !unlocked {
+		return model.LinkTarget{}, fmt.Errorf("key %s: %w", key, ErrPasswordRequired)
+	}
+
+	if isLimited && !consumed {
+		return model.LinkTarget{}, fmt.Errorf("key %s: %w", key, ErrClickLimitReached)
+	}
+
+	target.URL = model.URL(originalURL)
+	target.Restricted = isExpiring || isLimited || isProtected
+	if redirectStatus != nil {
+		target.Redirect.Status = int(*redirectStatus)
+	}
+	if cacheMaxAge != nil {
+		maxAge := time.Duration(*cacheMaxAge) * time.Second
+		target.Redirect.MaxAge = &maxAge
+	}
+	if queryPassthrough != nil {
+		target.Query.Passthrough = model.QueryPassthrough(*queryPassthrough)
+	}
+	if utmParams != nil {
+		target.Query.UTM = decodeUTM(*utmParams)
+	}
+	if redirectRules != nil {
+		if err := json.Unmarshal(redirectRules, &target.Rules); err != nil {
+			return model.LinkTarget{}, fmt.Errorf("failed to decode redirect rules: %w", err)
+		}
+	}
+	if splitVariants != nil {
+		if err := json.Unmarshal(splitVariants, &target.Variants); err != nil {
+			return model.LinkTarget{}, fmt.Errorf("failed to decode split variants: %w", err)
+		}
+	}
+	return target, nil
+}
+
+// Inspect возвращает сведения о ссылке для предпросмотра, не расходуя переход.
+// Ссылка, по которой перейти нельзя, даёт ту же ошибку, что и Follow.
+func (ds *DatabaseStore) Inspect(key model.Code, unlocked bool) (model.LinkInfo, error) {
+	var info model.LinkInfo
+	var code, originalURL string
+	var createdAt *time.Time
+	var isDeleted, isExpired, isProtected, isExhausted bool
+
+	query := fmt.Sprintf(`
+		SELECT code, original_url, created_at, click_count, unsafe, is_deleted,
+			COALESCE(expires_at <= CURRENT_TIMESTAMP, false),
+			password_hash IS NOT NULL,
+			COALESCE(remaining_clicks <= 0, false)
+		FROM urls
+		WHERE %s
+		ORDER BY code = $1 DESC, id
+		LIMIT 1
+	`, ds.codeEquals(1))
+
+	err := ds.pool.QueryRow(context.Background(), query, string(key)).Scan(&code, &originalURL,
+		&createdAt, &info.Clicks, &info.Unsafe, &isDeleted, &isExpired, &isProtected, &isExhausted)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return model.LinkInfo{}, fmt.Errorf("key %s: %w", key, ErrNotFound)
+		}
+		return model.LinkInfo{}, fmt.Errorf("failed to inspect URL: %w", err)
+	}
+
+	switch {
+	case isExpired:
+		return model.LinkInfo{}, fmt.Errorf("key %s: %w", key, ErrURLExpired)
+	case isDeleted:
+		return model.LinkInfo{}, fmt.Errorf("key %s: %w", key, ErrURLDeleted)
+	case isProtected && !unlocked:
+		return model.LinkInfo{}, fmt.Errorf("key %s: %w", key, ErrPasswordRequired)
+	case isExhausted:
+		return model.LinkInfo{}, fmt.Errorf("key %s: %w", key, ErrClickLimitReached)
+	}
+
+	info.Code = model.Code(code)
+	info.URL = model.URL(originalURL)
+	if createdAt != nil {
+		info.CreatedAt = *createdAt
+	}
+	return info, nil
+}
+
+// SetURLUnsafe помечает ссылку небезопасной или снимает пометку.
+// Пометка ставится и удалённой ссылке, чтобы сохраниться после её восстановления.
+func (ds *DatabaseStore) SetURLUnsafe(key model.Code, unsafe bool) error {
+	query := fmt.Sprintf(`
+		UPDATE urls SET unsafe = $2
+		WHERE id = (SELECT id FROM urls WHERE %s ORDER BY code = $1 DESC, id LIMIT 1)
+	`, ds.codeEquals(1))
+
+	tag, err := ds.pool.Exec(context.Background(), query, string(key), unsafe)
+	if err != nil {
+		return fmt.Errorf("failed to set unsafe flag: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("key %s: %w", key, ErrNotFound)
+	}
+	return nil
+}
+
+// PasswordHash возвращает хеш пароля ссылки; пустая строка означает ссылку без пароля
+func (ds *DatabaseStore) PasswordHash(key model.Code) (string, error) {
+	var passwordHash string
+	var isDeleted, isExpired bool
+
+	query := fmt.Sprintf(`
+		SELECT COALESCE(password_hash, ''), is_deleted, COALESCE(expires_at <= CURRENT_TIMESTAMP, false)
+		FROM urls
+		WHERE %s
+		ORDER BY code = $1 DESC, id
+		LIMIT 1
+	`, ds.codeEquals(1))
+
+	err := ds.pool.QueryRow(context.Background(), query, string(key)).Scan(&passwordHash, &isDeleted, &isExpired)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("key %s: %w", key, ErrNotFound)
+		}
+		return "", fmt.Errorf("failed to read password hash: %w", err)
+	}
+
+	if isExpired {
+		return "", fmt.Errorf("key %s: %w", key, ErrURLExpired)
+	}
+
+	if isDeleted {
+		return "", fmt.Errorf("key %s: %w", key, ErrURLDeleted)
+	}
+
+	return passwordHash, nil
+}
+
 // Write сохраняет пару код-URL с userID в базу данных
 func (ds *DatabaseStore) Write(key model.Code, value model.URL, userID string) error {
 	ctx := context.Background()
@@ -63,10 +319,10 @@ func (ds *DatabaseStore) Write(key model.Code, value model.URL, userID string) e
 	// Проверяем существование ключа
 	var exists bool
 
-	query := `
+	query := fmt.Sprintf(`
 		SELECT EXISTS
-		(SELECT 1 FROM urls WHERE code = $1)
-	`
+		(SELECT 1 FROM urls WHERE %s)
+	`, codeTaken(1))
 
 	err := ds.pool.QueryRow(ctx, query, string(key)).Scan(&exists)
 	if err != nil {
@@ -91,8 +347,9 @@ func (ds *DatabaseStore) Write(key model.Code, value model.URL, userID string) e
 	return nil
 }
 
-// WriteBatch сохраняет несколько пар код-URL с userID в базу данных в рамках одной транзакции
-func (ds *DatabaseStore) WriteBatch(urls map[model.Code]model.URL, userID string) error {
+// WriteBatch сохраняет несколько пар код-URL с userID в базу данных в рамках одной транзакции;
+// opts применяются ко всем ссылкам
+func (ds *DatabaseStore) WriteBatch(urls map[model.Code]model.URL, userID string, opts model.LinkOptions) error {
 	ctx := context.Background()
 
 	// Начинаем транзакцию
@@ -117,11 +374,12 @@ func (ds *DatabaseStore) WriteBatch(urls map[model.Code]model.URL, userID string
 			if i > 0 {
 				placeholders += ","
 			}
-			placeholders += fmt.Sprintf("$%d", i+1)
+			placeholders += fmt.Sprintf("lower($%d)", i+1)
 		}
 
+		// Коды уникальны без учёта регистра, как и в codeTaken
 		query := fmt.Sprintf(`
-			SELECT code FROM urls WHERE code IN (%s)
+			SELECT code FROM urls WHERE lower(code) IN (%s)
 		`, placeholders)
 
 		rows, queryErr := tx.Query(ctx, query, args...)
@@ -143,15 +401,36 @@ func (ds *DatabaseStore) WriteBatch(urls map[model.Code]model.URL, userID string
 
 	// Вставляем все записи
 	query := `
-		INSERT INTO urls (code, original_url, user_id)
-		VALUES ($1, $2, $3)
+		INSERT INTO urls (code, original_url, user_id, expires_at, remaining_clicks, password_hash, folder,
+			redirect_status, cache_max_age, query_passthrough, utm_params, redirect_rules, split_variants,
+			display_url)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10, $11, $12, $13, NULLIF($14, ''))
+		RETURNING id
 	`
 
+	expiresAt := nullableTime(opts.ExpiresAt)
+	maxClicks := nullableClicks(opts.MaxClicks)
+	redirectStatus, cacheMaxAge := redirectParams(opts.Redirect)
+	queryPassthrough, utmParams := queryParams(opts.Query)
+	redirectRules, err := rulesParam(opts.Rules)
+	if err != nil {
+		return err
+	}
+	splitVariants, err := variantsParam(opts.Variants)
+	if err != nil {
+		return err
+	}
 	for code, url := range urls {
-		_, err = tx.Exec(ctx, query, string(code), string(url), userID)
+		var id int64
+		err = tx.QueryRow(ctx, query, string(code), string(url), userID, expiresAt, maxClicks,
+			opts.PasswordHash, opts.Labels.Folder, redirectStatus, cacheMaxAge, queryPassthrough, utmParams,
+			redirectRules, splitVariants, opts.DisplayURLs[url]).Scan(&id)
 		if err != nil {
 			return fmt.Errorf("failed to insert into database: %w", err)
 		}
+		if err = insertTags(ctx, tx, id, opts.Labels.Tags); err != nil {
+			return err
+		}
 	}
 
 	// Фиксируем транзакцию
@@ -163,20 +442,37 @@ func (ds *DatabaseStore) WriteBatch(urls map[model.Code]model.URL, userID string
 }
 
 // CreateOrGetURL создает новую запись или возвращает код существующей для данного URL
-// Использует CTE для атомарной проверки существования и вставки без изменения существующего кода
-func (ds *DatabaseStore) CreateOrGetURL(code model.Code, url model.URL, userID string) (model.Code, bool, error) {
+// Использует CTE для атомарной проверки существования и вставки без изменения существующего кода.
+// Дедупликация применяется только к ссылкам, для которых её допускает model.LinkOptions.IsDeduplicated,
+// и только среди таких же ссылок. Метки назначаются только новой ссылке
+func (ds *DatabaseStore) CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error) {
 	ctx := context.Background()
 
 	// Используем CTE для атомарной проверки существования URL и вставки
 	query := `
 		WITH existing_url AS (
-			SELECT code FROM urls WHERE original_url = $2 AND user_id = $3
+			SELECT code FROM urls
+			WHERE original_url = $2 AND user_id = $3
+				AND expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL
+				AND redirect_status IS NULL AND cache_max_age IS NULL
+				AND query_passthrough IS NULL AND utm_params IS NULL
+				AND redirect_rules IS NULL AND split_variants IS NULL
+				AND $4::timestamptz IS NULL AND $5::integer IS NULL AND $6::text = ''
+				AND $9::smallint IS NULL AND $10::integer IS NULL
+				AND $11::text IS NULL AND $12::text IS NULL AND $13::jsonb IS NULL AND $14::jsonb IS NULL
 		),
 		insert_result AS (
-			INSERT INTO urls (code, original_url, user_id)
-			SELECT $1, $2, $3
+			INSERT INTO urls (code, original_url, user_id, expires_at, remaining_clicks, password_hash, folder,
+				redirect_status, cache_max_age, query_passthrough, utm_params, redirect_rules, split_variants,
+				display_url)
+			SELECT $1, $2, $3, $4, $5, NULLIF($6, ''), $7, $9, $10, $11, $12, $13, $14, NULLIF($15, '')
 			WHERE NOT EXISTS (SELECT 1 FROM existing_url)
-			RETURNING code
+			RETURNING id, code
+		),
+		-- Модифицирующий CTE выполняется, даже если основной запрос на него не ссылается
+		tags_result AS (
+			INSERT INTO url_tags (url_id, tag)
+			SELECT inserted.id, tag FROM insert_result inserted, unnest($8::text[]) AS tag
 		)
 		SELECT
 			COALESCE(existing.code, inserted.code) as final_code,
@@ -193,7 +489,20 @@ func (ds *DatabaseStore) CreateOrGetURL(code model.Code, url model.URL, userID s
 	var finalCode string
 	var created bool
 
-	err := ds.pool.QueryRow(ctx, query, string(code), string(url), userID).Scan(&finalCode, &created)
+	redirectStatus, cacheMaxAge := redirectParams(opts.Redirect)
+	queryPassthrough, utmParams := queryParams(opts.Query)
+	redirectRules, err := rulesParam(opts.Rules)
+	if err != nil {
+		return "", false, err
+	}
+	splitVariants, err := variantsParam(opts.Variants)
+	if err != nil {
+		return "", false, err
+	}
+	err = ds.pool.QueryRow(ctx, query, string(code), string(url), userID,
+		nullableTime(opts.ExpiresAt), nullableClicks(opts.MaxClicks), opts.PasswordHash,
+		opts.Labels.Folder, tagsParam(opts.Labels.Tags), redirectStatus, cacheMaxAge,
+		queryPassthrough, utmParams, redirectRules, splitVariants, opts.DisplayURLs[url]).Scan(&finalCode, &created)
 	if err != nil {
 		return "", false, fmt.Errorf("failed to create or get URL: %w", err)
 	}
@@ -201,10 +510,351 @@ func (ds *DatabaseStore) CreateOrGetURL(code model.Code, url model.URL, userID s
 	return model.Code(finalCode), created, nil
 }
 
+// RecordClicks сохраняет пачку переходов одним запросом INSERT.
+// Переход привязывается к строке ссылки, найденной по коду так же, как при чтении;
+// переходы по несуществующим кодам пропускаются.
+func (ds *DatabaseStore) RecordClicks(clicks []model.Click) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+
+	codes := make([]string, len(clicks))
+	at := make([]time.Time, len(clicks))
+	referrers := make([]string, len(clicks))
+	uaFamilies := make([]string, len(clicks))
+	countries := make([]string, len(clicks))
+	visitorIDs := make([]string, len(clicks))
+	variants := make([]int16, len(clicks))
+	for i, click := range clicks {
+		codes[i] = string(click.Code)
+		at[i] = click.At
+		referrers[i] = click.Referrer
+		uaFamilies[i] = click.UAFamily
+		countries[i] = click.Country
+		visitorIDs[i] = click.VisitorID
+		variants[i] = int16(click.Variant)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO url_clicks (url_id, clicked_at, referrer, ua_family, country, visitor_id, variant)
+		SELECT target.id, c.clicked_at, c.referrer, c.ua_family, c.country, c.visitor_id, c.variant
+		FROM unnest($1::text[], $2::timestamptz[], $3::text[], $4::text[], $5::text[], $6::text[], $7::smallint[])
+			AS c(key, clicked_at, referrer, ua_family, country, visitor_id, variant)
+		CROSS JOIN LATERAL (
+			SELECT id FROM urls
+			WHERE %s
+			ORDER BY code = c.key DESC, id
+			LIMIT 1
+		) AS target
+	`, ds.codeMatches("c.key"))
+
+	_, err := ds.pool.Exec(context.Background(), query,
+		codes, at, referrers, uaFamilies, countries, visitorIDs, variants)
+	if err != nil {
+		return fmt.Errorf("failed to insert clicks: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateURL меняет адрес ссылки владельца, сохраняет прежний адрес в url_versions
+// и возвращает его. Строка ссылки блокируется до конца транзакции, поэтому проверка владельца
+// и изменение выполняются атомарно. Нарушение индекса idx_urls_original_url_user_id
+// означает, что новый URL уже сокращён другой ссылкой пользователя.
+func (ds *DatabaseStore) UpdateURL(code model.Code, newURL model.URL, userID string) (model.URL, error) {
+	ctx := context.Background()
+
+	tx, err := ds.pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := fmt.Sprintf(`
+		SELECT id, original_url, user_id, is_deleted, COALESCE(expires_at <= CURRENT_TIMESTAMP, false)
+		FROM urls
+		WHERE %s
+		ORDER BY code = $1 DESC, id
+		LIMIT 1
+		FOR UPDATE
+	`, ds.codeEquals(1))
+
+	var id int64
+	var previous, owner string
+	var isDeleted, isExpired bool
+	err = tx.QueryRow(ctx, query, string(code)).Scan(&id, &previous, &owner, &isDeleted, &isExpired)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("key %s: %w", code, ErrNotFound)
+		}
+		return "", fmt.Errorf("failed to lock URL: %w", err)
+	}
+
+	switch {
+	case isExpired:
+		return "", fmt.Errorf("key %s: %w", code, ErrURLExpired)
+	case isDeleted:
+		return "", fmt.Errorf("key %s: %w", code, ErrURLDeleted)
+	case owner != userID:
+		return "", fmt.Errorf("key %s: %w", code, ErrNotFound)
+	case previous == string(newURL):
+		return model.URL(previous), nil
+	}
+
+	// Исходная строка относилась к прежнему адресу
+	_, err = tx.Exec(ctx, `UPDATE urls SET original_url = $2, display_url = NULL WHERE id = $1`, id, string(newURL))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return "", ds.urlConflict(ctx, newURL, userID)
+		}
+		return "", fmt.Errorf("failed to update URL: %w", err)
+	}
+
+	// Строка ссылки заблокирована, поэтому номер версии вычисляется без гонок
+	_, err = tx.Exec(ctx, `
+		INSERT INTO url_versions (url_id, version, original_url, changed_by)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3
+		FROM url_versions
+		WHERE url_id = $1
+	`, id, previous, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to insert URL version: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return model.URL(previous), nil
+}
+
+// GetURLVersions возвращает прежние адреса ссылки от новых к старым
+func (ds *DatabaseStore) GetURLVersions(code model.Code) ([]model.URLVersion, error) {
+	ctx := context.Background()
+
+	query := fmt.Sprintf(`
+		WITH target AS (
+			SELECT id FROM urls
+			WHERE %s
+			ORDER BY code = $1 DESC, id
+			LIMIT 1
+		)
+		SELECT v.version, v.original_url, v.changed_by, v.changed_at
+		FROM target
+		LEFT JOIN url_versions v ON v.url_id = target.id
+		ORDER BY v.version DESC
+	`, ds.codeEquals(1))
+
+	rows, err := ds.pool.Query(ctx, query, string(code))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query URL versions: %w", err)
+	}
+	defer rows.Close()
+
+	found := false
+	versions := []model.URLVersion{}
+	for rows.Next() {
+		found = true
+		var version *int
+		var originalURL, changedBy *string
+		var changedAt *time.Time
+		if err := rows.Scan(&version, &originalURL, &changedBy, &changedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan URL version: %w", err)
+		}
+		// Ссылка без истории даёт одну строку с NULL из LEFT JOIN
+		if version == nil {
+			continue
+		}
+		versions = append(versions, model.URLVersion{
+			Version:     *version,
+			OriginalURL: *originalURL,
+			ChangedBy:   *changedBy,
+			ChangedAt:   *changedAt,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over URL versions: %w", err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("key %s: %w", code, ErrNotFound)
+	}
+
+	return versions, nil
+}
+
+// PruneURLVersions удаляет из истории версии, изменённые раньше before,
+// кроме последней версии каждой ссылки, и возвращает их количество
+func (ds *DatabaseStore) PruneURLVersions(before time.Time) (int, error) {
+	tag, err := ds.pool.Exec(context.Background(), `
+		DELETE FROM url_versions v
+		WHERE v.changed_at < $1
+			AND v.version < (SELECT MAX(version) FROM url_versions WHERE url_id = v.url_id)
+	`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune URL versions: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// urlConflict возвращает URLConflictError с кодом ссылки пользователя,
+// занимающей URL в индексе дедупликации
+func (ds *DatabaseStore) urlConflict(ctx context.Context, originalURL model.URL, userID string) error {
+	var existing string
+	err := ds.pool.QueryRow(ctx, `
+		SELECT code
+		FROM urls
+		WHERE original_url = $1 AND user_id = $2
+			AND expires_at IS NULL AND remaining_clicks IS NULL AND password_hash IS NULL
+			AND redirect_status IS NULL AND cache_max_age IS NULL
+			AND query_passthrough IS NULL AND utm_params IS NULL
+			AND redirect_rules IS NULL AND split_variants IS NULL
+	`, string(originalURL), userID).Scan(&existing)
+	if err != nil {
+		return fmt.Errorf("URL %s: %w", originalURL, ErrURLAlreadyExists)
+	}
+	return URLConflictError{Code: model.Code(existing)}
+}
+
+// AddClickCounts добавляет приращения к счётчикам переходов одним запросом UPDATE.
+// Приращения для одного кода суммируются заранее: UPDATE ... FROM применяет к строке
+// только одну из совпавших строк-источников.
+func (ds *DatabaseStore) AddClickCounts(counts []model.ClickCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	codes := make([]string, len(counts))
+	clicks := make([]int64, len(counts))
+	accessed := make([]time.Time, len(counts))
+	for i, count := range counts {
+		codes[i] = string(count.Code)
+		clicks[i] = count.Clicks
+		accessed[i] = count.LastAccessedAt
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE urls
+		SET click_count = urls.click_count + delta.clicks,
+			last_accessed_at = GREATEST(urls.last_accessed_at, delta.accessed_at)
+		FROM (
+			SELECT target.id, SUM(d.clicks) AS clicks, MAX(d.accessed_at) AS accessed_at
+			FROM unnest($1::text[], $2::bigint[], $3::timestamptz[]) AS d(key, clicks, accessed_at)
+			CROSS JOIN LATERAL (
+				SELECT id FROM urls
+				WHERE %s
+				ORDER BY code = d.key DESC, id
+				LIMIT 1
+			) AS target
+			GROUP BY target.id
+		) AS delta
+		WHERE urls.id = delta.id
+	`, ds.codeMatches("d.key"))
+
+	if _, err := ds.pool.Exec(context.Background(), query, codes, clicks, accessed); err != nil {
+		return fmt.Errorf("failed to add click counts: %w", err)
+	}
+
+	return nil
+}
+
+// GetClickStats возвращает статистику переходов по ссылке за период from—to.
+// Переходы группируются в PostgreSQL: по сети передаются только счётчики групп,
+// а не отдельные переходы. Бакеты считаются в UTC.
+func (ds *DatabaseStore) GetClickStats(code model.Code, from, to time.Time) (model.URLStats, error) {
+	ctx := context.Background()
+
+	query := fmt.Sprintf(`
+		WITH target AS (
+			SELECT id FROM urls
+			WHERE %s
+			ORDER BY code = $1 DESC, id
+			LIMIT 1
+		), clicks AS (
+			SELECT c.clicked_at, c.referrer, c.ua_family, c.country, c.visitor_id, c.variant
+			FROM url_clicks c
+			JOIN target ON c.url_id = target.id
+			WHERE c.clicked_at >= $2 AND c.clicked_at <= $3
+		)
+		SELECT 'link', NULL::timestamptz, '', 0::bigint FROM target
+		UNION ALL
+		SELECT 'visitors', NULL, '', COUNT(DISTINCT visitor_id) FROM clicks WHERE visitor_id <> ''
+		UNION ALL
+		SELECT 'hour', date_trunc('hour', clicked_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', '', COUNT(*)
+		FROM clicks GROUP BY 2
+		UNION ALL
+		SELECT 'day', date_trunc('day', clicked_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', '', COUNT(*)
+		FROM clicks GROUP BY 2
+		UNION ALL
+		SELECT 'referrer', NULL, referrer, COUNT(*) FROM clicks GROUP BY referrer
+		UNION ALL
+		SELECT 'browser', NULL, ua_family, COUNT(*) FROM clicks GROUP BY ua_family
+		UNION ALL
+		SELECT 'country', NULL, country, COUNT(*) FROM clicks GROUP BY country
+		UNION ALL
+		SELECT 'variant', NULL, variant::text, COUNT(*) FROM clicks WHERE variant > 0 GROUP BY variant
+	`, ds.codeEquals(1))
+
+	rows, err := ds.pool.Query(ctx, query, string(code), from, to)
+	if err != nil {
+		return model.URLStats{}, fmt.Errorf("failed to query click stats: %w", err)
+	}
+	defer rows.Close()
+
+	found := false
+	stats := newClickStats()
+	for rows.Next() {
+		var kind, value string
+		var bucket *time.Time
+		var clicks int64
+		if err := rows.Scan(&kind, &bucket, &value, &clicks); err != nil {
+			return model.URLStats{}, fmt.Errorf("failed to scan click stats: %w", err)
+		}
+
+		n := int(clicks)
+		switch kind {
+		case "link":
+			found = true
+		case "visitors":
+			stats.visitors = n
+		case "hour":
+			stats.total += n
+			stats.hourly[bucket.UTC()] = n
+		case "day":
+			stats.daily[bucket.UTC()] = n
+		case "referrer":
+			stats.referrers[value] = n
+		case "browser":
+			stats.browsers[value] = n
+		case "country":
+			stats.countries[value] = n
+		case "variant":
+			variant, err := strconv.Atoi(value)
+			if err != nil {
+				return model.URLStats{}, fmt.Errorf("failed to parse click variant %q: %w", value, err)
+			}
+			stats.variants[variant] = n
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return model.URLStats{}, fmt.Errorf("error iterating over click stats: %w", err)
+	}
+
+	if !found {
+		return model.URLStats{}, fmt.Errorf("key %s: %w", code, ErrNotFound)
+	}
+
+	return stats.build(code, from, to), nil
+}
+
 // IsCodeUnique проверяет, свободен ли код в базе данных
 func (ds *DatabaseStore) IsCodeUnique(code model.Code) bool {
 	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM urls WHERE code = $1)`
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM urls WHERE %s)`, codeTaken(1))
 
 	err := ds.pool.QueryRow(context.Background(), query, string(code)).Scan(&exists)
 	if err != nil {
@@ -231,52 +881,270 @@ func (ds *DatabaseStore) GetCodeByURL(url model.URL) (model.Code, error) {
 	return model.Code(code), nil
 }
 
-// GetURLsByUserID возвращает все URL для указанного пользователя (исключая удалённые)
-func (ds *DatabaseStore) GetURLsByUserID(userID string, baseURL string) ([]model.UserURLResponse, error) {
+// GetURLsByUserID возвращает страницу URL пользователя (исключая удалённые), подходящих под запрос.
+// Страница выбирается по курсору (keyset): условие на пару (поле сортировки, код) использует
+// индекс idx_urls_user_id_created_at и не зависит от номера страницы, в отличие от OFFSET.
+func (ds *DatabaseStore) GetURLsByUserID(userID string, baseURL string, query model.URLQuery) (model.URLPage, error) {
 	ctx := context.Background()
 
-	query := `
-		SELECT code, original_url
+	sortColumn := urlSortColumns[query.Sort]
+	if sortColumn == "" {
+		sortColumn = urlSortColumns[model.URLSortCreated]
+	}
+	direction, comparison := "ASC", ">"
+	if query.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	args := []any{userID, query.Filter.Tag, query.Filter.Folder, query.Search}
+	sql := `
+		SELECT code, original_url, COALESCE(display_url, ''), click_count, last_accessed_at, created_at, folder,
+			COALESCE((SELECT array_agg(tag ORDER BY tag) FROM url_tags WHERE url_id = urls.id), '{}')
 		FROM urls
-		WHERE user_id = $1 AND is_deleted = false
-		ORDER BY created_at DESC
-	`
+		WHERE user_id = $1 AND is_deleted = false` + labelFilter + `
+			AND ($4 = '' OR strpos(lower(original_url), lower($4)) > 0)`
+	if query.After != nil {
+		args = append(args, cursorValue(query.Sort, *query.After), string(query.After.Code))
+		sql += fmt.Sprintf(`
+			AND (%s, code) %s ($%d, $%d)`, sortColumn, comparison, len(args)-1, len(args))
+	}
+	sql += fmt.Sprintf(`
+		ORDER BY %[1]s %[2]s, code %[2]s`, sortColumn, direction)
+	if query.Limit > 0 {
+		// Лишняя строка показывает, есть ли следующая страница
+		args = append(args, query.Limit+1)
+		sql += fmt.Sprintf(`
+		LIMIT $%d`, len(args))
+	}
 
-	rows, err := ds.pool.Query(ctx, query, userID)
+	rows, err := ds.pool.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query URLs by user ID: %w", err)
+		return model.URLPage{}, fmt.Errorf("failed to query URLs by user ID: %w", err)
 	}
 	defer rows.Close()
 
-	var urls []model.UserURLResponse
+	var page model.URLPage
+	var last model.URLCursor
 	for rows.Next() {
-		var code, originalURL string
-		if err := rows.Scan(&code, &originalURL); err != nil {
-			return nil, fmt.Errorf("failed to scan URL row: %w", err)
+		if query.Limit > 0 && len(page.URLs) == query.Limit {
+			page.Next = &last
+			break
+		}
+
+		var code, originalURL, displayURL, folder string
+		var clicks int64
+		var lastAccessedAt *time.Time
+		var createdAt time.Time
+		var tags []string
+		if err := rows.Scan(&code, &originalURL, &displayURL, &clicks, &lastAccessedAt, &createdAt, &folder, &tags); err != nil {
+			return model.URLPage{}, fmt.Errorf("failed to scan URL row: %w", err)
 		}
 
 		shortURL, err := url.JoinPath(baseURL, code)
 		if err != nil {
-			return nil, fmt.Errorf("failed to construct short URL: %w", err)
+			return model.URLPage{}, fmt.Errorf("failed to construct short URL: %w", err)
 		}
 
-		urls = append(urls, model.UserURLResponse{
-			ShortURL:    shortURL,
-			OriginalURL: originalURL,
-		})
+		entry := model.UserURLResponse{
+			ShortURL:       shortURL,
+			OriginalURL:    originalURL,
+			DisplayURL:     displayURL,
+			Clicks:         clicks,
+			LastAccessedAt: lastAccessedAt,
+			CreatedAt:      &createdAt,
+			Folder:         folder,
+		}
+		if len(tags) > 0 {
+			entry.Tags = tags
+		}
+		page.URLs = append(page.URLs, entry)
+		last = model.URLCursor{CreatedAt: createdAt, Clicks: clicks, URL: originalURL, Code: model.Code(code)}
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over URL rows: %w", err)
+		return model.URLPage{}, fmt.Errorf("error iterating over URL rows: %w", err)
 	}
 
-	return urls, nil
+	return page, nil
+}
+
+// urlSortColumns сопоставляет сортировку списка ссылок с колонкой таблицы urls
+var urlSortColumns = map[model.URLSort]string{
+	model.URLSortCreated: "created_at",
+	model.URLSortClicks:  "click_count",
+	model.URLSortURL:     "original_url",
+}
+
+// cursorValue возвращает значение поля сортировки из курсора
+func cursorValue(sort model.URLSort, cursor model.URLCursor) any {
+	switch sort {
+	case model.URLSortClicks:
+		return cursor.Clicks
+	case model.URLSortURL:
+		return cursor.URL
+	default:
+		return cursor.CreatedAt
+	}
+}
+
+// labelFilter — условие отбора ссылок по тегу ($2) и папке ($3); пустое значение не ограничивает выборку
+const labelFilter = `
+			AND ($2 = '' OR EXISTS (SELECT 1 FROM url_tags WHERE url_id = urls.id AND tag = $2))
+			AND ($3 = '' OR folder = $3)`
+
+// SetURLLabels заменяет теги и папку ссылки владельца. Строка ссылки блокируется
+// до конца транзакции, поэтому проверка владельца и замена тегов выполняются атомарно.
+func (ds *DatabaseStore) SetURLLabels(code model.Code, labels model.LinkLabels, userID string) error {
+	ctx := context.Background()
+
+	tx, err := ds.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := ds.lockOwnedURL(ctx, tx, code, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE urls SET folder = $2 WHERE id = $1`, id, labels.Folder); err != nil {
+		return fmt.Errorf("failed to update folder: %w", err)
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM url_tags WHERE url_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+	if err = insertTags(ctx, tx, id, labels.Tags); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetURLRules заменяет правила условного редиректа ссылки владельца
+func (ds *DatabaseStore) SetURLRules(code model.Code, rules []model.RedirectRule, userID string) error {
+	redirectRules, err := rulesParam(rules)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	tx, err := ds.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := ds.lockOwnedURL(ctx, tx, code, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE urls SET redirect_rules = $2 WHERE id = $1`, id, redirectRules); err != nil {
+		return fmt.Errorf("failed to update redirect rules: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetURLVariants заменяет варианты сплит-ссылки владельца
+func (ds *DatabaseStore) SetURLVariants(code model.Code, variants []model.SplitVariant, userID string) error {
+	splitVariants, err := variantsParam(variants)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	tx, err := ds.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := ds.lockOwnedURL(ctx, tx, code, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE urls SET split_variants = $2 WHERE id = $1`, id, splitVariants); err != nil {
+		return fmt.Errorf("failed to update split variants: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// lockOwnedURL блокирует до конца транзакции строку действующей ссылки владельца
+// и возвращает её идентификатор. Чужая ссылка считается ненайденной.
+func (ds *DatabaseStore) lockOwnedURL(ctx context.Context, tx pgx.Tx, code model.Code, userID string) (int64, error) {
+	query := fmt.Sprintf(`
+		SELECT id, user_id, is_deleted, COALESCE(expires_at <= CURRENT_TIMESTAMP, false)
+		FROM urls
+		WHERE %s
+		ORDER BY code = $1 DESC, id
+		LIMIT 1
+		FOR UPDATE
+	`, ds.codeEquals(1))
+
+	var id int64
+	var owner string
+	var isDeleted, isExpired bool
+	err := tx.QueryRow(ctx, query, string(code)).Scan(&id, &owner, &isDeleted, &isExpired)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, fmt.Errorf("key %s: %w", code, ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to lock URL: %w", err)
+	}
+
+	switch {
+	case isExpired:
+		return 0, fmt.Errorf("key %s: %w", code, ErrURLExpired)
+	case isDeleted:
+		return 0, fmt.Errorf("key %s: %w", code, ErrURLDeleted)
+	case owner != userID:
+		return 0, fmt.Errorf("key %s: %w", code, ErrNotFound)
+	}
+	return id, nil
+}
+
+// insertTags привязывает теги к ссылке с идентификатором urlID
+func insertTags(ctx context.Context, tx pgx.Tx, urlID int64, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `INSERT INTO url_tags (url_id, tag) SELECT $1, unnest($2::text[])`, urlID, tags)
+	if err != nil {
+		return fmt.Errorf("failed to insert tags: %w", err)
+	}
+	return nil
+}
+
+// tagsParam возвращает теги для параметра text[]: nil передаётся как пустой массив, а не NULL
+func tagsParam(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
 }
 
 // IsURLOwnedByUser проверяет, принадлежит ли URL указанному пользователю
 func (ds *DatabaseStore) IsURLOwnedByUser(code model.Code, userID string) bool {
 	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM urls WHERE code = $1 AND user_id = $2 AND is_deleted = false)`
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM urls WHERE %s AND user_id = $2 AND is_deleted = false)`, ds.codeEquals(1))
 	err := ds.pool.QueryRow(context.Background(), query, string(code), userID).Scan(&exists)
 	return err == nil && exists
 }
@@ -289,7 +1157,108 @@ func (ds *DatabaseStore) DeleteURLsBatch(codes []model.Code, userID string) erro
 	}
 
 	ctx := context.Background()
-	return ds.batchUpdateDeletedFlag(ctx, codes, userID, true)
+	_, err := ds.batchUpdateDeletedFlag(ctx, codes, userID, true, time.Time{})
+	return err
+}
+
+// DeleteURLsByFilter помечает удалёнными ссылки пользователя, подходящие под фильтр,
+// и возвращает их коды. Пустой фильтр не удаляет ничего.
+func (ds *DatabaseStore) DeleteURLsByFilter(userID string, filter model.URLFilter) ([]model.Code, error) {
+	if filter.IsZero() {
+		return nil, nil
+	}
+
+	query := `
+		UPDATE urls
+		SET is_deleted = true, deleted_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND is_deleted = false` + labelFilter + `
+		RETURNING code
+	`
+
+	rows, err := ds.pool.Query(context.Background(), query, userID, filter.Tag, filter.Folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete URLs by filter: %w", err)
+	}
+	defer rows.Close()
+
+	var deleted []model.Code
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted code: %w", err)
+		}
+		deleted = append(deleted, model.Code(code))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over deleted codes: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// RestoreURLsBatch снимает пометку удаления со ссылок пользователя, удалённых
+// не раньше deletedAfter, и возвращает восстановленные коды. Истёкшие ссылки не восстанавливаются.
+func (ds *DatabaseStore) RestoreURLsBatch(codes []model.Code, userID string, deletedAfter time.Time) ([]model.Code, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	restored, err := ds.batchUpdateDeletedFlag(context.Background(), codes, userID, false, deletedAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore URLs: %w", err)
+	}
+	return restored, nil
+}
+
+// GetDeletedURLsByUserID возвращает удалённые ссылки пользователя, которые ещё можно
+// восстановить: удалённые не раньше deletedAfter и не истёкшие.
+func (ds *DatabaseStore) GetDeletedURLsByUserID(userID string, baseURL string, deletedAfter time.Time) ([]model.UserURLResponse, error) {
+	ctx := context.Background()
+
+	query := `
+		SELECT code, original_url, COALESCE(display_url, ''), click_count, last_accessed_at, deleted_at
+		FROM urls
+		WHERE user_id = $1 AND is_deleted = true AND deleted_at >= $2
+			AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		ORDER BY deleted_at DESC
+	`
+
+	rows, err := ds.pool.Query(ctx, query, userID, deletedAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted URLs by user ID: %w", err)
+	}
+	defer rows.Close()
+
+	var urls []model.UserURLResponse
+	for rows.Next() {
+		var code, originalURL, displayURL string
+		var clicks int64
+		var lastAccessedAt, deletedAt *time.Time
+		if err := rows.Scan(&code, &originalURL, &displayURL, &clicks, &lastAccessedAt, &deletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted URL row: %w", err)
+		}
+
+		shortURL, err := url.JoinPath(baseURL, code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct short URL: %w", err)
+		}
+
+		urls = append(urls, model.UserURLResponse{
+			ShortURL:       shortURL,
+			OriginalURL:    originalURL,
+			DisplayURL:     displayURL,
+			Clicks:         clicks,
+			LastAccessedAt: lastAccessedAt,
+			DeletedAt:      deletedAt,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over deleted URL rows: %w", err)
+	}
+
+	return urls, nil
 }
 
 // GetStats возвращает количество активных URL и уникальных пользователей из базы данных
@@ -310,29 +1279,266 @@ func (ds *DatabaseStore) GetStats() (model.Stats, error) {
 	return stats, nil
 }
 
-// batchUpdateDeletedFlag выполняет batch update флага is_deleted
-func (ds *DatabaseStore) batchUpdateDeletedFlag(ctx context.Context, codes []model.Code, userID string, isDeleted bool) error {
+// batchUpdateDeletedFlag выполняет batch update флага is_deleted и возвращает изменённые коды.
+// При восстановлении (isDeleted = false) меняются только ссылки, удалённые не раньше
+// deletedAfter и не истёкшие; при удалении deletedAfter не используется.
+func (ds *DatabaseStore) batchUpdateDeletedFlag(ctx context.Context, codes []model.Code, userID string, isDeleted bool, deletedAfter time.Time) ([]model.Code, error) {
 	if len(codes) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Создаем placeholders для IN запроса
 	placeholders := make([]string, len(codes))
-	args := make([]interface{}, len(codes)+2)
+	args := make([]interface{}, len(codes)+2, len(codes)+3)
 
+	codeColumn := "code"
 	for i, code := range codes {
 		placeholders[i] = fmt.Sprintf("$%d", i+1)
 		args[i] = string(code)
+		if ds.caseInsensitive {
+			placeholders[i] = fmt.Sprintf("lower($%d)", i+1)
+		}
+	}
+	if ds.caseInsensitive {
+		codeColumn = "lower(code)"
 	}
 	args[len(codes)] = userID
 	args[len(codes)+1] = isDeleted
 
+	restoreFilter := ""
+	if !isDeleted {
+		args = append(args, deletedAfter)
+		restoreFilter = fmt.Sprintf(`
+			AND is_deleted AND deleted_at >= $%d
+			AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)`, len(args))
+	}
+
+	// deleted_at фиксирует время первого удаления: повторное удаление не продлевает срок хранения
 	query := fmt.Sprintf(`
 		UPDATE urls
-		SET is_deleted = $%d
-		WHERE code IN (%s) AND user_id = $%d
-	`, len(codes)+2, strings.Join(placeholders, ","), len(codes)+1)
+		SET is_deleted = $%[1]d,
+			deleted_at = CASE
+				WHEN NOT $%[1]d THEN NULL
+				WHEN is_deleted THEN deleted_at
+				ELSE CURRENT_TIMESTAMP
+			END
+		WHERE %[2]s IN (%[3]s) AND user_id = $%[4]d%[5]s
+		RETURNING code
+	`, len(codes)+2, codeColumn, strings.Join(placeholders, ","), len(codes)+1, restoreFilter)
 
-	_, err := ds.pool.Exec(ctx, query, args...)
-	return err
+	rows, err := ds.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var updated []model.Code
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		updated = append(updated, model.Code(code))
+	}
+
+	return updated, rows.Err()
+}
+
+// MarkExpiredURLs помечает удалёнными ссылки, срок жизни которых истёк к моменту now,
+// и возвращает их коды. Временем удаления считается момент истечения
+func (ds *DatabaseStore) MarkExpiredURLs(now time.Time) ([]model.Code, error) {
+	ctx := context.Background()
+
+	query := `
+		UPDATE urls
+		SET is_deleted = true, deleted_at = expires_at
+		WHERE is_deleted = false AND expires_at <= $1
+		RETURNING code
+	`
+
+	rows, err := ds.pool.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark expired URLs: %w", err)
+	}
+	defer rows.Close()
+
+	var expired []model.Code
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, fmt.Errorf("failed to scan expired code: %w", err)
+		}
+		expired = append(expired, model.Code(code))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over expired codes: %w", err)
+	}
+
+	return expired, nil
+}
+
+// PurgeDeletedURLs окончательно удаляет ссылки, мягко удалённые раньше deletedBefore,
+// и возвращает освободившиеся коды
+func (ds *DatabaseStore) PurgeDeletedURLs(deletedBefore time.Time) ([]model.Code, error) {
+	ctx := context.Background()
+
+	query := `
+		DELETE FROM urls
+		WHERE is_deleted = true AND deleted_at < $1
+		RETURNING code
+	`
+
+	rows, err := ds.pool.Query(ctx, query, deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted URLs: %w", err)
+	}
+	defer rows.Close()
+
+	var purged []model.Code
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, fmt.Errorf("failed to scan purged code: %w", err)
+		}
+		purged = append(purged, model.Code(code))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over purged codes: %w", err)
+	}
+
+	return purged, nil
+}
+
+// ReleaseCodes помещает освободившиеся коды в пул переиспользования.
+// Если код уже в пуле, сохраняется более поздний срок окончания карантина
+func (ds *DatabaseStore) ReleaseCodes(codes []model.Code, availableAt time.Time) error {
+	if len(codes) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(codes))
+	for i, code := range codes {
+		values[i] = string(code)
+	}
+
+	query := `
+		INSERT INTO recycled_codes (code, available_at)
+		SELECT unnest($1::text[]), $2
+		ON CONFLICT (code) DO UPDATE
+		SET available_at = GREATEST(recycled_codes.available_at, EXCLUDED.available_at)
+	`
+
+	if _, err := ds.pool.Exec(context.Background(), query, values, availableAt); err != nil {
+		return fmt.Errorf("failed to release codes: %w", err)
+	}
+
+	return nil
+}
+
+// AcquireRecycledCode атомарно извлекает из пула код, карантин которого истёк к моменту now.
+// SKIP LOCKED позволяет нескольким экземплярам сервиса извлекать коды параллельно,
+// не получая один и тот же код
+func (ds *DatabaseStore) AcquireRecycledCode(now time.Time) (model.Code, bool, error) {
+	query := `
+		DELETE FROM recycled_codes
+		WHERE code = (
+			SELECT code FROM recycled_codes
+			WHERE available_at <= $1
+			ORDER BY available_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING code
+	`
+
+	var code string
+	err := ds.pool.QueryRow(context.Background(), query, now).Scan(&code)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to acquire recycled code: %w", err)
+	}
+
+	return model.Code(code), true, nil
+}
+
+// nullableTime преобразует нулевое время в NULL для параметров запроса
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// redirectParams преобразует параметры редиректа в параметры запроса; NULL — значение по умолчанию
+func redirectParams(policy model.RedirectPolicy) (status, maxAge *int) {
+	if policy.Status != 0 {
+		status = &policy.Status
+	}
+	if policy.MaxAge != nil {
+		seconds := int(policy.MaxAge.Seconds())
+		maxAge = &seconds
+	}
+	return status, maxAge
+}
+
+// queryParams преобразует изменения query-строки в параметры запроса; NULL — адрес не меняется.
+// Метки UTM хранятся закодированной query-строкой
+func queryParams(policy model.QueryPolicy) (passthrough, utm *string) {
+	if policy.Passthrough != "" {
+		value := string(policy.Passthrough)
+		passthrough = &value
+	}
+	if !policy.UTM.IsZero() {
+		value := policy.UTM.Values().Encode()
+		utm = &value
+	}
+	return passthrough, utm
+}
+
+// rulesParam кодирует правила условного редиректа в JSON; NULL — у ссылки нет правил
+func rulesParam(rules []model.RedirectRule) ([]byte, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode redirect rules: %w", err)
+	}
+	return encoded, nil
+}
+
+// decodeUTM разбирает метки UTM, сохранённые закодированной query-строкой
+func decodeUTM(encoded string) model.UTMParams {
+	values, _ := url.ParseQuery(encoded)
+	return model.UTMParams{
+		Source:   values.Get("utm_source"),
+		Medium:   values.Get("utm_medium"),
+		Campaign: values.Get("utm_campaign"),
+		Term:     values.Get("utm_term"),
+		Content:  values.Get("utm_content"),
+	}
+}
+
+// nullableClicks преобразует отсутствие лимита переходов в NULL для параметров запроса
+func nullableClicks(n int) *int {
+	if n <= 0 {
+		return nil
+	}
+	return &n
+}
+
+// variantsParam кодирует варианты сплит-ссылки в JSON; NULL — ссылка без вариантов
+func variantsParam(variants []model.SplitVariant) ([]byte, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(variants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode split variants: %w", err)
+	}
+	return encoded, nil
 }
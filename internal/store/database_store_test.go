@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config/db"
+	"github.com/avc-dev/url-shortener/internal/migrations"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newTestDatabaseStore подключается к PostgreSQL из TEST_DATABASE_DSN, применяет миграции
+// и очищает таблицы ссылок. Без TEST_DATABASE_DSN тест пропускается.
+func newTestDatabaseStore(t *testing.T) *DatabaseStore {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	ctx := context.Background()
+	database, err := db.NewConfig(dsn).Connect(ctx)
+	require.NoError(t, err)
+	t.Cleanup(database.Close)
+
+	require.NoError(t, migrations.NewMigrator(database.DB(), zap.NewNop()).RunUp())
+	_, err = database.DB().ExecContext(ctx, "TRUNCATE urls RESTART IDENTITY CASCADE")
+	require.NoError(t, err)
+
+	return NewDatabaseStore(database)
+}
+
+func TestDatabaseStore_Deduplication(t *testing.T) {
+	for _, tt := range dedupExclusionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assertNotDeduplicated(t, newTestDatabaseStore(t), tt.opts)
+		})
+	}
+}
+
+func TestDatabaseStore_ClickStats(t *testing.T) {
+	ds := newTestDatabaseStore(t)
+	require.NoError(t, ds.Write("abc", "https://example.com", "user-1"))
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(72 * time.Hour)
+
+	require.NoError(t, ds.RecordClicks([]model.Click{
+		{Code: "abc", At: from.Add(10*time.Hour + 5*time.Minute), Referrer: "a.com", UAFamily: "Chrome", Country: "US", VisitorID: "v1", Variant: 2},
+		{Code: "abc", At: from.Add(10*time.Hour + 50*time.Minute), Referrer: "a.com", UAFamily: "Chrome", Country: "US", VisitorID: "v1", Variant: 2},
+		{Code: "abc", At: from.Add(26 * time.Hour), UAFamily: "Firefox", Country: "DE", VisitorID: "v2", Variant: 1},
+		{Code: "abc", At: from.Add(-time.Minute), Referrer: "old.com", VisitorID: "v3", Variant: 1},
+		{Code: "missing", At: from},
+	}))
+
+	stats, err := ds.GetClickStats("abc", from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalClicks)
+	assert.Equal(t, 2, stats.UniqueVisitors)
+	assert.Equal(t, []model.StatsBucket{
+		{Start: from.Add(10 * time.Hour), Clicks: 2},
+		{Start: from.Add(26 * time.Hour), Clicks: 1},
+	}, stats.Hourly)
+	assert.Equal(t, []model.StatsBucket{
+		{Start: from, Clicks: 2},
+		{Start: from.Add(24 * time.Hour), Clicks: 1},
+	}, stats.Daily)
+	assert.Equal(t, []model.StatsCount{{Value: "a.com", Clicks: 2}, {Value: "", Clicks: 1}}, stats.Referrers)
+	assert.Equal(t, []model.StatsCount{{Value: "US", Clicks: 2}, {Value: "DE", Clicks: 1}}, stats.Countries)
+	assert.Equal(t, []model.VariantCount{{Variant: 1, Clicks: 1}, {Variant: 2, Clicks: 2}}, stats.Variants)
+
+	_, err = ds.GetClickStats("missing", from, to)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
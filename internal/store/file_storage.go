@@ -2,6 +2,7 @@ package store
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -55,35 +56,64 @@ func (fs *FileStorage) Load() ([]model.URLEntry, error) {
 }
 
 // Save сохраняет все записи в файл (JSONL формат - каждая запись на отдельной строке)
-// Используется для компакции или начального сохранения
+// Используется для компакции или начального сохранения. Записи пишутся во временный файл,
+// который затем заменяет основной, поэтому сбой посреди записи не портит журнал.
 func (fs *FileStorage) Save(entries []model.URLEntry) error {
-	file, err := os.Create(fs.filePath)
+	tmpPath := fs.filePath + ".tmp"
+	file, err := os.Create(tmpPath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
-	defer file.Close()
+	defer os.Remove(tmpPath)
 
-	encoder := json.NewEncoder(file)
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
 	for _, entry := range entries {
 		if err := encoder.Encode(entry); err != nil {
+			file.Close()
 			return fmt.Errorf("failed to encode entry: %w", err)
 		}
 	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, fs.filePath); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
 
 	return nil
 }
 
 // Append добавляет одну запись в конец файла (JSONL формат)
 func (fs *FileStorage) Append(entry model.URLEntry) error {
+	return fs.AppendAll([]model.URLEntry{entry})
+}
+
+// AppendAll добавляет несколько записей в конец файла за одно открытие (JSONL формат)
+func (fs *FileStorage) AppendAll(entries []model.URLEntry) error {
 	file, err := os.OpenFile(fs.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to open file for append: %w", err)
 	}
 	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(entry); err != nil {
-		return fmt.Errorf("failed to encode entry: %w", err)
+	// Записи собираются в буфер и пишутся одним вызовом, чтобы не оставлять в файле
+	// часть пачки при ошибке кодирования
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode entry: %w", err)
+		}
+	}
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to append entries: %w", err)
 	}
 
 	return nil
@@ -2,30 +2,30 @@ package store
 
 import (
 	"fmt"
-	"net/url"
+	"sync"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/google/uuid"
 )
 
-// FileStore декоратор над Store, который добавляет персистентность через файл
+// FileStore декоратор над Store, который добавляет персистентность через файл.
+// Файл — журнал изменений: каждое изменение ссылки дописывается полной записью,
+// а при загрузке более поздняя запись с тем же кодом заменяет предыдущую.
 type FileStore struct {
 	store       *Store
 	fileStorage *FileStorage
-	userMap     map[model.Code]string // code -> userID mapping
-	deletedMap  map[model.Code]bool   // code -> is_deleted mapping
+	appendMu    sync.Mutex // упорядочивает чтение состояния и запись в файл
 }
 
 // NewFileStore создаёт FileStore и загружает данные из файла
-func NewFileStore(filePath string) (*FileStore, error) {
-	store := NewStore()
+func NewFileStore(filePath string, opts ...Option) (*FileStore, error) {
+	store := NewStore(opts...)
 	fileStorage := NewFileStorage(filePath)
 
 	fs := &FileStore{
 		store:       store,
 		fileStorage: fileStorage,
-		userMap:     make(map[model.Code]string),
-		deletedMap:  make(map[model.Code]bool),
 	}
 
 	// Загружаем данные из файла при инициализации
@@ -41,29 +41,58 @@ func (fs *FileStore) Read(key model.Code) (model.URL, error) {
 	return fs.store.Read(key)
 }
 
-// Write записывает значение в in-memory store и добавляет в файл
-func (fs *FileStore) Write(key model.Code, value model.URL, userID string) error {
-	if err := fs.store.Write(key, value, userID); err != nil {
-		return fmt.Errorf("failed to write to in-memory store: %w", err)
+// Follow расходует переход по ссылке и сохраняет оставшееся число переходов в файл.
+// Если записать файл не удалось, переход возвращается ссылке: иначе после перезапуска
+// лимит восстановился бы из файла с большим значением, чем было израсходовано в памяти.
+func (fs *FileStore) Follow(key model.Code, unlocked bool) (model.LinkTarget, error) {
+	target, err := fs.store.Follow(key, unlocked)
+	if err != nil {
+		return model.LinkTarget{}, err
 	}
 
-	fs.userMap[key] = userID
-	fs.deletedMap[key] = false
+	stored, _ := fs.store.canonicalCode(key)
+	if fs.store.isClickLimited(stored) {
+		if err := fs.appendCurrent(stored); err != nil {
+			fs.store.refundClick(stored)
+			return model.LinkTarget{}, err
+		}
+	}
 
-	// Добавляем только новую запись в файл (O(1) вместо O(n))
-	entry := model.URLEntry{
-		UUID:        uuid.New().String(),
-		ShortURL:    string(key),
-		OriginalURL: string(value),
-		UserID:      userID,
-		DeletedFlag: false,
+	return target, nil
+}
+
+// PasswordHash возвращает хеш пароля ссылки из in-memory store
+func (fs *FileStore) PasswordHash(key model.Code) (string, error) {
+	return fs.store.PasswordHash(key)
+}
+
+// Peek возвращает адрес и параметры редиректа ссылки из in-memory store, не расходуя переход
+func (fs *FileStore) Peek(key model.Code, unlocked bool) (model.LinkTarget, error) {
+	return fs.store.Peek(key, unlocked)
+}
+
+// Inspect возвращает сведения о ссылке для предпросмотра из in-memory store
+func (fs *FileStore) Inspect(key model.Code, unlocked bool) (model.LinkInfo, error) {
+	return fs.store.Inspect(key, unlocked)
+}
+
+// SetURLUnsafe меняет пометку небезопасной ссылки и сохраняет её в файл
+func (fs *FileStore) SetURLUnsafe(key model.Code, unsafe bool) error {
+	stored, err := fs.store.setURLUnsafe(key, unsafe)
+	if err != nil {
+		return err
 	}
+	return fs.appendCurrent(stored)
+}
 
-	if err := fs.fileStorage.Append(entry); err != nil {
-		return fmt.Errorf("failed to append to file: %w", err)
+// Write записывает значение в in-memory store и добавляет в файл
+func (fs *FileStore) Write(key model.Code, value model.URL, userID string) error {
+	if err := fs.store.Write(key, value, userID); err != nil {
+		return fmt.Errorf("failed to write to in-memory store: %w", err)
 	}
 
-	return nil
+	// Добавляем только новую запись в файл (O(1) вместо O(n))
+	return fs.appendCurrent(key)
 }
 
 // loadFromFile загружает данные из файла в in-memory store
@@ -73,52 +102,193 @@ func (fs *FileStore) loadFromFile() error {
 		return fmt.Errorf("failed to load data from file: %w", err)
 	}
 
-	data := make(URLMap, len(entries))
-	for _, entry := range entries {
-		code := model.Code(entry.ShortURL)
-		url := model.URL(entry.OriginalURL)
-		data[code] = url
-		if entry.UserID != "" {
-			fs.userMap[code] = entry.UserID
+	live := fs.store.withoutExpiredClicks(entries, time.Now())
+	fs.store.loadEntries(live)
+
+	// Журнал сжимается при загрузке: переходы старше срока хранения больше не нужны
+	if len(live) < len(entries) {
+		if err := fs.fileStorage.Save(live); err != nil {
+			return fmt.Errorf("failed to compact file: %w", err)
 		}
-		fs.deletedMap[code] = entry.DeletedFlag
 	}
 
-	fs.store.InitializeWith(data, fs.userMap, fs.deletedMap)
+	return nil
+}
+
+// appendCurrent дописывает в файл текущее состояние ссылки.
+// Снимок и запись выполняются под одним мьютексом, поэтому последняя строка
+// в файле всегда отражает самое свежее состояние, даже при параллельных изменениях.
+func (fs *FileStore) appendCurrent(code model.Code) error {
+	fs.appendMu.Lock()
+	defer fs.appendMu.Unlock()
+
+	entry, ok := fs.store.entryFor(code)
+	if !ok {
+		return nil
+	}
+	entry.UUID = uuid.New().String()
+
+	if err := fs.fileStorage.Append(entry); err != nil {
+		return fmt.Errorf("failed to append to file: %w", err)
+	}
 
 	return nil
 }
 
 // WriteBatch записывает несколько значений в in-memory store и добавляет их в файл
-func (fs *FileStore) WriteBatch(urls URLMap, userID string) error {
+func (fs *FileStore) WriteBatch(urls URLMap, userID string, opts model.LinkOptions) error {
 	// Сначала записываем в in-memory store
-	if err := fs.store.WriteBatch(urls, userID); err != nil {
+	if err := fs.store.WriteBatch(urls, userID, opts); err != nil {
 		return fmt.Errorf("failed to write batch to in-memory store: %w", err)
 	}
 
+	// Добавляем все записи в файл
 	for code := range urls {
-		fs.userMap[code] = userID
-		fs.deletedMap[code] = false
+		if err := fs.appendCurrent(code); err != nil {
+			return err
+		}
 	}
 
-	// Добавляем все записи в файл
-	for code, url := range urls {
-		entry := model.URLEntry{
-			UUID:        uuid.New().String(),
-			ShortURL:    string(code),
-			OriginalURL: string(url),
-			UserID:      userID,
-			DeletedFlag: false,
+	return nil
+}
+
+// RecordClicks сохраняет переходы в in-memory store и дописывает их в файл одной операцией
+func (fs *FileStore) RecordClicks(clicks []model.Click) error {
+	recorded := fs.store.recordClicks(clicks)
+	if len(recorded) == 0 {
+		return nil
+	}
+
+	entries := make([]model.URLEntry, len(recorded))
+	for i := range recorded {
+		entries[i] = model.URLEntry{
+			UUID:     uuid.New().String(),
+			ShortURL: string(recorded[i].Code),
+			Click:    &recorded[i],
 		}
+	}
 
-		if err := fs.fileStorage.Append(entry); err != nil {
-			return fmt.Errorf("failed to append to file: %w", err)
+	fs.appendMu.Lock()
+	defer fs.appendMu.Unlock()
+
+	if err := fs.fileStorage.AppendAll(entries); err != nil {
+		return fmt.Errorf("failed to append clicks to file: %w", err)
+	}
+
+	return nil
+}
+
+// AddClickCounts добавляет приращения счётчиков в in-memory store
+// и дописывает их в файл одной операцией
+func (fs *FileStore) AddClickCounts(counts []model.ClickCount) error {
+	applied := fs.store.addClickCounts(counts)
+	if len(applied) == 0 {
+		return nil
+	}
+
+	entries := make([]model.URLEntry, len(applied))
+	for i := range applied {
+		entries[i] = model.URLEntry{
+			UUID:       uuid.New().String(),
+			ShortURL:   string(applied[i].Code),
+			ClickCount: &applied[i],
 		}
 	}
 
+	fs.appendMu.Lock()
+	defer fs.appendMu.Unlock()
+
+	if err := fs.fileStorage.AppendAll(entries); err != nil {
+		return fmt.Errorf("failed to append click counts to file: %w", err)
+	}
+
 	return nil
 }
 
+// GetClickStats возвращает статистику переходов по ссылке из in-memory store
+func (fs *FileStore) GetClickStats(code model.Code, from, to time.Time) (model.URLStats, error) {
+	return fs.store.GetClickStats(code, from, to)
+}
+
+// UpdateURL меняет адрес ссылки в in-memory store и дописывает в файл одной операцией
+// новое состояние ссылки и версию с прежним адресом
+func (fs *FileStore) UpdateURL(code model.Code, url model.URL, userID string) (model.URL, error) {
+	stored, previous, version, err := fs.store.updateURL(code, url, userID)
+	if err != nil {
+		return "", err
+	}
+	if version.Version == 0 {
+		return previous, nil
+	}
+
+	fs.appendMu.Lock()
+	defer fs.appendMu.Unlock()
+
+	entry, ok := fs.store.entryFor(stored)
+	if !ok {
+		return previous, nil
+	}
+	entry.UUID = uuid.New().String()
+	entries := []model.URLEntry{entry, {
+		UUID:     uuid.New().String(),
+		ShortURL: string(stored),
+		Version:  &version,
+	}}
+
+	if err := fs.fileStorage.AppendAll(entries); err != nil {
+		return "", fmt.Errorf("failed to append URL update to file: %w", err)
+	}
+
+	return previous, nil
+}
+
+// GetURLVersions возвращает прежние адреса ссылки из in-memory store
+func (fs *FileStore) GetURLVersions(code model.Code) ([]model.URLVersion, error) {
+	return fs.store.GetURLVersions(code)
+}
+
+// PruneURLVersions удаляет устаревшие версии в in-memory store и фиксирует очистку в файле
+func (fs *FileStore) PruneURLVersions(before time.Time) (int, error) {
+	fs.appendMu.Lock()
+	defer fs.appendMu.Unlock()
+
+	pruned, err := fs.store.PruneURLVersions(before)
+	if err != nil || pruned == 0 {
+		return pruned, err
+	}
+
+	entry := model.URLEntry{
+		UUID:                uuid.New().String(),
+		PruneVersionsBefore: &before,
+	}
+	if err := fs.fileStorage.Append(entry); err != nil {
+		return pruned, fmt.Errorf("failed to append version pruning to file: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// RestoreURLsBatch восстанавливает ссылки в in-memory store и дописывает их состояние в файл
+func (fs *FileStore) RestoreURLsBatch(codes []model.Code, userID string, deletedAfter time.Time) ([]model.Code, error) {
+	restored, err := fs.store.RestoreURLsBatch(codes, userID, deletedAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range restored {
+		if err := fs.appendCurrent(code); err != nil {
+			return nil, err
+		}
+	}
+
+	return restored, nil
+}
+
+// GetDeletedURLsByUserID возвращает восстановимые удалённые ссылки пользователя из in-memory store
+func (fs *FileStore) GetDeletedURLsByUserID(userID string, baseURL string, deletedAfter time.Time) ([]model.UserURLResponse, error) {
+	return fs.store.GetDeletedURLsByUserID(userID, baseURL, deletedAfter)
+}
+
 // IsCodeUnique проверяет, свободен ли код
 func (fs *FileStore) IsCodeUnique(code model.Code) bool {
 	return fs.store.IsCodeUnique(code)
@@ -130,71 +300,55 @@ func (fs *FileStore) GetCodeByURL(url model.URL) (model.Code, error) {
 }
 
 // CreateOrGetURL создает новую запись или возвращает код существующей для данного URL
-func (fs *FileStore) CreateOrGetURL(code model.Code, url model.URL, userID string) (model.Code, bool, error) {
-	// Для file storage мы всегда создаем новую запись (нет проверки дубликатов)
-	// TODO: Можно добавить логику проверки существования URL для пользователя
-	finalCode, created, err := fs.store.CreateOrGetURL(code, url, userID)
+func (fs *FileStore) CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error) {
+	finalCode, created, err := fs.store.CreateOrGetURL(code, url, userID, opts)
 	if err != nil {
 		return "", false, err
 	}
 
-	// Сохраняем user_id и deleted flag в памяти
-	fs.userMap[finalCode] = userID
-	fs.deletedMap[finalCode] = false
-
-	// Сохраняем в файл
-	entry := model.URLEntry{
-		UUID:        uuid.New().String(),
-		ShortURL:    string(finalCode),
-		OriginalURL: string(url),
-		UserID:      userID,
-		DeletedFlag: false,
-	}
-
-	if err := fs.fileStorage.Append(entry); err != nil {
-		return "", false, fmt.Errorf("failed to append to file: %w", err)
+	// Сохраняем в файл: для существующей записи фиксируется новый владелец
+	if err := fs.appendCurrent(finalCode); err != nil {
+		return "", false, err
 	}
 
 	return finalCode, created, nil
 }
 
-// GetURLsByUserID возвращает все URL для указанного пользователя из file store (исключая удалённые)
-func (fs *FileStore) GetURLsByUserID(userID string, baseURL string) ([]model.UserURLResponse, error) {
-	var urls []model.UserURLResponse
-
-	for code, storedUserID := range fs.userMap {
-		if storedUserID == userID {
-			// Проверяем, не удалён ли URL
-			if fs.deletedMap[code] {
-				continue // Пропускаем удалённые URL
-			}
-
-			// Получаем оригинальный URL
-			originalURL, err := fs.store.Read(code)
-			if err != nil {
-				continue // Пропускаем если URL не найден (несогласованность данных)
-			}
-
-			// Формируем полный короткий URL
-			shortURL, err := url.JoinPath(baseURL, string(code))
-			if err != nil {
-				continue // Пропускаем если не удалось сформировать URL
-			}
-
-			urls = append(urls, model.UserURLResponse{
-				ShortURL:    shortURL,
-				OriginalURL: string(originalURL),
-			})
-		}
+// GetURLsByUserID возвращает страницу URL пользователя из file store (исключая удалённые)
+func (fs *FileStore) GetURLsByUserID(userID string, baseURL string, query model.URLQuery) (model.URLPage, error) {
+	return fs.store.GetURLsByUserID(userID, baseURL, query)
+}
+
+// SetURLLabels заменяет теги и папку ссылки владельца и сохраняет изменение в файл
+func (fs *FileStore) SetURLLabels(code model.Code, labels model.LinkLabels, userID string) error {
+	stored, err := fs.store.setURLLabels(code, labels, userID)
+	if err != nil {
+		return err
 	}
+	return fs.appendCurrent(stored)
+}
 
-	return urls, nil
+// SetURLRules заменяет правила условного редиректа ссылки владельца и сохраняет изменение в файл
+func (fs *FileStore) SetURLRules(code model.Code, rules []model.RedirectRule, userID string) error {
+	stored, err := fs.store.setURLRules(code, rules, userID)
+	if err != nil {
+		return err
+	}
+	return fs.appendCurrent(stored)
+}
+
+// SetURLVariants заменяет варианты сплит-ссылки владельца и сохраняет изменение в файл
+func (fs *FileStore) SetURLVariants(code model.Code, variants []model.SplitVariant, userID string) error {
+	stored, err := fs.store.setURLVariants(code, variants, userID)
+	if err != nil {
+		return err
+	}
+	return fs.appendCurrent(stored)
 }
 
 // IsURLOwnedByUser проверяет, принадлежит ли URL указанному пользователю
 func (fs *FileStore) IsURLOwnedByUser(code model.Code, userID string) bool {
-	storedUserID, exists := fs.userMap[code]
-	return exists && storedUserID == userID && !fs.deletedMap[code]
+	return fs.store.IsURLOwnedByUser(code, userID)
 }
 
 // GetStats возвращает количество сокращённых URL и уникальных пользователей
@@ -203,13 +357,111 @@ func (fs *FileStore) GetStats() (model.Stats, error) {
 }
 
 // DeleteURLsBatch помечает несколько URL как удалённые для указанного пользователя
+// и сохраняет пометку в файл вместе со временем удаления
 func (fs *FileStore) DeleteURLsBatch(codes []model.Code, userID string) error {
-	// Обновляем deletedMap в FileStore для синхронизации
+	var deleted []model.Code
+	for _, code := range codes {
+		if stored, found := fs.store.canonicalCode(code); found && fs.store.IsURLOwnedByUser(stored, userID) {
+			deleted = append(deleted, stored)
+		}
+	}
+
+	if err := fs.store.DeleteURLsBatch(codes, userID); err != nil {
+		return err
+	}
+
+	for _, code := range deleted {
+		if err := fs.appendCurrent(code); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteURLsByFilter помечает удалёнными ссылки пользователя, подходящие под фильтр,
+// и сохраняет пометку в файл
+func (fs *FileStore) DeleteURLsByFilter(userID string, filter model.URLFilter) ([]model.Code, error) {
+	deleted, err := fs.store.DeleteURLsByFilter(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range deleted {
+		if err := fs.appendCurrent(code); err != nil {
+			return nil, err
+		}
+	}
+
+	return deleted, nil
+}
+
+// MarkExpiredURLs помечает удалёнными ссылки с истёкшим сроком жизни и сохраняет пометку в файл
+func (fs *FileStore) MarkExpiredURLs(now time.Time) ([]model.Code, error) {
+	expired, err := fs.store.MarkExpiredURLs(now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range expired {
+		if err := fs.appendCurrent(code); err != nil {
+			return nil, err
+		}
+	}
+
+	return expired, nil
+}
+
+// PurgeDeletedURLs окончательно удаляет ссылки, мягко удалённые раньше deletedBefore,
+// и записывает в файл отметки об удалении
+func (fs *FileStore) PurgeDeletedURLs(deletedBefore time.Time) ([]model.Code, error) {
+	purged, err := fs.store.PurgeDeletedURLs(deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.appendMu.Lock()
+	defer fs.appendMu.Unlock()
+
+	for _, code := range purged {
+		if err := fs.fileStorage.Append(model.URLEntry{
+			UUID:     uuid.New().String(),
+			ShortURL: string(code),
+			Purged:   true,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to append to file: %w", err)
+		}
+	}
+
+	return purged, nil
+}
+
+// ReleaseCodes помещает освободившиеся коды в пул переиспользования и сохраняет пул в файл
+func (fs *FileStore) ReleaseCodes(codes []model.Code, availableAt time.Time) error {
+	if err := fs.store.ReleaseCodes(codes, availableAt); err != nil {
+		return err
+	}
+
+	fs.appendMu.Lock()
+	defer fs.appendMu.Unlock()
+
 	for _, code := range codes {
-		if fs.IsURLOwnedByUser(code, userID) {
-			fs.deletedMap[code] = true
+		if err := fs.fileStorage.Append(model.URLEntry{
+			UUID:        uuid.New().String(),
+			ShortURL:    string(code),
+			Purged:      true,
+			AvailableAt: &availableAt,
+		}); err != nil {
+			return fmt.Errorf("failed to append to file: %w", err)
 		}
 	}
 
-	return fs.store.DeleteURLsBatch(codes, userID)
+	return nil
+}
+
+// AcquireRecycledCode извлекает из пула код, карантин которого истёк к моменту now.
+// Извлечение не записывается в файл: если код так и не будет использован,
+// после перезапуска он снова окажется в пуле, что безопасно.
+func (fs *FileStore) AcquireRecycledCode(now time.Time) (model.Code, bool, error) {
+	return fs.store.AcquireRecycledCode(now)
 }
@@ -1,9 +1,11 @@
 package store
 
 import (
+	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -181,3 +183,553 @@ func TestFileStore_MultipleWrites(t *testing.T) {
 		assert.Equal(t, expectedURL, result)
 	}
 }
+
+func TestFileStore_CaseInsensitiveCodes(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test_urls.json")
+
+	// Коды, записанные без режима, остаются доступными после его включения
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("MixedCase", "https://example.com", "user-1"))
+
+	fs2, err := NewFileStore(filePath, WithCaseInsensitiveCodes())
+	require.NoError(t, err)
+
+	value, err := fs2.Read("mixedcase")
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://example.com"), value)
+	assert.False(t, fs2.IsCodeUnique("MIXEDCASE"))
+	assert.True(t, fs2.IsURLOwnedByUser("MIXEDCASE", "user-1"))
+
+	require.NoError(t, fs2.DeleteURLsBatch([]model.Code{"mixedCASE"}, "user-1"))
+	assert.False(t, fs2.IsURLOwnedByUser("MixedCase", "user-1"))
+}
+
+func TestFileStore_PurgeAndRecyclingPersistence(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test_urls.json")
+	availableAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("deleted", "https://deleted.com", "user-1"))
+	require.NoError(t, fs1.Write("active", "https://active.com", "user-1"))
+	require.NoError(t, fs1.DeleteURLsBatch([]model.Code{"deleted"}, "user-1"))
+
+	// Удаление сохраняется в файл
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, err = fs2.Read("deleted")
+	assert.ErrorIs(t, err, ErrURLDeleted)
+
+	purged, err := fs2.PurgeDeletedURLs(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, []model.Code{"deleted"}, purged)
+	require.NoError(t, fs2.ReleaseCodes(purged, availableAt))
+
+	// Окончательное удаление и пул восстанавливаются после перезапуска
+	fs3, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, err = fs3.Read("deleted")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.True(t, fs3.IsCodeUnique("deleted"))
+
+	_, ok, err := fs3.AcquireRecycledCode(time.Now())
+	require.NoError(t, err)
+	assert.False(t, ok, "code must stay in quarantine after restart")
+
+	code, ok, err := fs3.AcquireRecycledCode(availableAt)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, model.Code("deleted"), code)
+
+	// Переиспользованный код не возвращается в пул после перезапуска
+	require.NoError(t, fs3.Write(code, "https://reused.com", "user-2"))
+	fs4, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	value, err := fs4.Read("deleted")
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://reused.com"), value)
+	_, ok, err = fs4.AcquireRecycledCode(availableAt)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFileStore_ExpiryPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.WriteBatch(URLMap{"expiring": "https://example.com"}, "user-1",
+		model.LinkOptions{ExpiresAt: expiresAt}))
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, err = fs2.Read("expiring")
+	require.NoError(t, err)
+
+	expired, err := fs2.MarkExpiredURLs(expiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Code{"expiring"}, expired)
+
+	fs3, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	page, err := fs3.GetURLsByUserID("user-1", "http://localhost:8080/", model.URLQuery{})
+	require.NoError(t, err)
+	urls := page.URLs
+	assert.Empty(t, urls)
+
+	// Срок жизни восстановлен из файла вместе с пометкой об удалении
+	_, err = fs3.MarkExpiredURLs(expiresAt)
+	require.NoError(t, err)
+	purged, err := fs3.PurgeDeletedURLs(expiresAt.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []model.Code{"expiring"}, purged)
+}
+
+func TestFileStore_ClickLimitPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, _, err = fs1.CreateOrGetURL("limited", "https://example.com", "user-1", model.LinkOptions{MaxClicks: 2})
+	require.NoError(t, err)
+	_, err = fs1.Follow("limited", false)
+	require.NoError(t, err)
+
+	// После перезапуска остаётся один переход
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, err = fs2.Follow("limited", false)
+	require.NoError(t, err)
+
+	fs3, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, err = fs3.Follow("limited", false)
+	assert.ErrorIs(t, err, ErrClickLimitReached)
+}
+
+func TestFileStore_FollowRefundsClickOnWriteError(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, _, err = fs.CreateOrGetURL("limited", "https://example.com", "user-1", model.LinkOptions{MaxClicks: 1})
+	require.NoError(t, err)
+
+	// Каталог на месте файла не даёт дописать журнал
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filePath))
+	require.NoError(t, os.Mkdir(filePath, 0755))
+
+	_, err = fs.Follow("limited", false)
+	require.Error(t, err)
+
+	require.NoError(t, os.Remove(filePath))
+	require.NoError(t, os.WriteFile(filePath, data, 0644))
+
+	_, err = fs.Follow("limited", false)
+	require.NoError(t, err, "failed follow does not use up the click")
+	_, err = fs.Follow("limited", false)
+	assert.ErrorIs(t, err, ErrClickLimitReached)
+}
+
+func TestFileStore_PasswordPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.WriteBatch(URLMap{"locked": "https://example.com"}, "user-1",
+		model.LinkOptions{PasswordHash: "hash"}))
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	hash, err := fs2.PasswordHash("locked")
+	require.NoError(t, err)
+	assert.Equal(t, "hash", hash)
+
+	_, err = fs2.Follow("locked", false)
+	assert.ErrorIs(t, err, ErrPasswordRequired)
+}
+
+func TestFileStore_ClickPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("kept", "https://kept.com", "user-1"))
+	require.NoError(t, fs1.Write("reused", "https://reused.com", "user-1"))
+	require.NoError(t, fs1.RecordClicks([]model.Click{
+		{Code: "kept", At: at, Country: "US"},
+		{Code: "reused", At: at},
+	}))
+
+	// Код окончательно удалённой ссылки занимает новая ссылка
+	require.NoError(t, fs1.DeleteURLsBatch([]model.Code{"reused"}, "user-1"))
+	_, err = fs1.PurgeDeletedURLs(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("reused", "https://new.com", "user-2"))
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	stats, err := fs2.GetClickStats("kept", at, at)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalClicks)
+	assert.Equal(t, []model.StatsCount{{Value: "US", Clicks: 1}}, stats.Countries)
+
+	stats, err = fs2.GetClickStats("reused", at, at)
+	require.NoError(t, err)
+	assert.Zero(t, stats.TotalClicks, "new link does not inherit clicks of the purged one")
+}
+
+func TestFileStore_CompactsExpiredClicks(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+	now := time.Now().UTC()
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("kept", "https://kept.com", "user-1"))
+	require.NoError(t, fs1.RecordClicks([]model.Click{
+		{Code: "kept", At: now.Add(-2 * time.Hour)},
+		{Code: "kept", At: now},
+	}))
+
+	fs2, err := NewFileStore(filePath, WithClickRetention(time.Hour))
+	require.NoError(t, err)
+
+	stats, err := fs2.GetClickStats("kept", time.Time{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalClicks)
+
+	entries, err := NewFileStorage(filePath).Load()
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "expired click is removed from the file")
+
+	u, err := fs2.Read("kept")
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://kept.com"), u, "links survive compaction")
+}
+
+func TestFileStore_ClickCountPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("kept", "https://kept.com", "user-1"))
+	require.NoError(t, fs1.Write("reused", "https://reused.com", "user-1"))
+	require.NoError(t, fs1.AddClickCounts([]model.ClickCount{{Code: "kept", Clicks: 2, LastAccessedAt: at}}))
+	require.NoError(t, fs1.AddClickCounts([]model.ClickCount{
+		{Code: "kept", Clicks: 3, LastAccessedAt: at.Add(time.Minute)},
+		{Code: "reused", Clicks: 7, LastAccessedAt: at},
+	}))
+
+	// Код окончательно удалённой ссылки занимает новая ссылка
+	require.NoError(t, fs1.DeleteURLsBatch([]model.Code{"reused"}, "user-1"))
+	_, err = fs1.PurgeDeletedURLs(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("reused", "https://new.com", "user-1"))
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	page, err := fs2.GetURLsByUserID("user-1", "http://localhost:8080", model.URLQuery{})
+	require.NoError(t, err)
+	urls := page.URLs
+	require.Len(t, urls, 2)
+	for _, u := range urls {
+		switch u.ShortURL {
+		case "http://localhost:8080/kept":
+			assert.Equal(t, int64(5), u.Clicks)
+			require.NotNil(t, u.LastAccessedAt)
+			assert.True(t, at.Add(time.Minute).Equal(*u.LastAccessedAt))
+		case "http://localhost:8080/reused":
+			assert.Zero(t, u.Clicks, "new link does not inherit the purged link's counter")
+		}
+	}
+}
+
+func TestFileStore_UpdateURLPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("abc", "https://old.com", "user-1"))
+	previous, err := fs1.UpdateURL("abc", "https://new.com", "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://old.com"), previous)
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	value, err := fs2.Read("abc")
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://new.com"), value)
+
+	code, err := fs2.GetCodeByURL("https://new.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.Code("abc"), code)
+	_, err = fs2.GetCodeByURL("https://old.com")
+	assert.ErrorIs(t, err, ErrNotFound, "old URL no longer points to the code after reload")
+}
+
+func TestFileStore_URLVersionsPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("abc", "https://v1.com", "user-1"))
+	for _, url := range []model.URL{"https://v2.com", "https://v3.com", "https://v4.com"} {
+		_, err = fs1.UpdateURL("abc", url, "user-1")
+		require.NoError(t, err)
+	}
+	pruned, err := fs1.PruneURLVersions(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, pruned)
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	versions, err := fs2.GetURLVersions("abc")
+	require.NoError(t, err)
+	require.Len(t, versions, 1, "pruning is replayed from the log")
+	assert.Equal(t, 3, versions[0].Version)
+	assert.Equal(t, "https://v3.com", versions[0].OriginalURL)
+
+	value, err := fs2.Read("abc")
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://v4.com"), value)
+}
+
+func TestFileStore_RestorePersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("abc", "https://example.com", "user-1"))
+	require.NoError(t, fs1.DeleteURLsBatch([]model.Code{"abc"}, "user-1"))
+	restored, err := fs1.RestoreURLsBatch([]model.Code{"abc"}, "user-1", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []model.Code{"abc"}, restored)
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	value, err := fs2.Read("abc")
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://example.com"), value)
+	assert.True(t, fs2.IsURLOwnedByUser("abc", "user-1"))
+}
+
+func TestFileStore_LabelsPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, _, err = fs1.CreateOrGetURL("abc", "https://example.com", "user-1",
+		model.LinkOptions{Labels: model.LinkLabels{Tags: []string{"work"}, Folder: "Projects"}})
+	require.NoError(t, err)
+	require.NoError(t, fs1.SetURLLabels("abc", model.LinkLabels{Tags: []string{"docs", "work"}}, "user-1"))
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	page, err := fs2.GetURLsByUserID("user-1", "http://localhost:8080/", model.URLQuery{Filter: model.URLFilter{Tag: "docs"}})
+	require.NoError(t, err)
+	urls := page.URLs
+	require.Len(t, urls, 1)
+	assert.Equal(t, []string{"docs", "work"}, urls[0].Tags)
+	assert.Empty(t, urls[0].Folder, "folder was cleared by the later record")
+
+	deleted, err := fs2.DeleteURLsByFilter("user-1", model.URLFilter{Tag: "work"})
+	require.NoError(t, err)
+	assert.Equal(t, []model.Code{"abc"}, deleted)
+
+	fs3, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, err = fs3.Read("abc")
+	assert.ErrorIs(t, err, ErrURLDeleted)
+}
+
+func TestFileStore_CreatedAtPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	for _, code := range []model.Code{"c1", "c2", "c3"} {
+		require.NoError(t, fs1.Write(code, model.URL("https://"+code+".com"), "user-1"))
+	}
+	// Изменение ссылки дописывает запись, но не меняет время создания
+	_, err = fs1.UpdateURL("c1", "https://changed.com", "user-1")
+	require.NoError(t, err)
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	page, err := fs2.GetURLsByUserID("user-1", "http://localhost/",
+		model.URLQuery{Sort: model.URLSortCreated, Desc: true})
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 3)
+	assert.Equal(t, "http://localhost/c3", page.URLs[0].ShortURL)
+	assert.Equal(t, "http://localhost/c1", page.URLs[2].ShortURL)
+	require.NotNil(t, page.URLs[0].CreatedAt)
+}
+
+func TestFileStore_UnsafePersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	require.NoError(t, fs1.Write("flagged", "https://example.com", "user-1"))
+	require.NoError(t, fs1.Write("cleared", "https://other.com", "user-1"))
+	require.NoError(t, fs1.SetURLUnsafe("flagged", true))
+	require.NoError(t, fs1.SetURLUnsafe("cleared", true))
+	require.NoError(t, fs1.SetURLUnsafe("cleared", false))
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	info, err := fs2.Inspect("flagged", false)
+	require.NoError(t, err)
+	assert.True(t, info.Unsafe)
+
+	info, err = fs2.Inspect("cleared", false)
+	require.NoError(t, err)
+	assert.False(t, info.Unsafe)
+}
+
+func TestFileStore_RedirectPolicyPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+	maxAge := 90 * time.Minute
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, _, err = fs1.CreateOrGetURL("moved", "https://example.com", "user-1",
+		model.LinkOptions{Redirect: model.RedirectPolicy{Status: http.StatusMovedPermanently, MaxAge: &maxAge}})
+	require.NoError(t, err)
+	_, _, err = fs1.CreateOrGetURL("plain", "https://other.com", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	target, err := fs2.Follow("moved", false)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, target.Redirect.Status)
+	require.NotNil(t, target.Redirect.MaxAge)
+	assert.Equal(t, maxAge, *target.Redirect.MaxAge)
+
+	target, err = fs2.Follow("plain", false)
+	require.NoError(t, err)
+	assert.True(t, target.Redirect.IsZero())
+}
+
+func TestFileStore_QueryPolicyPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+	policy := model.QueryPolicy{
+		Passthrough: model.QueryPassthroughMerge,
+		UTM:         model.UTMParams{Medium: "email", Content: "hero banner"},
+	}
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, _, err = fs1.CreateOrGetURL("tagged", "https://example.com", "user-1", model.LinkOptions{Query: policy})
+	require.NoError(t, err)
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	target, err := fs2.Follow("tagged", false)
+	require.NoError(t, err)
+	assert.Equal(t, policy, target.Query)
+
+	// После перезапуска ссылка по-прежнему не участвует в поиске дубликатов
+	code, created, err := fs2.CreateOrGetURL("plain", "https://example.com", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, model.Code("plain"), code)
+}
+
+func TestFileStore_RedirectRulesPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+	rules := []model.RedirectRule{
+		{Platforms: []string{"android"}, URL: "https://play.google.com/app"},
+		{Languages: []string{"de"}, Countries: []string{"DE", "AT"}, URL: "https://example.de"},
+	}
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, _, err = fs1.CreateOrGetURL("app", "https://example.com", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+	require.NoError(t, fs1.SetURLRules("app", rules, "user-1"))
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	target, err := fs2.Follow("app", false)
+	require.NoError(t, err)
+	assert.Equal(t, rules, target.Rules)
+}
+
+func TestFileStore_SplitVariantsPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+	variants := []model.SplitVariant{
+		{URL: "https://a.example.com", Weight: 3},
+		{URL: "https://b.example.com", Weight: 1},
+	}
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, _, err = fs1.CreateOrGetURL("split", "https://example.com", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+	require.NoError(t, fs1.SetURLVariants("split", variants, "user-1"))
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	target, err := fs2.Follow("split", false)
+	require.NoError(t, err)
+	assert.Equal(t, variants, target.Variants)
+}
+
+func TestFileStore_DisplayURLPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "test_urls.json")
+
+	fs1, err := NewFileStore(filePath)
+	require.NoError(t, err)
+	_, _, err = fs1.CreateOrGetURL("abc", "https://example.com/", "user-1",
+		model.LinkOptions{DisplayURLs: map[model.URL]string{"https://example.com/": "HTTPS://Example.com"}})
+	require.NoError(t, err)
+
+	fs2, err := NewFileStore(filePath)
+	require.NoError(t, err)
+
+	page, err := fs2.GetURLsByUserID("user-1", "http://localhost", model.URLQuery{})
+	require.NoError(t, err)
+	require.Len(t, page.URLs, 1)
+	assert.Equal(t, "HTTPS://Example.com", page.URLs[0].DisplayURL)
+}
+
+func TestFileStore_Deduplication(t *testing.T) {
+	for _, tt := range dedupExclusionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "urls.json")
+			fs, err := NewFileStore(filePath)
+			require.NoError(t, err)
+			assertNotDeduplicated(t, fs, tt.opts)
+
+			// После перезапуска обычная ссылка совпадает с обычной, а не со ссылкой с параметрами
+			reloaded, err := NewFileStore(filePath)
+			require.NoError(t, err)
+			code, created, err := reloaded.CreateOrGetURL("plain3", "https://one.example.com/", "user-1", model.LinkOptions{})
+			require.NoError(t, err)
+			assert.False(t, created)
+			assert.Equal(t, model.Code("plain1"), code)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"strings"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// Option настраивает поведение хранилища.
+type Option func(*storeOptions)
+
+// storeOptions содержит параметры, общие для всех бэкендов хранилища.
+type storeOptions struct {
+	caseInsensitiveCodes bool
+	clickRetention       time.Duration
+}
+
+// WithCaseInsensitiveCodes включает поиск кодов без учёта регистра.
+// Точное совпадение регистра имеет приоритет: коды, созданные до включения режима
+// и различающиеся только регистром, остаются доступными по своему точному написанию.
+// В PostgreSQL такие коды невозможны: индекс idx_urls_code_lower уникален.
+func WithCaseInsensitiveCodes() Option {
+	return func(o *storeOptions) {
+		o.caseInsensitiveCodes = true
+	}
+}
+
+// WithClickRetention ограничивает срок хранения переходов в памяти и в файле:
+// переходы старше retention отбрасываются, а файл сжимается при загрузке.
+// Статистика строится только за последние дни, поэтому более старые переходы не нужны.
+// PostgreSQL хранит переходы бессрочно и этой опцией не ограничивается.
+func WithClickRetention(retention time.Duration) Option {
+	return func(o *storeOptions) {
+		o.clickRetention = retention
+	}
+}
+
+// applyOptions собирает параметры хранилища из списка опций.
+func applyOptions(opts []Option) storeOptions {
+	var o storeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// foldCode приводит код к форме, используемой для сравнения без учёта регистра.
+func foldCode(code model.Code) model.Code {
+	return model.Code(strings.ToLower(string(code)))
+}
@@ -3,11 +3,14 @@
 package store
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/model"
 )
@@ -18,45 +21,543 @@ var (
 	ErrCodeAlreadyExists = errors.New("code already exists")
 	ErrURLAlreadyExists  = errors.New("URL already exists")
 	ErrURLDeleted        = errors.New("URL deleted")
+	ErrURLExpired        = errors.New("URL expired")
+	ErrClickLimitReached = errors.New("click limit reached")
+	ErrPasswordRequired  = errors.New("password required")
 )
 
+// URLConflictError возвращается при изменении адреса ссылки на URL,
+// который уже сокращён другой ссылкой того же пользователя.
+type URLConflictError struct {
+	// Code — код ссылки, уже ведущей на этот URL.
+	Code model.Code
+}
+
+// Error реализует интерфейс error.
+func (e URLConflictError) Error() string {
+	return fmt.Sprintf("URL already shortened as %s", e.Code)
+}
+
+// Unwrap позволяет сопоставлять ошибку с ErrURLAlreadyExists через errors.Is.
+func (e URLConflictError) Unwrap() error {
+	return ErrURLAlreadyExists
+}
+
+// clickPruneInterval — как часто удаляются переходы старше срока хранения
+const clickPruneInterval = time.Hour
+
 // URLMap представляет маппинг коротких кодов на оригинальные URL
 type URLMap = map[model.Code]model.URL
 
 type Store struct {
-	store      URLMap
-	userMap    map[model.Code]string    // code -> userID mapping
-	deletedMap map[model.Code]bool      // code -> is_deleted mapping
-	urlIndex   map[model.URL]model.Code // reverse index: url -> code (O(1) lookup)
-	mutex      sync.Mutex
+	store       URLMap
+	userMap     map[model.Code]string               // code -> userID mapping
+	deletedMap  map[model.Code]bool                 // code -> is_deleted mapping
+	urlIndex    map[model.URL]model.Code            // reverse index: url -> code (O(1) lookup)
+	foldIndex   map[model.Code]model.Code           // lower(code) -> code, только в режиме без учёта регистра
+	deletedAt   map[model.Code]time.Time            // code -> время мягкого удаления
+	createdAt   map[model.Code]time.Time            // code -> время создания, если известно
+	expiresAt   map[model.Code]time.Time            // code -> время истечения, только для ссылок со сроком жизни
+	remaining   map[model.Code]int                  // code -> оставшиеся переходы, только для ссылок с лимитом
+	passwords   map[model.Code]string               // code -> хеш пароля, только для защищённых ссылок
+	clicks      map[model.Code][]model.Click        // code -> учтённые переходы
+	counters    map[model.Code]model.ClickCount     // code -> счётчик переходов и время последнего
+	versions    map[model.Code][]model.URLVersion   // code -> прежние адреса, от старых к новым
+	tags        map[model.Code][]string             // code -> теги ссылки, только для ссылок с тегами
+	folders     map[model.Code]string               // code -> папка ссылки, только для ссылок в папке
+	unsafe      map[model.Code]bool                 // code -> true, только для ссылок, помеченных небезопасными
+	redirects   map[model.Code]model.RedirectPolicy // code -> параметры редиректа, только для ссылок с заданными параметрами
+	queries     map[model.Code]model.QueryPolicy    // code -> изменения query-строки, только для ссылок, меняющих адрес
+	rules       map[model.Code][]model.RedirectRule // code -> правила условного редиректа, только для ссылок с правилами
+	variants    map[model.Code][]model.SplitVariant // code -> варианты сплит-ссылки, только для сплит-ссылок
+	displays    map[model.Code]string               // code -> исходная строка адреса, только для изменённых нормализацией
+	tagIndex    map[string]map[model.Code]struct{}  // tag -> коды ссылок с этим тегом
+	folderIndex map[string]map[model.Code]struct{}  // folder -> коды ссылок в этой папке
+	recycled    codePool                            // освободившиеся коды в карантине
+	mutex       sync.Mutex
+
+	clickRetention time.Duration // срок хранения переходов; 0 — бессрочно
+	clicksPrunedAt time.Time     // время последней очистки устаревших переходов
 }
 
-func NewStore() *Store {
-	return &Store{
-		store:      make(URLMap),
-		userMap:    make(map[model.Code]string),
-		deletedMap: make(map[model.Code]bool),
-		urlIndex:   make(map[model.URL]model.Code),
-		mutex:      sync.Mutex{},
+func NewStore(opts ...Option) *Store {
+	s := &Store{
+		store:       make(URLMap),
+		userMap:     make(map[model.Code]string),
+		deletedMap:  make(map[model.Code]bool),
+		urlIndex:    make(map[model.URL]model.Code),
+		deletedAt:   make(map[model.Code]time.Time),
+		createdAt:   make(map[model.Code]time.Time),
+		expiresAt:   make(map[model.Code]time.Time),
+		remaining:   make(map[model.Code]int),
+		passwords:   make(map[model.Code]string),
+		clicks:      make(map[model.Code][]model.Click),
+		counters:    make(map[model.Code]model.ClickCount),
+		versions:    make(map[model.Code][]model.URLVersion),
+		tags:        make(map[model.Code][]string),
+		folders:     make(map[model.Code]string),
+		unsafe:      make(map[model.Code]bool),
+		redirects:   make(map[model.Code]model.RedirectPolicy),
+		queries:     make(map[model.Code]model.QueryPolicy),
+		rules:       make(map[model.Code][]model.RedirectRule),
+		variants:    make(map[model.Code][]model.SplitVariant),
+		displays:    make(map[model.Code]string),
+		tagIndex:    make(map[string]map[model.Code]struct{}),
+		folderIndex: make(map[string]map[model.Code]struct{}),
+		recycled:    newCodePool(),
+		mutex:       sync.Mutex{},
+	}
+	options := applyOptions(opts)
+	if options.caseInsensitiveCodes {
+		s.foldIndex = make(map[model.Code]model.Code)
 	}
+	s.clickRetention = options.clickRetention
+	return s
+}
+
+// resolveCode возвращает код в том написании, в котором он сохранён.
+// В режиме без учёта регистра сначала ищется точное совпадение, затем — по fold-индексу.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) resolveCode(code model.Code) (model.Code, bool) {
+	if _, ok := s.store[code]; ok {
+		return code, true
+	}
+	if s.foldIndex == nil {
+		return "", false
+	}
+	stored, ok := s.foldIndex[foldCode(code)]
+	return stored, ok
+}
+
+// indexCode добавляет код в fold-индекс. Если код, отличающийся только регистром,
+// уже проиндексирован, сохраняется первый: он и будет найден без учёта регистра.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) indexCode(code model.Code) {
+	if s.foldIndex == nil {
+		return
+	}
+	folded := foldCode(code)
+	if _, exists := s.foldIndex[folded]; !exists {
+		s.foldIndex[folded] = code
+	}
+}
+
+// canonicalCode возвращает код в том написании, в котором он сохранён.
+// В отличие от resolveCode сам захватывает мьютекс.
+func (s *Store) canonicalCode(code model.Code) (model.Code, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.resolveCode(code)
+}
+
+// isClickLimited сообщает, ограничено ли число переходов по ссылке
+func (s *Store) isClickLimited(code model.Code) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, limited := s.remaining[code]
+	return limited
+}
+
+// refundClick возвращает ссылке с лимитом израсходованный переход,
+// если сохранить его не удалось
+func (s *Store) refundClick(code model.Code) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if remaining, limited := s.remaining[code]; limited {
+		s.remaining[code] = remaining + 1
+	}
+}
+
+// isCodeTaken проверяет занятость кода с учётом режима сравнения.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) isCodeTaken(code model.Code) bool {
+	_, taken := s.resolveCode(code)
+	return taken
 }
 
 func (s *Store) Read(key model.Code) (model.URL, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	value, ok := s.store[key]
+	stored, err := s.readable(key)
+	if err != nil {
+		return "", err
+	}
+
+	return s.store[stored], nil
+}
+
+// Follow возвращает адрес и параметры редиректа для перехода по ссылке и атомарно
+// расходует один переход, если число переходов ограничено.
+// Проверка и уменьшение счётчика выполняются под мьютексом,
+// поэтому параллельные переходы не превышают лимит.
+// Переход по защищённой паролем ссылке разрешён только с unlocked,
+// иначе возвращается ErrPasswordRequired и переход не расходуется.
+func (s *Store) Follow(key model.Code, unlocked bool) (model.LinkTarget, error) {
+	return s.linkTarget(key, unlocked, true)
+}
+
+// Peek возвращает то же, что Follow, но не расходует переход: по нему адрес назначения
+// проверяется до перехода. Ссылка, по которой перейти нельзя, даёт ту же ошибку, что и Follow.
+func (s *Store) Peek(key model.Code, unlocked bool) (model.LinkTarget, error) {
+	return s.linkTarget(key, unlocked, false)
+}
+
+// linkTarget возвращает адрес и параметры редиректа ссылки; с consume расходует переход
+func (s *Store) linkTarget(key model.Code, unlocked, consume bool) (model.LinkTarget, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, err := s.readable(key)
+	if err != nil {
+		return model.LinkTarget{}, err
+	}
+
+	if _, protected := s.passwords[stored]; protected && !unlocked {
+		return model.LinkTarget{}, fmt.Errorf("key %s: %w", stored, ErrPasswordRequired)
+	}
+
+	if remaining, limited := s.remaining[stored]; limited {
+		if remaining <= 0 {
+			return model.LinkTarget{}, fmt.Errorf("key %s: %w", stored, ErrClickLimitReached)
+		}
+		if consume {
+			s.remaining[stored] = remaining - 1
+		}
+	}
 
+	return model.LinkTarget{
+		URL:        s.store[stored],
+		Redirect:   s.redirects[stored],
+		Query:      s.queries[stored],
+		Rules:      slices.Clone(s.rules[stored]),
+		Variants:   slices.Clone(s.variants[stored]),
+		Restricted: s.isRestricted(stored),
+		Unsafe:     s.unsafe[stored],
+	}, nil
+}
+
+// Inspect возвращает сведения о ссылке для предпросмотра, не расходуя переход.
+// Ссылка, по которой перейти нельзя, даёт ту же ошибку, что и Follow.
+func (s *Store) Inspect(key model.Code, unlocked bool) (model.LinkInfo, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, err := s.readable(key)
+	if err != nil {
+		return model.LinkInfo{}, err
+	}
+
+	if _, protected := s.passwords[stored]; protected && !unlocked {
+		return model.LinkInfo{}, fmt.Errorf("key %s: %w", stored, ErrPasswordRequired)
+	}
+
+	if remaining, limited := s.remaining[stored]; limited && remaining <= 0 {
+		return model.LinkInfo{}, fmt.Errorf("key %s: %w", stored, ErrClickLimitReached)
+	}
+
+	return model.LinkInfo{
+		Code:      stored,
+		URL:       s.store[stored],
+		CreatedAt: s.createdAt[stored],
+		Clicks:    s.counters[stored].Clicks,
+		Unsafe:    s.unsafe[stored],
+	}, nil
+}
+
+// SetURLUnsafe помечает ссылку небезопасной или снимает пометку.
+// Пометка ставится и удалённой ссылке, чтобы сохраниться после её восстановления.
+func (s *Store) SetURLUnsafe(key model.Code, unsafe bool) error {
+	_, err := s.setURLUnsafe(key, unsafe)
+	return err
+}
+
+// setURLUnsafe меняет пометку и возвращает код в сохранённом написании
+func (s *Store) setURLUnsafe(key model.Code, unsafe bool) (model.Code, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, ok := s.resolveCode(key)
+	if !ok {
+		return "", fmt.Errorf("key %s: %w", key, ErrNotFound)
+	}
+
+	s.setUnsafe(stored, unsafe)
+	return stored, nil
+}
+
+// setUnsafe сохраняет пометку ссылки; вызывающий должен удерживать мьютекс
+func (s *Store) setUnsafe(code model.Code, unsafe bool) {
+	if unsafe {
+		s.unsafe[code] = true
+	} else {
+		delete(s.unsafe, code)
+	}
+}
+
+// PasswordHash возвращает хеш пароля ссылки; пустая строка означает ссылку без пароля
+func (s *Store) PasswordHash(key model.Code) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, err := s.readable(key)
+	if err != nil {
+		return "", err
+	}
+
+	return s.passwords[stored], nil
+}
+
+// readable находит код и проверяет, что по ссылке можно перейти.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) readable(key model.Code) (model.Code, error) {
+	stored, ok := s.resolveCode(key)
 	if !ok {
 		return "", fmt.Errorf("key %s: %w", key, ErrNotFound)
 	}
 
+	// Истёкшая ссылка помечается удалённой фоновой очисткой, поэтому срок проверяется первым
+	if s.isExpired(stored, time.Now()) {
+		return "", fmt.Errorf("key %s: %w", stored, ErrURLExpired)
+	}
+
 	// Проверяем, не удалён ли URL
-	if s.deletedMap[key] {
-		return "", fmt.Errorf("key %s: %w", key, ErrURLDeleted)
+	if s.deletedMap[stored] {
+		return "", fmt.Errorf("key %s: %w", stored, ErrURLDeleted)
+	}
+
+	return stored, nil
+}
+
+// isExpired проверяет, истёк ли срок жизни ссылки к моменту now.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) isExpired(code model.Code, now time.Time) bool {
+	expiresAt, ok := s.expiresAt[code]
+	return ok && !now.Before(expiresAt)
+}
+
+// putLink сохраняет новую ссылку во всех индексах хранилища.
+// В обратный индекс попадают только ссылки, участвующие в дедупликации
+// (см. model.LinkOptions.IsDeduplicated).
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) putLink(code model.Code, url model.URL, userID string, opts model.LinkOptions) {
+	s.store[code] = url
+	s.createdAt[code] = time.Now()
+	s.userMap[code] = userID
+	s.deletedMap[code] = false
+	if opts.IsDeduplicated() {
+		s.urlIndex[url] = code
 	}
+	if !opts.ExpiresAt.IsZero() {
+		s.expiresAt[code] = opts.ExpiresAt
+	}
+	if opts.MaxClicks > 0 {
+		s.remaining[code] = opts.MaxClicks
+	}
+	if opts.PasswordHash != "" {
+		s.passwords[code] = opts.PasswordHash
+	}
+	s.setLabels(code, opts.Labels)
+	s.setRedirect(code, opts.Redirect)
+	s.setQuery(code, opts.Query)
+	s.setRules(code, opts.Rules)
+	s.setVariants(code, opts.Variants)
+	s.setDisplay(code, opts.DisplayURLs[url])
+	s.indexCode(code)
+}
 
-	return value, nil
+// setRules заменяет правила условного редиректа ссылки; вызывающий должен удерживать мьютекс
+func (s *Store) setRules(code model.Code, rules []model.RedirectRule) {
+	if len(rules) == 0 {
+		delete(s.rules, code)
+	} else {
+		s.rules[code] = slices.Clone(rules)
+	}
+}
+
+// setDisplay сохраняет исходную строку адреса ссылки; вызывающий должен удерживать мьютекс
+func (s *Store) setDisplay(code model.Code, display string) {
+	if display == "" {
+		delete(s.displays, code)
+	} else {
+		s.displays[code] = display
+	}
+}
+
+// setVariants заменяет варианты сплит-ссылки; вызывающий должен удерживать мьютекс
+func (s *Store) setVariants(code model.Code, variants []model.SplitVariant) {
+	if len(variants) == 0 {
+		delete(s.variants, code)
+	} else {
+		s.variants[code] = slices.Clone(variants)
+	}
+}
+
+// setQuery сохраняет изменения query-строки ссылки; вызывающий должен удерживать мьютекс
+func (s *Store) setQuery(code model.Code, policy model.QueryPolicy) {
+	if policy.IsZero() {
+		delete(s.queries, code)
+	} else {
+		s.queries[code] = policy
+	}
+}
+
+// setRedirect сохраняет параметры редиректа ссылки; вызывающий должен удерживать мьютекс
+func (s *Store) setRedirect(code model.Code, policy model.RedirectPolicy) {
+	if policy.IsZero() {
+		delete(s.redirects, code)
+	} else {
+		s.redirects[code] = policy
+	}
+}
+
+// setLabels заменяет теги и папку ссылки и обновляет индексы меток.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) setLabels(code model.Code, labels model.LinkLabels) {
+	for _, tag := range s.tags[code] {
+		unindexLabel(s.tagIndex, tag, code)
+	}
+	if folder, ok := s.folders[code]; ok {
+		unindexLabel(s.folderIndex, folder, code)
+	}
+	delete(s.tags, code)
+	delete(s.folders, code)
+
+	if len(labels.Tags) > 0 {
+		s.tags[code] = slices.Clone(labels.Tags)
+		for _, tag := range labels.Tags {
+			indexLabel(s.tagIndex, tag, code)
+		}
+	}
+	if labels.Folder != "" {
+		s.folders[code] = labels.Folder
+		indexLabel(s.folderIndex, labels.Folder, code)
+	}
+}
+
+// indexLabel добавляет код в индекс метки label
+func indexLabel(index map[string]map[model.Code]struct{}, label string, code model.Code) {
+	codes, ok := index[label]
+	if !ok {
+		codes = make(map[model.Code]struct{})
+		index[label] = codes
+	}
+	codes[code] = struct{}{}
+}
+
+// unindexLabel удаляет код из индекса метки label; пустой набор кодов удаляется целиком
+func unindexLabel(index map[string]map[model.Code]struct{}, label string, code model.Code) {
+	delete(index[label], code)
+	if len(index[label]) == 0 {
+		delete(index, label)
+	}
+}
+
+// activeUserCodes возвращает коды неудалённых ссылок пользователя, подходящих под фильтр.
+// При заданном теге или папке перебираются только ссылки из индекса метки, а не все ссылки.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) activeUserCodes(userID string, filter model.URLFilter) []model.Code {
+	var codes []model.Code
+	match := func(code model.Code) {
+		if s.userMap[code] != userID || s.deletedMap[code] {
+			return
+		}
+		if _, tagged := s.tagIndex[filter.Tag][code]; filter.Tag != "" && !tagged {
+			return
+		}
+		if filter.Folder != "" && s.folders[code] != filter.Folder {
+			return
+		}
+		codes = append(codes, code)
+	}
+
+	switch {
+	case filter.Tag != "":
+		for code := range s.tagIndex[filter.Tag] {
+			match(code)
+		}
+	case filter.Folder != "":
+		for code := range s.folderIndex[filter.Folder] {
+			match(code)
+		}
+	default:
+		for code := range s.userMap {
+			match(code)
+		}
+	}
+	return codes
+}
+
+// SetURLLabels заменяет теги и папку ссылки владельца. Чужая ссылка считается ненайденной.
+func (s *Store) SetURLLabels(code model.Code, labels model.LinkLabels, userID string) error {
+	_, err := s.setURLLabels(code, labels, userID)
+	return err
+}
+
+// setURLLabels заменяет метки ссылки и возвращает код в написании хранилища
+func (s *Store) setURLLabels(code model.Code, labels model.LinkLabels, userID string) (model.Code, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, err := s.readable(code)
+	if err != nil {
+		return "", err
+	}
+	if s.userMap[stored] != userID {
+		return "", fmt.Errorf("key %s: %w", code, ErrNotFound)
+	}
+
+	s.setLabels(stored, labels)
+	return stored, nil
+}
+
+// SetURLRules заменяет правила условного редиректа ссылки владельца. Чужая ссылка считается ненайденной.
+func (s *Store) SetURLRules(code model.Code, rules []model.RedirectRule, userID string) error {
+	_, err := s.setURLRules(code, rules, userID)
+	return err
+}
+
+// setURLRules заменяет правила ссылки и возвращает код в написании хранилища
+func (s *Store) setURLRules(code model.Code, rules []model.RedirectRule, userID string) (model.Code, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, err := s.readable(code)
+	if err != nil {
+		return "", err
+	}
+	if s.userMap[stored] != userID {
+		return "", fmt.Errorf("key %s: %w", code, ErrNotFound)
+	}
+
+	s.setRules(stored, rules)
+	return stored, nil
+}
+
+// SetURLVariants заменяет варианты сплит-ссылки владельца. Чужая ссылка считается ненайденной.
+func (s *Store) SetURLVariants(code model.Code, variants []model.SplitVariant, userID string) error {
+	_, err := s.setURLVariants(code, variants, userID)
+	return err
+}
+
+// setURLVariants заменяет варианты сплит-ссылки и возвращает код в написании хранилища
+func (s *Store) setURLVariants(code model.Code, variants []model.SplitVariant, userID string) (model.Code, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, err := s.readable(code)
+	if err != nil {
+		return "", err
+	}
+	if s.userMap[stored] != userID {
+		return "", fmt.Errorf("key %s: %w", code, ErrNotFound)
+	}
+
+	s.setVariants(stored, variants)
+	return stored, nil
 }
 
 func (s *Store) Write(key model.Code, value model.URL, userID string) error {
@@ -64,14 +565,11 @@ func (s *Store) Write(key model.Code, value model.URL, userID string) error {
 	defer s.mutex.Unlock()
 
 	// Проверяем существование ключа напрямую, без вызова Read (чтобы избежать deadlock)
-	if _, exists := s.store[key]; exists {
+	if s.isCodeTaken(key) {
 		return fmt.Errorf("code %s: %w", key, ErrCodeAlreadyExists)
 	}
 
-	s.store[key] = value
-	s.userMap[key] = userID
-	s.deletedMap[key] = false
-	s.urlIndex[value] = key
+	s.putLink(key, value, userID, model.LinkOptions{})
 
 	return nil
 }
@@ -87,30 +585,38 @@ func (s *Store) InitializeWith(data URLMap, userData map[model.Code]string, dele
 	if deletedData != nil {
 		maps.Copy(s.deletedMap, deletedData)
 	}
+	// Время удаления неизвестно — отсчитываем срок хранения от момента загрузки
+	now := time.Now()
+	for code, deleted := range deletedData {
+		if _, known := s.deletedAt[code]; deleted && !known {
+			s.deletedAt[code] = now
+		}
+	}
 	// Перестраиваем обратный индекс из загруженных данных
 	for code, url := range data {
+		if _, known := s.createdAt[code]; !known {
+			s.createdAt[code] = now
+		}
 		s.urlIndex[url] = code
+		s.indexCode(code)
 	}
 }
 
-// WriteBatch сохраняет несколько пар код-URL в хранилище атомарно
-func (s *Store) WriteBatch(urls URLMap, userID string) error {
+// WriteBatch сохраняет несколько пар код-URL в хранилище атомарно; opts применяются ко всем ссылкам
+func (s *Store) WriteBatch(urls URLMap, userID string, opts model.LinkOptions) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	// Проверяем существование всех кодов перед вставкой
 	for code := range urls {
-		if _, exists := s.store[code]; exists {
+		if s.isCodeTaken(code) {
 			return fmt.Errorf("code %s: %w", code, ErrCodeAlreadyExists)
 		}
 	}
 
 	// Вставляем все записи
 	for code, url := range urls {
-		s.store[code] = url
-		s.userMap[code] = userID
-		s.deletedMap[code] = false
-		s.urlIndex[url] = code
+		s.putLink(code, url, userID, opts)
 	}
 
 	return nil
@@ -118,37 +624,164 @@ func (s *Store) WriteBatch(urls URLMap, userID string) error {
 
 // CreateOrGetURL создает новую запись или возвращает код существующей для данного URL.
 // Использует обратный индекс urlIndex для O(1) поиска дубликата вместо O(n) перебора.
-func (s *Store) CreateOrGetURL(code model.Code, url model.URL, userID string) (model.Code, bool, error) {
+// Ограниченная ссылка всегда создаётся заново.
+func (s *Store) CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	// O(1) проверка через обратный индекс
-	if existingCode, found := s.urlIndex[url]; found {
+	// Ссылка, получившая после создания параметры, исключающие дедупликацию, не возвращается
+	if existingCode, found := s.urlIndex[url]; found && opts.IsDeduplicated() && s.isDeduplicated(existingCode) {
 		// Обновляем userID для существующего кода
 		s.userMap[existingCode] = userID
 		return existingCode, false, nil // false = не создана новая запись
 	}
 
 	// Проверяем, свободен ли код
-	if _, exists := s.store[code]; exists {
+	if s.isCodeTaken(code) {
 		return "", false, fmt.Errorf("code %s: %w", code, ErrCodeAlreadyExists)
 	}
 
 	// Создаем новую запись
-	s.store[code] = url
-	s.userMap[code] = userID
-	s.deletedMap[code] = false
-	s.urlIndex[url] = code
+	s.putLink(code, url, userID, opts)
 	return code, true, nil // true = создана новая запись
 }
 
+// UpdateURL меняет адрес ссылки владельца, сохраняет прежний адрес в историю версий
+// и возвращает его. Чужая ссылка считается ненайденной. Если новый URL уже сокращён
+// другой ссылкой того же пользователя, возвращается URLConflictError с её кодом.
+func (s *Store) UpdateURL(code model.Code, url model.URL, userID string) (model.URL, error) {
+	_, previous, _, err := s.updateURL(code, url, userID)
+	return previous, err
+}
+
+// updateURL меняет адрес ссылки и возвращает код в написании хранилища, прежний адрес
+// и созданную версию истории. Если адрес не изменился, версия не создаётся и её номер равен 0.
+func (s *Store) updateURL(code model.Code, url model.URL, userID string) (model.Code, model.URL, model.URLVersion, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, err := s.readable(code)
+	if err != nil {
+		return "", "", model.URLVersion{}, err
+	}
+	if s.userMap[stored] != userID {
+		return "", "", model.URLVersion{}, fmt.Errorf("key %s: %w", code, ErrNotFound)
+	}
+
+	previous := s.store[stored]
+	if previous == url {
+		return stored, previous, model.URLVersion{}, nil
+	}
+
+	if s.isDeduplicated(stored) {
+		existing, indexed := s.urlIndex[url]
+		if indexed && s.userMap[existing] == userID {
+			return "", "", model.URLVersion{}, URLConflictError{Code: existing}
+		}
+		if s.urlIndex[previous] == stored {
+			delete(s.urlIndex, previous)
+		}
+		// Индекс, указывающий на ссылку другого пользователя, не перезаписывается
+		if !indexed {
+			s.urlIndex[url] = stored
+		}
+	}
+	s.store[stored] = url
+	// Исходная строка относилась к прежнему адресу
+	delete(s.displays, stored)
+
+	version := model.URLVersion{
+		Version:     s.nextVersion(stored),
+		OriginalURL: string(previous),
+		ChangedBy:   userID,
+		ChangedAt:   time.Now().UTC(),
+	}
+	s.versions[stored] = append(s.versions[stored], version)
+
+	return stored, previous, version, nil
+}
+
+// nextVersion возвращает номер следующей версии ссылки.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) nextVersion(code model.Code) int {
+	history := s.versions[code]
+	if len(history) == 0 {
+		return 1
+	}
+	return history[len(history)-1].Version + 1
+}
+
+// GetURLVersions возвращает прежние адреса ссылки от новых к старым
+func (s *Store) GetURLVersions(code model.Code) ([]model.URLVersion, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, ok := s.resolveCode(code)
+	if !ok {
+		return nil, fmt.Errorf("key %s: %w", code, ErrNotFound)
+	}
+
+	history := s.versions[stored]
+	result := make([]model.URLVersion, len(history))
+	for i, version := range history {
+		result[len(history)-1-i] = version
+	}
+	return result, nil
+}
+
+// PruneURLVersions удаляет из истории версии, изменённые раньше before,
+// и возвращает их количество. Последняя версия каждой ссылки сохраняется,
+// чтобы номера версий не начинались заново.
+func (s *Store) PruneURLVersions(before time.Time) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.pruneVersions(before), nil
+}
+
+// pruneVersions удаляет устаревшие версии; вызывающий должен удерживать мьютекс
+func (s *Store) pruneVersions(before time.Time) int {
+	pruned := 0
+	for code, history := range s.versions {
+		last := len(history) - 1
+		kept := history[:0]
+		for i, version := range history {
+			if i == last || !version.ChangedAt.Before(before) {
+				kept = append(kept, version)
+			}
+		}
+		pruned += len(history) - len(kept)
+		s.versions[code] = kept
+	}
+	return pruned
+}
+
+// isRestricted сообщает, задано ли для ссылки ограничение (срок жизни, лимит переходов, пароль).
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) isRestricted(code model.Code) bool {
+	_, expiring := s.expiresAt[code]
+	_, limited := s.remaining[code]
+	_, protected := s.passwords[code]
+	return expiring || limited || protected
+}
+
+// isDeduplicated сообщает, участвует ли ссылка в дедупликации (см. model.LinkOptions.IsDeduplicated).
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) isDeduplicated(code model.Code) bool {
+	_, customRedirect := s.redirects[code]
+	_, rewrites := s.queries[code]
+	_, conditional := s.rules[code]
+	_, split := s.variants[code]
+	return !s.isRestricted(code) && !customRedirect && !rewrites && !conditional && !split
+}
+
 // IsCodeUnique проверяет, свободен ли код в хранилище
 func (s *Store) IsCodeUnique(code model.Code) bool {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	_, exists := s.store[code]
-	return !exists
+	return !s.isCodeTaken(code)
 }
 
 // GetCodeByURL возвращает код для существующего URL.
@@ -164,55 +797,150 @@ func (s *Store) GetCodeByURL(url model.URL) (model.Code, error) {
 	return "", fmt.Errorf("URL not found: %w", ErrNotFound)
 }
 
-// GetURLsByUserID возвращает URL пользователя (исключая удалённые).
+// GetURLsByUserID возвращает страницу URL пользователя (исключая удалённые), подходящих под запрос.
+// Ссылки сортируются по полю запроса, при равенстве — по коду; страница начинается
+// сразу за курсором query.After. Если за страницей есть ещё ссылки, возвращается курсор её последней ссылки.
 // Вместо url.JoinPath (≥3 аллокации/вызов) использует простую конкатенацию строк (1 аллокация).
-// Результирующий срез предварительно выделяется под максимально возможный размер.
-func (s *Store) GetURLsByUserID(userID string, baseURL string) ([]model.UserURLResponse, error) {
+func (s *Store) GetURLsByUserID(userID string, baseURL string, query model.URLQuery) (model.URLPage, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	// Нормализуем baseURL один раз перед циклом
 	base := strings.TrimRight(baseURL, "/") + "/"
+	search := strings.ToLower(query.Search)
 
-	// Считаем количество активных URL пользователя для предварительного выделения среза
-	count := 0
-	for code, storedUserID := range s.userMap {
-		if storedUserID == userID && !s.deletedMap[code] {
-			count++
+	cursors := make([]model.URLCursor, 0)
+	for _, code := range s.activeUserCodes(userID, query.Filter) {
+		originalURL, exists := s.store[code]
+		if !exists {
+			continue // Несогласованность данных, пропускаем
+		}
+		if search != "" && !strings.Contains(strings.ToLower(string(originalURL)), search) {
+			continue
 		}
+
+		cursor := s.cursorFor(code)
+		if query.After != nil && compareURLs(query.Sort, query.Desc, cursor, *query.After) <= 0 {
+			continue
+		}
+		cursors = append(cursors, cursor)
 	}
 
-	urls := make([]model.UserURLResponse, 0, count)
+	slices.SortFunc(cursors, func(a, b model.URLCursor) int {
+		return compareURLs(query.Sort, query.Desc, a, b)
+	})
 
-	for code, storedUserID := range s.userMap {
-		if storedUserID == userID {
-			// Проверяем, не удалён ли URL
-			if s.deletedMap[code] {
-				continue // Пропускаем удалённые URL
-			}
+	var page model.URLPage
+	if query.Limit > 0 && len(cursors) > query.Limit {
+		cursors = cursors[:query.Limit]
+		next := cursors[len(cursors)-1]
+		page.Next = &next
+	}
 
-			// Получаем оригинальный URL
-			originalURL, exists := s.store[code]
-			if !exists {
-				continue // Несогласованность данных, пропускаем
-			}
+	page.URLs = make([]model.UserURLResponse, 0, len(cursors))
+	for _, cursor := range cursors {
+		page.URLs = append(page.URLs, s.userURL(base, cursor.Code, s.store[cursor.Code]))
+	}
 
-			// Формируем полный короткий URL: одна аллокация вместо ≥3 у url.JoinPath
-			urls = append(urls, model.UserURLResponse{
-				ShortURL:    base + string(code),
-				OriginalURL: string(originalURL),
-			})
+	return page, nil
+}
+
+// cursorFor возвращает позицию ссылки для сортировки списка.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) cursorFor(code model.Code) model.URLCursor {
+	return model.URLCursor{
+		CreatedAt: s.createdAt[code],
+		Clicks:    s.counters[code].Clicks,
+		URL:       string(s.store[code]),
+		Code:      code,
+	}
+}
+
+// compareURLs сравнивает позиции ссылок в списке, отсортированном по полю sort:
+// при равенстве поля порядок задаёт код. desc меняет порядок на обратный.
+func compareURLs(sort model.URLSort, desc bool, a, b model.URLCursor) int {
+	var result int
+	switch sort {
+	case model.URLSortClicks:
+		result = cmp.Compare(a.Clicks, b.Clicks)
+	case model.URLSortURL:
+		result = strings.Compare(a.URL, b.URL)
+	default:
+		result = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if result == 0 {
+		result = strings.Compare(string(a.Code), string(b.Code))
+	}
+	if desc {
+		return -result
+	}
+	return result
+}
+
+// GetDeletedURLsByUserID возвращает удалённые ссылки пользователя, которые ещё можно
+// восстановить: удалённые не раньше deletedAfter и не истёкшие.
+func (s *Store) GetDeletedURLsByUserID(userID string, baseURL string, deletedAfter time.Time) ([]model.UserURLResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	base := strings.TrimRight(baseURL, "/") + "/"
+	now := time.Now()
+
+	var urls []model.UserURLResponse
+	for code, storedUserID := range s.userMap {
+		if storedUserID != userID || !s.isRestorable(code, deletedAfter, now) {
+			continue
 		}
+		entry := s.userURL(base, code, s.store[code])
+		deletedAt := s.deletedAt[code]
+		entry.DeletedAt = &deletedAt
+		urls = append(urls, entry)
 	}
 
 	return urls, nil
 }
 
+// userURL формирует элемент списка ссылок пользователя со счётчиком переходов.
+// Полный короткий URL собирается конкатенацией: одна аллокация вместо ≥3 у url.JoinPath.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) userURL(base string, code model.Code, originalURL model.URL) model.UserURLResponse {
+	entry := model.UserURLResponse{
+		ShortURL:    base + string(code),
+		OriginalURL: string(originalURL),
+		DisplayURL:  s.displays[code],
+		Tags:        slices.Clone(s.tags[code]),
+		Folder:      s.folders[code],
+	}
+	if createdAt, ok := s.createdAt[code]; ok {
+		entry.CreatedAt = &createdAt
+	}
+	if counter, ok := s.counters[code]; ok {
+		entry.Clicks = counter.Clicks
+		lastAccessedAt := counter.LastAccessedAt
+		entry.LastAccessedAt = &lastAccessedAt
+	}
+	return entry
+}
+
+// isRestorable сообщает, можно ли восстановить ссылку: она удалена не раньше deletedAfter
+// и её срок жизни не истёк. Вызывающий должен удерживать мьютекс.
+func (s *Store) isRestorable(code model.Code, deletedAfter, now time.Time) bool {
+	if !s.deletedMap[code] || s.isExpired(code, now) {
+		return false
+	}
+	deletedAt, ok := s.deletedAt[code]
+	return ok && !deletedAt.Before(deletedAfter)
+}
+
 // IsURLOwnedByUser проверяет, принадлежит ли URL указанному пользователю
 func (s *Store) IsURLOwnedByUser(code model.Code, userID string) bool {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	code, found := s.resolveCode(code)
+	if !found {
+		return false
+	}
 	storedUserID, exists := s.userMap[code]
 	return exists && storedUserID == userID && !s.deletedMap[code]
 }
@@ -246,15 +974,447 @@ func (s *Store) DeleteURLsBatch(codes []model.Code, userID string) error {
 	defer s.mutex.Unlock()
 
 	for _, code := range codes {
+		code, found := s.resolveCode(code)
+		if !found {
+			continue
+		}
+
 		// Проверяем, что URL принадлежит пользователю
 		storedUserID, exists := s.userMap[code]
 		if !exists || storedUserID != userID {
 			continue // Пропускаем, если URL не существует или не принадлежит пользователю
 		}
 
-		// Помечаем как удалённый
+		// Помечаем как удалённый, сохраняя время первого удаления
+		if !s.deletedMap[code] {
+			s.deletedAt[code] = time.Now()
+		}
+		s.deletedMap[code] = true
+	}
+
+	return nil
+}
+
+// DeleteURLsByFilter помечает удалёнными ссылки пользователя, подходящие под фильтр,
+// и возвращает их коды. Пустой фильтр не удаляет ничего.
+func (s *Store) DeleteURLsByFilter(userID string, filter model.URLFilter) ([]model.Code, error) {
+	if filter.IsZero() {
+		return nil, nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	codes := s.activeUserCodes(userID, filter)
+	now := time.Now()
+	for _, code := range codes {
+		s.deletedMap[code] = true
+		s.deletedAt[code] = now
+	}
+
+	return codes, nil
+}
+
+// RestoreURLsBatch снимает пометку удаления со ссылок пользователя, удалённых
+// не раньше deletedAfter, и возвращает восстановленные коды. Истёкшие ссылки
+// не восстанавливаются: их удалила очистка по сроку жизни, а не пользователь.
+func (s *Store) RestoreURLsBatch(codes []model.Code, userID string, deletedAfter time.Time) ([]model.Code, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	var restored []model.Code
+	for _, code := range codes {
+		code, found := s.resolveCode(code)
+		if !found || s.userMap[code] != userID || !s.isRestorable(code, deletedAfter, now) {
+			continue
+		}
+		s.deletedMap[code] = false
+		delete(s.deletedAt, code)
+		restored = append(restored, code)
+	}
+
+	return restored, nil
+}
+
+// PurgeDeletedURLs окончательно удаляет ссылки, мягко удалённые раньше deletedBefore,
+// и возвращает освободившиеся коды.
+func (s *Store) PurgeDeletedURLs(deletedBefore time.Time) ([]model.Code, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var purged []model.Code
+	for code, deletedAt := range s.deletedAt {
+		if s.deletedMap[code] && deletedAt.Before(deletedBefore) {
+			purged = append(purged, code)
+		}
+	}
+	for _, code := range purged {
+		s.removeCode(code)
+	}
+
+	return purged, nil
+}
+
+// ReleaseCodes помещает освободившиеся коды в пул переиспользования.
+// Коды станут доступны генератору не раньше availableAt.
+func (s *Store) ReleaseCodes(codes []model.Code, availableAt time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, code := range codes {
+		s.recycled.add(code, availableAt)
+	}
+
+	return nil
+}
+
+// AcquireRecycledCode извлекает из пула код, карантин которого истёк к моменту now.
+// Второй возвращаемый параметр false означает, что доступных кодов нет.
+func (s *Store) AcquireRecycledCode(now time.Time) (model.Code, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	code, ok := s.recycled.take(now, s.isCodeTaken)
+	return code, ok, nil
+}
+
+// MarkExpiredURLs помечает удалёнными ссылки, срок жизни которых истёк к моменту now,
+// и возвращает их коды. Временем удаления считается момент истечения:
+// от него отсчитывается срок хранения до окончательного удаления.
+func (s *Store) MarkExpiredURLs(now time.Time) ([]model.Code, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var expired []model.Code
+	for code, expiresAt := range s.expiresAt {
+		if s.deletedMap[code] || now.Before(expiresAt) {
+			continue
+		}
 		s.deletedMap[code] = true
+		s.deletedAt[code] = expiresAt
+		expired = append(expired, code)
+	}
+
+	return expired, nil
+}
+
+// RecordClicks сохраняет переходы. Код перехода приводится к коду ссылки в хранилище,
+// переходы по несуществующим кодам пропускаются.
+func (s *Store) RecordClicks(clicks []model.Click) error {
+	s.recordClicks(clicks)
+	return nil
+}
+
+// recordClicks сохраняет переходы и возвращает принятые с кодами ссылок в хранилище
+func (s *Store) recordClicks(clicks []model.Click) []model.Click {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	recorded := make([]model.Click, 0, len(clicks))
+	for _, click := range clicks {
+		stored, ok := s.resolveCode(click.Code)
+		if !ok {
+			continue
+		}
+		click.Code = stored
+		s.clicks[stored] = append(s.clicks[stored], click)
+		recorded = append(recorded, click)
+	}
+	s.pruneClicks(time.Now())
+	return recorded
+}
+
+// pruneClicks удаляет переходы старше срока хранения не чаще раза в clickPruneInterval;
+// вызывающий должен удерживать мьютекс
+func (s *Store) pruneClicks(now time.Time) {
+	if s.clickRetention <= 0 || now.Sub(s.clicksPrunedAt) < clickPruneInterval {
+		return
+	}
+	s.clicksPrunedAt = now
+
+	cutoff := now.Add(-s.clickRetention)
+	for code, clicks := range s.clicks {
+		clicks = slices.DeleteFunc(clicks, func(click model.Click) bool { return click.At.Before(cutoff) })
+		if len(clicks) == 0 {
+			delete(s.clicks, code)
+			continue
+		}
+		s.clicks[code] = clicks
+	}
+}
+
+// withoutExpiredClicks возвращает записи журнала без переходов старше срока хранения
+func (s *Store) withoutExpiredClicks(entries []model.URLEntry, now time.Time) []model.URLEntry {
+	if s.clickRetention <= 0 {
+		return entries
+	}
+
+	cutoff := now.Add(-s.clickRetention)
+	return slices.DeleteFunc(slices.Clone(entries), func(entry model.URLEntry) bool {
+		return entry.Click != nil && entry.Click.At.Before(cutoff)
+	})
+}
+
+// GetClickStats возвращает статистику переходов по ссылке за период from—to
+func (s *Store) GetClickStats(code model.Code, from, to time.Time) (model.URLStats, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, ok := s.resolveCode(code)
+	if !ok {
+		return model.URLStats{}, fmt.Errorf("key %s: %w", code, ErrNotFound)
 	}
 
+	return aggregateClicks(code, s.clicks[stored], from, to), nil
+}
+
+// AddClickCounts добавляет приращения к счётчикам переходов. Код приводится
+// к коду ссылки в хранилище, приращения для несуществующих кодов пропускаются.
+func (s *Store) AddClickCounts(counts []model.ClickCount) error {
+	s.addClickCounts(counts)
 	return nil
 }
+
+// addClickCounts добавляет приращения и возвращает принятые с кодами ссылок в хранилище
+func (s *Store) addClickCounts(counts []model.ClickCount) []model.ClickCount {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	applied := make([]model.ClickCount, 0, len(counts))
+	for _, count := range counts {
+		stored, ok := s.resolveCode(count.Code)
+		if !ok {
+			continue
+		}
+		count.Code = stored
+		s.addClickCount(stored, count)
+		applied = append(applied, count)
+	}
+	return applied
+}
+
+// addClickCount прибавляет приращение к счётчику кода; вызывающий должен удерживать мьютекс
+func (s *Store) addClickCount(code model.Code, count model.ClickCount) {
+	counter := s.counters[code]
+	counter.Code = code
+	counter.Clicks += count.Clicks
+	if count.LastAccessedAt.After(counter.LastAccessedAt) {
+		counter.LastAccessedAt = count.LastAccessedAt
+	}
+	s.counters[code] = counter
+}
+
+// loadEntries применяет записи журнала по порядку: более поздняя запись с тем же кодом
+// заменяет предыдущую, а запись с флагом Purged удаляет код и, если задан AvailableAt,
+// возвращает его в пул переиспользования. Записи с Click, ClickCount и Version добавляют
+// переход, приращение счётчика и версию к ссылке, существующей на этот момент журнала,
+// поэтому данные удалённой ссылки не достаются ссылке, получившей её код повторно.
+func (s *Store) loadEntries(entries []model.URLEntry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for _, entry := range entries {
+		code := model.Code(entry.ShortURL)
+		if entry.Click != nil {
+			if _, exists := s.store[code]; exists {
+				s.clicks[code] = append(s.clicks[code], *entry.Click)
+			}
+			continue
+		}
+		if entry.ClickCount != nil {
+			if _, exists := s.store[code]; exists {
+				s.addClickCount(code, *entry.ClickCount)
+			}
+			continue
+		}
+		if entry.Version != nil {
+			if _, exists := s.store[code]; exists {
+				s.versions[code] = append(s.versions[code], *entry.Version)
+			}
+			continue
+		}
+		if entry.PruneVersionsBefore != nil {
+			s.pruneVersions(*entry.PruneVersionsBefore)
+			continue
+		}
+		if entry.Purged {
+			if _, exists := s.store[code]; exists {
+				s.removeCode(code)
+			}
+			if entry.AvailableAt != nil {
+				s.recycled.add(code, *entry.AvailableAt)
+			}
+			continue
+		}
+
+		s.recycled.remove(code)
+		url := model.URL(entry.OriginalURL)
+		// После изменения адреса ссылки прежний URL больше не ведёт на этот код
+		if previous, exists := s.store[code]; exists && previous != url && s.urlIndex[previous] == code {
+			delete(s.urlIndex, previous)
+		}
+		s.store[code] = url
+		if entry.CreatedAt != nil {
+			s.createdAt[code] = *entry.CreatedAt
+		}
+		if entry.UserID != "" {
+			s.userMap[code] = entry.UserID
+		}
+		s.deletedMap[code] = entry.DeletedFlag
+
+		switch {
+		case !entry.DeletedFlag:
+			delete(s.deletedAt, code)
+		case entry.DeletedAt != nil:
+			s.deletedAt[code] = *entry.DeletedAt
+		default:
+			// Время удаления неизвестно — отсчитываем срок хранения от момента загрузки
+			s.deletedAt[code] = now
+		}
+
+		if entry.ExpiresAt != nil {
+			s.expiresAt[code] = *entry.ExpiresAt
+		} else {
+			delete(s.expiresAt, code)
+		}
+		if entry.RemainingClicks != nil {
+			s.remaining[code] = *entry.RemainingClicks
+		} else {
+			delete(s.remaining, code)
+		}
+		if entry.PasswordHash != "" {
+			s.passwords[code] = entry.PasswordHash
+		} else {
+			delete(s.passwords, code)
+		}
+		s.setLabels(code, model.LinkLabels{Tags: entry.Tags, Folder: entry.Folder})
+		s.setUnsafe(code, entry.Unsafe)
+		s.setRedirect(code, redirectFromEntry(entry))
+		s.setQuery(code, queryFromEntry(entry))
+		s.setRules(code, entry.RedirectRules)
+		s.setVariants(code, entry.SplitVariants)
+		s.setDisplay(code, entry.DisplayURL)
+		if s.isDeduplicated(code) {
+			s.urlIndex[url] = code
+		}
+		s.indexCode(code)
+	}
+}
+
+// entryFor возвращает текущее состояние ссылки в виде записи журнала без UUID.
+// Используется файловым хранилищем: каждое изменение ссылки дописывается полной записью.
+func (s *Store) entryFor(code model.Code) (model.URLEntry, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	url, exists := s.store[code]
+	if !exists {
+		return model.URLEntry{}, false
+	}
+
+	entry := model.URLEntry{
+		ShortURL:    string(code),
+		OriginalURL: string(url),
+		UserID:      s.userMap[code],
+		DeletedFlag: s.deletedMap[code],
+	}
+	if deletedAt, ok := s.deletedAt[code]; ok && entry.DeletedFlag {
+		entry.DeletedAt = &deletedAt
+	}
+	if createdAt, ok := s.createdAt[code]; ok {
+		entry.CreatedAt = &createdAt
+	}
+	if expiresAt, ok := s.expiresAt[code]; ok {
+		entry.ExpiresAt = &expiresAt
+	}
+	if remaining, ok := s.remaining[code]; ok {
+		entry.RemainingClicks = &remaining
+	}
+	entry.PasswordHash = s.passwords[code]
+	entry.Tags = slices.Clone(s.tags[code])
+	entry.Folder = s.folders[code]
+	entry.Unsafe = s.unsafe[code]
+	entry.RedirectRules = slices.Clone(s.rules[code])
+	entry.SplitVariants = slices.Clone(s.variants[code])
+	entry.DisplayURL = s.displays[code]
+	if policy, ok := s.queries[code]; ok {
+		entry.QueryPassthrough = string(policy.Passthrough)
+		if !policy.UTM.IsZero() {
+			utm := policy.UTM
+			entry.UTM = &utm
+		}
+	}
+	if policy, ok := s.redirects[code]; ok {
+		entry.RedirectStatus = policy.Status
+		if policy.MaxAge != nil {
+			seconds := int64(policy.MaxAge.Seconds())
+			entry.CacheMaxAge = &seconds
+		}
+	}
+
+	return entry, true
+}
+
+// queryFromEntry восстанавливает изменения query-строки из записи журнала
+func queryFromEntry(entry model.URLEntry) model.QueryPolicy {
+	policy := model.QueryPolicy{Passthrough: model.QueryPassthrough(entry.QueryPassthrough)}
+	if entry.UTM != nil {
+		policy.UTM = *entry.UTM
+	}
+	return policy
+}
+
+// redirectFromEntry восстанавливает параметры редиректа из записи журнала
+func redirectFromEntry(entry model.URLEntry) model.RedirectPolicy {
+	policy := model.RedirectPolicy{Status: entry.RedirectStatus}
+	if entry.CacheMaxAge != nil {
+		maxAge := time.Duration(*entry.CacheMaxAge) * time.Second
+		policy.MaxAge = &maxAge
+	}
+	return policy
+}
+
+// removeCode удаляет код из всех индексов хранилища.
+// Вызывающий должен удерживать мьютекс.
+func (s *Store) removeCode(code model.Code) {
+	url := s.store[code]
+	delete(s.store, code)
+	delete(s.userMap, code)
+	delete(s.deletedMap, code)
+	delete(s.deletedAt, code)
+	delete(s.createdAt, code)
+	delete(s.expiresAt, code)
+	delete(s.remaining, code)
+	delete(s.passwords, code)
+	delete(s.clicks, code)
+	delete(s.counters, code)
+	delete(s.versions, code)
+	delete(s.unsafe, code)
+	delete(s.redirects, code)
+	delete(s.queries, code)
+	delete(s.rules, code)
+	delete(s.variants, code)
+	delete(s.displays, code)
+	s.setLabels(code, model.LinkLabels{})
+	if s.urlIndex[url] == code {
+		delete(s.urlIndex, url)
+	}
+
+	if s.foldIndex == nil {
+		return
+	}
+	folded := foldCode(code)
+	if s.foldIndex[folded] != code {
+		return
+	}
+	delete(s.foldIndex, folded)
+	// Если остался код, отличающийся только регистром, он становится доступным без учёта регистра
+	for other := range s.store {
+		if foldCode(other) == folded {
+			s.foldIndex[folded] = other
+			break
+		}
+	}
+}
@@ -40,7 +40,7 @@ func BenchmarkStoreCreateOrGetURL_New(b *testing.B) {
 		n++
 		code := model.Code(fmt.Sprintf("code%08d", n))
 		url := model.URL(fmt.Sprintf("https://example.com/%d", n))
-		_, _, _ = s.CreateOrGetURL(code, url, "user1")
+		_, _, _ = s.CreateOrGetURL(code, url, "user1", model.LinkOptions{})
 	}
 }
 
@@ -60,7 +60,7 @@ func BenchmarkStoreCreateOrGetURL_Existing(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
 	for b.Loop() {
-		_, _, _ = s.CreateOrGetURL("newcode", targetURL, "user1")
+		_, _, _ = s.CreateOrGetURL("newcode", targetURL, "user1", model.LinkOptions{})
 	}
 }
 
@@ -79,7 +79,7 @@ func BenchmarkStoreGetURLsByUserID(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
 	for b.Loop() {
-		_, _ = s.GetURLsByUserID("benchuser", "http://localhost:8080")
+		_, _ = s.GetURLsByUserID("benchuser", "http://localhost:8080", model.URLQuery{})
 	}
 }
 
@@ -1,8 +1,11 @@
 package store
 
 import (
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -546,3 +549,1042 @@ func TestStore_StoreIsolation(t *testing.T) {
 	assert.Equal(t, url2, value2, "Store2 should have its own value")
 	assert.NotEqual(t, value1, value2, "Stores should be isolated")
 }
+
+// TestStore_CaseInsensitiveCodes проверяет поиск кодов без учёта регистра
+func TestStore_CaseInsensitiveCodes(t *testing.T) {
+	t.Run("lookup ignores case", func(t *testing.T) {
+		s := NewStore(WithCaseInsensitiveCodes())
+		require.NoError(t, s.Write("abcdefgh", "https://example.com", "user-1"))
+
+		value, err := s.Read("AbCdEfGh")
+
+		require.NoError(t, err)
+		assert.Equal(t, model.URL("https://example.com"), value)
+		assert.True(t, s.IsURLOwnedByUser("ABCDEFGH", "user-1"))
+	})
+
+	t.Run("codes differing only in case are not unique", func(t *testing.T) {
+		s := NewStore(WithCaseInsensitiveCodes())
+		require.NoError(t, s.Write("abcdefgh", "https://example.com", "user-1"))
+
+		assert.False(t, s.IsCodeUnique("ABCDEFGH"))
+		err := s.Write("ABCDEFGH", "https://other.com", "user-1")
+		assert.ErrorIs(t, err, ErrCodeAlreadyExists)
+	})
+
+	t.Run("legacy mixed-case codes keep exact match priority", func(t *testing.T) {
+		s := NewStore(WithCaseInsensitiveCodes())
+		s.InitializeWith(URLMap{"AbC": "https://first.com", "aBc": "https://second.com"}, nil, nil)
+
+		exact, err := s.Read("aBc")
+		require.NoError(t, err)
+		assert.Equal(t, model.URL("https://second.com"), exact)
+
+		folded, err := s.Read("abc")
+		require.NoError(t, err)
+		assert.Contains(t, []model.URL{"https://first.com", "https://second.com"}, folded)
+	})
+
+	t.Run("delete resolves code case-insensitively", func(t *testing.T) {
+		s := NewStore(WithCaseInsensitiveCodes())
+		require.NoError(t, s.Write("abcdefgh", "https://example.com", "user-1"))
+
+		require.NoError(t, s.DeleteURLsBatch([]model.Code{"ABCDEFGH"}, "user-1"))
+
+		_, err := s.Read("abcdefgh")
+		assert.ErrorIs(t, err, ErrURLDeleted)
+	})
+
+	t.Run("default mode is case-sensitive", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.Write("abcdefgh", "https://example.com", "user-1"))
+
+		_, err := s.Read("ABCDEFGH")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.True(t, s.IsCodeUnique("ABCDEFGH"))
+	})
+}
+
+// TestStore_PurgeDeletedURLs проверяет окончательное удаление мягко удалённых ссылок
+func TestStore_PurgeDeletedURLs(t *testing.T) {
+	s := NewStore()
+	require.NoError(t, s.Write("deleted", "https://deleted.com", "user-1"))
+	require.NoError(t, s.Write("active", "https://active.com", "user-1"))
+	require.NoError(t, s.DeleteURLsBatch([]model.Code{"deleted"}, "user-1"))
+
+	// Срок хранения ещё не истёк
+	purged, err := s.PurgeDeletedURLs(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+
+	purged, err = s.PurgeDeletedURLs(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []model.Code{"deleted"}, purged)
+
+	_, err = s.Read("deleted")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.True(t, s.IsCodeUnique("deleted"))
+
+	value, err := s.Read("active")
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://active.com"), value)
+
+	// URL удалённой ссылки можно сократить заново
+	code, created, err := s.CreateOrGetURL("newcode", "https://deleted.com", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, model.Code("newcode"), code)
+}
+
+// TestStore_RecycledCodes проверяет карантин и выдачу кодов из пула
+func TestStore_RecycledCodes(t *testing.T) {
+	now := time.Now()
+
+	t.Run("code is not available during quarantine", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.ReleaseCodes([]model.Code{"freed"}, now.Add(time.Hour)))
+
+		_, ok, err := s.AcquireRecycledCode(now)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		code, ok, err := s.AcquireRecycledCode(now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, model.Code("freed"), code)
+
+		// Код выдаётся только один раз
+		_, ok, err = s.AcquireRecycledCode(now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("codes are acquired in quarantine order", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.ReleaseCodes([]model.Code{"later"}, now.Add(-time.Minute)))
+		require.NoError(t, s.ReleaseCodes([]model.Code{"earlier"}, now.Add(-time.Hour)))
+
+		code, ok, err := s.AcquireRecycledCode(now)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, model.Code("earlier"), code)
+	})
+
+	t.Run("release cannot shorten quarantine", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.ReleaseCodes([]model.Code{"freed"}, now.Add(time.Hour)))
+		require.NoError(t, s.ReleaseCodes([]model.Code{"freed"}, now))
+
+		_, ok, err := s.AcquireRecycledCode(now)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("taken codes are skipped", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.Write("taken", "https://example.com", "user-1"))
+		require.NoError(t, s.ReleaseCodes([]model.Code{"taken", "free"}, now))
+
+		code, ok, err := s.AcquireRecycledCode(now)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, model.Code("free"), code)
+	})
+}
+
+// TestStore_Expiry проверяет ссылки со сроком жизни
+func TestStore_Expiry(t *testing.T) {
+	t.Run("expired link returns distinct error", func(t *testing.T) {
+		s := NewStore()
+		_, _, err := s.CreateOrGetURL("expired", "https://example.com", "user-1",
+			model.LinkOptions{ExpiresAt: time.Now().Add(-time.Second)})
+		require.NoError(t, err)
+
+		_, err = s.Read("expired")
+		assert.ErrorIs(t, err, ErrURLExpired)
+	})
+
+	t.Run("link works until expiry", func(t *testing.T) {
+		s := NewStore()
+		_, _, err := s.CreateOrGetURL("alive", "https://example.com", "user-1",
+			model.LinkOptions{ExpiresAt: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+
+		value, err := s.Read("alive")
+		require.NoError(t, err)
+		assert.Equal(t, model.URL("https://example.com"), value)
+	})
+
+	t.Run("expiring links are not deduplicated", func(t *testing.T) {
+		s := NewStore()
+		_, created, err := s.CreateOrGetURL("permanent", "https://example.com", "user-1", model.LinkOptions{})
+		require.NoError(t, err)
+		require.True(t, created)
+
+		code, created, err := s.CreateOrGetURL("expiring", "https://example.com", "user-1",
+			model.LinkOptions{ExpiresAt: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, model.Code("expiring"), code)
+
+		// Бессрочная ссылка по-прежнему дедуплицируется
+		code, created, err = s.CreateOrGetURL("another", "https://example.com", "user-1", model.LinkOptions{})
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, model.Code("permanent"), code)
+	})
+
+	t.Run("sweeper marks expired links deleted", func(t *testing.T) {
+		s := NewStore()
+		expiresAt := time.Now().Add(time.Minute)
+		_, _, err := s.CreateOrGetURL("expiring", "https://a.com", "user-1", model.LinkOptions{ExpiresAt: expiresAt})
+		require.NoError(t, err)
+		require.NoError(t, s.Write("permanent", "https://b.com", "user-1"))
+
+		expired, err := s.MarkExpiredURLs(time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+
+		expired, err = s.MarkExpiredURLs(expiresAt)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Code{"expiring"}, expired)
+
+		page, err := s.GetURLsByUserID("user-1", "http://localhost:8080/", model.URLQuery{})
+		require.NoError(t, err)
+		urls := page.URLs
+		require.Len(t, urls, 1)
+		assert.Equal(t, "https://b.com", urls[0].OriginalURL)
+
+		// Истёкшая ссылка попадает под очистку удалённых со временем удаления, равным сроку истечения
+		purged, err := s.PurgeDeletedURLs(expiresAt.Add(time.Nanosecond))
+		require.NoError(t, err)
+		assert.Equal(t, []model.Code{"expiring"}, purged)
+	})
+}
+
+func TestStore_ClickLimit(t *testing.T) {
+	t.Run("link burns after max clicks", func(t *testing.T) {
+		s := NewStore()
+		_, _, err := s.CreateOrGetURL("limited", "https://example.com", "user-1", model.LinkOptions{MaxClicks: 2})
+		require.NoError(t, err)
+
+		for range 2 {
+			value, followErr := s.Follow("limited", false)
+			require.NoError(t, followErr)
+			assert.Equal(t, model.URL("https://example.com"), value.URL)
+			assert.True(t, value.Restricted)
+		}
+
+		_, err = s.Follow("limited", false)
+		assert.ErrorIs(t, err, ErrClickLimitReached)
+
+		// Чтение без перехода не расходует и не проверяет лимит
+		_, err = s.Read("limited")
+		assert.NoError(t, err)
+	})
+
+	t.Run("peek does not consume clicks", func(t *testing.T) {
+		s := NewStore()
+		_, _, err := s.CreateOrGetURL("limited", "https://example.com", "user-1", model.LinkOptions{MaxClicks: 1})
+		require.NoError(t, err)
+
+		for range 3 {
+			value, peekErr := s.Peek("limited", false)
+			require.NoError(t, peekErr)
+			assert.Equal(t, model.URL("https://example.com"), value.URL)
+		}
+
+		_, err = s.Follow("limited", false)
+		require.NoError(t, err)
+
+		_, err = s.Peek("limited", false)
+		assert.ErrorIs(t, err, ErrClickLimitReached)
+	})
+
+	t.Run("unlimited link is never exhausted", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.Write("plain", "https://example.com", "user-1"))
+
+		for range 5 {
+			_, err := s.Follow("plain", false)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("click-limited links are not deduplicated", func(t *testing.T) {
+		s := NewStore()
+		_, _, err := s.CreateOrGetURL("permanent", "https://example.com", "user-1", model.LinkOptions{})
+		require.NoError(t, err)
+
+		code, created, err := s.CreateOrGetURL("limited", "https://example.com", "user-1", model.LinkOptions{MaxClicks: 1})
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, model.Code("limited"), code)
+	})
+
+	t.Run("concurrent followers never exceed the limit", func(t *testing.T) {
+		const maxClicks, followers = 10, 100
+
+		s := NewStore()
+		_, _, err := s.CreateOrGetURL("limited", "https://example.com", "user-1", model.LinkOptions{MaxClicks: maxClicks})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		var succeeded atomic.Int32
+		for range followers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, followErr := s.Follow("limited", false); followErr == nil {
+					succeeded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(maxClicks), succeeded.Load())
+	})
+}
+
+func TestStore_PasswordProtection(t *testing.T) {
+	s := NewStore()
+	_, _, err := s.CreateOrGetURL("permanent", "https://example.com", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+	code, created, err := s.CreateOrGetURL("locked", "https://example.com", "user-1",
+		model.LinkOptions{PasswordHash: "hash", MaxClicks: 1})
+	require.NoError(t, err)
+	require.True(t, created, "protected links are not deduplicated")
+	assert.Equal(t, model.Code("locked"), code)
+
+	hash, err := s.PasswordHash("locked")
+	require.NoError(t, err)
+	assert.Equal(t, "hash", hash)
+
+	hash, err = s.PasswordHash("permanent")
+	require.NoError(t, err)
+	assert.Empty(t, hash)
+
+	// Без подтверждения пароля переход не выполняется и не расходует лимит
+	_, err = s.Follow("locked", false)
+	assert.ErrorIs(t, err, ErrPasswordRequired)
+
+	value, err := s.Follow("locked", true)
+	require.NoError(t, err)
+	assert.Equal(t, model.URL("https://example.com"), value.URL)
+}
+
+func TestStore_DisplayURL(t *testing.T) {
+	s := NewStore()
+	_, _, err := s.CreateOrGetURL("abc", "https://example.com/", "user-1",
+		model.LinkOptions{DisplayURLs: map[model.URL]string{"https://example.com/": "HTTPS://Example.com"}})
+	require.NoError(t, err)
+	require.NoError(t, s.WriteBatch(URLMap{"def": "https://other.com/"}, "user-1", model.LinkOptions{}))
+
+	page, err := s.GetURLsByUserID("user-1", "http://localhost", model.URLQuery{})
+	require.NoError(t, err)
+	displays := make(map[string]string)
+	for _, u := range page.URLs {
+		displays[u.OriginalURL] = u.DisplayURL
+	}
+	assert.Equal(t, map[string]string{"https://example.com/": "HTTPS://Example.com", "https://other.com/": ""}, displays)
+
+	// Смена адреса сбрасывает исходное написание
+	_, err = s.UpdateURL("abc", "https://new.com/", "user-1")
+	require.NoError(t, err)
+	page, err = s.GetURLsByUserID("user-1", "http://localhost", model.URLQuery{})
+	require.NoError(t, err)
+	for _, u := range page.URLs {
+		assert.Empty(t, u.DisplayURL)
+	}
+}
+
+func TestStore_Clicks(t *testing.T) {
+	s := NewStore(WithCaseInsensitiveCodes())
+	require.NoError(t, s.Write("AbC", "https://example.com", "user-1"))
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, s.RecordClicks([]model.Click{
+		{Code: "abc", At: base.Add(-time.Hour)},
+		{Code: "ABC", At: base},
+		{Code: "missing", At: base},
+	}))
+
+	stats, err := s.GetClickStats("abc", base, base)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalClicks, "clicks before from are filtered out")
+	assert.Equal(t, "abc", stats.Code)
+	assert.Len(t, s.clicks["AbC"], 2, "click code is canonicalized")
+
+	_, err = s.GetClickStats("missing", base, base)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Окончательное удаление ссылки удаляет и её переходы
+	require.NoError(t, s.DeleteURLsBatch([]model.Code{"AbC"}, "user-1"))
+	_, err = s.PurgeDeletedURLs(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.NoError(t, s.Write("AbC", "https://other.com", "user-2"))
+	stats, err = s.GetClickStats("AbC", time.Time{}, base)
+	require.NoError(t, err)
+	assert.Zero(t, stats.TotalClicks)
+}
+
+func TestStore_ClickRetention(t *testing.T) {
+	s := NewStore(WithClickRetention(time.Hour))
+	require.NoError(t, s.Write("fresh", "https://fresh.com", "user-1"))
+	require.NoError(t, s.Write("stale", "https://stale.com", "user-1"))
+	now := time.Now().UTC()
+
+	require.NoError(t, s.RecordClicks([]model.Click{
+		{Code: "fresh", At: now.Add(-2 * time.Hour)},
+		{Code: "fresh", At: now},
+		{Code: "stale", At: now.Add(-2 * time.Hour)},
+	}))
+
+	stats, err := s.GetClickStats("fresh", time.Time{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalClicks, "clicks older than retention are dropped")
+
+	stats, err = s.GetClickStats("stale", time.Time{}, now)
+	require.NoError(t, err)
+	assert.Zero(t, stats.TotalClicks)
+	assert.NotContains(t, s.clicks, model.Code("stale"))
+}
+
+func TestStore_ClickCounts(t *testing.T) {
+	s := NewStore(WithCaseInsensitiveCodes())
+	require.NoError(t, s.Write("AbC", "https://example.com", "user-1"))
+	require.NoError(t, s.Write("idle", "https://idle.com", "user-1"))
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, s.AddClickCounts([]model.ClickCount{
+		{Code: "abc", Clicks: 2, LastAccessedAt: at},
+		{Code: "ABC", Clicks: 3, LastAccessedAt: at.Add(-time.Hour)},
+		{Code: "missing", Clicks: 1, LastAccessedAt: at},
+	}))
+
+	page, err := s.GetURLsByUserID("user-1", "http://localhost:8080", model.URLQuery{})
+	require.NoError(t, err)
+	urls := page.URLs
+	require.Len(t, urls, 2)
+	for _, u := range urls {
+		switch u.ShortURL {
+		case "http://localhost:8080/AbC":
+			assert.Equal(t, int64(5), u.Clicks)
+			require.NotNil(t, u.LastAccessedAt)
+			assert.Equal(t, at, *u.LastAccessedAt, "latest access wins")
+		default:
+			assert.Zero(t, u.Clicks)
+			assert.Nil(t, u.LastAccessedAt)
+		}
+	}
+}
+
+func TestStore_UpdateURL(t *testing.T) {
+	newStore := func(t *testing.T) *Store {
+		s := NewStore()
+		require.NoError(t, s.Write("abc", "https://old.com", "user-1"))
+		require.NoError(t, s.Write("other", "https://taken.com", "user-1"))
+		require.NoError(t, s.Write("foreign", "https://foreign.com", "user-2"))
+		return s
+	}
+
+	t.Run("Owner changes destination and dedup index", func(t *testing.T) {
+		s := newStore(t)
+
+		previous, err := s.UpdateURL("abc", "https://new.com", "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, model.URL("https://old.com"), previous)
+
+		value, err := s.Read("abc")
+		require.NoError(t, err)
+		assert.Equal(t, model.URL("https://new.com"), value)
+
+		code, err := s.GetCodeByURL("https://new.com")
+		require.NoError(t, err)
+		assert.Equal(t, model.Code("abc"), code)
+		_, err = s.GetCodeByURL("https://old.com")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		// Прежний URL снова можно сократить новой ссылкой
+		_, created, err := s.CreateOrGetURL("fresh", "https://old.com", "user-1", model.LinkOptions{})
+		require.NoError(t, err)
+		assert.True(t, created)
+	})
+
+	t.Run("URL of another own link conflicts", func(t *testing.T) {
+		s := newStore(t)
+
+		_, err := s.UpdateURL("abc", "https://taken.com", "user-1")
+		var conflict URLConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, model.Code("other"), conflict.Code)
+		assert.ErrorIs(t, err, ErrURLAlreadyExists)
+
+		value, err := s.Read("abc")
+		require.NoError(t, err)
+		assert.Equal(t, model.URL("https://old.com"), value, "failed update changes nothing")
+	})
+
+	t.Run("Foreign link is not found", func(t *testing.T) {
+		s := newStore(t)
+
+		_, err := s.UpdateURL("foreign", "https://new.com", "user-1")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Deleted link", func(t *testing.T) {
+		s := newStore(t)
+		require.NoError(t, s.DeleteURLsBatch([]model.Code{"abc"}, "user-1"))
+
+		_, err := s.UpdateURL("abc", "https://new.com", "user-1")
+		assert.ErrorIs(t, err, ErrURLDeleted)
+	})
+
+	t.Run("Restricted link stays out of dedup index", func(t *testing.T) {
+		s := newStore(t)
+		_, _, err := s.CreateOrGetURL("limited", "https://limited.com", "user-1", model.LinkOptions{MaxClicks: 3})
+		require.NoError(t, err)
+
+		_, err = s.UpdateURL("limited", "https://taken.com", "user-1")
+		require.NoError(t, err)
+
+		code, err := s.GetCodeByURL("https://taken.com")
+		require.NoError(t, err)
+		assert.Equal(t, model.Code("other"), code)
+	})
+}
+
+func TestStore_URLVersions(t *testing.T) {
+	s := NewStore()
+	require.NoError(t, s.Write("abc", "https://v1.com", "user-1"))
+
+	versions, err := s.GetURLVersions("abc")
+	require.NoError(t, err)
+	assert.Empty(t, versions, "unchanged link has no history")
+
+	_, err = s.UpdateURL("abc", "https://v2.com", "user-1")
+	require.NoError(t, err)
+	_, err = s.UpdateURL("abc", "https://v2.com", "user-1")
+	require.NoError(t, err, "same destination is a no-op")
+	_, err = s.UpdateURL("abc", "https://v3.com", "user-1")
+	require.NoError(t, err)
+
+	versions, err = s.GetURLVersions("abc")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 2, versions[0].Version, "newest first")
+	assert.Equal(t, "https://v2.com", versions[0].OriginalURL)
+	assert.Equal(t, 1, versions[1].Version)
+	assert.Equal(t, "https://v1.com", versions[1].OriginalURL)
+	assert.Equal(t, "user-1", versions[1].ChangedBy)
+
+	_, err = s.GetURLVersions("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Очистка сохраняет последнюю версию, и нумерация продолжается
+	pruned, err := s.PruneURLVersions(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	_, err = s.UpdateURL("abc", "https://v4.com", "user-1")
+	require.NoError(t, err)
+	versions, err = s.GetURLVersions("abc")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 3, versions[0].Version)
+	assert.Equal(t, 2, versions[1].Version)
+
+	// Окончательное удаление ссылки удаляет и её историю
+	require.NoError(t, s.DeleteURLsBatch([]model.Code{"abc"}, "user-1"))
+	_, err = s.PurgeDeletedURLs(time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.NoError(t, s.Write("abc", "https://other.com", "user-2"))
+	versions, err = s.GetURLVersions("abc")
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestStore_RestoreURLs(t *testing.T) {
+	t.Run("restores own link deleted within window", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.Write("abc", "https://example.com", "user-1"))
+		require.NoError(t, s.DeleteURLsBatch([]model.Code{"abc"}, "user-1"))
+
+		trash, err := s.GetDeletedURLsByUserID("user-1", "http://localhost/", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.Len(t, trash, 1)
+		assert.Equal(t, "http://localhost/abc", trash[0].ShortURL)
+		require.NotNil(t, trash[0].DeletedAt)
+
+		restored, err := s.RestoreURLsBatch([]model.Code{"abc", "missing"}, "user-1", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, []model.Code{"abc"}, restored)
+
+		value, err := s.Read("abc")
+		require.NoError(t, err)
+		assert.Equal(t, model.URL("https://example.com"), value)
+
+		trash, err = s.GetDeletedURLsByUserID("user-1", "http://localhost/", time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, trash)
+	})
+
+	t.Run("link deleted before window is not restored", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.Write("abc", "https://example.com", "user-1"))
+		require.NoError(t, s.DeleteURLsBatch([]model.Code{"abc"}, "user-1"))
+
+		trash, err := s.GetDeletedURLsByUserID("user-1", "http://localhost/", time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Empty(t, trash)
+
+		restored, err := s.RestoreURLsBatch([]model.Code{"abc"}, "user-1", time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Empty(t, restored)
+
+		_, err = s.Read("abc")
+		assert.ErrorIs(t, err, ErrURLDeleted)
+	})
+
+	t.Run("foreign and expired links are not restored", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.Write("foreign", "https://foreign.com", "user-2"))
+		require.NoError(t, s.DeleteURLsBatch([]model.Code{"foreign"}, "user-2"))
+		_, _, err := s.CreateOrGetURL("expired", "https://expired.com", "user-1",
+			model.LinkOptions{ExpiresAt: time.Now().Add(-time.Second)})
+		require.NoError(t, err)
+		require.NoError(t, s.DeleteURLsBatch([]model.Code{"expired"}, "user-1"))
+
+		restored, err := s.RestoreURLsBatch([]model.Code{"foreign", "expired"}, "user-1", time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, restored)
+
+		_, err = s.Read("foreign")
+		assert.ErrorIs(t, err, ErrURLDeleted)
+	})
+
+	t.Run("active link is not reported as restored", func(t *testing.T) {
+		s := NewStore()
+		require.NoError(t, s.Write("abc", "https://example.com", "user-1"))
+
+		restored, err := s.RestoreURLsBatch([]model.Code{"abc"}, "user-1", time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, restored)
+	})
+}
+
+func TestStore_Labels(t *testing.T) {
+	newLabeledStore := func(t *testing.T) *Store {
+		s := NewStore()
+		links := []struct {
+			code   model.Code
+			labels model.LinkLabels
+		}{
+			{"work1", model.LinkLabels{Tags: []string{"docs", "work"}, Folder: "Projects"}},
+			{"work2", model.LinkLabels{Tags: []string{"work"}}},
+			{"home", model.LinkLabels{Tags: []string{"home"}, Folder: "Projects"}},
+			{"plain", model.LinkLabels{}},
+		}
+		for _, link := range links {
+			_, _, err := s.CreateOrGetURL(link.code, model.URL("https://"+link.code+".com"), "user-1",
+				model.LinkOptions{Labels: link.labels})
+			require.NoError(t, err)
+		}
+		_, _, err := s.CreateOrGetURL("foreign", "https://foreign.com", "user-2",
+			model.LinkOptions{Labels: model.LinkLabels{Tags: []string{"work"}, Folder: "Projects"}})
+		require.NoError(t, err)
+		return s
+	}
+
+	listCodes := func(t *testing.T, s *Store, filter model.URLFilter) []string {
+		page, err := s.GetURLsByUserID("user-1", "http://localhost/", model.URLQuery{Filter: filter})
+		require.NoError(t, err)
+		urls := page.URLs
+		codes := make([]string, 0, len(urls))
+		for _, u := range urls {
+			codes = append(codes, strings.TrimPrefix(u.ShortURL, "http://localhost/"))
+		}
+		return codes
+	}
+
+	t.Run("filters by tag and folder", func(t *testing.T) {
+		s := newLabeledStore(t)
+
+		assert.ElementsMatch(t, []string{"work1", "work2", "home", "plain"}, listCodes(t, s, model.URLFilter{}))
+		assert.ElementsMatch(t, []string{"work1", "work2"}, listCodes(t, s, model.URLFilter{Tag: "work"}))
+		assert.ElementsMatch(t, []string{"work1", "home"}, listCodes(t, s, model.URLFilter{Folder: "Projects"}))
+		assert.ElementsMatch(t, []string{"work1"}, listCodes(t, s, model.URLFilter{Tag: "work", Folder: "Projects"}))
+		assert.Empty(t, listCodes(t, s, model.URLFilter{Tag: "missing"}))
+	})
+
+	t.Run("listing returns labels", func(t *testing.T) {
+		s := newLabeledStore(t)
+
+		page, err := s.GetURLsByUserID("user-1", "http://localhost/", model.URLQuery{Filter: model.URLFilter{Tag: "docs"}})
+		require.NoError(t, err)
+		urls := page.URLs
+		require.Len(t, urls, 1)
+		assert.Equal(t, []string{"docs", "work"}, urls[0].Tags)
+		assert.Equal(t, "Projects", urls[0].Folder)
+	})
+
+	t.Run("set labels replaces indexes", func(t *testing.T) {
+		s := newLabeledStore(t)
+
+		require.NoError(t, s.SetURLLabels("work1", model.LinkLabels{Tags: []string{"archive"}}, "user-1"))
+
+		assert.ElementsMatch(t, []string{"work2"}, listCodes(t, s, model.URLFilter{Tag: "work"}))
+		assert.ElementsMatch(t, []string{"home"}, listCodes(t, s, model.URLFilter{Folder: "Projects"}))
+		assert.ElementsMatch(t, []string{"work1"}, listCodes(t, s, model.URLFilter{Tag: "archive"}))
+	})
+
+	t.Run("set labels of foreign link is not found", func(t *testing.T) {
+		s := newLabeledStore(t)
+
+		err := s.SetURLLabels("foreign", model.LinkLabels{Tags: []string{"mine"}}, "user-1")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("delete by filter marks only matching own links", func(t *testing.T) {
+		s := newLabeledStore(t)
+
+		deleted, err := s.DeleteURLsByFilter("user-1", model.URLFilter{Tag: "work"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []model.Code{"work1", "work2"}, deleted)
+
+		assert.ElementsMatch(t, []string{"home", "plain"}, listCodes(t, s, model.URLFilter{}))
+		_, err = s.Read("foreign")
+		assert.NoError(t, err)
+
+		deleted, err = s.DeleteURLsByFilter("user-1", model.URLFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, deleted, "empty filter deletes nothing")
+	})
+
+	t.Run("purge clears label indexes", func(t *testing.T) {
+		s := newLabeledStore(t)
+		require.NoError(t, s.DeleteURLsBatch([]model.Code{"home"}, "user-1"))
+		_, err := s.PurgeDeletedURLs(time.Now().Add(time.Second))
+		require.NoError(t, err)
+
+		assert.NotContains(t, s.tagIndex, "home")
+		assert.ElementsMatch(t, []string{"work1"}, listCodes(t, s, model.URLFilter{Folder: "Projects"}))
+	})
+}
+
+func TestStore_GetURLsByUserIDPagination(t *testing.T) {
+	s := NewStore()
+	links := []struct {
+		code   model.Code
+		url    model.URL
+		clicks int64
+	}{
+		{"c1", "https://b.example.com/docs", 5},
+		{"c2", "https://a.example.com", 1},
+		{"c3", "https://d.example.com/DOCS/api", 5},
+		{"c4", "https://c.example.com", 0},
+		{"c5", "https://e.example.com", 9},
+	}
+	for _, link := range links {
+		require.NoError(t, s.Write(link.code, link.url, "user-1"))
+		if link.clicks > 0 {
+			require.NoError(t, s.AddClickCounts([]model.ClickCount{{Code: link.code, Clicks: link.clicks}}))
+		}
+	}
+	require.NoError(t, s.Write("foreign", "https://foreign.com", "user-2"))
+
+	// collect обходит все страницы и возвращает коды в порядке выдачи
+	collect := func(t *testing.T, query model.URLQuery) []string {
+		var codes []string
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 10, "pagination must terminate")
+			page, err := s.GetURLsByUserID("user-1", "http://localhost/", query)
+			require.NoError(t, err)
+			for _, u := range page.URLs {
+				codes = append(codes, strings.TrimPrefix(u.ShortURL, "http://localhost/"))
+			}
+			if page.Next == nil {
+				return codes
+			}
+			require.Len(t, page.URLs, query.Limit)
+			query.After = page.Next
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query model.URLQuery
+		want  []string
+	}{
+		{
+			name:  "newest first",
+			query: model.URLQuery{Sort: model.URLSortCreated, Desc: true, Limit: 2},
+			want:  []string{"c5", "c4", "c3", "c2", "c1"},
+		},
+		{
+			name:  "oldest first",
+			query: model.URLQuery{Sort: model.URLSortCreated, Limit: 3},
+			want:  []string{"c1", "c2", "c3", "c4", "c5"},
+		},
+		{
+			name:  "most clicked first, ties by code",
+			query: model.URLQuery{Sort: model.URLSortClicks, Desc: true, Limit: 2},
+			want:  []string{"c5", "c3", "c1", "c2", "c4"},
+		},
+		{
+			name:  "by target URL",
+			query: model.URLQuery{Sort: model.URLSortURL, Limit: 4},
+			want:  []string{"c2", "c1", "c4", "c3", "c5"},
+		},
+		{
+			name:  "case-insensitive search",
+			query: model.URLQuery{Sort: model.URLSortCreated, Search: "docs", Limit: 1},
+			want:  []string{"c1", "c3"},
+		},
+		{
+			name:  "without limit",
+			query: model.URLQuery{Sort: model.URLSortURL, Desc: true},
+			want:  []string{"c5", "c3", "c4", "c1", "c2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, collect(t, tt.query))
+		})
+	}
+
+	t.Run("links are returned with creation time and clicks", func(t *testing.T) {
+		page, err := s.GetURLsByUserID("user-1", "http://localhost/", model.URLQuery{Sort: model.URLSortClicks, Desc: true, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page.URLs, 1)
+		assert.Equal(t, int64(9), page.URLs[0].Clicks)
+		assert.NotNil(t, page.URLs[0].CreatedAt)
+	})
+}
+
+func TestStore_Inspect(t *testing.T) {
+	s := NewStore()
+	require.NoError(t, s.Write("plain", "https://example.com", "user-1"))
+	_, _, err := s.CreateOrGetURL("limited", "https://limited.com", "user-1", model.LinkOptions{MaxClicks: 1})
+	require.NoError(t, err)
+	_, _, err = s.CreateOrGetURL("locked", "https://locked.com", "user-1", model.LinkOptions{PasswordHash: "hash"})
+	require.NoError(t, err)
+	require.NoError(t, s.AddClickCounts([]model.ClickCount{{Code: "plain", Clicks: 4}}))
+
+	t.Run("returns link details", func(t *testing.T) {
+		info, err := s.Inspect("plain", false)
+		require.NoError(t, err)
+		assert.Equal(t, model.Code("plain"), info.Code)
+		assert.Equal(t, model.URL("https://example.com"), info.URL)
+		assert.Equal(t, int64(4), info.Clicks)
+		assert.False(t, info.CreatedAt.IsZero())
+		assert.False(t, info.Unsafe)
+	})
+
+	t.Run("does not consume clicks", func(t *testing.T) {
+		for range 3 {
+			_, err := s.Inspect("limited", false)
+			require.NoError(t, err)
+		}
+		_, err := s.Follow("limited", false)
+		require.NoError(t, err)
+
+		_, err = s.Inspect("limited", false)
+		assert.ErrorIs(t, err, ErrClickLimitReached)
+	})
+
+	t.Run("password-protected link requires unlock", func(t *testing.T) {
+		_, err := s.Inspect("locked", false)
+		assert.ErrorIs(t, err, ErrPasswordRequired)
+
+		info, err := s.Inspect("locked", true)
+		require.NoError(t, err)
+		assert.Equal(t, model.URL("https://locked.com"), info.URL)
+	})
+
+	t.Run("unsafe flag", func(t *testing.T) {
+		require.NoError(t, s.SetURLUnsafe("plain", true))
+		info, err := s.Inspect("plain", false)
+		require.NoError(t, err)
+		assert.True(t, info.Unsafe)
+
+		require.NoError(t, s.SetURLUnsafe("plain", false))
+		info, err = s.Inspect("plain", false)
+		require.NoError(t, err)
+		assert.False(t, info.Unsafe)
+
+		assert.ErrorIs(t, s.SetURLUnsafe("missing", true), ErrNotFound)
+	})
+
+	t.Run("deleted link", func(t *testing.T) {
+		require.NoError(t, s.DeleteURLsBatch([]model.Code{"plain"}, "user-1"))
+		_, err := s.Inspect("plain", false)
+		assert.ErrorIs(t, err, ErrURLDeleted)
+	})
+}
+
+func TestStore_FollowRedirectPolicy(t *testing.T) {
+	s := NewStore()
+	maxAge := time.Duration(0)
+	_, _, err := s.CreateOrGetURL("moved", "https://example.com", "user-1",
+		model.LinkOptions{Redirect: model.RedirectPolicy{Status: 308, MaxAge: &maxAge}})
+	require.NoError(t, err)
+	require.NoError(t, s.Write("plain", "https://other.com", "user-1"))
+	require.NoError(t, s.SetURLUnsafe("plain", true))
+
+	target, err := s.Follow("moved", false)
+	require.NoError(t, err)
+	assert.Equal(t, model.RedirectPolicy{Status: 308, MaxAge: &maxAge}, target.Redirect)
+	assert.False(t, target.Restricted)
+	assert.False(t, target.Unsafe)
+
+	target, err = s.Follow("plain", false)
+	require.NoError(t, err)
+	assert.True(t, target.Redirect.IsZero())
+	assert.True(t, target.Unsafe)
+}
+
+func TestStore_QueryPolicy(t *testing.T) {
+	s := NewStore()
+	policy := model.QueryPolicy{
+		Passthrough: model.QueryPassthroughOverride,
+		UTM:         model.UTMParams{Source: "newsletter", Campaign: "spring"},
+	}
+	_, _, err := s.CreateOrGetURL("plain", "https://example.com", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+
+	// Ссылка с правилами query-параметров не совпадает с обычной ссылкой на тот же адрес
+	code, created, err := s.CreateOrGetURL("tagged", "https://example.com", "user-1", model.LinkOptions{Query: policy})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, model.Code("tagged"), code)
+
+	code, created, err = s.CreateOrGetURL("again", "https://example.com", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, model.Code("plain"), code)
+
+	target, err := s.Follow("tagged", false)
+	require.NoError(t, err)
+	assert.Equal(t, policy, target.Query)
+
+	target, err = s.Follow("plain", false)
+	require.NoError(t, err)
+	assert.True(t, target.Query.IsZero())
+}
+
+func TestStore_SetURLRules(t *testing.T) {
+	s := NewStore()
+	rules := []model.RedirectRule{
+		{Platforms: []string{"ios"}, URL: "https://apps.apple.com/app"},
+		{Countries: []string{"DE"}, URL: "https://example.de"},
+	}
+	_, _, err := s.CreateOrGetURL("app", "https://example.com", "user-1", model.LinkOptions{Rules: rules[:1]})
+	require.NoError(t, err)
+	require.NoError(t, s.Write("foreign", "https://other.com", "user-2"))
+
+	target, err := s.Follow("app", false)
+	require.NoError(t, err)
+	assert.Equal(t, rules[:1], target.Rules)
+
+	require.NoError(t, s.SetURLRules("app", rules, "user-1"))
+	target, err = s.Follow("app", false)
+	require.NoError(t, err)
+	assert.Equal(t, rules, target.Rules)
+
+	require.NoError(t, s.SetURLRules("app", nil, "user-1"))
+	target, err = s.Follow("app", false)
+	require.NoError(t, err)
+	assert.Empty(t, target.Rules)
+
+	assert.ErrorIs(t, s.SetURLRules("foreign", rules, "user-1"), ErrNotFound)
+	assert.ErrorIs(t, s.SetURLRules("missing", rules, "user-1"), ErrNotFound)
+}
+
+func TestStore_SetURLVariants(t *testing.T) {
+	s := NewStore()
+	variants := []model.SplitVariant{
+		{URL: "https://a.example.com", Weight: 50},
+		{URL: "https://b.example.com", Weight: 50},
+	}
+	_, _, err := s.CreateOrGetURL("split", "https://example.com", "user-1", model.LinkOptions{Variants: variants})
+	require.NoError(t, err)
+	require.NoError(t, s.Write("foreign", "https://other.com", "user-2"))
+
+	target, err := s.Follow("split", false)
+	require.NoError(t, err)
+	assert.Equal(t, variants, target.Variants)
+
+	require.NoError(t, s.SetURLVariants("split", nil, "user-1"))
+	target, err = s.Follow("split", false)
+	require.NoError(t, err)
+	assert.Empty(t, target.Variants)
+
+	assert.ErrorIs(t, s.SetURLVariants("foreign", variants, "user-1"), ErrNotFound)
+	assert.ErrorIs(t, s.SetURLVariants("missing", variants, "user-1"), ErrNotFound)
+}
+
+// urlCreator — хранилище, создающее ссылку или возвращающее существующую на тот же URL
+type urlCreator interface {
+	CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error)
+}
+
+// dedupMaxAge — срок кэширования редиректа для dedupExclusionCases
+var dedupMaxAge = time.Hour
+
+// dedupExclusionCases — параметры, с которыми ссылка не совпадает с обычной ссылкой на тот же URL
+var dedupExclusionCases = []struct {
+	name string
+	opts model.LinkOptions
+}{
+	{
+		name: "redirect status",
+		opts: model.LinkOptions{Redirect: model.RedirectPolicy{Status: 308}},
+	},
+	{
+		name: "cache max age",
+		opts: model.LinkOptions{Redirect: model.RedirectPolicy{MaxAge: &dedupMaxAge}},
+	},
+	{
+		name: "redirect rules",
+		opts: model.LinkOptions{Rules: []model.RedirectRule{
+			{Platforms: []string{"ios"}, URL: "https://apps.apple.com/app"},
+		}},
+	},
+	{
+		name: "split variants",
+		opts: model.LinkOptions{Variants: []model.SplitVariant{
+			{URL: "https://a.example.com/", Weight: 1},
+			{URL: "https://b.example.com/", Weight: 1},
+		}},
+	},
+}
+
+// assertNotDeduplicated проверяет, что ссылка с opts создаётся заново рядом с обычной ссылкой
+// на тот же URL и что обычная ссылка не возвращает вместо себя ссылку с opts
+func assertNotDeduplicated(t *testing.T, s urlCreator, opts model.LinkOptions) {
+	t.Helper()
+
+	_, _, err := s.CreateOrGetURL("plain1", "https://one.example.com/", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+	code, created, err := s.CreateOrGetURL("special1", "https://one.example.com/", "user-1", opts)
+	require.NoError(t, err)
+	assert.True(t, created, "link with options is not replaced by the existing plain link")
+	assert.Equal(t, model.Code("special1"), code)
+
+	_, _, err = s.CreateOrGetURL("special2", "https://two.example.com/", "user-1", opts)
+	require.NoError(t, err)
+	code, created, err = s.CreateOrGetURL("plain2", "https://two.example.com/", "user-1", model.LinkOptions{})
+	require.NoError(t, err)
+	assert.True(t, created, "plain link is not replaced by the existing link with options")
+	assert.Equal(t, model.Code("plain2"), code)
+}
+
+func TestStore_Deduplication(t *testing.T) {
+	for _, tt := range dedupExclusionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assertNotDeduplicated(t, NewStore(), tt.opts)
+		})
+	}
+}
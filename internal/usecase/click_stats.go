@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// clickBufferSize — сколько переходов может ждать записи; сверх этого переходы отбрасываются
+	clickBufferSize = 4096
+	// clickBatchSize — размер пачки переходов, записываемой в хранилище за раз
+	clickBatchSize = 100
+	// clickFlushInterval — максимальная задержка записи неполной пачки
+	clickFlushInterval = time.Second
+	// clickCountFlushInterval — период сброса накопленных счётчиков переходов в хранилище
+	clickCountFlushInterval = 5 * time.Second
+	// StatsWindow — период, за который отдаётся статистика переходов
+	StatsWindow = 30 * 24 * time.Hour
+)
+
+// RecordClick асинхронно учитывает переход по ссылке и не блокирует редирект
+func (u *URLUsecase) RecordClick(code string, visit model.Visit) {
+	now := time.Now()
+	u.clickCounter.Increment(model.Code(code), now)
+	u.clickRecorder.Record(u.clickEnricher.Enrich(model.Code(code), now, visit))
+}
+
+// GetURLStats возвращает статистику переходов по ссылке за последние StatsWindow.
+// Статистика доступна только владельцу: для чужой ссылки возвращается ErrURLNotFound,
+// чтобы не раскрывать существование кода.
+func (u *URLUsecase) GetURLStats(code string, userID string) (model.URLStats, error) {
+	if !u.repo.IsURLOwnedByUser(model.Code(code), userID) {
+		return model.URLStats{}, fmt.Errorf("%w: code %s", ErrURLNotFound, code)
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-StatsWindow)
+
+	stats, err := u.repo.GetClickStats(model.Code(code), from, to)
+	if err != nil {
+		u.logger.Error("failed to get click stats",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return model.URLStats{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	return stats, nil
+}
+
+// logClickError журналирует потерю переходов при записи статистики
+func (u *URLUsecase) logClickError(err error, dropped int) {
+	if err == nil {
+		u.logger.Warn("click buffer full, click dropped", zap.Int("dropped", dropped))
+		return
+	}
+	u.logger.Error("failed to record clicks", zap.Int("dropped", dropped), zap.Error(err))
+}
+
+// logClickCountError журналирует ошибку сброса счётчиков переходов
+func (u *URLUsecase) logClickCountError(err error, lost int) {
+	u.logger.Error("failed to flush click counters", zap.Int("lost_codes", lost), zap.Error(err))
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetURLStats(t *testing.T) {
+	t.Run("Foreign link is not found", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-2").Return(false).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		_, err := uc.GetURLStats("abc", "user-2")
+		assert.ErrorIs(t, err, ErrURLNotFound)
+	})
+
+	t.Run("Stats cover the stats window", func(t *testing.T) {
+		stats := model.URLStats{Code: "abc", TotalClicks: 2, UniqueVisitors: 1}
+
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+		mockRepo.EXPECT().
+			GetClickStats(model.Code("abc"), mock.Anything, mock.Anything).
+			RunAndReturn(func(_ model.Code, from, to time.Time) (model.URLStats, error) {
+				assert.Equal(t, StatsWindow, to.Sub(from))
+				assert.WithinDuration(t, time.Now(), to, time.Minute)
+				return stats, nil
+			}).
+			Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		got, err := uc.GetURLStats("abc", "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, stats, got)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+		mockRepo.EXPECT().GetClickStats(model.Code("abc"), mock.Anything, mock.Anything).Return(model.URLStats{}, errors.New("db down")).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		_, err := uc.GetURLStats("abc", "user-1")
+		assert.ErrorIs(t, err, ErrServiceUnavailable)
+	})
+}
+
+func TestRecordClick_FlushedOnClose(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().
+		RecordClicks(mock.MatchedBy(func(clicks []model.Click) bool {
+			return len(clicks) == 2 &&
+				clicks[0].Code == "abc" &&
+				clicks[0].Referrer == "example.org" &&
+				clicks[0].VisitorID != ""
+		})).
+		Return(nil).
+		Once()
+	mockRepo.EXPECT().
+		AddClickCounts(mock.MatchedBy(func(counts []model.ClickCount) bool {
+			return len(counts) == 1 && counts[0].Code == "abc" && counts[0].Clicks == 2
+		})).
+		Return(nil).
+		Once()
+
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+	uc.RecordClick("abc", model.Visit{Referrer: "https://example.org/page", UserAgent: "curl/8.5.0", IP: "192.0.2.1"})
+	uc.RecordClick("abc", model.Visit{Referrer: "https://example.org/page", UserAgent: "curl/8.5.0", IP: "192.0.2.1"})
+	uc.Close()
+}
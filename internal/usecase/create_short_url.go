@@ -1,39 +1,36 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/avc-dev/url-shortener/internal/config"
 	"github.com/avc-dev/url-shortener/internal/model"
+	svc "github.com/avc-dev/url-shortener/internal/service"
 	"go.uber.org/zap"
 )
 
 // CreateShortURLFromString создает короткий URL из строки оригинального URL
-// Выполняет валидацию, очистку URL и генерацию короткого кода
-func (u *URLUsecase) CreateShortURLFromString(urlString string, userID string) (string, error) {
-	urlString = strings.TrimSpace(urlString)
-	urlString = strings.Trim(urlString, `"'`)
-
-	if urlString == "" {
-		return "", ErrEmptyURL
-	}
-
-	parsedURL, err := url.Parse(urlString)
+// Выполняет валидацию, очистку и нормализацию URL и генерацию короткого кода с учётом opts.
+// Дубликат ищется по каноническому виду адреса, а исходная строка сохраняется для отображения.
+func (u *URLUsecase) CreateShortURLFromString(urlString string, userID string, opts model.LinkOptions) (string, error) {
+	opts, err := resolveLinkOptions(opts, time.Now())
 	if err != nil {
-		return "", fmt.Errorf("%w: %w", ErrInvalidURL, err)
+		return "", err
 	}
-
-	if parsedURL.Scheme == "" {
-		return "", fmt.Errorf("%w: scheme is missing", ErrInvalidURL)
+	if err := u.canonicalLinkTargets(opts.Rules, opts.Variants); err != nil {
+		return "", err
 	}
 
-	if parsedURL.Host == "" {
-		return "", fmt.Errorf("%w: host is missing", ErrInvalidURL)
+	originalURL, err := u.canonicalURL(urlString, &opts)
+	if err != nil {
+		return "", err
 	}
 
-	originalURL := model.URL(urlString)
-	code, created, err := u.service.CreateShortURL(originalURL, userID)
+	code, created, err := u.service.CreateShortURL(originalURL, userID, opts)
 	if err != nil {
 		u.logger.Error("failed to create short URL",
 			zap.String("original_url", string(originalURL)),
@@ -69,3 +66,158 @@ func (u *URLUsecase) CreateShortURLFromString(urlString string, userID string) (
 
 	return shortURL, nil
 }
+
+// checkURLLength возвращает LimitExceededError, если адрес длиннее Limits.MaxURLLength
+func (u *URLUsecase) checkURLLength(urlString string) error {
+	if len(urlString) > u.cfg.Limits.MaxURLLength {
+		return LimitExceededError{
+			Limit:  LimitURLLength,
+			Max:    int64(u.cfg.Limits.MaxURLLength),
+			Actual: int64(len(urlString)),
+		}
+	}
+	return nil
+}
+
+// parseOriginalURL очищает строку URL от пробелов и кавычек и проверяет,
+// что это абсолютный URL со схемой и хостом
+func parseOriginalURL(urlString string) (model.URL, error) {
+	urlString = strings.TrimSpace(urlString)
+	urlString = strings.Trim(urlString, `"'`)
+
+	if urlString == "" {
+		return "", ErrEmptyURL
+	}
+
+	parsedURL, err := url.Parse(urlString)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+
+	if parsedURL.Scheme == "" {
+		return "", fmt.Errorf("%w: scheme is missing", ErrInvalidURL)
+	}
+
+	if parsedURL.Host == "" {
+		return "", fmt.Errorf("%w: host is missing", ErrInvalidURL)
+	}
+
+	return model.URL(urlString), nil
+}
+
+// canonicalURL проверяет строку адреса по политике сервиса и приводит её к каноническому виду,
+// если нормализация включена. Исходная строка, изменённая нормализацией, добавляется в opts.DisplayURLs.
+func (u *URLUsecase) canonicalURL(urlString string, opts *model.LinkOptions) (model.URL, error) {
+	originalURL, err := parseOriginalURL(urlString)
+	if err != nil {
+		return "", err
+	}
+	if err := u.checkURLLength(string(originalURL)); err != nil {
+		return "", err
+	}
+
+	parsed, err := url.Parse(string(originalURL))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	normalized, err := u.normalizer.Normalize(parsed)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	if err := u.checkURL(normalized); err != nil {
+		return "", err
+	}
+	if u.normalizer == nil {
+		return originalURL, nil
+	}
+
+	canonical := model.URL(normalized.String())
+	// Нормализация может удлинить адрес, например при переводе хоста в punycode
+	if err := u.checkURLLength(string(canonical)); err != nil {
+		return "", err
+	}
+	if canonical != originalURL && opts != nil {
+		if opts.DisplayURLs == nil {
+			opts.DisplayURLs = make(map[model.URL]string)
+		}
+		opts.DisplayURLs[canonical] = string(originalURL)
+	}
+	return canonical, nil
+}
+
+// resolveLinkOptions проверяет параметры создания ссылки и приводит их к виду,
+// в котором они передаются дальше по слоям: TTL переводится в ExpiresAt относительно now
+func resolveLinkOptions(opts model.LinkOptions, now time.Time) (model.LinkOptions, error) {
+	if !opts.CodeStyle.IsValid() {
+		return opts, fmt.Errorf("%w: unknown code style %q", ErrInvalidOptions, opts.CodeStyle)
+	}
+
+	if opts.TTL != 0 {
+		if !opts.ExpiresAt.IsZero() {
+			return opts, fmt.Errorf("%w: ttl and expires_at are mutually exclusive", ErrInvalidOptions)
+		}
+		if opts.TTL < 0 {
+			return opts, fmt.Errorf("%w: ttl must be positive", ErrInvalidOptions)
+		}
+		opts.ExpiresAt = now.Add(opts.TTL)
+		opts.TTL = 0
+	}
+
+	if !opts.ExpiresAt.IsZero() && !opts.ExpiresAt.After(now) {
+		return opts, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidOptions)
+	}
+
+	if opts.MaxClicks < 0 {
+		return opts, fmt.Errorf("%w: max_clicks must be positive", ErrInvalidOptions)
+	}
+
+	if status := opts.Redirect.Status; status != 0 && !model.IsRedirectStatus(status) {
+		return opts, fmt.Errorf("%w: redirect status %d is not one of 301, 302, 307, 308", ErrInvalidOptions, status)
+	}
+	if maxAge := opts.Redirect.MaxAge; maxAge != nil {
+		// Срок ограничен карантином кода: закэшированный редирект не должен пережить повторную выдачу кода
+		if *maxAge < 0 || *maxAge > config.MinCodeQuarantine {
+			return opts, fmt.Errorf("%w: cache max-age must be between 0 and %s", ErrInvalidOptions, config.MinCodeQuarantine)
+		}
+		truncated := maxAge.Truncate(time.Second)
+		opts.Redirect.MaxAge = &truncated
+	}
+
+	query, err := normalizeQueryPolicy(opts.Query)
+	if err != nil {
+		return opts, err
+	}
+	opts.Query = query
+
+	rules, err := normalizeRedirectRules(opts.Rules)
+	if err != nil {
+		return opts, err
+	}
+	opts.Rules = rules
+
+	variants, err := normalizeSplitVariants(opts.Variants)
+	if err != nil {
+		return opts, err
+	}
+	opts.Variants = variants
+
+	labels, err := normalizeLabels(opts.Labels)
+	if err != nil {
+		return opts, err
+	}
+	opts.Labels = labels
+
+	if opts.Password != "" {
+		hash, err := svc.HashLinkPassword(opts.Password)
+		if err != nil {
+			if errors.Is(err, svc.ErrLinkPasswordTooLong) {
+				return opts, fmt.Errorf("%w: %w", ErrInvalidOptions, err)
+			}
+			return opts, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+		}
+		opts.PasswordHash = hash
+		opts.Password = ""
+	}
+
+	return opts, nil
+}
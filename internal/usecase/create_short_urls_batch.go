@@ -3,44 +3,46 @@ package usecase
 import (
 	"fmt"
 	"net/url"
-	"strings"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/model"
 	"go.uber.org/zap"
 )
 
 // CreateShortURLsBatch создает короткие URL для нескольких строковых URL
-// Выполняет валидацию, очистку URL и генерацию коротких кодов для каждого
-func (u *URLUsecase) CreateShortURLsBatch(urlStrings []string, userID string) ([]string, error) {
+// Выполняет валидацию, очистку и нормализацию URL и генерацию коротких кодов для каждого;
+// opts применяются ко всем ссылкам батча. Батч больше Limits.MaxBatchSize
+// отклоняется с LimitExceededError.
+func (u *URLUsecase) CreateShortURLsBatch(urlStrings []string, userID string, opts model.LinkOptions) ([]string, error) {
+	if len(urlStrings) > u.cfg.Limits.MaxBatchSize {
+		return nil, LimitExceededError{
+			Limit:  LimitBatchSize,
+			Max:    int64(u.cfg.Limits.MaxBatchSize),
+			Actual: int64(len(urlStrings)),
+		}
+	}
+
+	opts, err := resolveLinkOptions(opts, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.canonicalLinkTargets(opts.Rules, opts.Variants); err != nil {
+		return nil, err
+	}
+
 	originalURLs := make([]model.URL, len(urlStrings))
 
 	// Валидируем и очищаем все URL
 	for i, urlString := range urlStrings {
-		urlString = strings.TrimSpace(urlString)
-		urlString = strings.Trim(urlString, `"'`)
-
-		if urlString == "" {
-			return nil, fmt.Errorf("%w: empty URL at index %d", ErrEmptyURL, i)
-		}
-
-		parsedURL, err := url.Parse(urlString)
+		originalURL, err := u.canonicalURL(urlString, &opts)
 		if err != nil {
-			return nil, fmt.Errorf("%w: invalid URL at index %d: %w", ErrInvalidURL, i, err)
-		}
-
-		if parsedURL.Scheme == "" {
-			return nil, fmt.Errorf("%w: scheme is missing at index %d", ErrInvalidURL, i)
+			return nil, fmt.Errorf("URL at index %d: %w", i, err)
 		}
-
-		if parsedURL.Host == "" {
-			return nil, fmt.Errorf("%w: host is missing at index %d", ErrInvalidURL, i)
-		}
-
-		originalURLs[i] = model.URL(urlString)
+		originalURLs[i] = originalURL
 	}
 
 	// Создаем короткие URL через сервис
-	codes, err := u.service.CreateShortURLsBatch(originalURLs, userID)
+	codes, err := u.service.CreateShortURLsBatch(originalURLs, userID, opts)
 	if err != nil {
 		u.logger.Error("failed to create short URLs batch",
 			zap.Strings("original_urls", urlStrings),
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	svc "github.com/avc-dev/url-shortener/internal/service"
+	"go.uber.org/zap"
+)
+
+// GetBlockRules возвращает правила блокировки доменов в порядке добавления.
+func (u *URLUsecase) GetBlockRules() []model.BlockRule {
+	return u.blocklist.Rules()
+}
+
+// BlockDomain добавляет правило блокировки доменов и возвращает его в каноническом виде.
+// Правило сразу действует и при создании ссылок, и при переходе по уже созданным.
+// created равно false, если такое правило уже было.
+func (u *URLUsecase) BlockDomain(pattern string) (rule model.BlockRule, created bool, err error) {
+	rule, created, err = u.blocklist.Add(pattern)
+	if err != nil {
+		return model.BlockRule{}, false, u.mapBlocklistError(err)
+	}
+	if created {
+		u.logger.Info("domain block rule added", zap.String("rule", rule.Pattern))
+	}
+	return rule, created, nil
+}
+
+// UnblockDomain снимает правило блокировки доменов и возвращает его в каноническом виде.
+// Для отсутствующего правила возвращается ErrBlockRuleNotFound.
+func (u *URLUsecase) UnblockDomain(pattern string) (model.BlockRule, error) {
+	rule, err := svc.ParseBlockRule(pattern)
+	if err != nil {
+		return model.BlockRule{}, u.mapBlocklistError(err)
+	}
+
+	removed, err := u.blocklist.Remove(rule.Pattern)
+	if err != nil {
+		return model.BlockRule{}, u.mapBlocklistError(err)
+	}
+	if !removed {
+		return model.BlockRule{}, fmt.Errorf("%w: %s", ErrBlockRuleNotFound, rule.Pattern)
+	}
+
+	u.logger.Info("domain block rule removed", zap.String("rule", rule.Pattern))
+	return rule, nil
+}
+
+// mapBlocklistError маппит ошибки списка блокировки на ошибки usecase
+func (u *URLUsecase) mapBlocklistError(err error) error {
+	if errors.Is(err, svc.ErrInvalidBlockRule) {
+		return fmt.Errorf("%w: %w", ErrInvalidOptions, err)
+	}
+	u.logger.Error("failed to update domain blocklist", zap.Error(err))
+	return fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+}
+
+// checkBlocked возвращает URLBlockedError, если домен адреса назначения заблокирован.
+// Проверяется каждый переход, поэтому блокировка действует и на ссылки, созданные до неё.
+func (u *URLUsecase) checkBlocked(code, destination string) error {
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return nil
+	}
+	rule, blocked := u.blocklist.Match(parsed.Hostname())
+	if !blocked {
+		return nil
+	}
+
+	u.logger.Info("redirect to blocked domain refused",
+		zap.String("code", code),
+		zap.String("rule", rule.Pattern),
+	)
+	return URLBlockedError{URL: destination, Rule: rule.Pattern}
+}
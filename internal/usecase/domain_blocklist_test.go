@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	svc "github.com/avc-dev/url-shortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBlockDomain(t *testing.T) {
+	uc := NewURLUsecase(mocks.NewMockURLRepository(t), mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+	rule, created, err := uc.BlockDomain("*.Evil.test")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, model.BlockRule{Pattern: "*.evil.test", Kind: model.BlockRuleSuffix}, rule)
+
+	_, created, err = uc.BlockDomain("*.evil.test")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, []model.BlockRule{rule}, uc.GetBlockRules())
+
+	_, _, err = uc.BlockDomain("evil.test/path")
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+
+	_, err = uc.UnblockDomain("other.test")
+	assert.ErrorIs(t, err, ErrBlockRuleNotFound)
+
+	removed, err := uc.UnblockDomain("*.EVIL.test")
+	require.NoError(t, err)
+	assert.Equal(t, rule, removed)
+	assert.Empty(t, uc.GetBlockRules())
+}
+
+func TestBlockDomain_SaveError(t *testing.T) {
+	blocklist, err := svc.LoadDomainBlocklist(filepath.Join(t.TempDir(), "missing", "blocklist.txt"))
+	require.NoError(t, err)
+	uc := NewURLUsecase(mocks.NewMockURLRepository(t), mocks.NewMockURLService(t), config.NewDefaultConfig(),
+		zap.NewNop(), WithDomainBlocklist(blocklist))
+
+	_, _, err = uc.BlockDomain("evil.test")
+	assert.ErrorIs(t, err, ErrServiceUnavailable)
+	assert.Empty(t, uc.GetBlockRules(), "rule is not applied when it cannot be saved")
+}
+
+func TestCreateShortURLFromString_BlockedDomain(t *testing.T) {
+	uc := NewURLUsecase(mocks.NewMockURLRepository(t), mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+	_, _, err := uc.BlockDomain("*.evil.test")
+	require.NoError(t, err)
+
+	_, err = uc.CreateShortURLFromString("https://WWW.evil.test/login", "user-1", model.LinkOptions{})
+	var rejectedErr URLRejectedError
+	require.ErrorAs(t, err, &rejectedErr)
+	assert.Equal(t, RejectBlockedDomain, rejectedErr.Reason)
+}
+
+func TestGetOriginalURL_BlockedDomain(t *testing.T) {
+	// Заблокированная ссылка проверяется без FollowURL: отказ не расходует переход
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().PeekURL(model.Code("abc"), false).
+		Return(model.LinkTarget{URL: "https://phish.evil.test/login"}, nil).Once()
+	mockRepo.EXPECT().PeekURL(model.Code("ok"), false).
+		Return(model.LinkTarget{URL: "https://example.com/"}, nil).Once()
+	mockRepo.EXPECT().FollowURL(model.Code("ok"), false).
+		Return(model.LinkTarget{URL: "https://example.com/"}, nil).Once()
+
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+	_, _, err := uc.BlockDomain("*.evil.test")
+	require.NoError(t, err)
+
+	_, err = uc.GetOriginalURL("abc", model.LinkAccess{}, model.Visit{})
+	assert.ErrorIs(t, err, ErrURLBlocked)
+	var blockedErr URLBlockedError
+	require.ErrorAs(t, err, &blockedErr)
+	assert.Equal(t, URLBlockedError{URL: "https://phish.evil.test/login", Rule: "*.evil.test"}, blockedErr)
+
+	redirect, err := uc.GetOriginalURL("ok", model.LinkAccess{}, model.Visit{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/", redirect.URL)
+}
+
+func TestPreviewURL_BlockedDomain(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().InspectURL(model.Code("abc"), false).
+		Return(model.LinkInfo{Code: "abc", URL: "https://evil.test/"}, nil).Once()
+
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+	_, _, err := uc.BlockDomain("evil.test")
+	require.NoError(t, err)
+
+	_, err = uc.PreviewURL("abc", model.LinkAccess{})
+	assert.ErrorIs(t, err, ErrURLBlocked)
+}
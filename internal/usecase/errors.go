@@ -1,19 +1,46 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
 var (
 	// ErrInvalidURL возвращается, когда переданный URL не прошёл парсинг
 	// или не содержит обязательных частей (scheme, host).
 	ErrInvalidURL = errors.New("invalid URL")
+	// ErrInvalidOptions возвращается, когда параметры создания ссылки некорректны
+	// (например, неизвестный стиль кода).
+	ErrInvalidOptions = errors.New("invalid link options")
 	// ErrEmptyURL возвращается, когда тело запроса пустое или содержит только пробелы.
 	ErrEmptyURL = errors.New("empty URL")
 	// ErrServiceUnavailable возвращается при внутренних ошибках (хранилище, генератор кодов).
 	ErrServiceUnavailable = errors.New("service unavailable")
 	// ErrURLNotFound возвращается, когда короткий код не найден в хранилище.
 	ErrURLNotFound = errors.New("URL not found")
+	// ErrVersionNotFound возвращается, когда в истории ссылки нет запрошенной версии.
+	ErrVersionNotFound = errors.New("URL version not found")
 	// ErrURLDeleted возвращается, когда URL был найден, но помечен как удалённый.
 	ErrURLDeleted = errors.New("URL deleted")
+	// ErrURLExpired возвращается, когда срок жизни ссылки истёк.
+	ErrURLExpired = errors.New("URL expired")
+	// ErrClickLimitReached возвращается, когда у ссылки закончились разрешённые переходы.
+	ErrClickLimitReached = errors.New("click limit reached")
+	// ErrPasswordRequired возвращается при переходе по защищённой ссылке без пароля.
+	ErrPasswordRequired = errors.New("password required")
+	// ErrInvalidPassword возвращается, когда введён неверный пароль ссылки.
+	ErrInvalidPassword = errors.New("invalid password")
+	// ErrTooManyAttempts возвращается, когда попытки ввода пароля для кода временно заблокированы.
+	ErrTooManyAttempts = errors.New("too many password attempts")
+	// ErrPreviewRequired возвращается при переходе по небезопасной ссылке без подтверждения,
+	// если для таких ссылок по умолчанию показывается предпросмотр.
+	ErrPreviewRequired = errors.New("preview required")
+	// ErrURLBlocked возвращается при переходе по ссылке, домен назначения которой заблокирован.
+	ErrURLBlocked = errors.New("URL blocked")
+	// ErrBlockRuleNotFound возвращается при снятии правила блокировки, которого нет в списке.
+	ErrBlockRuleNotFound = errors.New("block rule not found")
+	// ErrLimitExceeded возвращается, когда запрос превышает ограничение размера.
+	ErrLimitExceeded = errors.New("limit exceeded")
 	// ErrURLAlreadyExists — устаревший сентинел; используйте URLAlreadyExistsError для получения кода.
 	ErrURLAlreadyExists = errors.New("URL already exists")
 )
@@ -32,3 +59,92 @@ func (e URLAlreadyExistsError) Error() string {
 func (e URLAlreadyExistsError) ExistingCode() string {
 	return e.Code
 }
+
+// URLRejectReason — машиночитаемая причина отказа в адресе назначения.
+type URLRejectReason string
+
+const (
+	// RejectSchemeNotAllowed — схема адреса не входит в разрешённый список.
+	RejectSchemeNotAllowed URLRejectReason = "SCHEME_NOT_ALLOWED"
+	// RejectUserInfo — адрес содержит имя пользователя или пароль.
+	RejectUserInfo URLRejectReason = "USERINFO_NOT_ALLOWED"
+	// RejectPrivateHost — адрес указывает на localhost или частную сеть.
+	RejectPrivateHost URLRejectReason = "PRIVATE_HOST"
+	// RejectSelfReference — адрес указывает на сам сервис и привёл бы к циклу редиректов.
+	RejectSelfReference URLRejectReason = "SELF_REFERENCE"
+	// RejectBlockedDomain — домен адреса заблокирован модерацией.
+	RejectBlockedDomain URLRejectReason = "BLOCKED_DOMAIN"
+	// RejectMaliciousURL — адрес признан вредоносным источником репутации.
+	RejectMaliciousURL URLRejectReason = "MALICIOUS_URL"
+)
+
+// URLRejectedError представляет отказ в адресе назначения по политике сервиса.
+// Оборачивает ErrInvalidURL, поэтому обрабатывается как некорректный URL.
+type URLRejectedError struct {
+	Reason URLRejectReason
+	Detail string
+}
+
+// Error реализует интерфейс error.
+func (e URLRejectedError) Error() string {
+	return "invalid URL: " + e.Detail
+}
+
+// Unwrap позволяет сопоставить отказ с ErrInvalidURL через errors.Is.
+func (e URLRejectedError) Unwrap() error {
+	return ErrInvalidURL
+}
+
+// URLBlockedError представляет переход по ссылке, домен назначения которой заблокирован.
+// Оборачивает ErrURLBlocked.
+type URLBlockedError struct {
+	// URL — адрес назначения, на который вёл бы переход.
+	URL string
+	// Rule — сработавшее правило блокировки.
+	Rule string
+}
+
+// Error реализует интерфейс error.
+func (e URLBlockedError) Error() string {
+	return "URL blocked by rule " + e.Rule
+}
+
+// Unwrap позволяет сопоставить блокировку с ErrURLBlocked через errors.Is.
+func (e URLBlockedError) Unwrap() error {
+	return ErrURLBlocked
+}
+
+// Limit — машиночитаемое название ограничения размера запроса.
+type Limit string
+
+const (
+	// LimitBodySize — размер тела запроса в байтах.
+	LimitBodySize Limit = "body_size"
+	// LimitURLLength — длина адреса назначения в байтах.
+	LimitURLLength Limit = "url_length"
+	// LimitBatchSize — число адресов в пакетном запросе.
+	LimitBatchSize Limit = "batch_size"
+)
+
+// LimitExceededError представляет превышение ограничения размера запроса.
+// Оборачивает ErrLimitExceeded.
+type LimitExceededError struct {
+	Limit Limit
+	// Max — наибольшее допустимое значение.
+	Max int64
+	// Actual — фактическое значение; 0, если оно неизвестно (например, тело дочитано не до конца).
+	Actual int64
+}
+
+// Error реализует интерфейс error.
+func (e LimitExceededError) Error() string {
+	if e.Actual > 0 {
+		return string(e.Limit) + " " + strconv.FormatInt(e.Actual, 10) + " exceeds limit " + strconv.FormatInt(e.Max, 10)
+	}
+	return string(e.Limit) + " exceeds limit " + strconv.FormatInt(e.Max, 10)
+}
+
+// Unwrap позволяет сопоставить превышение с ErrLimitExceeded через errors.Is.
+func (e LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
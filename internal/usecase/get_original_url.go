@@ -3,28 +3,223 @@ package usecase
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/model"
+	svc "github.com/avc-dev/url-shortener/internal/service"
 	"github.com/avc-dev/url-shortener/internal/store"
 	"go.uber.org/zap"
 )
 
-// GetOriginalURL получает оригинальный URL по короткому коду
-func (u *URLUsecase) GetOriginalURL(code string) (string, error) {
-	originalURL, err := u.repo.GetURLByCode(model.Code(code))
+// GetOriginalURL получает оригинальный URL по короткому коду для перехода
+// вместе с кодом ответа и сроком кэширования редиректа.
+// У ссылки с лимитом переходов каждый вызов расходует один переход.
+// Защищённая паролем ссылка открывается по действительному токену доступа
+// или верному паролю из access. Если включён предпросмотр небезопасных ссылок,
+// переход по помеченной ссылке без подтверждения возвращает ErrPreviewRequired;
+// подтверждением служат access.Confirmed или токен из PreviewURL в access.ConfirmToken.
+// Адрес назначения выбирается правилами условного редиректа ссылки по устройству,
+// языку и стране посетителя из visit, а query-параметры перехода передаются
+// в него по правилам ссылки. Переход по сплит-ссылке ведёт на вариант, закреплённый
+// за посетителем в visit.Variant, или на выбранный по весам; номер варианта
+// возвращается в model.Redirect. Переход на заблокированный домен возвращает URLBlockedError,
+// не расходуя переход.
+// Если включена проверка при переходе, переход на адрес, признанный вредоносным,
+// без подтверждения возвращает ErrPreviewRequired, также не расходуя переход.
+func (u *URLUsecase) GetOriginalURL(code string, access model.LinkAccess, visit model.Visit) (model.Redirect, error) {
+	unlocked, err := u.unlockLink(code, access)
+	if err != nil {
+		return model.Redirect{}, err
+	}
+	access.Confirmed = u.linkConfirmed(code, access)
+
+	if u.cfg.PreviewUnsafeLinks && !access.Confirmed {
+		info, err := u.repo.InspectURL(model.Code(code), unlocked)
+		if err != nil {
+			return model.Redirect{}, mapLookupError(err)
+		}
+		if info.Unsafe {
+			return model.Redirect{}, fmt.Errorf("%w: code %s", ErrPreviewRequired, code)
+		}
+	}
+
+	if u.blocklist.Len() > 0 || u.checksOnRedirect() {
+		visit, err = u.checkDestination(code, unlocked, access, visit)
+		if err != nil {
+			return model.Redirect{}, err
+		}
+	}
+
+	target, err := u.repo.FollowURL(model.Code(code), unlocked)
 	if err != nil {
 		u.logger.Error("failed to get URL by code",
 			zap.String("code", code),
 			zap.Error(err),
 		)
+		return model.Redirect{}, mapLookupError(err)
+	}
+
+	return u.redirectFor(code, target, visit), nil
+}
 
-		// Проверяем, не удалён ли URL
-		if errors.Is(err, store.ErrURLDeleted) {
-			return "", fmt.Errorf("%w: %w", ErrURLDeleted, err)
+// checkDestination проверяет адрес назначения до перехода, чтобы отказ не расходовал
+// переход у ссылки с лимитом. Вариант сплит-теста, выбранный при проверке, закрепляется
+// в возвращаемом visit, поэтому переход ведёт на проверенный адрес.
+func (u *URLUsecase) checkDestination(code string, unlocked bool, access model.LinkAccess, visit model.Visit) (model.Visit, error) {
+	target, err := u.repo.PeekURL(model.Code(code), unlocked)
+	if err != nil {
+		u.logger.Error("failed to get URL by code",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return visit, mapLookupError(err)
+	}
+
+	redirect := u.redirectFor(code, target, visit)
+	if err := u.checkBlocked(code, redirect.URL); err != nil {
+		return visit, err
+	}
+	if err := u.checkMaliciousRedirect(code, redirect.URL, access); err != nil {
+		return visit, err
+	}
+	if redirect.Variant > 0 {
+		visit.Variant = redirect.Variant
+	}
+	return visit, nil
+}
+
+// redirectFor выбирает код ответа и срок кэширования: параметры ссылки, если они заданы,
+// иначе значения по умолчанию из конфигурации. Ограниченные и помеченные небезопасными
+// ссылки не кэшируются: закэшированный редирект обошёл бы пароль, лимит переходов,
+// срок жизни или снятие ссылки модерацией. Не кэшируются и ссылки с правилами
+// или вариантами: адрес назначения у них зависит от посетителя.
+// Сработавшее правило важнее сплит-теста; вариант закрепляется за посетителем
+// по visit.Variant, а без него выбирается по весам. К адресу назначения дописываются
+// параметры перехода и метки UTM ссылки.
+func (u *URLUsecase) redirectFor(code string, target model.LinkTarget, visit model.Visit) model.Redirect {
+	destination := target.URL.String()
+	matched := false
+	if len(target.Rules) > 0 {
+		var rule model.RedirectRule
+		if rule, matched = svc.MatchRedirectRule(target.Rules, svc.NewRedirectClient(visit, u.geo)); matched {
+			destination = rule.URL
 		}
+	}
 
-		return "", fmt.Errorf("%w: %w", ErrURLNotFound, err)
+	variant := 0
+	if !matched && len(target.Variants) > 0 {
+		variant = svc.PickSplitVariant(target.Variants, visit.Variant, svc.SplitVisitorKey(code, visit))
+		if variant > 0 {
+			destination = target.Variants[variant-1].URL
+		}
 	}
 
-	return originalURL.String(), nil
+	redirect := model.Redirect{
+		URL:     applyQueryPolicy(destination, target.Query, visit.Query),
+		Status:  u.cfg.RedirectStatus,
+		MaxAge:  u.cfg.RedirectCacheMaxAge.Duration(),
+		Variant: variant,
+	}
+	if target.Redirect.Status != 0 {
+		redirect.Status = target.Redirect.Status
+	}
+	if target.Redirect.MaxAge != nil {
+		redirect.MaxAge = *target.Redirect.MaxAge
+	}
+	if target.Restricted || target.Unsafe || len(target.Rules) > 0 || len(target.Variants) > 0 {
+		redirect.MaxAge = 0
+	}
+	return redirect
+}
+
+// linkConfirmed сообщает, подтверждён ли переход по небезопасной ссылке:
+// флагом access.Confirmed или действительным токеном со страницы предпросмотра
+func (u *URLUsecase) linkConfirmed(code string, access model.LinkAccess) bool {
+	if access.Confirmed {
+		return true
+	}
+	return access.ConfirmToken != "" && u.linkAccess.ValidatePreviewConfirmToken(access.ConfirmToken, code)
+}
+
+// unlockLink проверяет подтверждение доступа к защищённой ссылке из access.
+// Возвращает false без ошибки, если ни токена, ни пароля не передано.
+func (u *URLUsecase) unlockLink(code string, access model.LinkAccess) (bool, error) {
+	if access.Token != "" && u.linkAccess.ValidateLinkAccessToken(access.Token, code) {
+		return true, nil
+	}
+	if access.Password == "" {
+		return false, nil
+	}
+	if err := u.verifyLinkPassword(code, access.Password); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UnlockURL проверяет пароль защищённой ссылки и возвращает подписанный токен доступа,
+// по которому GetOriginalURL открывает ссылку без повторного ввода пароля
+func (u *URLUsecase) UnlockURL(code, password string) (string, error) {
+	if err := u.verifyLinkPassword(code, password); err != nil {
+		return "", err
+	}
+
+	token, err := u.linkAccess.GenerateLinkAccessToken(code, u.cfg.LinkPassword.AccessTTL.Duration())
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	return token, nil
+}
+
+// verifyLinkPassword сверяет пароль с хешем ссылки. Неверные попытки считаются по коду,
+// поэтому перебор пароля одной ссылки блокируется независимо от адреса клиента.
+// Для ссылки без пароля проверка проходит успешно.
+func (u *URLUsecase) verifyLinkPassword(code, password string) error {
+	key := code
+	if u.cfg.CaseInsensitiveCodes {
+		key = strings.ToLower(code)
+	}
+
+	// Попытка учитывается до сравнения с хешем: иначе параллельные запросы успели бы
+	// пройти проверку лимита, пока медленное сравнение не записало ни одной неудачи
+	now := time.Now()
+	if !u.attempts.Reserve(key, now) {
+		return ErrTooManyAttempts
+	}
+
+	hash, err := u.repo.GetURLPasswordHash(model.Code(code))
+	if err != nil {
+		u.attempts.Release(key, now)
+		return mapLookupError(err)
+	}
+	if hash == "" {
+		u.attempts.Release(key, now)
+		return nil
+	}
+
+	if !svc.CheckLinkPassword(hash, password) {
+		u.logger.Info("invalid link password", zap.String("code", code))
+		return ErrInvalidPassword
+	}
+
+	u.attempts.Reset(key)
+	return nil
+}
+
+// mapLookupError переводит ошибку хранилища при поиске ссылки в ошибку usecase
+func mapLookupError(err error) error {
+	// Проверяем, не истёк ли срок жизни, не исчерпаны ли переходы и не удалён ли URL
+	switch {
+	case errors.Is(err, store.ErrURLExpired):
+		return fmt.Errorf("%w: %w", ErrURLExpired, err)
+	case errors.Is(err, store.ErrClickLimitReached):
+		return fmt.Errorf("%w: %w", ErrClickLimitReached, err)
+	case errors.Is(err, store.ErrPasswordRequired):
+		return fmt.Errorf("%w: %w", ErrPasswordRequired, err)
+	case errors.Is(err, store.ErrURLDeleted):
+		return fmt.Errorf("%w: %w", ErrURLDeleted, err)
+	default:
+		return fmt.Errorf("%w: %w", ErrURLNotFound, err)
+	}
 }
@@ -2,11 +2,15 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/config"
 	"github.com/avc-dev/url-shortener/internal/mocks"
 	"github.com/avc-dev/url-shortener/internal/model"
+	svc "github.com/avc-dev/url-shortener/internal/service"
+	"github.com/avc-dev/url-shortener/internal/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -59,18 +63,18 @@ func TestGetOriginalURL_Success(t *testing.T) {
 			cfg := config.NewDefaultConfig()
 
 			mockRepo.EXPECT().
-				GetURLByCode(model.Code(tt.code)).
-				Return(model.URL(tt.storedURL), nil).
+				FollowURL(model.Code(tt.code), false).
+				Return(model.LinkTarget{URL: model.URL(tt.storedURL)}, nil).
 				Once()
 
 			usecase := NewURLUsecase(mockRepo, mockService, cfg, zap.NewNop())
 
 			// Act
-			result, err := usecase.GetOriginalURL(tt.code)
+			result, err := usecase.GetOriginalURL(tt.code, model.LinkAccess{}, model.Visit{})
 
 			// Assert
 			require.NoError(t, err)
-			assert.Equal(t, tt.expectedURL, result)
+			assert.Equal(t, tt.expectedURL, result.URL)
 		})
 	}
 }
@@ -106,14 +110,14 @@ func TestGetOriginalURL_NotFound(t *testing.T) {
 			cfg := config.NewDefaultConfig()
 
 			mockRepo.EXPECT().
-				GetURLByCode(model.Code(tt.code)).
-				Return(model.URL(""), tt.repoError).
+				FollowURL(model.Code(tt.code), false).
+				Return(model.LinkTarget{}, tt.repoError).
 				Once()
 
 			usecase := NewURLUsecase(mockRepo, mockService, cfg, zap.NewNop())
 
 			// Act
-			result, err := usecase.GetOriginalURL(tt.code)
+			result, err := usecase.GetOriginalURL(tt.code, model.LinkAccess{}, model.Visit{})
 
 			// Assert
 			assert.ErrorIs(t, err, ErrURLNotFound)
@@ -129,14 +133,14 @@ func TestGetOriginalURL_EmptyCode(t *testing.T) {
 	cfg := config.NewDefaultConfig()
 
 	mockRepo.EXPECT().
-		GetURLByCode(model.Code("")).
-		Return(model.URL(""), errors.New("not found")).
+		FollowURL(model.Code(""), false).
+		Return(model.LinkTarget{}, errors.New("not found")).
 		Once()
 
 	usecase := NewURLUsecase(mockRepo, mockService, cfg, zap.NewNop())
 
 	// Act
-	result, err := usecase.GetOriginalURL("")
+	result, err := usecase.GetOriginalURL("", model.LinkAccess{}, model.Visit{})
 
 	// Assert
 	assert.ErrorIs(t, err, ErrURLNotFound)
@@ -189,18 +193,158 @@ func TestGetOriginalURL_SpecialCodes(t *testing.T) {
 			cfg := config.NewDefaultConfig()
 
 			mockRepo.EXPECT().
-				GetURLByCode(model.Code(tt.code)).
-				Return(model.URL(tt.storedURL), nil).
+				FollowURL(model.Code(tt.code), false).
+				Return(model.LinkTarget{URL: model.URL(tt.storedURL)}, nil).
 				Once()
 
 			usecase := NewURLUsecase(mockRepo, mockService, cfg, zap.NewNop())
 
 			// Act
-			result, err := usecase.GetOriginalURL(tt.code)
+			result, err := usecase.GetOriginalURL(tt.code, model.LinkAccess{}, model.Visit{})
 
 			// Assert
 			require.NoError(t, err)
-			assert.Equal(t, tt.storedURL, result)
+			assert.Equal(t, tt.storedURL, result.URL)
+		})
+	}
+}
+
+func TestGetOriginalURL_DeletedOrExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		repoError error
+		wantError error
+	}{
+		{name: "Deleted link", repoError: fmt.Errorf("read: %w", store.ErrURLDeleted), wantError: ErrURLDeleted},
+		{name: "Expired link", repoError: fmt.Errorf("read: %w", store.ErrURLExpired), wantError: ErrURLExpired},
+		{name: "Click limit reached", repoError: fmt.Errorf("follow: %w", store.ErrClickLimitReached), wantError: ErrClickLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			mockRepo.EXPECT().
+				FollowURL(model.Code("abc123"), false).
+				Return(model.LinkTarget{}, tt.repoError).
+				Once()
+
+			usecase := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+			result, err := usecase.GetOriginalURL("abc123", model.LinkAccess{}, model.Visit{})
+
+			assert.ErrorIs(t, err, tt.wantError)
+			assert.Empty(t, result)
+		})
+	}
+}
+
+func TestGetOriginalURL_PasswordProtected(t *testing.T) {
+	hash, err := svc.HashLinkPassword("secret")
+	require.NoError(t, err)
+
+	t.Run("Without password", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().
+			FollowURL(model.Code("locked"), false).
+			Return(model.LinkTarget{}, fmt.Errorf("follow: %w", store.ErrPasswordRequired)).
+			Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		_, err := uc.GetOriginalURL("locked", model.LinkAccess{}, model.Visit{})
+		assert.ErrorIs(t, err, ErrPasswordRequired)
+	})
+
+	t.Run("Correct password unlocks", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().GetURLPasswordHash(model.Code("locked")).Return(hash, nil).Once()
+		mockRepo.EXPECT().FollowURL(model.Code("locked"), true).Return(model.LinkTarget{URL: model.URL("https://example.com")}, nil).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		result, err := uc.GetOriginalURL("locked", model.LinkAccess{Password: "secret"}, model.Visit{})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", result.URL)
+	})
+
+	t.Run("Access token unlocks", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().GetURLPasswordHash(model.Code("locked")).Return(hash, nil).Once()
+		mockRepo.EXPECT().FollowURL(model.Code("locked"), true).Return(model.LinkTarget{URL: model.URL("https://example.com")}, nil).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		token, err := uc.UnlockURL("locked", "secret")
+		require.NoError(t, err)
+
+		result, err := uc.GetOriginalURL("locked", model.LinkAccess{Token: token}, model.Visit{})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", result.URL)
+	})
+
+	t.Run("Wrong passwords are throttled per code", func(t *testing.T) {
+		cfg := config.NewDefaultConfig()
+		cfg.LinkPassword.MaxAttempts = 2
+
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().GetURLPasswordHash(model.Code("locked")).Return(hash, nil).Times(2)
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+
+		for range 2 {
+			_, err := uc.GetOriginalURL("locked", model.LinkAccess{Password: "guess"}, model.Visit{})
+			assert.ErrorIs(t, err, ErrInvalidPassword)
+		}
+
+		// Даже верный пароль отклоняется до конца окна, а хеш больше не проверяется
+		_, err := uc.UnlockURL("locked", "secret")
+		assert.ErrorIs(t, err, ErrTooManyAttempts)
+	})
+}
+
+func TestGetOriginalURL_RedirectPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		target model.LinkTarget
+		want   model.Redirect
+	}{
+		{
+			name:   "Deployment defaults",
+			target: model.LinkTarget{URL: "https://example.com"},
+			want:   model.Redirect{URL: "https://example.com", Status: 302, MaxAge: time.Hour},
+		},
+		{
+			name: "Link settings override defaults",
+			target: model.LinkTarget{URL: "https://example.com",
+				Redirect: model.RedirectPolicy{Status: 301, MaxAge: durationPtr(0)}},
+			want: model.Redirect{URL: "https://example.com", Status: 301},
+		},
+		{
+			name: "Restricted link is not cached",
+			target: model.LinkTarget{URL: "https://example.com", Restricted: true,
+				Redirect: model.RedirectPolicy{MaxAge: durationPtr(time.Minute)}},
+			want: model.Redirect{URL: "https://example.com", Status: 302},
+		},
+		{
+			name:   "Unsafe link is not cached",
+			target: model.LinkTarget{URL: "https://example.com", Unsafe: true},
+			want:   model.Redirect{URL: "https://example.com", Status: 302},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			mockRepo.EXPECT().FollowURL(model.Code("abc"), false).Return(tt.target, nil).Once()
+			cfg := config.NewDefaultConfig()
+			cfg.RedirectStatus = 302
+			cfg.RedirectCacheMaxAge = config.Duration(time.Hour)
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+			defer uc.Close()
+
+			redirect, err := uc.GetOriginalURL("abc", model.LinkAccess{}, model.Visit{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, redirect)
 		})
 	}
 }
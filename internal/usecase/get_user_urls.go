@@ -1,25 +1,152 @@
 package usecase
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/model"
 	"go.uber.org/zap"
 )
 
-// GetURLsByUserID возвращает все URL для указанного пользователя
-func (u *URLUsecase) GetURLsByUserID(userID string) ([]model.UserURLResponse, error) {
+const (
+	// defaultPageSize — размер страницы списка ссылок, если клиент передал токен страницы
+	// без её размера; без размера и токена список возвращается целиком
+	defaultPageSize = 100
+	// maxPageSize — максимальный размер страницы списка ссылок
+	maxPageSize = 1000
+)
+
+// GetURLsByUserID возвращает страницу URL указанного пользователя.
+// Ссылки отбираются по тегу, папке и подстроке адреса и сортируются по полю запроса;
+// следующая страница запрашивается с токеном NextPageToken и той же сортировкой.
+// Без размера страницы и токена возвращается весь список.
+func (u *URLUsecase) GetURLsByUserID(userID string, request model.URLListRequest) (model.URLList, error) {
 	u.logger.Info("GetURLsByUserID called", zap.String("user_id", userID))
 
-	urls, err := u.repo.GetURLsByUserID(userID, u.cfg.BaseURL.String())
+	query, err := parseURLListRequest(request)
+	if err != nil {
+		return model.URLList{}, err
+	}
+
+	page, err := u.repo.GetURLsByUserID(userID, u.cfg.BaseURL.String(), query)
 	if err != nil {
 		u.logger.Error("failed to get URLs by user ID",
 			zap.String("user_id", userID),
 			zap.Error(err),
 		)
-		return nil, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+		return model.URLList{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	list := model.URLList{URLs: page.URLs}
+	if page.Next != nil {
+		list.NextPageToken = encodePageToken(query, *page.Next)
+	}
+
+	u.logger.Info("GetURLsByUserID result", zap.Int("urls_count", len(list.URLs)))
+	return list, nil
+}
+
+// parseURLListRequest проверяет параметры списка ссылок и переводит их в запрос к хранилищу
+func parseURLListRequest(request model.URLListRequest) (model.URLQuery, error) {
+	filter, err := normalizeFilter(request.Filter)
+	if err != nil {
+		return model.URLQuery{}, err
+	}
+
+	sort := model.URLSort(request.Sort)
+	if !sort.IsValid() {
+		return model.URLQuery{}, fmt.Errorf("%w: unknown sort %q", ErrInvalidOptions, request.Sort)
+	}
+	if sort == "" {
+		sort = model.URLSortCreated
+	}
+
+	var desc bool
+	switch request.Order {
+	case "":
+		desc = sort != model.URLSortURL
+	case "asc":
+	case "desc":
+		desc = true
+	default:
+		return model.URLQuery{}, fmt.Errorf("%w: unknown order %q", ErrInvalidOptions, request.Order)
+	}
+
+	limit := request.PageSize
+	switch {
+	case limit < 0 || limit > maxPageSize:
+		return model.URLQuery{}, fmt.Errorf("%w: page size must be between 1 and %d", ErrInvalidOptions, maxPageSize)
+	case limit == 0 && request.PageToken != "":
+		limit = defaultPageSize
 	}
 
-	u.logger.Info("GetURLsByUserID result", zap.Int("urls_count", len(urls)))
-	return urls, nil
+	query := model.URLQuery{
+		Filter: filter,
+		Search: request.Search,
+		Sort:   sort,
+		Desc:   desc,
+		Limit:  limit,
+	}
+	if request.PageToken != "" {
+		after, err := decodePageToken(request.PageToken, sort, desc)
+		if err != nil {
+			return model.URLQuery{}, err
+		}
+		query.After = &after
+	}
+
+	return query, nil
+}
+
+// pageToken — содержимое токена страницы: сортировка, для которой он выдан,
+// и позиция последней ссылки предыдущей страницы
+type pageToken struct {
+	Sort      model.URLSort `json:"s"`
+	Desc      bool          `json:"d,omitempty"`
+	CreatedAt *time.Time    `json:"t,omitempty"`
+	Clicks    int64         `json:"n,omitempty"`
+	URL       string        `json:"u,omitempty"`
+	Code      model.Code    `json:"c"`
+}
+
+// encodePageToken кодирует курсор в непрозрачный токен, пригодный для query-параметра
+func encodePageToken(query model.URLQuery, cursor model.URLCursor) string {
+	token := pageToken{Sort: query.Sort, Desc: query.Desc, Code: cursor.Code}
+	switch query.Sort {
+	case model.URLSortClicks:
+		token.Clicks = cursor.Clicks
+	case model.URLSortURL:
+		token.URL = cursor.URL
+	default:
+		token.CreatedAt = &cursor.CreatedAt
+	}
+
+	// Маршалинг структуры из строк, чисел и времени не может завершиться ошибкой
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken разбирает токен страницы. Токен действителен только для той сортировки,
+// с которой он выдан: при другой сортировке курсор указывал бы на случайную позицию.
+func decodePageToken(encoded string, sort model.URLSort, desc bool) (model.URLCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return model.URLCursor{}, fmt.Errorf("%w: malformed page token", ErrInvalidOptions)
+	}
+
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil || token.Code == "" {
+		return model.URLCursor{}, fmt.Errorf("%w: malformed page token", ErrInvalidOptions)
+	}
+	if token.Sort != sort || token.Desc != desc {
+		return model.URLCursor{}, fmt.Errorf("%w: page token was issued for another sort order", ErrInvalidOptions)
+	}
+
+	cursor := model.URLCursor{Clicks: token.Clicks, URL: token.URL, Code: token.Code}
+	if token.CreatedAt != nil {
+		cursor.CreatedAt = *token.CreatedAt
+	}
+	return cursor, nil
 }
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseURLListRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     model.URLListRequest
+		want        model.URLQuery
+		expectedErr error
+	}{
+		{
+			name:    "Defaults to whole list, newest first",
+			request: model.URLListRequest{},
+			want:    model.URLQuery{Sort: model.URLSortCreated, Desc: true},
+		},
+		{
+			name:    "URL sort defaults to ascending",
+			request: model.URLListRequest{Sort: "url", PageSize: 10},
+			want:    model.URLQuery{Sort: model.URLSortURL, Limit: 10},
+		},
+		{
+			name: "Explicit order, filter and search",
+			request: model.URLListRequest{
+				Filter: model.URLFilter{Tag: " Work "},
+				Search: "docs",
+				Sort:   "clicks",
+				Order:  "asc",
+			},
+			want: model.URLQuery{
+				Filter: model.URLFilter{Tag: "work"},
+				Search: "docs",
+				Sort:   model.URLSortClicks,
+			},
+		},
+		{
+			name:        "Unknown sort",
+			request:     model.URLListRequest{Sort: "title"},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Unknown order",
+			request:     model.URLListRequest{Order: "up"},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Page size too large",
+			request:     model.URLListRequest{PageSize: maxPageSize + 1},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Negative page size",
+			request:     model.URLListRequest{PageSize: -1},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name: "Page token without size uses default page size",
+			request: model.URLListRequest{
+				PageToken: encodePageToken(model.URLQuery{Sort: model.URLSortCreated, Desc: true}, model.URLCursor{Code: "abc"}),
+			},
+			want: model.URLQuery{
+				Sort:  model.URLSortCreated,
+				Desc:  true,
+				Limit: defaultPageSize,
+				After: &model.URLCursor{Code: "abc"},
+			},
+		},
+		{
+			name:        "Malformed page token",
+			request:     model.URLListRequest{PageToken: "!!!"},
+			expectedErr: ErrInvalidOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := parseURLListRequest(tt.request)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, query)
+		})
+	}
+}
+
+func TestPageToken(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cursor := model.URLCursor{CreatedAt: createdAt, Clicks: 7, URL: "https://example.com", Code: "abc"}
+
+	t.Run("Round trip keeps the sort key", func(t *testing.T) {
+		token := encodePageToken(model.URLQuery{Sort: model.URLSortCreated, Desc: true}, cursor)
+
+		decoded, err := decodePageToken(token, model.URLSortCreated, true)
+		require.NoError(t, err)
+		assert.Equal(t, model.URLCursor{CreatedAt: createdAt, Code: "abc"}, decoded)
+	})
+
+	t.Run("Token is bound to its sort", func(t *testing.T) {
+		token := encodePageToken(model.URLQuery{Sort: model.URLSortClicks, Desc: true}, cursor)
+
+		_, err := decodePageToken(token, model.URLSortClicks, false)
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+		_, err = decodePageToken(token, model.URLSortURL, true)
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+}
+
+func TestGetURLsByUserID(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+	defer uc.Close()
+
+	first := []model.UserURLResponse{{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://a.com"}}
+	mockRepo.EXPECT().GetURLsByUserID("user-1", "http://localhost:8080/",
+		model.URLQuery{Sort: model.URLSortURL, Limit: 1}).
+		Return(model.URLPage{URLs: first, Next: &model.URLCursor{URL: "https://a.com", Code: "abc"}}, nil).Once()
+
+	list, err := uc.GetURLsByUserID("user-1", model.URLListRequest{Sort: "url", PageSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, first, list.URLs)
+	require.NotEmpty(t, list.NextPageToken)
+
+	// Токен следующей страницы превращается обратно в курсор запроса к хранилищу
+	mockRepo.EXPECT().GetURLsByUserID("user-1", "http://localhost:8080/",
+		model.URLQuery{Sort: model.URLSortURL, Limit: 1, After: &model.URLCursor{URL: "https://a.com", Code: "abc"}}).
+		Return(model.URLPage{}, nil).Once()
+
+	list, err = uc.GetURLsByUserID("user-1", model.URLListRequest{Sort: "url", PageSize: 1, PageToken: list.NextPageToken})
+	require.NoError(t, err)
+	assert.Empty(t, list.URLs)
+	assert.Empty(t, list.NextPageToken)
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetURLsByUserID("user-2", "http://localhost:8080/",
+			model.URLQuery{Sort: model.URLSortCreated, Desc: true}).
+			Return(model.URLPage{}, errors.New("db down")).Once()
+
+		_, err := uc.GetURLsByUserID("user-2", model.URLListRequest{})
+		assert.ErrorIs(t, err, ErrServiceUnavailable)
+	})
+}
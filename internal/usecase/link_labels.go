@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxLinkTags — максимальное число тегов одной ссылки
+	maxLinkTags = 20
+	// maxLabelLength — максимальная длина тега или названия папки в символах
+	maxLabelLength = 64
+)
+
+// SetURLLabels заменяет теги и папку ссылки пользователя и возвращает сохранённые метки.
+// Пустые метки снимают с ссылки все теги и убирают её из папки.
+// Для чужой ссылки возвращается ErrURLNotFound, чтобы не раскрывать существование кода.
+func (u *URLUsecase) SetURLLabels(code string, labels model.LinkLabels, userID string) (model.LinkLabels, error) {
+	labels, err := normalizeLabels(labels)
+	if err != nil {
+		return model.LinkLabels{}, err
+	}
+
+	if err := u.repo.SetURLLabels(model.Code(code), labels, userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrURLExpired) ||
+			errors.Is(err, store.ErrURLDeleted) {
+			return model.LinkLabels{}, mapLookupError(err)
+		}
+		u.logger.Error("failed to set URL labels",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return model.LinkLabels{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	return labels, nil
+}
+
+// DeleteURLsByFilter удаляет ссылки пользователя с заданным тегом или в заданной папке
+// и возвращает коды удалённых ссылок. Фильтр обязателен: удалить все ссылки разом нельзя.
+func (u *URLUsecase) DeleteURLsByFilter(filter model.URLFilter, userID string) ([]string, error) {
+	filter, err := normalizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.IsZero() {
+		return nil, fmt.Errorf("%w: tag or folder is required", ErrInvalidOptions)
+	}
+
+	deleted, err := u.repo.DeleteURLsByFilter(userID, filter)
+	if err != nil {
+		u.logger.Error("failed to delete URLs by filter",
+			zap.String("user_id", userID),
+			zap.String("tag", filter.Tag),
+			zap.String("folder", filter.Folder),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	codes := make([]string, len(deleted))
+	for i, code := range deleted {
+		codes[i] = string(code)
+	}
+
+	u.logger.Info("URLs deleted by filter",
+		zap.String("user_id", userID),
+		zap.String("tag", filter.Tag),
+		zap.String("folder", filter.Folder),
+		zap.Int("deleted", len(codes)),
+	)
+	return codes, nil
+}
+
+// normalizeLabels проверяет метки и приводит их к каноническому виду: теги обрезаются
+// по краям, переводятся в нижний регистр, сортируются и избавляются от повторов;
+// название папки обрезается по краям, регистр сохраняется
+func normalizeLabels(labels model.LinkLabels) (model.LinkLabels, error) {
+	var tags []string
+	for _, tag := range labels.Tags {
+		tag, err := normalizeLabel("tag", strings.ToLower(tag))
+		if err != nil {
+			return model.LinkLabels{}, err
+		}
+		if tag == "" {
+			return model.LinkLabels{}, fmt.Errorf("%w: tag must not be empty", ErrInvalidOptions)
+		}
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+	if len(tags) > maxLinkTags {
+		return model.LinkLabels{}, fmt.Errorf("%w: at most %d tags per link", ErrInvalidOptions, maxLinkTags)
+	}
+
+	folder, err := normalizeLabel("folder", labels.Folder)
+	if err != nil {
+		return model.LinkLabels{}, err
+	}
+
+	return model.LinkLabels{Tags: tags, Folder: folder}, nil
+}
+
+// normalizeFilter приводит фильтр к тому же виду, в котором хранятся метки
+func normalizeFilter(filter model.URLFilter) (model.URLFilter, error) {
+	tag, err := normalizeLabel("tag", strings.ToLower(filter.Tag))
+	if err != nil {
+		return model.URLFilter{}, err
+	}
+	folder, err := normalizeLabel("folder", filter.Folder)
+	if err != nil {
+		return model.URLFilter{}, err
+	}
+	return model.URLFilter{Tag: tag, Folder: folder}, nil
+}
+
+// normalizeLabel обрезает метку по краям и проверяет её длину
+func normalizeLabel(kind, label string) (string, error) {
+	label = strings.TrimSpace(label)
+	if utf8.RuneCountInString(label) > maxLabelLength {
+		return "", fmt.Errorf("%w: %s longer than %d characters", ErrInvalidOptions, kind, maxLabelLength)
+	}
+	return label, nil
+}
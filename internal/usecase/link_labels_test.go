@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNormalizeLabels(t *testing.T) {
+	tooMany := make([]string, maxLinkTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag%d", i)
+	}
+
+	tests := []struct {
+		name        string
+		labels      model.LinkLabels
+		want        model.LinkLabels
+		expectedErr error
+	}{
+		{
+			name:   "Empty labels",
+			labels: model.LinkLabels{},
+			want:   model.LinkLabels{},
+		},
+		{
+			name:   "Tags are lowercased, sorted and deduplicated",
+			labels: model.LinkLabels{Tags: []string{" Work ", "docs", "work"}, Folder: "  My Projects "},
+			want:   model.LinkLabels{Tags: []string{"docs", "work"}, Folder: "My Projects"},
+		},
+		{
+			name:        "Empty tag",
+			labels:      model.LinkLabels{Tags: []string{"  "}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Too long tag",
+			labels:      model.LinkLabels{Tags: []string{strings.Repeat("a", maxLabelLength+1)}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Too long folder",
+			labels:      model.LinkLabels{Folder: strings.Repeat("п", maxLabelLength+1)},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Too many tags",
+			labels:      model.LinkLabels{Tags: tooMany},
+			expectedErr: ErrInvalidOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeLabels(tt.labels)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetURLLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(m *mocks.MockURLRepository)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().SetURLLabels(model.Code("abc"),
+					model.LinkLabels{Tags: []string{"work"}, Folder: "Projects"}, "user-1").Return(nil).Once()
+			},
+		},
+		{
+			name: "Foreign link",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().SetURLLabels(model.Code("abc"),
+					model.LinkLabels{Tags: []string{"work"}, Folder: "Projects"}, "user-1").
+					Return(fmt.Errorf("set: %w", store.ErrNotFound)).Once()
+			},
+			expectedErr: ErrURLNotFound,
+		},
+		{
+			name: "Repository error",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().SetURLLabels(model.Code("abc"),
+					model.LinkLabels{Tags: []string{"work"}, Folder: "Projects"}, "user-1").
+					Return(errors.New("db down")).Once()
+			},
+			expectedErr: ErrServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			tt.setupMock(mockRepo)
+
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+			labels, err := uc.SetURLLabels("abc", model.LinkLabels{Tags: []string{"Work"}, Folder: "Projects"}, "user-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"work"}, labels.Tags)
+		})
+	}
+}
+
+func TestDeleteURLsByFilter(t *testing.T) {
+	t.Run("Deletes matching links", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().DeleteURLsByFilter("user-1", model.URLFilter{Tag: "work"}).
+			Return([]model.Code{"abc", "def"}, nil).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		deleted, err := uc.DeleteURLsByFilter(model.URLFilter{Tag: " Work "}, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"abc", "def"}, deleted)
+	})
+
+	t.Run("Filter is required", func(t *testing.T) {
+		uc := NewURLUsecase(mocks.NewMockURLRepository(t), mocks.NewMockURLService(t),
+			config.NewDefaultConfig(), zap.NewNop())
+
+		_, err := uc.DeleteURLsByFilter(model.URLFilter{Tag: "  "}, "user-1")
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"go.uber.org/zap"
+)
+
+// previewConfirmTTL — срок действия токена подтверждения перехода со страницы предпросмотра
+const previewConfirmTTL = 10 * time.Minute
+
+// PreviewURL возвращает сведения о ссылке для показа вместо перехода:
+// адрес назначения, время создания и число переходов. Переход не расходуется
+// и не учитывается в статистике. Защищённая паролем ссылка показывается,
+// как и при переходе, только по токену доступа или верному паролю из access.
+// Для ссылки на заблокированный домен возвращается URLBlockedError. Если включена
+// проверка при переходе, ссылка на адрес, признанный вредоносным, показывается небезопасной.
+// Для небезопасной ссылки возвращается токен, которым посетитель подтверждает переход.
+func (u *URLUsecase) PreviewURL(code string, access model.LinkAccess) (model.URLPreview, error) {
+	unlocked, err := u.unlockLink(code, access)
+	if err != nil {
+		return model.URLPreview{}, err
+	}
+
+	info, err := u.repo.InspectURL(model.Code(code), unlocked)
+	if err != nil {
+		u.logger.Debug("failed to inspect URL",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return model.URLPreview{}, mapLookupError(err)
+	}
+	if err := u.checkBlocked(code, info.URL.String()); err != nil {
+		return model.URLPreview{}, err
+	}
+
+	shortURL, err := url.JoinPath(u.cfg.BaseURL.String(), string(info.Code))
+	if err != nil {
+		return model.URLPreview{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	preview := model.URLPreview{
+		ShortURL:    shortURL,
+		OriginalURL: info.URL.String(),
+		Clicks:      info.Clicks,
+		Unsafe:      info.Unsafe || u.maliciousDestination(code, info.URL.String()),
+	}
+	if !info.CreatedAt.IsZero() {
+		preview.CreatedAt = &info.CreatedAt
+	}
+	if preview.Unsafe {
+		preview.ConfirmToken, err = u.linkAccess.GeneratePreviewConfirmToken(code, previewConfirmTTL)
+		if err != nil {
+			return model.URLPreview{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+		}
+	}
+	return preview, nil
+}
+
+// SetURLUnsafe помечает ссылку небезопасной или снимает пометку.
+// Метод предназначен для модерации и не проверяет владельца ссылки.
+func (u *URLUsecase) SetURLUnsafe(code string, unsafe bool) error {
+	if err := u.repo.SetURLUnsafe(model.Code(code), unsafe); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return fmt.Errorf("%w: %w", ErrURLNotFound, err)
+		}
+		u.logger.Error("failed to set unsafe flag",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	u.logger.Info("link unsafe flag changed", zap.String("code", code), zap.Bool("unsafe", unsafe))
+	return nil
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPreviewURL(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMock   func(m *mocks.MockURLRepository)
+		want        model.URLPreview
+		expectedErr error
+	}{
+		{
+			name: "Returns details without following",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().InspectURL(model.Code("abc"), false).
+					Return(model.LinkInfo{Code: "abc", URL: "https://example.com", CreatedAt: createdAt, Clicks: 3, Unsafe: true}, nil).Once()
+			},
+			want: model.URLPreview{
+				ShortURL:    "http://localhost:8080/abc",
+				OriginalURL: "https://example.com",
+				CreatedAt:   &createdAt,
+				Clicks:      3,
+				Unsafe:      true,
+			},
+		},
+		{
+			name: "Unknown creation time is omitted",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().InspectURL(model.Code("abc"), false).
+					Return(model.LinkInfo{Code: "abc", URL: "https://example.com"}, nil).Once()
+			},
+			want: model.URLPreview{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://example.com"},
+		},
+		{
+			name: "Password-protected link",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().InspectURL(model.Code("abc"), false).
+					Return(model.LinkInfo{}, fmt.Errorf("inspect: %w", store.ErrPasswordRequired)).Once()
+			},
+			expectedErr: ErrPasswordRequired,
+		},
+		{
+			name: "Exhausted link",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().InspectURL(model.Code("abc"), false).
+					Return(model.LinkInfo{}, fmt.Errorf("inspect: %w", store.ErrClickLimitReached)).Once()
+			},
+			expectedErr: ErrClickLimitReached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			tt.setupMock(mockRepo)
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+			defer uc.Close()
+
+			preview, err := uc.PreviewURL("abc", model.LinkAccess{})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			// Токен подтверждения выдаётся только для небезопасной ссылки
+			assert.Equal(t, tt.want.Unsafe, preview.ConfirmToken != "")
+			preview.ConfirmToken = ""
+			assert.Equal(t, tt.want, preview)
+		})
+	}
+}
+
+// TestGetOriginalURL_PreviewConfirmToken проверяет, что переход по небезопасной ссылке
+// подтверждает только токен, выданный вместе с её предпросмотром
+func TestGetOriginalURL_PreviewConfirmToken(t *testing.T) {
+	unsafe := model.LinkInfo{Code: "abc", URL: "https://example.com", Unsafe: true}
+
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().InspectURL(model.Code("abc"), false).Return(unsafe, nil)
+	mockRepo.EXPECT().InspectURL(model.Code("other"), false).
+		Return(model.LinkInfo{Code: "other", URL: "https://example.com", Unsafe: true}, nil)
+	mockRepo.EXPECT().FollowURL(model.Code("abc"), false).Return(model.LinkTarget{URL: "https://example.com"}, nil).Once()
+
+	cfg := config.NewDefaultConfig()
+	cfg.PreviewUnsafeLinks = true
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+	defer uc.Close()
+
+	preview, err := uc.PreviewURL("abc", model.LinkAccess{})
+	require.NoError(t, err)
+	require.NotEmpty(t, preview.ConfirmToken)
+
+	_, err = uc.GetOriginalURL("other", model.LinkAccess{ConfirmToken: preview.ConfirmToken}, model.Visit{})
+	assert.ErrorIs(t, err, ErrPreviewRequired, "token is bound to its link")
+
+	_, err = uc.GetOriginalURL("abc", model.LinkAccess{ConfirmToken: "forged"}, model.Visit{})
+	assert.ErrorIs(t, err, ErrPreviewRequired)
+
+	redirect, err := uc.GetOriginalURL("abc", model.LinkAccess{ConfirmToken: preview.ConfirmToken}, model.Visit{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", redirect.URL)
+}
+
+func TestGetOriginalURL_PreviewUnsafeLinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		access      model.LinkAccess
+		setupMock   func(m *mocks.MockURLRepository)
+		expectedErr error
+	}{
+		{
+			name: "Unsafe link requires preview",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().InspectURL(model.Code("abc"), false).
+					Return(model.LinkInfo{Code: "abc", URL: "https://example.com", Unsafe: true}, nil).Once()
+			},
+			expectedErr: ErrPreviewRequired,
+		},
+		{
+			name: "Safe link is followed",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().InspectURL(model.Code("abc"), false).
+					Return(model.LinkInfo{Code: "abc", URL: "https://example.com"}, nil).Once()
+				m.EXPECT().FollowURL(model.Code("abc"), false).Return(model.LinkTarget{URL: "https://example.com"}, nil).Once()
+			},
+		},
+		{
+			name:   "Confirmed visit skips the check",
+			access: model.LinkAccess{Confirmed: true},
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().FollowURL(model.Code("abc"), false).Return(model.LinkTarget{URL: "https://example.com"}, nil).Once()
+			},
+		},
+		{
+			name: "Lookup error",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().InspectURL(model.Code("abc"), false).
+					Return(model.LinkInfo{}, fmt.Errorf("inspect: %w", store.ErrNotFound)).Once()
+			},
+			expectedErr: ErrURLNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			tt.setupMock(mockRepo)
+			cfg := config.NewDefaultConfig()
+			cfg.PreviewUnsafeLinks = true
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+			defer uc.Close()
+
+			originalURL, err := uc.GetOriginalURL("abc", tt.access, model.Visit{})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com", originalURL.URL)
+		})
+	}
+}
+
+func TestSetURLUnsafe(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "Success"},
+		{name: "Unknown code", repoErr: fmt.Errorf("set: %w", store.ErrNotFound), expectedErr: ErrURLNotFound},
+		{name: "Storage failure", repoErr: errors.New("db down"), expectedErr: ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			mockRepo.EXPECT().SetURLUnsafe(model.Code("abc"), true).Return(tt.repoErr).Once()
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+			defer uc.Close()
+
+			err := uc.SetURLUnsafe("abc", true)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PurgeDeletedURLs окончательно удаляет ссылки, мягко удалённые дольше Purge.Retention назад,
+// и возвращает количество удалённых ссылок. При включённом переиспользовании кодов
+// освободившиеся коды помещаются в пул с карантином CodeRecycling.Quarantine.
+//
+// Если ссылки удалены, а коды не попали в пул (например, из-за ошибки БД),
+// коды просто не будут переиспользованы — это безопасно.
+func (u *URLUsecase) PurgeDeletedURLs() (int, error) {
+	retention := u.cfg.Purge.Retention.Duration()
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	// wg позволяет Close() дождаться очистки, запущенной до остановки приложения
+	u.wg.Add(1)
+	defer u.wg.Done()
+
+	now := time.Now()
+	codes, err := u.repo.PurgeDeletedURLs(now.Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted URLs: %w", err)
+	}
+	if len(codes) == 0 {
+		return 0, nil
+	}
+
+	if u.cfg.CodeRecycling.Enabled {
+		availableAt := now.Add(u.cfg.CodeRecycling.Quarantine.Duration())
+		if err := u.repo.ReleaseCodes(codes, availableAt); err != nil {
+			return len(codes), fmt.Errorf("failed to release purged codes: %w", err)
+		}
+		u.logger.Info("purged codes released for recycling",
+			zap.Int("count", len(codes)),
+			zap.Time("availableAt", availableAt),
+		)
+	}
+
+	return len(codes), nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestPurgeDeletedURLs_Disabled проверяет, что без срока хранения очистка не выполняется
+func TestPurgeDeletedURLs_Disabled(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	usecase := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), &config.Config{}, zap.NewNop())
+
+	purged, err := usecase.PurgeDeletedURLs()
+
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+}
+
+// TestPurgeDeletedURLs_Recycling проверяет передачу освободившихся кодов в пул с карантином
+func TestPurgeDeletedURLs_Recycling(t *testing.T) {
+	retention := 24 * time.Hour
+	quarantine := 30 * 24 * time.Hour
+	codes := []model.Code{"abc123", "def456"}
+
+	tests := []struct {
+		name        string
+		recycling   bool
+		releaseErr  error
+		wantRelease bool
+		wantErr     bool
+	}{
+		{name: "Recycling disabled", recycling: false},
+		{name: "Recycling enabled", recycling: true, wantRelease: true},
+		{name: "Release error", recycling: true, releaseErr: errors.New("db error"), wantRelease: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			cfg := &config.Config{
+				Purge:         config.PurgeConfig{Retention: config.Duration(retention)},
+				CodeRecycling: config.CodeRecyclingConfig{Enabled: tt.recycling, Quarantine: config.Duration(quarantine)},
+			}
+			usecase := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+			start := time.Now()
+
+			mockRepo.EXPECT().
+				PurgeDeletedURLs(mock.MatchedBy(func(before time.Time) bool {
+					return !before.Before(start.Add(-retention))
+				})).
+				Return(codes, nil).
+				Once()
+			if tt.wantRelease {
+				mockRepo.EXPECT().
+					ReleaseCodes(codes, mock.MatchedBy(func(availableAt time.Time) bool {
+						return !availableAt.Before(start.Add(quarantine))
+					})).
+					Return(tt.releaseErr).
+					Once()
+			}
+
+			purged, err := usecase.PurgeDeletedURLs()
+
+			assert.Equal(t, len(codes), purged)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestPurgeDeletedURLs_RepositoryError проверяет обработку ошибки очистки
+func TestPurgeDeletedURLs_RepositoryError(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	cfg := &config.Config{Purge: config.PurgeConfig{Retention: config.Duration(time.Hour)}}
+	usecase := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+
+	mockRepo.EXPECT().
+		PurgeDeletedURLs(mock.Anything).
+		Return(nil, errors.New("db error")).
+		Once()
+
+	purged, err := usecase.PurgeDeletedURLs()
+
+	require.Error(t, err)
+	assert.Zero(t, purged)
+}
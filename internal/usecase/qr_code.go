@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/qrcode"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultQRSize — ширина изображения QR-кода по умолчанию в пикселях
+	defaultQRSize = 256
+	// maxQRSize — максимальная ширина изображения QR-кода в пикселях
+	maxQRSize = 2048
+	// defaultQRMargin — светлое поле вокруг QR-кода по умолчанию; стандарт требует не меньше 4 модулей
+	defaultQRMargin = 4
+	// maxQRMargin — максимальная ширина поля в модулях
+	maxQRMargin = 16
+	// defaultQRLevel — уровень коррекции ошибок по умолчанию
+	defaultQRLevel = qrcode.LevelM
+)
+
+// GetQRCode возвращает QR-код полного короткого URL ссылки в формате PNG или SVG.
+// Код строится для любой ссылки, по которой можно перейти; изображение зависит только
+// от короткого URL и параметров, поэтому ETag стабилен между запросами.
+func (u *URLUsecase) GetQRCode(code string, opts model.QROptions) (model.QRImage, error) {
+	format, size, level, margin, err := parseQROptions(opts)
+	if err != nil {
+		return model.QRImage{}, err
+	}
+
+	if _, err := u.repo.GetURLByCode(model.Code(code)); err != nil {
+		return model.QRImage{}, mapLookupError(err)
+	}
+
+	shortURL, err := url.JoinPath(u.cfg.BaseURL.String(), code)
+	if err != nil {
+		return model.QRImage{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	qr, err := qrcode.Encode([]byte(shortURL), level)
+	if err != nil {
+		u.logger.Error("failed to encode QR code",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return model.QRImage{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	image := model.QRImage{ContentType: "image/png"}
+	if format == model.QRFormatSVG {
+		image.ContentType = "image/svg+xml"
+		image.Data, err = qr.SVG(size, margin)
+	} else {
+		image.Data, err = qr.PNG(max(1, size/(qr.Size()+2*margin)), margin)
+	}
+	if err != nil {
+		return model.QRImage{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	sum := sha256.Sum256(image.Data)
+	image.ETag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	return image, nil
+}
+
+// parseQROptions проверяет параметры QR-кода и подставляет значения по умолчанию
+func parseQROptions(opts model.QROptions) (format string, size int, level qrcode.Level, margin int, err error) {
+	format = opts.Format
+	switch format {
+	case "":
+		format = model.QRFormatPNG
+	case model.QRFormatPNG, model.QRFormatSVG:
+	default:
+		return "", 0, 0, 0, fmt.Errorf("%w: unknown QR format %q", ErrInvalidOptions, opts.Format)
+	}
+
+	size = opts.Size
+	switch {
+	case size == 0:
+		size = defaultQRSize
+	case size < 0 || size > maxQRSize:
+		return "", 0, 0, 0, fmt.Errorf("%w: QR size must be between 1 and %d", ErrInvalidOptions, maxQRSize)
+	}
+
+	level = defaultQRLevel
+	if opts.Level != "" {
+		if level, err = qrcode.ParseLevel(opts.Level); err != nil {
+			return "", 0, 0, 0, fmt.Errorf("%w: %w", ErrInvalidOptions, err)
+		}
+	}
+
+	margin = defaultQRMargin
+	if opts.Margin != nil {
+		margin = *opts.Margin
+		if margin < 0 || margin > maxQRMargin {
+			return "", 0, 0, 0, fmt.Errorf("%w: QR margin must be between 0 and %d", ErrInvalidOptions, maxQRMargin)
+		}
+	}
+
+	return format, size, level, margin, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetQRCode(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name        string
+		opts        model.QROptions
+		setupMock   func(m *mocks.MockURLRepository)
+		contentType string
+		width       int
+		expectedErr error
+	}{
+		{
+			name: "PNG by default",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().GetURLByCode(model.Code("abc")).Return(model.URL("https://example.com"), nil).Once()
+			},
+			contentType: "image/png",
+			// Версия 2 (25 модулей) с полем 4: 256 / 33 = 7 пикселей на модуль
+			width: 33 * 7,
+		},
+		{
+			name: "PNG without margin",
+			opts: model.QROptions{Format: model.QRFormatPNG, Size: 100, Level: "L", Margin: intPtr(0)},
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().GetURLByCode(model.Code("abc")).Return(model.URL("https://example.com"), nil).Once()
+			},
+			contentType: "image/png",
+			width:       25 * 4,
+		},
+		{
+			name: "SVG",
+			opts: model.QROptions{Format: model.QRFormatSVG, Level: "H"},
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().GetURLByCode(model.Code("abc")).Return(model.URL("https://example.com"), nil).Once()
+			},
+			contentType: "image/svg+xml",
+		},
+		{
+			name: "Deleted link",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().GetURLByCode(model.Code("abc")).Return(model.URL(""), fmt.Errorf("get: %w", store.ErrURLDeleted)).Once()
+			},
+			expectedErr: ErrURLDeleted,
+		},
+		{
+			name:        "Unknown format",
+			opts:        model.QROptions{Format: "gif"},
+			setupMock:   func(m *mocks.MockURLRepository) {},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Size too large",
+			opts:        model.QROptions{Size: maxQRSize + 1},
+			setupMock:   func(m *mocks.MockURLRepository) {},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Unknown level",
+			opts:        model.QROptions{Level: "X"},
+			setupMock:   func(m *mocks.MockURLRepository) {},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Negative margin",
+			opts:        model.QROptions{Margin: intPtr(-1)},
+			setupMock:   func(m *mocks.MockURLRepository) {},
+			expectedErr: ErrInvalidOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			tt.setupMock(mockRepo)
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+			defer uc.Close()
+
+			image, err := uc.GetQRCode("abc", tt.opts)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.contentType, image.ContentType)
+			assert.Regexp(t, `^"[0-9a-f]{32}"$`, image.ETag)
+
+			if tt.width > 0 {
+				img, err := png.Decode(bytes.NewReader(image.Data))
+				require.NoError(t, err)
+				assert.Equal(t, tt.width, img.Bounds().Dx())
+			}
+		})
+	}
+}
+
+func TestGetQRCode_StableETag(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().GetURLByCode(model.Code("abc")).Return(model.URL("https://example.com"), nil).Times(3)
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+	defer uc.Close()
+
+	first, err := uc.GetQRCode("abc", model.QROptions{})
+	require.NoError(t, err)
+	second, err := uc.GetQRCode("abc", model.QROptions{})
+	require.NoError(t, err)
+	assert.Equal(t, first.ETag, second.ETag)
+
+	other, err := uc.GetQRCode("abc", model.QROptions{Size: 512})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ETag, other.ETag)
+}
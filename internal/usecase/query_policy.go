@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+)
+
+// maxUTMLength — максимальная длина значения метки UTM в символах
+const maxUTMLength = 256
+
+// applyQueryPolicy дописывает к адресу назначения query-параметры перехода и метки UTM ссылки.
+// Исходная query-строка и фрагмент адреса сохраняются как есть: из неё удаляются только
+// заменяемые параметры, новые дописываются в конец, отсортированные по ключу.
+// Метки UTM ссылки заменяют одноимённые параметры и адреса, и перехода.
+func applyQueryPolicy(destination string, policy model.QueryPolicy, incoming url.Values) string {
+	utm := policy.UTM.Values()
+	added := url.Values{}
+	if policy.Passthrough != "" {
+		for key, values := range incoming {
+			added[key] = values
+		}
+	}
+	for key, values := range utm {
+		added[key] = values
+	}
+	if len(added) == 0 {
+		return destination
+	}
+
+	base, fragment, hasFragment := strings.Cut(destination, "#")
+	base, rawQuery, _ := strings.Cut(base, "?")
+
+	var kept []string
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key := queryKey(pair)
+		if _, replaced := added[key]; replaced {
+			_, fromUTM := utm[key]
+			// При слиянии параметр адреса назначения важнее одноимённого параметра перехода
+			if policy.Passthrough == model.QueryPassthroughMerge && !fromUTM {
+				delete(added, key)
+			} else {
+				continue
+			}
+		}
+		kept = append(kept, pair)
+	}
+	if encoded := added.Encode(); encoded != "" {
+		kept = append(kept, encoded)
+	}
+
+	result := base
+	if len(kept) > 0 {
+		result += "?" + strings.Join(kept, "&")
+	}
+	if hasFragment {
+		result += "#" + fragment
+	}
+	return result
+}
+
+// queryKey возвращает раскодированный ключ пары query-строки; нераскодируемый ключ сравнивается как есть
+func queryKey(pair string) string {
+	key, _, _ := strings.Cut(pair, "=")
+	if decoded, err := url.QueryUnescape(key); err == nil {
+		return decoded
+	}
+	return key
+}
+
+// normalizeQueryPolicy проверяет способ передачи параметров и очищает метки UTM от пробелов по краям
+func normalizeQueryPolicy(policy model.QueryPolicy) (model.QueryPolicy, error) {
+	if !policy.Passthrough.IsValid() {
+		return policy, fmt.Errorf("%w: unknown query passthrough %q", ErrInvalidOptions, policy.Passthrough)
+	}
+
+	utm := []*string{&policy.UTM.Source, &policy.UTM.Medium, &policy.UTM.Campaign, &policy.UTM.Term, &policy.UTM.Content}
+	for _, value := range utm {
+		*value = strings.TrimSpace(*value)
+	}
+	if slices.ContainsFunc(utm, func(value *string) bool { return utf8.RuneCountInString(*value) > maxUTMLength }) {
+		return policy, fmt.Errorf("%w: UTM parameter longer than %d characters", ErrInvalidOptions, maxUTMLength)
+	}
+	return policy, nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestApplyQueryPolicy(t *testing.T) {
+	campaign := model.UTMParams{Source: "newsletter", Campaign: "spring sale"}
+
+	tests := []struct {
+		name        string
+		destination string
+		policy      model.QueryPolicy
+		incoming    string
+		want        string
+	}{
+		{
+			name:        "No policy drops visit parameters",
+			destination: "https://example.com/page?a=1",
+			incoming:    "b=2",
+			want:        "https://example.com/page?a=1",
+		},
+		{
+			name:        "Merge adds new parameters",
+			destination: "https://example.com/page",
+			policy:      model.QueryPolicy{Passthrough: model.QueryPassthroughMerge},
+			incoming:    "ref=tw&b=2",
+			want:        "https://example.com/page?b=2&ref=tw",
+		},
+		{
+			name:        "Merge keeps destination values",
+			destination: "https://example.com/page?a=1&ref=site",
+			policy:      model.QueryPolicy{Passthrough: model.QueryPassthroughMerge},
+			incoming:    "ref=tw&b=2",
+			want:        "https://example.com/page?a=1&ref=site&b=2",
+		},
+		{
+			name:        "Override replaces destination values",
+			destination: "https://example.com/page?a=1&ref=site&ref=old",
+			policy:      model.QueryPolicy{Passthrough: model.QueryPassthroughOverride},
+			incoming:    "ref=tw",
+			want:        "https://example.com/page?a=1&ref=tw",
+		},
+		{
+			name:        "Link UTM wins over destination and visit",
+			destination: "https://example.com/?utm_source=site&x=1",
+			policy:      model.QueryPolicy{Passthrough: model.QueryPassthroughMerge, UTM: campaign},
+			incoming:    "utm_source=tw&utm_medium=social",
+			want:        "https://example.com/?x=1&utm_campaign=spring+sale&utm_medium=social&utm_source=newsletter",
+		},
+		{
+			name:        "Fragment stays at the end",
+			destination: "https://example.com/docs#section?x",
+			policy:      model.QueryPolicy{UTM: model.UTMParams{Medium: "email"}},
+			want:        "https://example.com/docs?utm_medium=email#section?x",
+		},
+		{
+			name:        "Encoded destination keys are matched",
+			destination: "https://example.com/?a%5B%5D=1&q=%D0%BF",
+			policy:      model.QueryPolicy{Passthrough: model.QueryPassthroughOverride},
+			incoming:    "a[]=2",
+			want:        "https://example.com/?q=%D0%BF&a%5B%5D=2",
+		},
+		{
+			name:        "Empty visit query leaves destination untouched",
+			destination: "https://example.com/?a=1&&b",
+			policy:      model.QueryPolicy{Passthrough: model.QueryPassthroughOverride},
+			want:        "https://example.com/?a=1&&b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incoming, err := url.ParseQuery(tt.incoming)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, applyQueryPolicy(tt.destination, tt.policy, incoming))
+		})
+	}
+}
+
+func TestNormalizeQueryPolicy(t *testing.T) {
+	policy, err := normalizeQueryPolicy(model.QueryPolicy{
+		Passthrough: model.QueryPassthroughMerge,
+		UTM:         model.UTMParams{Source: "  newsletter ", Term: "\t"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.UTMParams{Source: "newsletter"}, policy.UTM)
+
+	_, err = normalizeQueryPolicy(model.QueryPolicy{Passthrough: "append"})
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+
+	_, err = normalizeQueryPolicy(model.QueryPolicy{UTM: model.UTMParams{Content: strings.Repeat("я", maxUTMLength+1)}})
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+}
+
+func TestGetOriginalURL_QueryPolicy(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().FollowURL(model.Code("abc"), false).Return(model.LinkTarget{
+		URL:   "https://example.com/landing?lang=en",
+		Query: model.QueryPolicy{Passthrough: model.QueryPassthroughMerge, UTM: model.UTMParams{Source: "qr"}},
+	}, nil).Once()
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+	defer uc.Close()
+
+	redirect, err := uc.GetOriginalURL("abc", model.LinkAccess{},
+		model.Visit{Query: url.Values{"lang": {"de"}, "gclid": {"x1"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/landing?lang=en&gclid=x1&utm_source=qr", redirect.URL)
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	svc "github.com/avc-dev/url-shortener/internal/service"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"go.uber.org/zap"
+)
+
+// maxRedirectRules — максимальное число правил условного редиректа одной ссылки
+const maxRedirectRules = 20
+
+var (
+	// languageTagPattern — языковой тег вида "en" или "pt-br" в нижнем регистре
+	languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{1,8})*$`)
+	// countryCodePattern — двухбуквенный код страны ISO 3166-1 в верхнем регистре
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// SetURLRules заменяет правила условного редиректа ссылки пользователя и возвращает
+// сохранённые правила. Пустой список снимает правила: все переходы ведут на оригинальный URL.
+// Для чужой ссылки возвращается ErrURLNotFound, чтобы не раскрывать существование кода.
+func (u *URLUsecase) SetURLRules(code string, rules []model.RedirectRule, userID string) ([]model.RedirectRule, error) {
+	rules, err := normalizeRedirectRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.canonicalLinkTargets(rules, nil); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.SetURLRules(model.Code(code), rules, userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrURLExpired) ||
+			errors.Is(err, store.ErrURLDeleted) {
+			return nil, mapLookupError(err)
+		}
+		u.logger.Error("failed to set URL rules",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	return rules, nil
+}
+
+// normalizeRedirectRules проверяет правила и приводит их условия к каноническому виду:
+// платформы и языки — в нижний регистр, страны — в верхний. У каждого правила должно быть
+// хотя бы одно условие, иначе оно перехватывало бы все переходы вместо оригинального URL.
+func normalizeRedirectRules(rules []model.RedirectRule) ([]model.RedirectRule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if len(rules) > maxRedirectRules {
+		return nil, fmt.Errorf("%w: at most %d redirect rules per link", ErrInvalidOptions, maxRedirectRules)
+	}
+
+	result := make([]model.RedirectRule, len(rules))
+	for i, rule := range rules {
+		target, err := parseOriginalURL(rule.URL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rule %d: %w", ErrInvalidOptions, i+1, err)
+		}
+
+		normalized := model.RedirectRule{URL: target.String()}
+		for _, platform := range rule.Platforms {
+			platform = strings.ToLower(strings.TrimSpace(platform))
+			if !svc.IsPlatform(platform) {
+				return nil, fmt.Errorf("%w: rule %d: unknown platform %q", ErrInvalidOptions, i+1, platform)
+			}
+			normalized.Platforms = append(normalized.Platforms, platform)
+		}
+		for _, language := range rule.Languages {
+			language = strings.ToLower(strings.TrimSpace(language))
+			if !languageTagPattern.MatchString(language) {
+				return nil, fmt.Errorf("%w: rule %d: invalid language %q", ErrInvalidOptions, i+1, language)
+			}
+			normalized.Languages = append(normalized.Languages, language)
+		}
+		for _, country := range rule.Countries {
+			country = strings.ToUpper(strings.TrimSpace(country))
+			if !countryCodePattern.MatchString(country) {
+				return nil, fmt.Errorf("%w: rule %d: invalid country %q", ErrInvalidOptions, i+1, country)
+			}
+			normalized.Countries = append(normalized.Countries, country)
+		}
+
+		if len(normalized.Platforms) == 0 && len(normalized.Languages) == 0 && len(normalized.Countries) == 0 {
+			return nil, fmt.Errorf("%w: rule %d has no conditions", ErrInvalidOptions, i+1)
+		}
+		result[i] = normalized
+	}
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNormalizeRedirectRules(t *testing.T) {
+	tooMany := make([]model.RedirectRule, maxRedirectRules+1)
+	for i := range tooMany {
+		tooMany[i] = model.RedirectRule{Platforms: []string{"ios"}, URL: "https://example.com"}
+	}
+
+	tests := []struct {
+		name        string
+		rules       []model.RedirectRule
+		want        []model.RedirectRule
+		expectedErr error
+	}{
+		{
+			name: "No rules",
+		},
+		{
+			name: "Conditions are canonicalized",
+			rules: []model.RedirectRule{{
+				Platforms: []string{" iOS "},
+				Languages: []string{"pt-BR"},
+				Countries: []string{"de"},
+				URL:       ` "https://apps.apple.com/app" `,
+			}},
+			want: []model.RedirectRule{{
+				Platforms: []string{"ios"},
+				Languages: []string{"pt-br"},
+				Countries: []string{"DE"},
+				URL:       "https://apps.apple.com/app",
+			}},
+		},
+		{
+			name:        "Rule without conditions",
+			rules:       []model.RedirectRule{{URL: "https://example.com"}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Unknown platform",
+			rules:       []model.RedirectRule{{Platforms: []string{"symbian"}, URL: "https://example.com"}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Invalid language",
+			rules:       []model.RedirectRule{{Languages: []string{"english"}, URL: "https://example.com"}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Invalid country",
+			rules:       []model.RedirectRule{{Countries: []string{"USA"}, URL: "https://example.com"}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Invalid target",
+			rules:       []model.RedirectRule{{Platforms: []string{"android"}, URL: "/relative"}},
+			expectedErr: ErrInvalidURL,
+		},
+		{
+			name:        "Too many rules",
+			rules:       tooMany,
+			expectedErr: ErrInvalidOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := normalizeRedirectRules(tt.rules)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rules)
+		})
+	}
+}
+
+func TestSetURLRules(t *testing.T) {
+	stored := []model.RedirectRule{{Platforms: []string{"android"}, URL: "https://play.google.com/app"}}
+
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "Success"},
+		{name: "Foreign link", repoErr: fmt.Errorf("set: %w", store.ErrNotFound), expectedErr: ErrURLNotFound},
+		{name: "Deleted link", repoErr: fmt.Errorf("set: %w", store.ErrURLDeleted), expectedErr: ErrURLDeleted},
+		{name: "Repository error", repoErr: errors.New("db down"), expectedErr: ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			mockRepo.EXPECT().SetURLRules(model.Code("abc"), stored, "user-1").Return(tt.repoErr).Once()
+
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+			rules, err := uc.SetURLRules("abc",
+				[]model.RedirectRule{{Platforms: []string{"Android"}, URL: "https://play.google.com/app"}}, "user-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, stored, rules)
+		})
+	}
+
+	t.Run("Invalid rules are not stored", func(t *testing.T) {
+		uc := NewURLUsecase(mocks.NewMockURLRepository(t), mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		_, err := uc.SetURLRules("abc", []model.RedirectRule{{URL: "https://example.com"}}, "user-1")
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+}
+
+func TestGetOriginalURL_RedirectRules(t *testing.T) {
+	target := model.LinkTarget{
+		URL: "https://example.com",
+		Rules: []model.RedirectRule{
+			{Platforms: []string{"ios"}, URL: "https://apps.apple.com/app"},
+			{Languages: []string{"de"}, URL: "https://example.com/de"},
+		},
+		Query: model.QueryPolicy{UTM: model.UTMParams{Source: "link"}},
+	}
+
+	tests := []struct {
+		name    string
+		visit   model.Visit
+		wantURL string
+	}{
+		{
+			name:    "Platform rule",
+			visit:   model.Visit{UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X)", AcceptLanguage: "de"},
+			wantURL: "https://apps.apple.com/app?utm_source=link",
+		},
+		{
+			name:    "Language rule",
+			visit:   model.Visit{UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", AcceptLanguage: "de-AT,en;q=0.5"},
+			wantURL: "https://example.com/de?utm_source=link",
+		},
+		{
+			name:    "Fallback to link URL",
+			visit:   model.Visit{UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", AcceptLanguage: "en"},
+			wantURL: "https://example.com?utm_source=link",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			mockRepo.EXPECT().FollowURL(model.Code("abc"), false).Return(target, nil).Once()
+			cfg := config.NewDefaultConfig()
+			cfg.RedirectCacheMaxAge = config.Duration(time.Hour)
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+			defer uc.Close()
+
+			redirect, err := uc.GetOriginalURL("abc", model.LinkAccess{}, tt.visit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantURL, redirect.URL)
+			// Адрес зависит от посетителя, поэтому редирект не кэшируется
+			assert.Zero(t, redirect.MaxAge)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"go.uber.org/zap"
+)
+
+// GetDeletedURLsByUserID возвращает корзину пользователя — удалённые ссылки,
+// которые ещё можно восстановить
+func (u *URLUsecase) GetDeletedURLsByUserID(userID string) ([]model.UserURLResponse, error) {
+	urls, err := u.repo.GetDeletedURLsByUserID(userID, u.cfg.BaseURL.String(), u.restoreDeadline(time.Now()))
+	if err != nil {
+		u.logger.Error("failed to get deleted URLs by user ID",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	return urls, nil
+}
+
+// RestoreURLs восстанавливает удалённые ссылки пользователя и возвращает восстановленные коды.
+// Чужие, неизвестные, истёкшие и удалённые раньше окна хранения коды пропускаются.
+func (u *URLUsecase) RestoreURLs(codes []string, userID string) ([]string, error) {
+	modelCodes := make([]model.Code, len(codes))
+	for i, code := range codes {
+		modelCodes[i] = model.Code(code)
+	}
+
+	restored, err := u.repo.RestoreURLsBatch(modelCodes, userID, u.restoreDeadline(time.Now()))
+	if err != nil {
+		u.logger.Error("failed to restore URLs",
+			zap.Strings("codes", codes),
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	result := make([]string, len(restored))
+	for i, code := range restored {
+		result[i] = string(code)
+	}
+
+	u.logger.Info("URLs restored",
+		zap.String("user_id", userID),
+		zap.Int("requested", len(codes)),
+		zap.Int("restored", len(result)),
+	)
+	return result, nil
+}
+
+// restoreDeadline возвращает самый ранний момент удаления, после которого ссылку ещё можно
+// восстановить. Окно совпадает со сроком хранения Purge.Retention; без него ограничения нет.
+func (u *URLUsecase) restoreDeadline(now time.Time) time.Time {
+	retention := u.cfg.Purge.Retention.Duration()
+	if retention <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-retention)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRestoreURLs(t *testing.T) {
+	tests := []struct {
+		name        string
+		retention   time.Duration
+		setupMock   func(m *mocks.MockURLRepository)
+		want        []string
+		expectedErr error
+	}{
+		{
+			name:      "Restores within retention window",
+			retention: 24 * time.Hour,
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().RestoreURLsBatch([]model.Code{"abc", "def"}, "user-1",
+					mock.MatchedBy(func(after time.Time) bool {
+						return time.Since(after) >= 24*time.Hour && time.Since(after) < 25*time.Hour
+					})).
+					Return([]model.Code{"abc"}, nil).Once()
+			},
+			want: []string{"abc"},
+		},
+		{
+			name: "Without retention any deletion is restorable",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().RestoreURLsBatch([]model.Code{"abc", "def"}, "user-1", time.Time{}).
+					Return(nil, nil).Once()
+			},
+			want: []string{},
+		},
+		{
+			name: "Repository error",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().RestoreURLsBatch([]model.Code{"abc", "def"}, "user-1", time.Time{}).
+					Return(nil, errors.New("db down")).Once()
+			},
+			expectedErr: ErrServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			tt.setupMock(mockRepo)
+
+			cfg := config.NewDefaultConfig()
+			cfg.Purge.Retention = config.Duration(tt.retention)
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+			defer uc.Close()
+
+			restored, err := uc.RestoreURLs([]string{"abc", "def"}, "user-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, restored)
+		})
+	}
+}
+
+func TestGetDeletedURLsByUserID(t *testing.T) {
+	deletedAt := time.Now().Add(-time.Hour)
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().GetDeletedURLsByUserID("user-1", "http://localhost:8080/", time.Time{}).
+		Return([]model.UserURLResponse{{
+			ShortURL:    "http://localhost:8080/abc",
+			OriginalURL: "https://example.com",
+			DeletedAt:   &deletedAt,
+		}}, nil).Once()
+
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+	defer uc.Close()
+
+	urls, err := uc.GetDeletedURLsByUserID("user-1")
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	assert.Equal(t, &deletedAt, urls[0].DeletedAt)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxSplitVariants — максимальное число вариантов сплит-ссылки
+	maxSplitVariants = 10
+	// maxSplitWeight — максимальный вес одного варианта сплит-ссылки
+	maxSplitWeight = 1000
+)
+
+// SetURLVariants заменяет варианты сплит-ссылки пользователя и возвращает сохранённые варианты.
+// Пустой список снимает сплит-тест: все переходы ведут на оригинальный URL.
+// Для чужой ссылки возвращается ErrURLNotFound, чтобы не раскрывать существование кода.
+func (u *URLUsecase) SetURLVariants(code string, variants []model.SplitVariant, userID string) ([]model.SplitVariant, error) {
+	variants, err := normalizeSplitVariants(variants)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.canonicalLinkTargets(nil, variants); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.SetURLVariants(model.Code(code), variants, userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrURLExpired) ||
+			errors.Is(err, store.ErrURLDeleted) {
+			return nil, mapLookupError(err)
+		}
+		u.logger.Error("failed to set URL variants",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	return variants, nil
+}
+
+// normalizeSplitVariants проверяет варианты сплит-ссылки и приводит их адреса к каноническому виду.
+// Вариантов должно быть не меньше двух, а сумма весов — больше нуля: вариант с нулевым весом
+// новым посетителям не назначается, но закреплённые за ним посетители продолжают на него попадать.
+func normalizeSplitVariants(variants []model.SplitVariant) ([]model.SplitVariant, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	if len(variants) < 2 || len(variants) > maxSplitVariants {
+		return nil, fmt.Errorf("%w: split link needs 2 to %d variants", ErrInvalidOptions, maxSplitVariants)
+	}
+
+	result := make([]model.SplitVariant, len(variants))
+	total := 0
+	for i, variant := range variants {
+		target, err := parseOriginalURL(variant.URL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: variant %d: %w", ErrInvalidOptions, i+1, err)
+		}
+		if variant.Weight < 0 || variant.Weight > maxSplitWeight {
+			return nil, fmt.Errorf("%w: variant %d: weight must be between 0 and %d", ErrInvalidOptions, i+1, maxSplitWeight)
+		}
+		total += variant.Weight
+		result[i] = model.SplitVariant{URL: target.String(), Weight: variant.Weight}
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: at least one variant must have a positive weight", ErrInvalidOptions)
+	}
+	return result, nil
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNormalizeSplitVariants(t *testing.T) {
+	tooMany := make([]model.SplitVariant, maxSplitVariants+1)
+	for i := range tooMany {
+		tooMany[i] = model.SplitVariant{URL: "https://example.com", Weight: 1}
+	}
+
+	tests := []struct {
+		name        string
+		variants    []model.SplitVariant
+		want        []model.SplitVariant
+		expectedErr error
+	}{
+		{
+			name: "No variants",
+		},
+		{
+			name: "Targets are canonicalized",
+			variants: []model.SplitVariant{
+				{URL: ` "https://a.example.com" `, Weight: 70},
+				{URL: "https://b.example.com", Weight: 0},
+				{URL: "https://c.example.com", Weight: 30},
+			},
+			want: []model.SplitVariant{
+				{URL: "https://a.example.com", Weight: 70},
+				{URL: "https://b.example.com", Weight: 0},
+				{URL: "https://c.example.com", Weight: 30},
+			},
+		},
+		{
+			name:        "Single variant",
+			variants:    []model.SplitVariant{{URL: "https://a.example.com", Weight: 1}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Too many variants",
+			variants:    tooMany,
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Negative weight",
+			variants:    []model.SplitVariant{{URL: "https://a.example.com", Weight: -1}, {URL: "https://b.example.com", Weight: 2}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Weight too large",
+			variants:    []model.SplitVariant{{URL: "https://a.example.com", Weight: maxSplitWeight + 1}, {URL: "https://b.example.com", Weight: 2}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Zero total weight",
+			variants:    []model.SplitVariant{{URL: "https://a.example.com"}, {URL: "https://b.example.com"}},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "Invalid target",
+			variants:    []model.SplitVariant{{URL: "/relative", Weight: 1}, {URL: "https://b.example.com", Weight: 1}},
+			expectedErr: ErrInvalidURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variants, err := normalizeSplitVariants(tt.variants)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, variants)
+		})
+	}
+}
+
+func TestSetURLVariants(t *testing.T) {
+	variants := []model.SplitVariant{{URL: "https://a.example.com", Weight: 1}, {URL: "https://b.example.com", Weight: 1}}
+
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "Success"},
+		{name: "Foreign link", repoErr: fmt.Errorf("set: %w", store.ErrNotFound), expectedErr: ErrURLNotFound},
+		{name: "Expired link", repoErr: fmt.Errorf("set: %w", store.ErrURLExpired), expectedErr: ErrURLExpired},
+		{name: "Repository error", repoErr: errors.New("db down"), expectedErr: ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			mockRepo.EXPECT().SetURLVariants(model.Code("abc"), variants, "user-1").Return(tt.repoErr).Once()
+
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+			stored, err := uc.SetURLVariants("abc", variants, "user-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, variants, stored)
+		})
+	}
+
+	t.Run("Invalid variants are not stored", func(t *testing.T) {
+		uc := NewURLUsecase(mocks.NewMockURLRepository(t), mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		_, err := uc.SetURLVariants("abc", variants[:1], "user-1")
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+}
+
+func TestGetOriginalURL_SplitVariants(t *testing.T) {
+	target := model.LinkTarget{
+		URL: "https://example.com",
+		Rules: []model.RedirectRule{
+			{Platforms: []string{"ios"}, URL: "https://apps.apple.com/app"},
+		},
+		Variants: []model.SplitVariant{
+			{URL: "https://a.example.com", Weight: 1},
+			{URL: "https://b.example.com", Weight: 0},
+			{URL: "https://c.example.com", Weight: 1},
+		},
+	}
+	windows := "Mozilla/5.0 (Windows NT 10.0; Win64; x64)"
+
+	tests := []struct {
+		name        string
+		visit       model.Visit
+		wantURL     string
+		wantVariant int
+	}{
+		{
+			name:        "Pinned variant",
+			visit:       model.Visit{UserAgent: windows, Variant: 3},
+			wantURL:     "https://c.example.com",
+			wantVariant: 3,
+		},
+		{
+			name:        "Pinned zero-weight variant is reassigned",
+			visit:       model.Visit{UserAgent: windows, IP: "198.51.100.1", Variant: 2},
+			wantVariant: -1,
+		},
+		{
+			name:        "Matching rule wins over split",
+			visit:       model.Visit{UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X)", Variant: 1},
+			wantURL:     "https://apps.apple.com/app",
+			wantVariant: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			mockRepo.EXPECT().FollowURL(model.Code("abc"), false).Return(target, nil).Once()
+			cfg := config.NewDefaultConfig()
+			cfg.RedirectCacheMaxAge = config.Duration(time.Hour)
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+			defer uc.Close()
+
+			redirect, err := uc.GetOriginalURL("abc", model.LinkAccess{}, tt.visit)
+			require.NoError(t, err)
+			if tt.wantVariant < 0 {
+				// Вариант выбирается по весам: нулевой вес новым посетителям не достаётся
+				assert.Contains(t, []int{1, 3}, redirect.Variant)
+				assert.Equal(t, target.Variants[redirect.Variant-1].URL, redirect.URL)
+			} else {
+				assert.Equal(t, tt.wantVariant, redirect.Variant)
+				assert.Equal(t, tt.wantURL, redirect.URL)
+			}
+			// Адрес зависит от посетителя, поэтому редирект не кэшируется
+			assert.Zero(t, redirect.MaxAge)
+		})
+	}
+}
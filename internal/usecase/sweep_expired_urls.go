@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+)
+
+// SweepExpiredURLs помечает удалёнными ссылки с истёкшим сроком жизни и возвращает их количество.
+// После пометки ссылки пропадают из списка пользователя, а по истечении Purge.Retention
+// удаляются окончательно вместе с остальными удалёнными ссылками.
+func (u *URLUsecase) SweepExpiredURLs() (int, error) {
+	// wg позволяет Close() дождаться очистки, запущенной до остановки приложения
+	u.wg.Add(1)
+	defer u.wg.Done()
+
+	codes, err := u.repo.MarkExpiredURLs(time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark expired URLs: %w", err)
+	}
+
+	return len(codes), nil
+}
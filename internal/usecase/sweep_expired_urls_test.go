@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestSweepExpiredURLs проверяет пометку истёкших ссылок
+func TestSweepExpiredURLs(t *testing.T) {
+	tests := []struct {
+		name      string
+		codes     []model.Code
+		repoErr   error
+		wantCount int
+		wantErr   bool
+	}{
+		{name: "Expired links marked", codes: []model.Code{"abc123", "def456"}, wantCount: 2},
+		{name: "Nothing expired", codes: nil, wantCount: 0},
+		{name: "Repository error", repoErr: errors.New("db error"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			usecase := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), &config.Config{}, zap.NewNop())
+
+			mockRepo.EXPECT().
+				MarkExpiredURLs(mock.Anything).
+				Return(tt.codes, tt.repoErr).
+				Once()
+
+			count, err := usecase.SweepExpiredURLs()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
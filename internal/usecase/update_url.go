@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"go.uber.org/zap"
+)
+
+// UpdateURL меняет адрес короткой ссылки пользователя.
+// Изменять можно только свою действующую ссылку: для чужой возвращается ErrURLNotFound,
+// чтобы не раскрывать существование кода. Если новый URL уже сокращён другой ссылкой
+// пользователя, возвращается URLAlreadyExistsError с её коротким URL. Новый адрес нормализуется
+// так же, как при создании; исходная строка прежнего адреса при этом не сохраняется.
+func (u *URLUsecase) UpdateURL(code string, urlString string, userID string) (model.URLUpdate, error) {
+	originalURL, err := u.canonicalURL(urlString, nil)
+	if err != nil {
+		return model.URLUpdate{}, err
+	}
+
+	if !u.repo.IsURLOwnedByUser(model.Code(code), userID) {
+		return model.URLUpdate{}, fmt.Errorf("%w: code %s", ErrURLNotFound, code)
+	}
+
+	return u.updateURL(code, originalURL, userID)
+}
+
+// updateURL меняет адрес ссылки, принадлежность которой пользователю уже проверена
+func (u *URLUsecase) updateURL(code string, originalURL model.URL, userID string) (model.URLUpdate, error) {
+	previous, err := u.repo.UpdateURL(model.Code(code), originalURL, userID)
+	if err != nil {
+		return model.URLUpdate{}, u.mapUpdateError(code, err)
+	}
+
+	shortURL, err := url.JoinPath(u.cfg.BaseURL.String(), code)
+	if err != nil {
+		return model.URLUpdate{}, fmt.Errorf("%w: failed to build short URL: %w", ErrServiceUnavailable, err)
+	}
+
+	u.logger.Info("URL updated",
+		zap.String("code", code),
+		zap.String("user_id", userID),
+	)
+
+	return model.URLUpdate{
+		ShortURL:    shortURL,
+		OriginalURL: string(originalURL),
+		PreviousURL: string(previous),
+	}, nil
+}
+
+// mapUpdateError преобразует ошибку хранилища при изменении ссылки в ошибку usecase
+func (u *URLUsecase) mapUpdateError(code string, err error) error {
+	var conflict store.URLConflictError
+	switch {
+	case errors.As(err, &conflict):
+		existingURL, joinErr := url.JoinPath(u.cfg.BaseURL.String(), string(conflict.Code))
+		if joinErr != nil {
+			return fmt.Errorf("%w: failed to build short URL: %w", ErrServiceUnavailable, joinErr)
+		}
+		return URLAlreadyExistsError{Code: existingURL}
+	case errors.Is(err, store.ErrNotFound), errors.Is(err, store.ErrURLExpired),
+		errors.Is(err, store.ErrURLDeleted):
+		return mapLookupError(err)
+	default:
+		u.logger.Error("failed to update URL",
+			zap.String("code", code),
+			zap.Error(err),
+		)
+		return fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+}
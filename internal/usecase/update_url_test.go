@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUpdateURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		setupMock   func(m *mocks.MockURLRepository)
+		want        model.URLUpdate
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			url:  "  https://new.com  ",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+				m.EXPECT().UpdateURL(model.Code("abc"), model.URL("https://new.com"), "user-1").
+					Return(model.URL("https://old.com"), nil).Once()
+			},
+			want: model.URLUpdate{
+				ShortURL:    "http://localhost:8080/abc",
+				OriginalURL: "https://new.com",
+				PreviousURL: "https://old.com",
+			},
+		},
+		{
+			name:        "Invalid URL",
+			url:         "not-a-url",
+			setupMock:   func(m *mocks.MockURLRepository) {},
+			expectedErr: ErrInvalidURL,
+		},
+		{
+			name: "Foreign link",
+			url:  "https://new.com",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(false).Once()
+			},
+			expectedErr: ErrURLNotFound,
+		},
+		{
+			name: "Deleted link",
+			url:  "https://new.com",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+				m.EXPECT().UpdateURL(model.Code("abc"), model.URL("https://new.com"), "user-1").
+					Return(model.URL(""), fmt.Errorf("update: %w", store.ErrURLDeleted)).Once()
+			},
+			expectedErr: ErrURLDeleted,
+		},
+		{
+			name: "Repository error",
+			url:  "https://new.com",
+			setupMock: func(m *mocks.MockURLRepository) {
+				m.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+				m.EXPECT().UpdateURL(model.Code("abc"), model.URL("https://new.com"), "user-1").
+					Return(model.URL(""), errors.New("db down")).Once()
+			},
+			expectedErr: ErrServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			tt.setupMock(mockRepo)
+
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+			got, err := uc.UpdateURL("abc", tt.url, "user-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateURL_Conflict(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+	mockRepo.EXPECT().UpdateURL(model.Code("abc"), model.URL("https://taken.com"), "user-1").
+		Return(model.URL(""), fmt.Errorf("update: %w", store.URLConflictError{Code: "other"})).Once()
+
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+	_, err := uc.UpdateURL("abc", "https://taken.com", "user-1")
+	var existsErr URLAlreadyExistsError
+	require.ErrorAs(t, err, &existsErr)
+	assert.Equal(t, "http://localhost:8080/other", existsErr.ExistingCode())
+}
+
+func TestUpdateURL_Normalization(t *testing.T) {
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+	mockRepo.EXPECT().UpdateURL(model.Code("abc"), model.URL("http://new.com/"), "user-1").
+		Return(model.URL("http://new.com/"), nil).Once()
+
+	cfg := config.NewDefaultConfig()
+	cfg.URLNormalization.Enabled = true
+	uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+
+	_, err := uc.UpdateURL("abc", "HTTP://New.com:80", "user-1")
+	require.NoError(t, err)
+}
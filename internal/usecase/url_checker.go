@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"go.uber.org/zap"
+)
+
+// checkReputation проверяет адрес по источникам репутации и возвращает совпадение
+// первого источника, признавшего адрес вредоносным. Недоступный источник не мешает
+// работе сервиса: ошибка логируется, и адрес проверяется остальными источниками.
+func (u *URLUsecase) checkReputation(target *url.URL) (string, bool) {
+	for _, checker := range u.checkers {
+		match, malicious, err := checker.CheckURL(target)
+		if err != nil {
+			u.logger.Warn("URL reputation check failed", zap.Error(err))
+			continue
+		}
+		if malicious {
+			return match, true
+		}
+	}
+	return "", false
+}
+
+// checkMaliciousRedirect возвращает ErrPreviewRequired, если включена проверка при переходе,
+// адрес назначения признан вредоносным, а посетитель не подтвердил переход.
+// Проверяется каждый переход, поэтому пополнение списков действует и на ссылки, созданные раньше.
+func (u *URLUsecase) checkMaliciousRedirect(code, destination string, access model.LinkAccess) error {
+	if access.Confirmed || !u.maliciousDestination(code, destination) {
+		return nil
+	}
+	return fmt.Errorf("%w: code %s", ErrPreviewRequired, code)
+}
+
+// checksOnRedirect сообщает, проверяются ли адреса назначения по источникам репутации при переходе
+func (u *URLUsecase) checksOnRedirect() bool {
+	return u.cfg.URLCheck.OnRedirect && len(u.checkers) > 0
+}
+
+// maliciousDestination сообщает, признан ли адрес назначения вредоносным при переходе.
+// Без включённой проверки при переходе всегда возвращает false.
+func (u *URLUsecase) maliciousDestination(code, destination string) bool {
+	if !u.checksOnRedirect() {
+		return false
+	}
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return false
+	}
+	match, malicious := u.checkReputation(parsed)
+	if malicious {
+		u.logger.Info("link destination flagged as malicious",
+			zap.String("code", code),
+			zap.String("match", match),
+		)
+	}
+	return malicious
+}
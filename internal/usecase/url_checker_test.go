@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// hostChecker — источник репутации для тестов: признаёт вредоносными адреса на хосте host
+type hostChecker struct {
+	host  string
+	err   error
+	calls int
+}
+
+func (c *hostChecker) CheckURL(target *url.URL) (string, bool, error) {
+	c.calls++
+	if c.err != nil {
+		return "", false, c.err
+	}
+	if target.Hostname() == c.host {
+		return c.host + "/", true, nil
+	}
+	return "", false, nil
+}
+
+func TestCreateShortURLFromString_MaliciousURL(t *testing.T) {
+	failing := &hostChecker{err: errors.New("reputation service unavailable")}
+	checker := &hostChecker{host: "evil.test"}
+	uc := NewURLUsecase(mocks.NewMockURLRepository(t), mocks.NewMockURLService(t), config.NewDefaultConfig(),
+		zap.NewNop(), WithURLCheckers(failing, checker))
+
+	_, err := uc.CreateShortURLFromString("https://evil.test/login", "user-1", model.LinkOptions{})
+	assert.ErrorIs(t, err, ErrInvalidURL)
+	var rejectedErr URLRejectedError
+	require.ErrorAs(t, err, &rejectedErr)
+	assert.Equal(t, RejectMaliciousURL, rejectedErr.Reason)
+	assert.Equal(t, 1, failing.calls, "failed source does not stop the check")
+	assert.Equal(t, 1, checker.calls)
+
+	_, err = uc.CreateShortURLFromString("https://example.com/", "user-1", model.LinkOptions{
+		Rules: []model.RedirectRule{{Platforms: []string{"ios"}, URL: "https://evil.test/app"}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+	require.ErrorAs(t, err, &rejectedErr)
+	assert.Equal(t, RejectMaliciousURL, rejectedErr.Reason)
+}
+
+func TestGetOriginalURL_MaliciousURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		onRedirect  bool
+		access      model.LinkAccess
+		wantPreview bool
+	}{
+		{name: "Check on redirect disabled", onRedirect: false},
+		{name: "Held for confirmation", onRedirect: true, wantPreview: true},
+		{name: "Confirmed", onRedirect: true, access: model.LinkAccess{Confirmed: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Удержанный переход проверяется без FollowURL и не расходует переход
+			target := model.LinkTarget{URL: "https://evil.test/login"}
+			mockRepo := mocks.NewMockURLRepository(t)
+			if tt.onRedirect {
+				mockRepo.EXPECT().PeekURL(model.Code("abc"), false).Return(target, nil).Once()
+			}
+			if !tt.wantPreview {
+				mockRepo.EXPECT().FollowURL(model.Code("abc"), false).Return(target, nil).Once()
+			}
+
+			cfg := config.NewDefaultConfig()
+			cfg.URLCheck.OnRedirect = tt.onRedirect
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop(),
+				WithURLCheckers(&hostChecker{host: "evil.test"}))
+
+			redirect, err := uc.GetOriginalURL("abc", tt.access, model.Visit{})
+			if tt.wantPreview {
+				assert.ErrorIs(t, err, ErrPreviewRequired)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://evil.test/login", redirect.URL)
+		})
+	}
+}
+
+func TestPreviewURL_MaliciousURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		onRedirect bool
+	}{
+		{name: "Check on redirect disabled", onRedirect: false},
+		{name: "Check on redirect enabled", onRedirect: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockURLRepository(t)
+			mockRepo.EXPECT().InspectURL(model.Code("abc"), false).
+				Return(model.LinkInfo{Code: "abc", URL: "https://evil.test/"}, nil).Once()
+
+			cfg := config.NewDefaultConfig()
+			cfg.URLCheck.OnRedirect = tt.onRedirect
+			uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop(),
+				WithURLCheckers(&hostChecker{host: "evil.test"}))
+
+			preview, err := uc.PreviewURL("abc", model.LinkAccess{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.onRedirect, preview.Unsafe)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/avc-dev/url-shortener/internal/store"
+	"go.uber.org/zap"
+)
+
+// GetURLHistory возвращает текущий адрес ссылки пользователя и её прежние версии.
+// История доступна только владельцу: для чужой ссылки возвращается ErrURLNotFound.
+func (u *URLUsecase) GetURLHistory(code string, userID string) (model.URLHistory, error) {
+	if !u.repo.IsURLOwnedByUser(model.Code(code), userID) {
+		return model.URLHistory{}, fmt.Errorf("%w: code %s", ErrURLNotFound, code)
+	}
+
+	current, err := u.repo.GetURLByCode(model.Code(code))
+	if err != nil {
+		return model.URLHistory{}, mapLookupError(err)
+	}
+
+	versions, err := u.repo.GetURLVersions(model.Code(code))
+	if err != nil {
+		return model.URLHistory{}, u.mapHistoryError(code, err)
+	}
+
+	return model.URLHistory{
+		Code:        code,
+		OriginalURL: string(current),
+		Versions:    versions,
+	}, nil
+}
+
+// RollbackURL возвращает ссылке адрес из версии истории.
+// Откат — обычное изменение адреса: текущий адрес сам становится новой версией,
+// а прежний адрес проверяется так же, как в UpdateURL, — версия, сохранённая до
+// блокировки домена или пополнения списков вредоносных адресов, не восстанавливается.
+func (u *URLUsecase) RollbackURL(code string, version int, userID string) (model.URLUpdate, error) {
+	if !u.repo.IsURLOwnedByUser(model.Code(code), userID) {
+		return model.URLUpdate{}, fmt.Errorf("%w: code %s", ErrURLNotFound, code)
+	}
+
+	versions, err := u.repo.GetURLVersions(model.Code(code))
+	if err != nil {
+		return model.URLUpdate{}, u.mapHistoryError(code, err)
+	}
+
+	for _, v := range versions {
+		if v.Version == version {
+			originalURL, err := u.canonicalURL(v.OriginalURL, nil)
+			if err != nil {
+				return model.URLUpdate{}, err
+			}
+			return u.updateURL(code, originalURL, userID)
+		}
+	}
+
+	return model.URLUpdate{}, fmt.Errorf("%w: code %s, version %d", ErrVersionNotFound, code, version)
+}
+
+// PruneURLHistory удаляет версии адресов старше URLHistory.Retention
+// и возвращает их количество. Последняя версия каждой ссылки сохраняется.
+func (u *URLUsecase) PruneURLHistory() (int, error) {
+	retention := u.cfg.URLHistory.Retention.Duration()
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	// wg позволяет Close() дождаться очистки, запущенной до остановки приложения
+	u.wg.Add(1)
+	defer u.wg.Done()
+
+	pruned, err := u.repo.PruneURLVersions(time.Now().Add(-retention))
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune URL history: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// mapHistoryError преобразует ошибку чтения истории в ошибку usecase
+func (u *URLUsecase) mapHistoryError(code string, err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return mapLookupError(err)
+	}
+	u.logger.Error("failed to get URL versions",
+		zap.String("code", code),
+		zap.Error(err),
+	)
+	return fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+}
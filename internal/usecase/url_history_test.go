@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/avc-dev/url-shortener/internal/config"
+	"github.com/avc-dev/url-shortener/internal/mocks"
+	"github.com/avc-dev/url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetURLHistory(t *testing.T) {
+	versions := []model.URLVersion{
+		{Version: 2, OriginalURL: "https://v2.com", ChangedBy: "user-1"},
+		{Version: 1, OriginalURL: "https://v1.com", ChangedBy: "user-1"},
+	}
+
+	t.Run("Owner gets current destination and versions", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+		mockRepo.EXPECT().GetURLByCode(model.Code("abc")).Return(model.URL("https://v3.com"), nil).Once()
+		mockRepo.EXPECT().GetURLVersions(model.Code("abc")).Return(versions, nil).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		history, err := uc.GetURLHistory("abc", "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, model.URLHistory{Code: "abc", OriginalURL: "https://v3.com", Versions: versions}, history)
+	})
+
+	t.Run("Foreign link is not found", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-2").Return(false).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		_, err := uc.GetURLHistory("abc", "user-2")
+		assert.ErrorIs(t, err, ErrURLNotFound)
+	})
+}
+
+func TestRollbackURL(t *testing.T) {
+	versions := []model.URLVersion{{Version: 1, OriginalURL: "https://v1.com", ChangedBy: "user-1"}}
+
+	t.Run("Known version restores its destination", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+		mockRepo.EXPECT().GetURLVersions(model.Code("abc")).Return(versions, nil).Once()
+		mockRepo.EXPECT().UpdateURL(model.Code("abc"), model.URL("https://v1.com"), "user-1").
+			Return(model.URL("https://v2.com"), nil).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		update, err := uc.RollbackURL("abc", 1, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, "https://v1.com", update.OriginalURL)
+		assert.Equal(t, "https://v2.com", update.PreviousURL)
+	})
+
+	t.Run("Version forbidden since it was saved is not restored", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+		mockRepo.EXPECT().GetURLVersions(model.Code("abc")).Return(versions, nil).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+		_, _, err := uc.BlockDomain("v1.com")
+		require.NoError(t, err)
+
+		_, err = uc.RollbackURL("abc", 1, "user-1")
+		assert.ErrorIs(t, err, ErrInvalidURL)
+		var rejectedErr URLRejectedError
+		require.ErrorAs(t, err, &rejectedErr)
+		assert.Equal(t, RejectBlockedDomain, rejectedErr.Reason)
+	})
+
+	t.Run("Unknown version", func(t *testing.T) {
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().IsURLOwnedByUser(model.Code("abc"), "user-1").Return(true).Once()
+		mockRepo.EXPECT().GetURLVersions(model.Code("abc")).Return(versions, nil).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		_, err := uc.RollbackURL("abc", 7, "user-1")
+		assert.ErrorIs(t, err, ErrVersionNotFound)
+	})
+}
+
+func TestPruneURLHistory(t *testing.T) {
+	t.Run("Disabled without retention", func(t *testing.T) {
+		uc := NewURLUsecase(mocks.NewMockURLRepository(t), mocks.NewMockURLService(t), config.NewDefaultConfig(), zap.NewNop())
+
+		pruned, err := uc.PruneURLHistory()
+		require.NoError(t, err)
+		assert.Zero(t, pruned)
+	})
+
+	t.Run("Prunes versions older than retention", func(t *testing.T) {
+		cfg := config.NewDefaultConfig()
+		cfg.URLHistory.Retention = config.Duration(24 * time.Hour)
+
+		mockRepo := mocks.NewMockURLRepository(t)
+		mockRepo.EXPECT().
+			PruneURLVersions(mock.MatchedBy(func(before time.Time) bool {
+				age := time.Since(before)
+				return age >= 24*time.Hour && age < 25*time.Hour
+			})).
+			Return(3, nil).Once()
+
+		uc := NewURLUsecase(mockRepo, mocks.NewMockURLService(t), cfg, zap.NewNop())
+
+		pruned, err := uc.PruneURLHistory()
+		require.NoError(t, err)
+		assert.Equal(t, 3, pruned)
+	})
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/avc-dev/url-shortener/internal/model"
+	svc "github.com/avc-dev/url-shortener/internal/service"
+	"go.uber.org/zap"
+)
+
+// rejectReasons сопоставляет ошибки проверки адреса с причинами отказа
+var rejectReasons = []struct {
+	err    error
+	reason URLRejectReason
+}{
+	{svc.ErrSchemeNotAllowed, RejectSchemeNotAllowed},
+	{svc.ErrURLUserInfo, RejectUserInfo},
+	{svc.ErrPrivateHost, RejectPrivateHost},
+	{svc.ErrSelfReference, RejectSelfReference},
+}
+
+// checkURL проверяет адрес назначения по политике сервиса, списку блокировки доменов
+// и источникам репутации; отказ возвращается как URLRejectedError
+func (u *URLUsecase) checkURL(target *url.URL) error {
+	err := u.urlPolicy.Check(target)
+	if err == nil {
+		if _, blocked := u.blocklist.Match(target.Hostname()); blocked {
+			return URLRejectedError{Reason: RejectBlockedDomain, Detail: "domain " + target.Hostname() + " is blocked"}
+		}
+		if match, malicious := u.checkReputation(target); malicious {
+			u.logger.Info("malicious URL rejected", zap.String("match", match))
+			return URLRejectedError{Reason: RejectMaliciousURL, Detail: "URL is listed as malicious"}
+		}
+		return nil
+	}
+	for _, r := range rejectReasons {
+		if errors.Is(err, r.err) {
+			return URLRejectedError{Reason: r.reason, Detail: err.Error()}
+		}
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidURL, err)
+}
+
+// canonicalLinkTargets проверяет по политике сервиса адреса правил перенаправления
+// и вариантов сплит-ссылки и приводит их к каноническому виду так же, как основной адрес ссылки.
+// Адреса заменяются на месте, поэтому срезы должны принадлежать вызывающему —
+// их возвращают normalizeRedirectRules и normalizeSplitVariants.
+func (u *URLUsecase) canonicalLinkTargets(rules []model.RedirectRule, variants []model.SplitVariant) error {
+	for i := range rules {
+		target, err := u.canonicalURL(rules[i].URL, nil)
+		if err != nil {
+			return fmt.Errorf("%w: rule %d: %w", ErrInvalidOptions, i+1, err)
+		}
+		rules[i].URL = string(target)
+	}
+	for i := range variants {
+		target, err := u.canonicalURL(variants[i].URL, nil)
+		if err != nil {
+			return fmt.Errorf("%w: variant %d: %w", ErrInvalidOptions, i+1, err)
+		}
+		variants[i].URL = string(target)
+	}
+	return nil
+}
@@ -4,7 +4,9 @@
 package usecase
 
 import (
+	"net/url"
 	"sync"
+	"time"
 
 	"github.com/avc-dev/url-shortener/internal/config"
 	"github.com/avc-dev/url-shortener/internal/model"
@@ -14,20 +16,48 @@ import (
 
 // URLRepository определяет интерфейс для работы с хранилищем URL
 type URLRepository interface {
-	CreateOrGetURL(code model.Code, url model.URL, userID string) (model.Code, bool, error)
-	CreateURLsBatch(urls map[model.Code]model.URL, userID string) error
+	CreateOrGetURL(code model.Code, url model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error)
+	CreateURLsBatch(urls map[model.Code]model.URL, userID string, opts model.LinkOptions) error
 	GetURLByCode(code model.Code) (model.URL, error)
-	GetURLsByUserID(userID string, baseURL string) ([]model.UserURLResponse, error)
+	FollowURL(code model.Code, unlocked bool) (model.LinkTarget, error)
+	PeekURL(code model.Code, unlocked bool) (model.LinkTarget, error)
+	InspectURL(code model.Code, unlocked bool) (model.LinkInfo, error)
+	SetURLUnsafe(code model.Code, unsafe bool) error
+	GetURLPasswordHash(code model.Code) (string, error)
+	GetURLsByUserID(userID string, baseURL string, query model.URLQuery) (model.URLPage, error)
+	SetURLLabels(code model.Code, labels model.LinkLabels, userID string) error
+	SetURLRules(code model.Code, rules []model.RedirectRule, userID string) error
+	SetURLVariants(code model.Code, variants []model.SplitVariant, userID string) error
+	UpdateURL(code model.Code, url model.URL, userID string) (model.URL, error)
+	GetURLVersions(code model.Code) ([]model.URLVersion, error)
+	PruneURLVersions(before time.Time) (int, error)
 	IsCodeUnique(code model.Code) bool
 	DeleteURLsBatch(codes []model.Code, userID string) error
+	DeleteURLsByFilter(userID string, filter model.URLFilter) ([]model.Code, error)
+	GetDeletedURLsByUserID(userID string, baseURL string, deletedAfter time.Time) ([]model.UserURLResponse, error)
+	RestoreURLsBatch(codes []model.Code, userID string, deletedAfter time.Time) ([]model.Code, error)
 	IsURLOwnedByUser(code model.Code, userID string) bool
 	GetStats() (model.Stats, error)
+	MarkExpiredURLs(now time.Time) ([]model.Code, error)
+	PurgeDeletedURLs(deletedBefore time.Time) ([]model.Code, error)
+	ReleaseCodes(codes []model.Code, availableAt time.Time) error
+	AcquireRecycledCode(now time.Time) (model.Code, bool, error)
+	RecordClicks(clicks []model.Click) error
+	GetClickStats(code model.Code, from, to time.Time) (model.URLStats, error)
+	AddClickCounts(counts []model.ClickCount) error
 }
 
 // URLService определяет интерфейс для работы с сервисом генерации коротких URL
 type URLService interface {
-	CreateShortURL(originalURL model.URL, userID string) (model.Code, bool, error)
-	CreateShortURLsBatch(originalURLs []model.URL, userID string) ([]model.Code, error)
+	CreateShortURL(originalURL model.URL, userID string, opts model.LinkOptions) (model.Code, bool, error)
+	CreateShortURLsBatch(originalURLs []model.URL, userID string, opts model.LinkOptions) ([]model.Code, error)
+}
+
+// URLChecker проверяет адрес назначения по источнику репутации: локальному списку
+// хешей, внешнему сервису и т. п. Возвращает описание совпадения и true,
+// если адрес признан вредоносным.
+type URLChecker interface {
+	CheckURL(target *url.URL) (string, bool, error)
 }
 
 // URLUsecase содержит бизнес-логику для работы с URL
@@ -35,33 +65,96 @@ type URLUsecase struct {
 	repo           URLRepository
 	service        URLService
 	asyncProcessor *svc.AsyncURLProcessor
+	linkAccess     *svc.AuthService
+	attempts       *svc.AttemptLimiter
+	geo            *svc.GeoIP
+	normalizer     *svc.URLNormalizer
+	urlPolicy      *svc.URLPolicy
+	blocklist      *svc.DomainBlocklist
+	checkers       []URLChecker
+	clickEnricher  *svc.ClickEnricher
+	clickRecorder  *svc.ClickRecorder
+	clickCounter   *svc.ClickCounter
 	cfg            *config.Config
 	logger         *zap.Logger
 	done           chan struct{} // канал для сигнализации завершения асинхронных операций (для тестов)
 	wg             sync.WaitGroup
 }
 
-// NewURLUsecase создает новый экземпляр URLUsecase
-func NewURLUsecase(repo URLRepository, service URLService, cfg *config.Config, logger *zap.Logger) *URLUsecase {
-	return &URLUsecase{
+// Option настраивает необязательные зависимости URLUsecase
+type Option func(*URLUsecase)
+
+// WithGeoIP включает определение страны переходов по локальной базе GeoIP
+func WithGeoIP(geo *svc.GeoIP) Option {
+	return func(u *URLUsecase) {
+		u.geo = geo
+	}
+}
+
+// WithDomainBlocklist задаёт список блокировки доменов назначения;
+// без него используется пустой список, который хранится только в памяти
+func WithDomainBlocklist(blocklist *svc.DomainBlocklist) Option {
+	return func(u *URLUsecase) {
+		u.blocklist = blocklist
+	}
+}
+
+// WithURLCheckers добавляет источники репутации, по которым проверяются адреса назначения
+func WithURLCheckers(checkers ...URLChecker) Option {
+	return func(u *URLUsecase) {
+		u.checkers = append(u.checkers, checkers...)
+	}
+}
+
+// NewURLUsecase создает новый экземпляр URLUsecase и запускает фоновую запись переходов;
+// её завершает Close
+func NewURLUsecase(repo URLRepository, service URLService, cfg *config.Config, logger *zap.Logger, opts ...Option) *URLUsecase {
+	u := &URLUsecase{
 		repo:           repo,
 		service:        service,
 		asyncProcessor: svc.NewAsyncURLProcessor(),
+		linkAccess:     svc.NewAuthService(cfg.JWTSecret),
+		attempts:       newPasswordAttemptLimiter(cfg),
+		normalizer:     newURLNormalizer(cfg),
+		urlPolicy:      newURLPolicy(cfg),
 		cfg:            cfg,
 		logger:         logger,
 	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	if u.blocklist == nil {
+		u.blocklist = svc.NewDomainBlocklist()
+	}
+	u.clickEnricher = svc.NewClickEnricher(u.geo, cfg.JWTSecret)
+	u.clickRecorder = svc.NewClickRecorder(repo, clickBufferSize, clickBatchSize, clickFlushInterval, u.logClickError)
+	u.clickCounter = svc.NewClickCounter(repo, clickCountFlushInterval, u.logClickCountError)
+	return u
 }
 
 // NewURLUsecaseWithDone создает новый экземпляр URLUsecase с каналом синхронизации для тестов
 func NewURLUsecaseWithDone(repo URLRepository, service URLService, cfg *config.Config, logger *zap.Logger, done chan struct{}) *URLUsecase {
-	return &URLUsecase{
-		repo:           repo,
-		service:        service,
-		asyncProcessor: svc.NewAsyncURLProcessor(),
-		cfg:            cfg,
-		logger:         logger,
-		done:           done,
+	u := NewURLUsecase(repo, service, cfg, logger)
+	u.done = done
+	return u
+}
+
+// newPasswordAttemptLimiter создаёт ограничитель попыток ввода пароля ссылок по конфигурации
+func newPasswordAttemptLimiter(cfg *config.Config) *svc.AttemptLimiter {
+	return svc.NewAttemptLimiter(cfg.LinkPassword.MaxAttempts, cfg.LinkPassword.Window.Duration())
+}
+
+// newURLPolicy создаёт проверку адресов назначения по конфигурации
+func newURLPolicy(cfg *config.Config) *svc.URLPolicy {
+	return svc.NewURLPolicy(cfg.URLValidation.AllowedSchemes, cfg.URLValidation.AllowPrivateHosts, cfg.BaseURL.String())
+}
+
+// newURLNormalizer создаёт нормализатор адресов по конфигурации; nil — нормализация выключена
+func newURLNormalizer(cfg *config.Config) *svc.URLNormalizer {
+	if !cfg.URLNormalization.Enabled {
+		return nil
 	}
+	return svc.NewURLNormalizer(cfg.URLNormalization.SortQuery, cfg.URLNormalization.StripTracking)
 }
 
 // GetStats возвращает количество сокращённых URL и уникальных пользователей в сервисе.
@@ -69,8 +162,11 @@ func (u *URLUsecase) GetStats() (model.Stats, error) {
 	return u.repo.GetStats()
 }
 
-// Close ожидает завершения всех асинхронных операций удаления URL.
-// Вызывать при остановке приложения, до закрытия соединения с БД.
+// Close ожидает завершения всех асинхронных операций удаления и очистки URL
+// и записывает накопленные переходы и счётчики переходов.
+// Вызывать при остановке приложения, после остановки серверов и до закрытия соединения с БД.
 func (u *URLUsecase) Close() {
 	u.wg.Wait()
+	u.clickRecorder.Close()
+	u.clickCounter.Close()
 }